@@ -0,0 +1,46 @@
+// Package BuildInfo holds the version of the scanner build. The values are injected at
+// build time with -ldflags, e.g.:
+//
+//	go build -ldflags "-X Engine-AntiGinx/App/BuildInfo.Version=1.4.0 \
+//	    -X Engine-AntiGinx/App/BuildInfo.Commit=$(git rev-parse --short HEAD) \
+//	    -X Engine-AntiGinx/App/BuildInfo.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./App
+//
+// Builds without ldflags (go run, go test) report "dev" and "unknown".
+package BuildInfo
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+)
+
+var (
+	// Version is the scanner version, "dev" when not injected at build time.
+	Version = "dev"
+	// Commit is the git commit the scanner was built from.
+	Commit = "unknown"
+	// BuildDate is the time of the build, preferably in RFC 3339 format.
+	BuildDate = "unknown"
+)
+
+// Print writes the build information printed by the version command: the scanner
+// version, git commit, build date, Go version and the number of registered tests.
+//
+// Parameters:
+//   - out: Destination of the build information (usually stdout)
+//   - registeredTests: Number of tests in the registry, plugin tests included
+//
+// Example output:
+//
+//	Engine-AntiGinx 1.4.0
+//	  Commit:           3f2c1ab
+//	  Build date:       2026-10-16T09:30:00Z
+//	  Go version:       go1.25.11
+//	  Registered tests: 36
+func Print(out io.Writer, registeredTests int) {
+	fmt.Fprintf(out, "Engine-AntiGinx %s\n", Version)
+	fmt.Fprintf(out, "  Commit:           %s\n", Commit)
+	fmt.Fprintf(out, "  Build date:       %s\n", BuildDate)
+	fmt.Fprintf(out, "  Go version:       %s\n", runtime.Version())
+	fmt.Fprintf(out, "  Registered tests: %d\n", registeredTests)
+}
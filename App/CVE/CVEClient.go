@@ -1,24 +1,96 @@
 // Package CVE provides functionality for querying and analyzing Common Vulnerabilities and Exposures (CVE)
 // from the NIST National Vulnerability Database (NVD). It enables security assessment of detected technologies
 // by checking for known vulnerabilities and calculating risk levels based on CVSS scores.
+//
+// Error codes:
+//   - 400: Invalid NVD base URL (NVD_BASE_URL or WithBaseURL)
+//   - 401: Unknown minimum severity (WithMinimumSeverity)
 package CVE
 
 import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Lookup"
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
 // CVEClient handles communication with CVE databases, specifically the NIST NVD API.
 // It provides methods for searching vulnerabilities and assessing security risks
 // for specific technologies and versions.
+//
+// A client is safe for concurrent use and is meant to be shared by every test of a scan:
+// assessments are cached per technology, version and query options (concurrent requests
+// for the same assessment wait for a single NVD lookup), requests to the NVD API are
+// spaced by the configured request interval and every request holds a slot of the shared
+// external lookup limiter (see package Lookup).
 type CVEClient struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient      *http.Client
+	baseURL         string
+	requestInterval time.Duration   // Minimum delay between NVD requests (0 = unlimited)
+	limiter         *Lookup.Limiter // Bound on concurrent lookups shared with other clients (nil = unbounded)
+
+	cacheMu sync.Mutex
+	cache   map[string]*assessmentCall
+
+	rateMu      sync.Mutex
+	lastRequest time.Time
+}
+
+// assessmentCall is a cached, possibly still running, vulnerability assessment.
+type assessmentCall struct {
+	done       chan struct{}
+	assessment *VulnerabilityAssessment
+	err        error
+}
+
+// ClientOption is a functional option type for configuring a CVEClient.
+type ClientOption func(*CVEClient)
+
+// DefaultRequestInterval spaces NVD requests according to the public API rate limit
+// of 5 requests in a rolling 30 second window (requests without an API key).
+const DefaultRequestInterval = 6 * time.Second
+
+// DefaultBaseURL is the official NVD CVE API 2.0 endpoint used when no mirror is configured.
+const DefaultBaseURL = "https://services.nvd.nist.gov/rest/json/cves/2.0"
+
+// BaseURLEnv names the environment variable pointing every client at an NVD compatible
+// mirror (e.g., an enterprise's internal NVD copy) instead of DefaultBaseURL.
+const BaseURLEnv = "NVD_BASE_URL"
+
+// WithBaseURL points the client at a different NVD compatible endpoint (e.g., a mirror).
+// It takes precedence over the NVD_BASE_URL environment variable.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *CVEClient) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithRequestInterval sets the minimum delay between two NVD requests.
+// A non-positive interval disables rate limiting.
+func WithRequestInterval(interval time.Duration) ClientOption {
+	return func(c *CVEClient) {
+		c.requestInterval = interval
+	}
+}
+
+// WithLimiter bounds the NVD requests of the client with limiter instead of the shared
+// Lookup limiter. A nil limiter leaves the requests unbounded.
+func WithLimiter(limiter *Lookup.Limiter) ClientOption {
+	return func(c *CVEClient) {
+		c.limiter = limiter
+	}
 }
 
 // CVEResult represents a single CVE vulnerability entry with essential information
@@ -45,61 +117,315 @@ type VulnerabilityAssessment struct {
 	MaxScore       float64     `json:"max_score"`       // Highest CVSS score among all CVEs
 	CVEs           []CVEResult `json:"cves"`            // Complete list of CVE entries
 	RiskLevel      string      `json:"risk_level"`      // Overall risk: NONE, LOW, MEDIUM, HIGH, or CRITICAL
+	DroppedEntries int         `json:"dropped_entries"` // NVD entries skipped because they could not be parsed
+}
+
+// AssessmentOption is a functional option type for tuning a single vulnerability assessment.
+// It follows the same composable configuration pattern as the HTTP client wrapper options.
+type AssessmentOption func(*assessmentConfig)
+
+// assessmentConfig holds per-assessment query settings applied to the NVD request.
+// The zero value performs a full, unfiltered fetch.
+type assessmentConfig struct {
+	minSeverity    string          // Lowest CVSS v3 severity to request (empty = all severities)
+	publishedSince time.Time       // Oldest publication date to request (zero = no date filter)
+	ctx            context.Context // Context bounding the NVD requests (nil = not cancellable)
+}
+
+// nvdQueryFilter describes the optional filters appended to the NVD requests of one query.
+type nvdQueryFilter struct {
+	severity string    // cvssV3Severity value (empty = no severity filter)
+	pubStart time.Time // pubStartDate value (zero = no date filter)
+	pubEnd   time.Time // pubEndDate value, required by NVD alongside pubStartDate
+}
+
+// cvssV3Severities lists the NVD cvssV3Severity values in ascending order of severity.
+var cvssV3Severities = []string{"LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+// DefaultRecentYears is the publication window used by WithRecentYears when a
+// non-positive number of years is supplied, and the default window of a scan (--cve-years).
+const DefaultRecentYears = 5
+
+// maxNVDDateRange is the longest publication date range the NVD API accepts in a
+// single request. Longer windows are split into consecutive ranges.
+const maxNVDDateRange = 120 * 24 * time.Hour
+
+// nvdPageSize is the number of results requested per page, the maximum of the NVD API.
+// Queries matching more CVEs are paged through with startIndex.
+const nvdPageSize = 2000
+
+// nvdDateLayout is the ISO-8601 timestamp format of the NVD API.
+const nvdDateLayout = "2006-01-02T15:04:05.000"
+
+// WithMinimumSeverity restricts the NVD query to CVEs rated at or above the given
+// CVSS v3 severity (LOW, MEDIUM, HIGH or CRITICAL, case-insensitive).
+//
+// The NVD API accepts a single cvssV3Severity value per request, so the client issues one
+// query per severity at or above the minimum and merges the results. Unknown severity
+// values make the assessment fail with an Errors.Error of code 401.
+//
+// Parameters:
+//   - severity: Minimum CVSS v3 severity to fetch
+//
+// Returns:
+//   - AssessmentOption: Configuration function applying the severity filter
+//
+// Example:
+//
+//	assessment, err := client.AssessTechnologyVulnerabilities("nginx", "", WithMinimumSeverity("HIGH"))
+func WithMinimumSeverity(severity string) AssessmentOption {
+	return func(cfg *assessmentConfig) {
+		cfg.minSeverity = strings.ToUpper(strings.TrimSpace(severity))
+	}
+}
+
+// WithPublishedSince restricts the assessment to CVEs published on or after the given time,
+// so decades-old CVEs for long-patched software do not inflate the assessment. The date is
+// sent to NVD as pubStartDate (with pubEndDate set to the current time); the API accepts
+// ranges of at most 120 days, so longer windows are split into one query per range. The
+// date is also enforced on the parsed results.
+//
+// Parameters:
+//   - since: Oldest publication date to include
+//
+// Returns:
+//   - AssessmentOption: Configuration function applying the recency filter
+func WithPublishedSince(since time.Time) AssessmentOption {
+	return func(cfg *assessmentConfig) {
+		cfg.publishedSince = since
+	}
+}
+
+// WithContext binds the NVD requests of the assessment to ctx, so that they are abandoned
+// once ctx is cancelled (e.g., when the scan deadline passes). Concurrent callers waiting
+// for the same cached assessment receive the error of a cancelled request.
+//
+// Parameters:
+//   - ctx: Context cancelling the requests
+//
+// Returns:
+//   - AssessmentOption: Configuration function applying the context
+func WithContext(ctx context.Context) AssessmentOption {
+	return func(cfg *assessmentConfig) {
+		cfg.ctx = ctx
+	}
+}
+
+// WithRecentYears restricts the assessment to CVEs published within the last given
+// number of years. A non-positive value falls back to DefaultRecentYears.
+//
+// Parameters:
+//   - years: Size of the publication window in years
+//
+// Returns:
+//   - AssessmentOption: Configuration function applying the recency filter
+//
+// Example:
+//
+//	assessment, err := client.AssessTechnologyVulnerabilities("Apache", "", WithRecentYears(3))
+func WithRecentYears(years int) AssessmentOption {
+	if years <= 0 {
+		years = DefaultRecentYears
+	}
+	return WithPublishedSince(time.Now().AddDate(-years, 0, 0))
+}
+
+// requestContext returns the context of the NVD requests, context.Background() when unset.
+func (cfg assessmentConfig) requestContext() context.Context {
+	if cfg.ctx == nil {
+		return context.Background()
+	}
+	return cfg.ctx
+}
+
+// validate reports an unknown minimum severity.
+func (cfg assessmentConfig) validate() error {
+	if cfg.minSeverity != "" && cfg.severitiesAtOrAbove() == nil {
+		return &Errors.Error{
+			Code: 401,
+			Message: fmt.Sprintf("CVE Client error occurred. This could be due to:\n- Unknown minimum severity %q"+
+				"\n- Expected one of %s", cfg.minSeverity, strings.Join(cvssV3Severities, ", ")),
+			Source:      "CVE Client",
+			IsRetryable: false,
+		}
+	}
+	return nil
+}
+
+// queryFilters expands the configuration into the list of NVD queries to perform. Each
+// requested severity is combined with each publication date range of at most
+// maxNVDDateRange; without any filters a single unfiltered query is returned.
+func (cfg assessmentConfig) queryFilters(now time.Time) []nvdQueryFilter {
+	severities := cfg.severitiesAtOrAbove()
+	if severities == nil {
+		severities = []string{""}
+	}
+
+	type window struct{ start, end time.Time }
+	windows := []window{{}}
+	if !cfg.publishedSince.IsZero() && cfg.publishedSince.Before(now) {
+		windows = windows[:0]
+		for start := cfg.publishedSince; start.Before(now); start = start.Add(maxNVDDateRange) {
+			end := start.Add(maxNVDDateRange)
+			if end.After(now) {
+				end = now
+			}
+			windows = append(windows, window{start, end})
+		}
+	}
+
+	filters := make([]nvdQueryFilter, 0, len(severities)*len(windows))
+	for _, severity := range severities {
+		for _, w := range windows {
+			filters = append(filters, nvdQueryFilter{
+				severity: severity,
+				pubStart: w.start,
+				pubEnd:   w.end,
+			})
+		}
+	}
+	return filters
+}
+
+// severitiesAtOrAbove returns the cvssV3Severity values to query for the configured minimum.
+// A nil slice means no severity filter should be applied.
+func (cfg assessmentConfig) severitiesAtOrAbove() []string {
+	for i, severity := range cvssV3Severities {
+		if severity == cfg.minSeverity {
+			return cvssV3Severities[i:]
+		}
+	}
+	return nil
 }
 
 // NVDResponse represents the structure of NIST NVD API response (CVE API 2.0).
 // This structure maps the JSON response from the National Vulnerability Database,
 // including pagination information and vulnerability details with CVSS metrics.
 type NVDResponse struct {
-	ResultsPerPage  int `json:"resultsPerPage"` // Number of results in current page
-	StartIndex      int `json:"startIndex"`     // Starting index for pagination
-	TotalResults    int `json:"totalResults"`   // Total number of matching results
-	Vulnerabilities []struct {
-		CVE struct {
-			ID          string `json:"id"`
-			Description struct {
-				DescriptionData []struct {
-					Lang  string `json:"lang"`
-					Value string `json:"value"`
-				} `json:"description_data"`
-			} `json:"description"`
-			Published time.Time `json:"published"`
-			Modified  time.Time `json:"lastModified"`
-			Metrics   struct {
-				CVSSMetricV31 []struct {
-					CVSSData struct {
-						BaseScore    float64 `json:"baseScore"`
-						BaseSeverity string  `json:"baseSeverity"`
-					} `json:"cvssData"`
-				} `json:"cvssMetricV31"`
-				CVSSMetricV2 []struct {
-					CVSSData struct {
-						BaseScore string `json:"baseScore"`
-					} `json:"cvssData"`
-				} `json:"cvssMetricV2"`
-			} `json:"metrics"`
-		} `json:"cve"`
-	} `json:"vulnerabilities"`
+	ResultsPerPage  int                `json:"resultsPerPage"`  // Number of results in current page
+	StartIndex      int                `json:"startIndex"`      // Starting index for pagination
+	TotalResults    int                `json:"totalResults"`    // Total number of matching results
+	Vulnerabilities []NVDVulnerability `json:"vulnerabilities"` // Vulnerabilities in current page
+}
+
+// NVDVulnerability is a single entry of the NVD response "vulnerabilities" array.
+type NVDVulnerability struct {
+	CVE struct {
+		ID           string `json:"id"`
+		Descriptions []struct {
+			Lang  string `json:"lang"`
+			Value string `json:"value"`
+		} `json:"descriptions"`
+		Description struct { // Legacy NVD 1.0 layout, kept for older mirrors
+			DescriptionData []struct {
+				Lang  string `json:"lang"`
+				Value string `json:"value"`
+			} `json:"description_data"`
+		} `json:"description"`
+		Published NVDTime `json:"published"`
+		Modified  NVDTime `json:"lastModified"`
+		Metrics   struct {
+			CVSSMetricV31 []struct {
+				CVSSData struct {
+					BaseScore    float64 `json:"baseScore"`
+					BaseSeverity string  `json:"baseSeverity"`
+				} `json:"cvssData"`
+			} `json:"cvssMetricV31"`
+			CVSSMetricV2 []struct {
+				CVSSData struct {
+					BaseScore string `json:"baseScore"`
+				} `json:"cvssData"`
+			} `json:"cvssMetricV2"`
+		} `json:"metrics"`
+	} `json:"cve"`
+}
+
+// NVDTime is a timestamp of an NVD response. The API sends them without a time zone in
+// nvdDateLayout (e.g., "2021-06-01T13:15:07.647"), meaning UTC; RFC 3339 timestamps, as
+// served by some mirrors, are accepted too.
+type NVDTime struct {
+	time.Time
+}
+
+// nvdTimeLayouts lists the timestamp formats accepted by NVDTime.UnmarshalJSON.
+var nvdTimeLayouts = []string{nvdDateLayout, "2006-01-02T15:04:05", time.RFC3339Nano}
+
+// UnmarshalJSON decodes an NVD timestamp. An empty string or null leaves the time zero.
+func (t *NVDTime) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == "" {
+		return nil
+	}
+	for _, layout := range nvdTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid NVD timestamp %q", value)
 }
 
 // NewCVEClient creates a new CVE client instance configured to communicate with the NIST NVD API.
-// The client is initialized with a 30-second timeout for HTTP requests and uses the official
-// NVD CVE API 2.0 endpoint.
+// The client is initialized with a 30-second timeout for HTTP requests, uses the official
+// NVD CVE API 2.0 endpoint and spaces requests by DefaultRequestInterval.
+//
+// The endpoint is replaced by the NVD_BASE_URL environment variable when it is set, and
+// by WithBaseURL above both. The resulting base URL must be an absolute http or https URL
+// without query or fragment, as the search parameters are appended to it.
+//
+// Parameters:
+//   - opts: Optional client settings (e.g., WithRequestInterval)
 //
 // Returns:
 //   - *CVEClient: A ready-to-use CVE client instance
 //
+// Panics:
+//   - Errors.Error with code 400: If the configured base URL is invalid
+//
 // Example:
 //
 //	client := NewCVEClient()
 //	assessment, err := client.AssessTechnologyVulnerabilities("nginx", "1.21.0")
-func NewCVEClient() *CVEClient {
-	return &CVEClient{
+func NewCVEClient(opts ...ClientOption) *CVEClient {
+	client := &CVEClient{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		baseURL: "https://services.nvd.nist.gov/rest/json/cves/2.0",
+		baseURL:         DefaultBaseURL,
+		requestInterval: DefaultRequestInterval,
+		limiter:         Lookup.Shared(),
+	}
+	if mirror := strings.TrimSpace(os.Getenv(BaseURLEnv)); mirror != "" {
+		client.baseURL = mirror
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.baseURL = validateBaseURL(client.baseURL)
+	return client
+}
+
+// validateBaseURL checks that baseURL is an absolute http(s) URL the search query can be
+// appended to and returns it without a trailing slash.
+//
+// Panics:
+//   - Errors.Error with code 400: If baseURL is not a valid NVD endpoint
+func validateBaseURL(baseURL string) string {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+		parsed.RawQuery != "" || parsed.Fragment != "" {
+		panic(Errors.Error{
+			Code: 400,
+			Message: fmt.Sprintf("CVE Client error occurred. This could be due to:\n- NVD base URL %q is not an absolute http or https URL"+
+				"\n- NVD base URL contains a query or fragment\n- %s is misconfigured", baseURL, BaseURLEnv),
+			Source:      "CVE Client",
+			IsRetryable: false,
+		})
 	}
+	return strings.TrimSuffix(baseURL, "/")
 }
 
 // AssessTechnologyVulnerabilities checks for CVEs affecting a specific technology and version.
@@ -109,13 +435,28 @@ func NewCVEClient() *CVEClient {
 // The method normalizes technology names for better search accuracy and aggregates
 // vulnerability data including severity counts and CVSS scores.
 //
+// By default every CVE matching the keyword search is fetched. Options such as
+// WithMinimumSeverity and WithPublishedSince narrow the assessment to keep it focused on
+// relevant vulnerabilities. Every page of a query is read, so the counts are not limited
+// to the first page of a popular keyword.
+//
+// NVD entries that cannot be parsed are skipped and counted in DroppedEntries. When they
+// outnumber the parsed ones the assessment fails instead, as its counts would be misleading.
+//
+// Successful assessments are cached for the lifetime of the client, keyed by the
+// normalized technology, version and options (the publication date by day). Callers
+// receive their own copy of the cached assessment. A panic during the lookup fails the
+// assessment with an error, for the caller and every caller waiting for it.
+//
 // Parameters:
 //   - technology: Technology name (e.g., "nginx", "Apache", "PHP")
 //   - version: Technology version string (e.g., "1.21.0", "2.4.41")
+//   - opts: Optional query settings (e.g., WithMinimumSeverity("HIGH"))
 //
 // Returns:
 //   - *VulnerabilityAssessment: Complete assessment with CVEs and risk analysis
-//   - error: Error if the search or analysis fails
+//   - error: Error if the search or analysis fails, an Errors.Error with code 401 for an
+//     unknown minimum severity
 //
 // Example:
 //
@@ -125,53 +466,190 @@ func NewCVEClient() *CVEClient {
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Found %d CVEs with risk level: %s\n", assessment.CVECount, assessment.RiskLevel)
-func (c *CVEClient) AssessTechnologyVulnerabilities(technology, version string) (*VulnerabilityAssessment, error) {
+func (c *CVEClient) AssessTechnologyVulnerabilities(technology, version string, opts ...AssessmentOption) (*VulnerabilityAssessment, error) {
+	cfg := assessmentConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
 	// Normalize technology name for search
 	normalizedTech := normalizeTechnologyName(technology)
 
-	// Search for CVEs
-	cves, err := c.searchCVEs(normalizedTech, version)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search CVEs: %w", err)
+	key := fmt.Sprintf("%s|%s|%s|%s", normalizedTech, version, cfg.minSeverity,
+		cfg.publishedSince.UTC().Truncate(24*time.Hour).Format(time.DateOnly))
+	c.cacheMu.Lock()
+	if c.cache == nil {
+		c.cache = make(map[string]*assessmentCall)
+	}
+	call, cached := c.cache[key]
+	if !cached {
+		call = &assessmentCall{done: make(chan struct{})}
+		c.cache[key] = call
+	}
+	c.cacheMu.Unlock()
+
+	if !cached {
+		func() {
+			// Waiting callers are released even when the assessment panics.
+			defer func() {
+				if r := recover(); r != nil {
+					call.assessment, call.err = nil, fmt.Errorf("failed to assess vulnerabilities: %v", r)
+				}
+				if call.err != nil {
+					// Do not keep failures, a later scan may succeed.
+					c.cacheMu.Lock()
+					delete(c.cache, key)
+					c.cacheMu.Unlock()
+				}
+				close(call.done)
+			}()
+			call.assessment, call.err = c.assess(technology, normalizedTech, version, cfg)
+		}()
+	}
+	<-call.done
+	if call.err != nil {
+		return nil, call.err
+	}
+	assessment := *call.assessment
+	return &assessment, nil
+}
+
+// assess performs the NVD queries and analysis behind AssessTechnologyVulnerabilities.
+func (c *CVEClient) assess(technology, normalizedTech, version string, cfg assessmentConfig) (*VulnerabilityAssessment, error) {
+	// Search for CVEs, one query per severity and date range when filtering. A CVE
+	// published on the boundary of two ranges is returned by both.
+	var cves []CVEResult
+	seen := make(map[string]bool)
+	dropped := 0
+	for _, filter := range cfg.queryFilters(time.Now().UTC()) {
+		found, skipped, err := c.searchCVEs(cfg.requestContext(), normalizedTech, version, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search CVEs: %w", err)
+		}
+		for _, cve := range found {
+			if cve.ID != "" && seen[cve.ID] {
+				continue
+			}
+			seen[cve.ID] = true
+			cves = append(cves, cve)
+		}
+		dropped += skipped
+	}
+
+	// An assessment built from a minority of the entries would pass for "no vulnerabilities"
+	if dropped > len(cves) {
+		return nil, fmt.Errorf("failed to parse CVEs: %d of %d NVD entries could not be parsed", dropped, dropped+len(cves))
+	}
+
+	// Keep the CVEs of the requested severities and publication window, should the
+	// endpoint (e.g., a mirror) ignore the filters
+	if severities := cfg.severitiesAtOrAbove(); severities != nil || !cfg.publishedSince.IsZero() {
+		kept := cves[:0]
+		for _, cve := range cves {
+			if severities != nil && !slices.Contains(severities, cve.Severity) {
+				continue
+			}
+			if !cfg.publishedSince.IsZero() && cve.Published.Before(cfg.publishedSince) {
+				continue
+			}
+			kept = append(kept, cve)
+		}
+		cves = kept
 	}
 
 	// Analyze the results
 	assessment := c.analyzeCVEs(technology, version, cves)
+	assessment.DroppedEntries = dropped
 
 	return assessment, nil
 }
 
 // searchCVEs performs the actual search against the NVD database using the CVE API 2.0.
-// It constructs an HTTP request with appropriate headers, executes the search,
-// and parses the JSON response into CVEResult structures.
+// It requests the pages of the query one after the other (startIndex) until every one of
+// the totalResults matches has been read, so the assessment is not limited to the first
+// page. Entries which cannot be parsed are skipped rather than failing the whole search,
+// see parseNVDResponse.
 //
 // Parameters:
+//   - ctx: Context bounding the requests
 //   - technology: Normalized technology name
 //   - version: Technology version string
+//   - filter: Optional severity and publication date filters for this query
 //
 // Returns:
 //   - []CVEResult: List of matching CVE entries
-//   - error: Error if the request fails or response cannot be parsed
-func (c *CVEClient) searchCVEs(technology, version string) ([]CVEResult, error) {
+//   - int: Number of entries dropped because they could not be parsed
+//   - error: Error if a request fails or a response contains no usable data
+func (c *CVEClient) searchCVEs(ctx context.Context, technology, version string, filter nvdQueryFilter) ([]CVEResult, int, error) {
 	// Build search query
 	query := buildSearchQuery(technology, version)
+	requestURL := fmt.Sprintf("%s?keywordSearch=%s&resultsPerPage=%d", c.baseURL, url.QueryEscape(query), nvdPageSize)
+	if filter.severity != "" {
+		requestURL += "&cvssV3Severity=" + url.QueryEscape(filter.severity)
+	}
+	if !filter.pubStart.IsZero() {
+		requestURL += "&pubStartDate=" + url.QueryEscape(filter.pubStart.UTC().Format(nvdDateLayout)) +
+			"&pubEndDate=" + url.QueryEscape(filter.pubEnd.UTC().Format(nvdDateLayout))
+	}
 
-	// Make request to NVD API
-	requestURL := fmt.Sprintf("%s?keywordSearch=%s&resultsPerPage=100", c.baseURL, url.QueryEscape(query))
+	var cves []CVEResult
+	dropped := 0
+	for startIndex := 0; ; {
+		pageURL := requestURL
+		if startIndex > 0 {
+			pageURL += fmt.Sprintf("&startIndex=%d", startIndex)
+		}
+		nvdResp, skipped, err := c.fetchNVDPage(ctx, pageURL)
+		if err != nil {
+			return nil, 0, err
+		}
+		cves = append(cves, c.convertNVDToCVEResults(nvdResp)...)
+		dropped += skipped
+
+		// A page without entries ends the query, whatever totalResults claims
+		read := len(nvdResp.Vulnerabilities) + skipped
+		if read == 0 || startIndex+read >= nvdResp.TotalResults {
+			return cves, dropped, nil
+		}
+		startIndex += read
+	}
+}
 
-	req, err := http.NewRequest("GET", requestURL, nil)
+// fetchNVDPage requests a single page of an NVD query and parses the response.
+//
+// Parameters:
+//   - ctx: Context bounding the request
+//   - requestURL: URL of the page, search parameters included
+//
+// Returns:
+//   - NVDResponse: Response holding every vulnerability of the page that could be parsed
+//   - int: Number of entries dropped because they could not be parsed
+//   - error: Error if the request fails or the response contains no usable data
+func (c *CVEClient) fetchNVDPage(ctx context.Context, requestURL string) (NVDResponse, int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return NVDResponse{}, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Add headers
+	// Add headers. Gzip is requested explicitly, so the body is decompressed by
+	// decodedBody whatever the transport (NVD compresses large pages).
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
 	req.Header.Set("User-Agent", "AntiGinx-CVE-Client/1.0")
 
-	// Execute request
+	// Execute request once a lookup slot is free, holding it until the body is read
+	c.waitForRateLimit()
+	release, err := c.limiter.Acquire(ctx)
+	if err != nil {
+		return NVDResponse{}, 0, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer release()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return NVDResponse{}, 0, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -180,25 +658,161 @@ func (c *CVEClient) searchCVEs(technology, version string) ([]CVEResult, error)
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+		return NVDResponse{}, 0, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
 	}
 
 	// Read response
-	body, err := io.ReadAll(resp.Body)
+	reader, err := decodedBody(resp)
+	if err != nil {
+		return NVDResponse{}, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	body, err := io.ReadAll(reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return NVDResponse{}, 0, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Parse response
+	nvdResp, dropped, err := parseNVDResponse(body)
+	if err != nil {
+		return NVDResponse{}, 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nvdResp, dropped, nil
+}
+
+// decodedBody returns a reader over the decoded response body, decompressing a body sent
+// with Content-Encoding gzip (or its legacy alias x-gzip). Bodies without a content coding,
+// or already decompressed by the transport, are returned as they are.
+//
+// Parameters:
+//   - resp: Response of the NVD API
+//
+// Returns:
+//   - io.Reader: Reader over the decoded body
+//   - error: Error if the body uses another coding or is not valid gzip
+func decodedBody(resp *http.Response) (io.Reader, error) {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if resp.Uncompressed || encoding == "" || encoding == "identity" {
+		return resp.Body, nil
+	}
+	if encoding != "gzip" && encoding != "x-gzip" {
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip body: %w", err)
+	}
+	return reader, nil
+}
+
+// parseNVDResponse decodes an NVD response body while tolerating partial data.
+// Vulnerability entries are decoded one by one, so an entry with unexpected field
+// types is skipped instead of discarding the whole page. A body truncated inside the
+// "vulnerabilities" array keeps the entries read so far and counts the missing ones
+// (at least one) as dropped, using resultsPerPage and totalResults when present.
+//
+// Parameters:
+//   - body: Raw response body
+//
+// Returns:
+//   - NVDResponse: Response holding every vulnerability that could be parsed
+//   - int: Number of vulnerability entries dropped
+//   - error: Error if the body is not a JSON object or ends before any vulnerability data
+func parseNVDResponse(body []byte) (NVDResponse, int, error) {
 	var nvdResp NVDResponse
-	if err := json.Unmarshal(body, &nvdResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nvdResp, 0, errors.New("response is not a JSON object")
+	}
+
+	dropped := 0
+	seenVulnerabilities := false
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		key, _ := tok.(string)
+		switch key {
+		case "resultsPerPage":
+			err = dec.Decode(&nvdResp.ResultsPerPage)
+		case "startIndex":
+			err = dec.Decode(&nvdResp.StartIndex)
+		case "totalResults":
+			err = dec.Decode(&nvdResp.TotalResults)
+		case "vulnerabilities":
+			seenVulnerabilities = true
+			var skipped int
+			skipped, err = decodeNVDVulnerabilities(dec, &nvdResp)
+			dropped += skipped
+		default:
+			var ignored json.RawMessage
+			err = dec.Decode(&ignored)
+		}
+		if err != nil {
+			if !seenVulnerabilities {
+				return nvdResp, 0, err
+			}
+			// Truncated body: count the entries that never arrived.
+			dropped += max(1, expectedNVDEntries(nvdResp)-len(nvdResp.Vulnerabilities)-dropped)
+			break
+		}
+	}
+	return nvdResp, dropped, nil
+}
+
+// decodeNVDVulnerabilities reads the "vulnerabilities" array from dec, appending every
+// entry that can be decoded to nvdResp and returning the number of skipped entries.
+// A non-nil error means the array itself could not be read to its end.
+func decodeNVDVulnerabilities(dec *json.Decoder, nvdResp *NVDResponse) (int, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, err
+	}
+	if tok == nil {
+		return 0, nil
+	}
+	if tok != json.Delim('[') {
+		return 0, errors.New("vulnerabilities is not an array")
+	}
+
+	skipped := 0
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return skipped, err
+		}
+		var vuln NVDVulnerability
+		if err := json.Unmarshal(raw, &vuln); err != nil || vuln.CVE.ID == "" {
+			skipped++
+			continue
+		}
+		nvdResp.Vulnerabilities = append(nvdResp.Vulnerabilities, vuln)
 	}
+	_, err = dec.Token()
+	return skipped, err
+}
 
-	// Convert to our format
-	cves := c.convertNVDToCVEResults(nvdResp)
+// expectedNVDEntries returns how many vulnerabilities the page announced, or 0 when unknown.
+func expectedNVDEntries(nvdResp NVDResponse) int {
+	expected := nvdResp.TotalResults - nvdResp.StartIndex
+	if nvdResp.ResultsPerPage > 0 && (expected <= 0 || nvdResp.ResultsPerPage < expected) {
+		expected = nvdResp.ResultsPerPage
+	}
+	return expected
+}
 
-	return cves, nil
+// waitForRateLimit blocks until the request interval has passed since the previous
+// NVD request of this client.
+func (c *CVEClient) waitForRateLimit() {
+	if c.requestInterval <= 0 {
+		return
+	}
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	if wait := c.requestInterval - time.Since(c.lastRequest); wait > 0 {
+		time.Sleep(wait)
+	}
+	c.lastRequest = time.Now()
 }
 
 // buildSearchQuery creates an optimized search query for the NVD API by combining
@@ -276,17 +890,23 @@ func (c *CVEClient) convertNVDToCVEResults(nvdResp NVDResponse) []CVEResult {
 	for _, vuln := range nvdResp.Vulnerabilities {
 		cve := CVEResult{
 			ID:        vuln.CVE.ID,
-			Published: vuln.CVE.Published,
-			Modified:  vuln.CVE.Modified,
+			Published: vuln.CVE.Published.Time,
+			Modified:  vuln.CVE.Modified.Time,
 		}
 
 		// Extract description
-		for _, desc := range vuln.CVE.Description.DescriptionData {
+		for _, desc := range vuln.CVE.Descriptions {
 			if desc.Lang == "en" {
 				cve.Description = desc.Value
 				break
 			}
 		}
+		for _, desc := range vuln.CVE.Description.DescriptionData {
+			if desc.Lang == "en" && cve.Description == "" {
+				cve.Description = desc.Value
+				break
+			}
+		}
 
 		// Extract CVSS score and severity
 		if len(vuln.CVE.Metrics.CVSSMetricV31) > 0 {
@@ -0,0 +1,587 @@
+package CVE
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Lookup"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const emptyNVDResponse = `{"resultsPerPage":0,"startIndex":0,"totalResults":0,"vulnerabilities":[]}`
+
+// newTestClient creates a CVEClient pointed at a local test server which records
+// every received query string and answers with the given NVD response body.
+func newTestClient(t *testing.T, body string) (*CVEClient, func() []string) {
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &CVEClient{
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+	}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), queries...)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_SeverityFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []AssessmentOption
+		expectedQuery []string
+	}{
+		{
+			name:          "No filter fetches everything",
+			opts:          nil,
+			expectedQuery: []string{"keywordSearch=nginx&resultsPerPage=2000"},
+		},
+		{
+			name: "High filter requests HIGH and CRITICAL",
+			opts: []AssessmentOption{WithMinimumSeverity("high")},
+			expectedQuery: []string{
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=HIGH",
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=CRITICAL",
+			},
+		},
+		{
+			name:          "Critical filter requests CRITICAL only",
+			opts:          []AssessmentOption{WithMinimumSeverity("CRITICAL")},
+			expectedQuery: []string{"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=CRITICAL"},
+		},
+		{
+			name: "Medium filter requests MEDIUM, HIGH and CRITICAL",
+			opts: []AssessmentOption{WithMinimumSeverity("medium")},
+			expectedQuery: []string{
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=MEDIUM",
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=HIGH",
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=CRITICAL",
+			},
+		},
+		{
+			name: "Low filter requests every severity",
+			opts: []AssessmentOption{WithMinimumSeverity("low")},
+			expectedQuery: []string{
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=LOW",
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=MEDIUM",
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=HIGH",
+				"keywordSearch=nginx&resultsPerPage=2000&cvssV3Severity=CRITICAL",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, queries := newTestClient(t, emptyNVDResponse)
+
+			_, err := client.AssessTechnologyVulnerabilities("Nginx", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			got := queries()
+			if len(got) != len(tt.expectedQuery) {
+				t.Fatalf("Expected %d requests, got %d: %v", len(tt.expectedQuery), len(got), got)
+			}
+			for i := range got {
+				if got[i] != tt.expectedQuery[i] {
+					t.Errorf("Expected query %q, got %q", tt.expectedQuery[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_MediumSeverity(t *testing.T) {
+	body := `{"resultsPerPage":3,"startIndex":0,"totalResults":3,"vulnerabilities":[
+		{"cve":{"id":"CVE-2024-0001","published":"2024-01-01T00:00:00.000",
+			"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":9.8,"baseSeverity":"CRITICAL"}}]}}},
+		{"cve":{"id":"CVE-2024-0002","published":"2024-01-01T00:00:00.000",
+			"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":5.3,"baseSeverity":"MEDIUM"}}]}}},
+		{"cve":{"id":"CVE-2024-0003","published":"2024-01-01T00:00:00.000",
+			"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":2.1,"baseSeverity":"LOW"}}]}}}
+	]}`
+	client, queries := newTestClient(t, body)
+
+	assessment, err := client.AssessTechnologyVulnerabilities("nginx", "", WithMinimumSeverity("MEDIUM"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := queries(); len(got) != 3 || !strings.HasSuffix(got[0], "&cvssV3Severity=MEDIUM") {
+		t.Errorf("Expected one request per severity from MEDIUM, got %v", got)
+	}
+	// The server ignores the filter, like some mirrors; the LOW CVE is still left out.
+	if assessment.CVECount != 2 || assessment.LowSeverity != 0 {
+		t.Errorf("Expected the LOW CVE to be filtered out, got %+v", assessment.CVEs)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_UnknownSeverity(t *testing.T) {
+	client, queries := newTestClient(t, emptyNVDResponse)
+
+	_, err := client.AssessTechnologyVulnerabilities("nginx", "", WithMinimumSeverity("severe"))
+
+	var cveErr *Errors.Error
+	if !errors.As(err, &cveErr) || cveErr.Code != 401 {
+		t.Fatalf("Expected Errors.Error with code 401, got %v", err)
+	}
+	if got := queries(); len(got) != 0 {
+		t.Errorf("Expected no NVD request for an invalid severity, got %v", got)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_PublishedSince(t *testing.T) {
+	since := time.Now().UTC().AddDate(-2, 0, 0).Truncate(time.Second)
+	recent := since.AddDate(0, 1, 0).Format(nvdDateLayout)
+	body := `{"resultsPerPage":2,"startIndex":0,"totalResults":2,"vulnerabilities":[
+		{"cve":{"id":"CVE-2009-0001","published":"2009-01-01T00:00:00.000","lastModified":"2009-01-01T00:00:00.000",
+			"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":9.8,"baseSeverity":"CRITICAL"}}]}}},
+		{"cve":{"id":"CVE-RECENT-0001","published":"` + recent + `","lastModified":"` + recent + `",
+			"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":5.3,"baseSeverity":"MEDIUM"}}]}}}
+	]}`
+	client, queries := newTestClient(t, body)
+
+	assessment, err := client.AssessTechnologyVulnerabilities("Apache", "", WithPublishedSince(since))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := queries()
+	if len(got) == 0 {
+		t.Fatal("Expected at least one request to be issued")
+	}
+	values, err := url.ParseQuery(got[0])
+	if err != nil {
+		t.Fatalf("Failed to parse query %q: %v", got[0], err)
+	}
+	if values.Get("pubStartDate") != since.Format(nvdDateLayout) {
+		t.Errorf("Expected pubStartDate %q, got %q", since.Format(nvdDateLayout), values.Get("pubStartDate"))
+	}
+	if values.Get("pubEndDate") == "" {
+		t.Error("Expected pubEndDate to accompany pubStartDate")
+	}
+
+	if assessment.CVECount != 1 || assessment.CVEs[0].ID != "CVE-RECENT-0001" {
+		t.Errorf("Expected only the recent CVE to remain, got %+v", assessment.CVEs)
+	}
+	if assessment.HighSeverity != 0 || assessment.MediumSeverity != 1 {
+		t.Errorf("Expected old CRITICAL CVE to be excluded from counts, got high=%d medium=%d",
+			assessment.HighSeverity, assessment.MediumSeverity)
+	}
+}
+
+func TestAssessmentConfig_QueryFilters_SplitsLongRanges(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := assessmentConfig{publishedSince: now.AddDate(-1, 0, 0)}
+
+	filters := cfg.queryFilters(now)
+	if len(filters) != 4 {
+		t.Fatalf("Expected a one year window to be split into 4 requests, got %d", len(filters))
+	}
+	for _, f := range filters {
+		if f.pubEnd.Sub(f.pubStart) > maxNVDDateRange {
+			t.Errorf("Window %s - %s exceeds NVD maximum range", f.pubStart, f.pubEnd)
+		}
+	}
+	if !filters[0].pubStart.Equal(cfg.publishedSince) || !filters[len(filters)-1].pubEnd.Equal(now) {
+		t.Errorf("Expected windows to cover %s - %s, got %s - %s", cfg.publishedSince, now,
+			filters[0].pubStart, filters[len(filters)-1].pubEnd)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_Pagination(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		id := "CVE-2024-0001"
+		if r.URL.Query().Get("startIndex") == "1" {
+			id = "CVE-2024-0002"
+		}
+		_, _ = fmt.Fprintf(w, `{"resultsPerPage":1,"startIndex":0,"totalResults":2,"vulnerabilities":[
+			{"cve":{"id":%q,"published":"2024-01-01T00:00:00.000",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":7.5,"baseSeverity":"HIGH"}}]}}}]}`, id)
+	}))
+	t.Cleanup(server.Close)
+	client := &CVEClient{httpClient: server.Client(), baseURL: server.URL}
+
+	assessment, err := client.AssessTechnologyVulnerabilities("nginx", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(queries) != 2 || !strings.HasSuffix(queries[1], "&startIndex=1") {
+		t.Errorf("Expected the second page to be requested with startIndex, got %v", queries)
+	}
+	if assessment.CVECount != 2 {
+		t.Errorf("Expected the CVEs of both pages, got %+v", assessment.CVEs)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_SharedLookups(t *testing.T) {
+	client, queries := newTestClient(t, emptyNVDResponse)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.AssessTechnologyVulnerabilities("nginx", ""); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := client.AssessTechnologyVulnerabilities("Nginx", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := queries(); len(got) != 1 {
+		t.Errorf("Expected concurrent and repeated assessments to share 1 request, got %d: %v", len(got), got)
+	}
+
+	if _, err := client.AssessTechnologyVulnerabilities("Apache", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := queries(); len(got) != 2 {
+		t.Errorf("Expected a different technology to issue its own request, got %d", len(got))
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_FailuresNotCached(t *testing.T) {
+	var calls int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(emptyNVDResponse))
+	}))
+	t.Cleanup(server.Close)
+	client := NewCVEClient(WithBaseURL(server.URL), WithRequestInterval(0))
+
+	if _, err := client.AssessTechnologyVulnerabilities("nginx", ""); err == nil {
+		t.Fatal("Expected first assessment to fail")
+	}
+	if _, err := client.AssessTechnologyVulnerabilities("nginx", ""); err != nil {
+		t.Fatalf("Expected retry after failure to succeed, got %v", err)
+	}
+}
+
+// panickingTransport panics on its first request and answers the later ones with body.
+type panickingTransport struct {
+	calls atomic.Int32
+	body  string
+}
+
+func (p *panickingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if p.calls.Add(1) == 1 {
+		panic("malformed response")
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: r,
+		Body: io.NopCloser(strings.NewReader(p.body))}, nil
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_PanicNotCached(t *testing.T) {
+	client := &CVEClient{
+		httpClient: &http.Client{Transport: &panickingTransport{body: emptyNVDResponse}},
+		baseURL:    "http://nvd.test",
+	}
+
+	if _, err := client.AssessTechnologyVulnerabilities("nginx", ""); err == nil {
+		t.Fatal("Expected the panicking assessment to fail")
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.AssessTechnologyVulnerabilities("nginx", "")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected retry after a panic to succeed, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Retry blocked on the assessment that panicked")
+	}
+}
+
+func TestCVEClient_RequestInterval(t *testing.T) {
+	client, _ := newTestClient(t, emptyNVDResponse)
+	client.requestInterval = 50 * time.Millisecond
+
+	start := time.Now()
+	for _, tech := range []string{"nginx", "apache", "php"} {
+		if _, err := client.AssessTechnologyVulnerabilities(tech, ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Expected 3 requests to be spaced by the interval, took only %s", elapsed)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_PartialData(t *testing.T) {
+	const validEntry = `{"cve":{"id":"%s","published":"2024-01-02T00:00:00.000","lastModified":"2024-01-03T00:00:00.000",` +
+		`"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":%s,"baseSeverity":"HIGH"}}]}}}`
+	tests := []struct {
+		name            string
+		body            string
+		expectedIDs     []string
+		expectedDropped int
+	}{
+		{
+			name: "Malformed entries are skipped",
+			body: `{"resultsPerPage":4,"startIndex":0,"totalResults":4,"vulnerabilities":[` +
+				fmt.Sprintf(validEntry, "CVE-2024-0001", "7.5") + `,` +
+				`{"cve":{"id":"CVE-2024-0002","published":"not-a-date"}},` +
+				fmt.Sprintf(validEntry, "CVE-2024-0003", `"high"`) + `,` +
+				fmt.Sprintf(validEntry, "CVE-2024-0004", "8.1") + `]}`,
+			expectedIDs:     []string{"CVE-2024-0001", "CVE-2024-0004"},
+			expectedDropped: 2,
+		},
+		{
+			name: "Truncated body keeps entries read so far",
+			body: `{"resultsPerPage":3,"startIndex":0,"totalResults":3,"vulnerabilities":[` +
+				fmt.Sprintf(validEntry, "CVE-2024-0001", "7.5") + `,` +
+				fmt.Sprintf(validEntry, "CVE-2024-0002", "5.0") + `,{"cve":{"id":"CVE-20`,
+			expectedIDs:     []string{"CVE-2024-0001", "CVE-2024-0002"},
+			expectedDropped: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, tt.body)
+
+			assessment, err := client.AssessTechnologyVulnerabilities("nginx", "")
+			if err != nil {
+				t.Fatalf("Expected partial data to be accepted, got %v", err)
+			}
+			var ids []string
+			for _, cve := range assessment.CVEs {
+				ids = append(ids, cve.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.expectedIDs) {
+				t.Errorf("Expected CVEs %v, got %v", tt.expectedIDs, ids)
+			}
+			if assessment.DroppedEntries != tt.expectedDropped {
+				t.Errorf("Expected %d dropped entries, got %d", tt.expectedDropped, assessment.DroppedEntries)
+			}
+		})
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_MostlyUnparseable(t *testing.T) {
+	const entry = `{"cve":{"id":"%s","published":%s,"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":9.8,"baseSeverity":"CRITICAL"}}]}}}`
+	client, _ := newTestClient(t, `{"resultsPerPage":3,"startIndex":0,"totalResults":3,"vulnerabilities":[`+
+		fmt.Sprintf(entry, "CVE-2024-0001", `"2024-01-02T00:00:00.000"`)+`,`+
+		fmt.Sprintf(entry, "CVE-2024-0002", `"01/02/2024"`)+`,`+
+		fmt.Sprintf(entry, "CVE-2024-0003", `20240102`)+`]}`)
+
+	assessment, err := client.AssessTechnologyVulnerabilities("nginx", "")
+	if err == nil {
+		t.Fatalf("Expected an error when most entries cannot be parsed, got %+v", assessment)
+	}
+	if !strings.Contains(err.Error(), "2 of 3 NVD entries") {
+		t.Errorf("Expected the error to count the dropped entries, got %v", err)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_UnparseableBody(t *testing.T) {
+	client, _ := newTestClient(t, `<html>Service Unavailable</html>`)
+
+	if _, err := client.AssessTechnologyVulnerabilities("nginx", ""); err == nil {
+		t.Error("Expected a body without any JSON data to fail")
+	}
+}
+
+func TestNewCVEClient_BaseURL(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		_, _ = w.Write([]byte(emptyNVDResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("Default", func(t *testing.T) {
+		t.Setenv(BaseURLEnv, "")
+		if client := NewCVEClient(); client.baseURL != DefaultBaseURL {
+			t.Errorf("Expected default base URL, got %q", client.baseURL)
+		}
+	})
+
+	t.Run("Environment mirror is used for requests", func(t *testing.T) {
+		t.Setenv(BaseURLEnv, server.URL+"/nvd/rest/json/cves/2.0/")
+		client := NewCVEClient(WithRequestInterval(0))
+		if _, err := client.AssessTechnologyVulnerabilities("nginx", ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(paths) != 1 || paths[0] != "/nvd/rest/json/cves/2.0" {
+			t.Errorf("Expected one request to the mirror path, got %v", paths)
+		}
+	})
+
+	t.Run("Option overrides environment", func(t *testing.T) {
+		t.Setenv(BaseURLEnv, "https://mirror.internal/nvd")
+		if client := NewCVEClient(WithBaseURL(server.URL)); client.baseURL != server.URL {
+			t.Errorf("Expected WithBaseURL to win, got %q", client.baseURL)
+		}
+	})
+}
+
+func TestNewCVEClient_InvalidBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"mirror.internal/nvd", "ftp://mirror.internal/nvd", "https://", "https://mirror.internal/nvd?x=1", "://bad"} {
+		t.Run(baseURL, func(t *testing.T) {
+			t.Setenv(BaseURLEnv, baseURL)
+			defer func() {
+				err, ok := recover().(Errors.Error)
+				if !ok || err.Code != 400 {
+					t.Errorf("Expected panic with code 400, got %v", err)
+				}
+			}()
+			NewCVEClient()
+		})
+	}
+}
+
+func TestCVEClient_LookupConcurrencyBound(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		_, _ = w.Write([]byte(emptyNVDResponse))
+	}))
+	t.Cleanup(server.Close)
+	client := NewCVEClient(WithBaseURL(server.URL), WithRequestInterval(0), WithLimiter(Lookup.NewLimiter(2)))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.AssessTechnologyVulnerabilities(fmt.Sprintf("tech%d", i), ""); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 || peak == 0 {
+		t.Errorf("Expected at most 2 concurrent NVD requests, got %d", peak)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_RealNVDFormat(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/nvd-nginx.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _ := newTestClient(t, string(fixture))
+
+	assessment, err := client.AssessTechnologyVulnerabilities("nginx", "")
+	if err != nil {
+		t.Fatalf("Expected the NVD response to parse, got %v", err)
+	}
+	if assessment.DroppedEntries != 0 || assessment.CVECount != 2 {
+		t.Fatalf("Expected both entries to be kept, got %d CVEs and %d dropped", assessment.CVECount, assessment.DroppedEntries)
+	}
+	cve := assessment.CVEs[0]
+	if want := time.Date(2021, 6, 1, 13, 15, 7, 647e6, time.UTC); !cve.Published.Equal(want) {
+		t.Errorf("Expected the zone-less timestamp to be read as %v, got %v", want, cve.Published)
+	}
+	if !strings.HasPrefix(cve.Description, "A security issue in nginx resolver") {
+		t.Errorf("Expected the English description, got %q", cve.Description)
+	}
+	if assessment.RiskLevel != "CRITICAL" {
+		t.Errorf("Expected HIGH CVEs to make the risk CRITICAL, got %s", assessment.RiskLevel)
+	}
+}
+
+func TestCVEClient_AssessTechnologyVulnerabilities_GzipResponse(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/nvd-nginx.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write(fixture)
+	_ = writer.Close()
+
+	tests := []struct {
+		name    string
+		body    []byte
+		wantErr bool
+	}{
+		{name: "Gzip encoded NVD response", body: compressed.Bytes()},
+		{name: "Corrupt gzip body", body: []byte("not gzip"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+					t.Errorf("Expected gzip to be accepted, got Accept-Encoding %q", r.Header.Get("Accept-Encoding"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Content-Encoding", "gzip")
+				_, _ = w.Write(tt.body)
+			}))
+			defer server.Close()
+			client := NewCVEClient(WithBaseURL(server.URL), WithRequestInterval(0))
+
+			assessment, err := client.AssessTechnologyVulnerabilities("nginx", "")
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected a corrupt gzip body to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected the gzip encoded response to parse, got %v", err)
+			}
+			if assessment.CVECount != 2 || len(assessment.CVEs) != 2 || assessment.CVEs[0].ID != "CVE-2021-23017" {
+				t.Errorf("Expected the 2 fixture CVEs, got %+v", assessment.CVEs)
+			}
+		})
+	}
+}
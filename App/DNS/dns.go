@@ -0,0 +1,338 @@
+// Package DNS provides the DNS lookups used by tests that inspect a target's DNS
+// configuration rather than its HTTP response. TXT records are resolved by the standard
+// library resolver, which follows the system configuration. The standard library does not
+// expose CAA records, so CAA lookups send a minimal RFC 1035 query to the configured
+// nameserver over UDP, falling back to TCP for truncated answers. Every lookup is bounded
+// by the client's timeout and holds a slot of the shared external lookup limiter (see
+// package Lookup) while it runs.
+//
+// Tests depend on the Resolver interface so that lookups can be stubbed in unit tests.
+package DNS
+
+import (
+	"Engine-AntiGinx/App/Lookup"
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds a single DNS lookup, including the TCP fallback.
+	DefaultTimeout = 5 * time.Second
+
+	typeCAA   uint16 = 257
+	classINET uint16 = 1
+
+	rcodeSuccess  = 0
+	rcodeNXDomain = 3
+
+	maxUDPMessageSize = 4096
+)
+
+// CAARecord is a single Certification Authority Authorization record (RFC 8659).
+type CAARecord struct {
+	Flag  uint8  `json:"flag"`  // Record flags, 128 marks the property as critical
+	Tag   string `json:"tag"`   // Property tag (e.g., "issue", "issuewild", "iodef")
+	Value string `json:"value"` // Property value (e.g., "letsencrypt.org")
+}
+
+// Resolver performs the DNS lookups needed by DNS based tests.
+type Resolver interface {
+	// LookupCAA returns the CAA records published directly at domain.
+	// A domain without CAA records (including a non-existent domain) yields an empty slice.
+	LookupCAA(ctx context.Context, domain string) ([]CAARecord, error)
+
+	// LookupTXT returns the TXT records published at domain, each record's character
+	// strings joined together. A domain without TXT records yields an empty slice.
+	LookupTXT(ctx context.Context, domain string) ([]string, error)
+}
+
+// NameserverEnv names the environment variable setting the nameserver of CAA lookups
+// (host or host:port), for systems without /etc/resolv.conf.
+const NameserverEnv = "DNS_NAMESERVER"
+
+// errNoNameserver is returned by CAA lookups when no nameserver is configured.
+var errNoNameserver = errors.New("dns: no nameserver configured for CAA lookups, set " + NameserverEnv)
+
+// Client is the default Resolver. CAA queries go to a single nameserver, TXT lookups to the
+// system resolver.
+type Client struct {
+	Server   string          // Nameserver of CAA lookups in host:port form (empty = none configured)
+	Resolver *net.Resolver   // Resolver of TXT lookups (nil = net.DefaultResolver)
+	Timeout  time.Duration   // Timeout applied to every lookup, including the wait for a limiter slot
+	Limiter  *Lookup.Limiter // Bound on concurrent lookups shared with other clients (nil = unbounded)
+}
+
+// NewClient creates a Client sending CAA queries to the nameserver set in NameserverEnv, or
+// else to the first nameserver from /etc/resolv.conf. Without either, CAA lookups fail
+// with an error naming NameserverEnv; TXT lookups use the system resolver regardless.
+//
+// Returns:
+//   - *Client: Client with DefaultTimeout, bounded by the shared Lookup limiter
+//
+// Example:
+//
+//	records, err := DNS.NewClient().LookupCAA(context.Background(), "example.com")
+func NewClient() *Client {
+	return &Client{
+		Server:  configuredNameserver(os.Getenv(NameserverEnv), "/etc/resolv.conf"),
+		Timeout: DefaultTimeout,
+		Limiter: Lookup.Shared(),
+	}
+}
+
+// LookupCAA implements Resolver.
+func (c *Client) LookupCAA(ctx context.Context, domain string) ([]CAARecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	answers, err := c.query(ctx, domain, typeCAA)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]CAARecord, 0, len(answers))
+	for _, rdata := range answers {
+		record, err := parseCAA(rdata)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// LookupTXT implements Resolver. The character strings of a record are joined by the
+// standard library resolver, as required for SPF and DMARC records.
+func (c *Client) LookupTXT(ctx context.Context, domain string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	release, err := c.Limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resolver := c.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	records, err := resolver.LookupTXT(ctx, domain)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return []string{}, nil
+	}
+	return records, err
+}
+
+// query sends a question for domain and returns the raw RDATA of every answer of qtype.
+func (c *Client) query(ctx context.Context, domain string, qtype uint16) ([][]byte, error) {
+	if c.Server == "" {
+		return nil, errNoNameserver
+	}
+	id := uint16(rand.UintN(1 << 16))
+	msg, err := buildQuery(id, domain, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := c.Limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, err := exchange(ctx, "udp", c.Server, msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) >= 4 && resp[2]&0x02 != 0 {
+		// Truncated answer, repeat the query over TCP.
+		if resp, err = exchange(ctx, "tcp", c.Server, msg); err != nil {
+			return nil, err
+		}
+	}
+	return parseAnswers(resp, id, qtype)
+}
+
+// exchange sends msg to server and reads a single response.
+func exchange(ctx context.Context, network, server string, msg []byte) ([]byte, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if network == "tcp" {
+		framed := make([]byte, 2+len(msg))
+		binary.BigEndian.PutUint16(framed, uint16(len(msg)))
+		copy(framed[2:], msg)
+		if _, err := conn.Write(framed); err != nil {
+			return nil, err
+		}
+		reader := bufio.NewReader(conn)
+		var length uint16
+		if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+			return nil, err
+		}
+		resp := make([]byte, length)
+		if _, err := io.ReadFull(reader, resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	if _, err := conn.Write(msg); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, maxUDPMessageSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// buildQuery encodes a recursive query for a single question.
+func buildQuery(id uint16, domain string, qtype uint16) ([]byte, error) {
+	msg := make([]byte, 12, 12+len(domain)+6)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	binary.BigEndian.PutUint16(msg[2:], 0x0100) // RD: recursion desired
+	binary.BigEndian.PutUint16(msg[4:], 1)      // QDCOUNT
+
+	domain = strings.TrimSuffix(domain, ".")
+	if domain == "" {
+		return nil, errors.New("dns: empty domain")
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return nil, fmt.Errorf("dns: invalid domain %q", domain)
+		}
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0)
+	msg = binary.BigEndian.AppendUint16(msg, qtype)
+	msg = binary.BigEndian.AppendUint16(msg, classINET)
+	return msg, nil
+}
+
+// parseAnswers validates a response and returns the RDATA of every answer of qtype.
+// NXDOMAIN is treated as an empty answer.
+func parseAnswers(resp []byte, id uint16, qtype uint16) ([][]byte, error) {
+	if len(resp) < 12 {
+		return nil, errors.New("dns: response too short")
+	}
+	if binary.BigEndian.Uint16(resp[0:]) != id {
+		return nil, errors.New("dns: response id mismatch")
+	}
+	switch rcode := resp[3] & 0x0F; rcode {
+	case rcodeSuccess:
+	case rcodeNXDomain:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("dns: server returned rcode %d", rcode)
+	}
+
+	qdCount := int(binary.BigEndian.Uint16(resp[4:]))
+	anCount := int(binary.BigEndian.Uint16(resp[6:]))
+	offset := 12
+	for i := 0; i < qdCount; i++ {
+		next, err := skipName(resp, offset)
+		if err != nil {
+			return nil, err
+		}
+		offset = next + 4
+	}
+
+	var answers [][]byte
+	for i := 0; i < anCount; i++ {
+		next, err := skipName(resp, offset)
+		if err != nil {
+			return nil, err
+		}
+		if next+10 > len(resp) {
+			return nil, errors.New("dns: truncated resource record")
+		}
+		rrType := binary.BigEndian.Uint16(resp[next:])
+		rdLength := int(binary.BigEndian.Uint16(resp[next+8:]))
+		start := next + 10
+		if start+rdLength > len(resp) {
+			return nil, errors.New("dns: truncated resource record data")
+		}
+		if rrType == qtype {
+			answers = append(answers, resp[start:start+rdLength])
+		}
+		offset = start + rdLength
+	}
+	return answers, nil
+}
+
+// skipName returns the offset directly after the (possibly compressed) name at offset.
+func skipName(msg []byte, offset int) (int, error) {
+	for {
+		if offset >= len(msg) {
+			return 0, errors.New("dns: truncated name")
+		}
+		length := int(msg[offset])
+		switch {
+		case length == 0:
+			return offset + 1, nil
+		case length&0xC0 == 0xC0:
+			// Compression pointer terminates the name.
+			return offset + 2, nil
+		default:
+			offset += 1 + length
+		}
+	}
+}
+
+// parseCAA decodes CAA RDATA: flags, tag length, tag and value.
+func parseCAA(rdata []byte) (CAARecord, error) {
+	if len(rdata) < 2 {
+		return CAARecord{}, errors.New("dns: malformed CAA record")
+	}
+	tagLength := int(rdata[1])
+	if tagLength == 0 || 2+tagLength > len(rdata) {
+		return CAARecord{}, errors.New("dns: malformed CAA record tag")
+	}
+	return CAARecord{
+		Flag:  rdata[0],
+		Tag:   strings.ToLower(string(rdata[2 : 2+tagLength])),
+		Value: string(rdata[2+tagLength:]),
+	}, nil
+}
+
+// configuredNameserver returns the nameserver of CAA lookups: override (host or host:port)
+// when set, or else the first nameserver listed in a resolv.conf file. It returns an empty
+// string when neither names one.
+func configuredNameserver(override, resolvConf string) string {
+	if override = strings.TrimSpace(override); override != "" {
+		if _, _, err := net.SplitHostPort(override); err == nil {
+			return override
+		}
+		return net.JoinHostPort(override, "53")
+	}
+	data, err := os.ReadFile(resolvConf)
+	if err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && fields[0] == "nameserver" {
+				return net.JoinHostPort(fields[1], "53")
+			}
+		}
+	}
+	return ""
+}
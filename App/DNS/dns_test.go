@@ -0,0 +1,224 @@
+package DNS
+
+import (
+	"Engine-AntiGinx/App/Lookup"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// typeTXT is the TXT resource record type, answered by the fake server in TXT tests.
+const typeTXT uint16 = 16
+
+// startFakeServer answers every UDP query with the given rcode and answers of rrType,
+// copying the question section (dropping any additional records such as EDNS options) and
+// using a compression pointer for answer names.
+func startFakeServer(t *testing.T, rcode byte, rrType uint16, answers [][]byte) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			query := buf[:n]
+			end, err := skipName(query, 12)
+			if err != nil || end+4 > len(query) {
+				continue
+			}
+			resp := append([]byte{}, query[:12]...)
+			resp[2] = 0x81 // QR + RD
+			resp[3] = 0x80 | rcode
+			binary.BigEndian.PutUint16(resp[6:], uint16(len(answers)))
+			binary.BigEndian.PutUint16(resp[8:], 0)
+			binary.BigEndian.PutUint16(resp[10:], 0)
+			resp = append(resp, query[12:end+4]...)
+			for _, rdata := range answers {
+				resp = append(resp, 0xC0, 12) // pointer to the question name
+				resp = binary.BigEndian.AppendUint16(resp, rrType)
+				resp = binary.BigEndian.AppendUint16(resp, classINET)
+				resp = binary.BigEndian.AppendUint32(resp, 300)
+				resp = binary.BigEndian.AppendUint16(resp, uint16(len(rdata)))
+				resp = append(resp, rdata...)
+			}
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+// fakeResolver returns a standard library resolver sending its queries to server.
+func fakeResolver(server string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "udp", server)
+		},
+	}
+}
+
+// caaRData encodes CAA records as answer RDATA.
+func caaRData(records []CAARecord) [][]byte {
+	answers := make([][]byte, 0, len(records))
+	for _, r := range records {
+		rdata := append([]byte{r.Flag, byte(len(r.Tag))}, r.Tag...)
+		answers = append(answers, append(rdata, r.Value...))
+	}
+	return answers
+}
+
+func TestClient_LookupCAA(t *testing.T) {
+	records := []CAARecord{
+		{Flag: 0, Tag: "issue", Value: "letsencrypt.org"},
+		{Flag: 128, Tag: "IODEF", Value: "mailto:security@example.com"},
+	}
+	client := &Client{Server: startFakeServer(t, rcodeSuccess, typeCAA, caaRData(records)), Timeout: time.Second}
+
+	got, err := client.LookupCAA(context.Background(), "example.com.")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 records, got %+v", got)
+	}
+	if got[0] != records[0] {
+		t.Errorf("Expected %+v, got %+v", records[0], got[0])
+	}
+	if got[1].Flag != 128 || got[1].Tag != "iodef" || got[1].Value != records[1].Value {
+		t.Errorf("Expected lower-cased iodef record, got %+v", got[1])
+	}
+}
+
+func TestClient_LookupCAA_NXDomain(t *testing.T) {
+	client := &Client{Server: startFakeServer(t, rcodeNXDomain, typeCAA, nil), Timeout: time.Second}
+
+	got, err := client.LookupCAA(context.Background(), "missing.example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Expected no records, got %+v", got)
+	}
+}
+
+func TestClient_LookupCAA_ServerFailure(t *testing.T) {
+	client := &Client{Server: startFakeServer(t, 2, typeCAA, nil), Timeout: time.Second}
+
+	if _, err := client.LookupCAA(context.Background(), "example.com"); err == nil {
+		t.Error("Expected SERVFAIL to be reported as an error")
+	}
+}
+
+func TestClient_LookupTXT(t *testing.T) {
+	answers := [][]byte{
+		append([]byte{6}, "v=spf1"...),
+		append(append([]byte{12}, "v=spf1 -all "...), append([]byte{9}, "mx ~all!!"...)...),
+	}
+	client := &Client{Resolver: fakeResolver(startFakeServer(t, rcodeSuccess, typeTXT, answers)), Timeout: time.Second}
+
+	got, err := client.LookupTXT(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []string{"v=spf1", "v=spf1 -all mx ~all!!"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestClient_LookupTXT_Malformed(t *testing.T) {
+	answers := [][]byte{append([]byte{20}, "short"...)}
+	client := &Client{Resolver: fakeResolver(startFakeServer(t, rcodeSuccess, typeTXT, answers)), Timeout: time.Second}
+
+	if _, err := client.LookupTXT(context.Background(), "example.com"); err == nil {
+		t.Error("Expected error for TXT string longer than its record")
+	}
+}
+
+func TestClient_LookupTXT_Timeout(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	client := &Client{Resolver: fakeResolver(conn.LocalAddr().String()), Timeout: 50 * time.Millisecond}
+
+	start := time.Now()
+	if _, err := client.LookupTXT(context.Background(), "example.com"); err == nil {
+		t.Fatal("Expected timeout error from silent server")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected lookup to honour the client timeout, took %s", elapsed)
+	}
+}
+
+func TestClient_LookupWaitsForLimiter(t *testing.T) {
+	server := startFakeServer(t, 0, typeTXT, nil)
+	limiter := Lookup.NewLimiter(1)
+	client := &Client{Resolver: fakeResolver(server), Timeout: 50 * time.Millisecond, Limiter: limiter}
+
+	release, _ := limiter.Acquire(context.Background())
+	if _, err := client.LookupTXT(context.Background(), "example.com"); err == nil {
+		t.Fatal("Expected the lookup to time out while the only slot is taken")
+	}
+	release()
+	if _, err := client.LookupTXT(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Expected the lookup to run once the slot is free, got %v", err)
+	}
+}
+
+func TestBuildQuery_InvalidDomain(t *testing.T) {
+	for _, domain := range []string{"", "a..b", string(make([]byte, 64)) + ".com"} {
+		if _, err := buildQuery(1, domain, typeCAA); err == nil {
+			t.Errorf("Expected error for domain %q", domain)
+		}
+	}
+}
+
+func TestConfiguredNameserver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resolv.conf")
+	content := "# generated\nsearch local\nnameserver 10.0.0.2\nnameserver 10.0.0.3\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write resolv.conf: %v", err)
+	}
+	missing := filepath.Join(t.TempDir(), "missing")
+	tests := []struct {
+		name       string
+		override   string
+		resolvConf string
+		want       string
+	}{
+		{name: "resolv.conf", resolvConf: path, want: "10.0.0.2:53"},
+		{name: "Override host", override: "192.0.2.53", resolvConf: path, want: "192.0.2.53:53"},
+		{name: "Override host and port", override: "[2001:db8::53]:5353", resolvConf: path, want: "[2001:db8::53]:5353"},
+		{name: "Nothing configured", resolvConf: missing, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := configuredNameserver(tt.override, tt.resolvConf); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClient_LookupCAA_NoNameserver(t *testing.T) {
+	client := &Client{Timeout: time.Second}
+
+	if _, err := client.LookupCAA(context.Background(), "example.com"); !errors.Is(err, errNoNameserver) {
+		t.Errorf("Expected errNoNameserver without a configured nameserver, got %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+// Package Diff compares two saved scan reports and reports what changed between them.
+// It consumes the TestResultWrapper JSON produced by the reporters, so reports captured from
+// the backend stream can be compared directly to track a site's security posture over time.
+//
+// A finding is any test result with a threat level above None. Between two reports a finding is:
+//   - Added: present in the newer report but not in the older one
+//   - Resolved: present in the older report but not in the newer one
+//   - Changed: present in both reports with a different threat level
+//
+// Error codes:
+//   - 100: Report file reading error
+//   - 101: Report decoding error
+package Diff
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Finding identifies a single non-passing test result within a report.
+type Finding struct {
+	Target      string            `json:"target"`      // Scanned target
+	TestId      string            `json:"testId"`      // Registry ID of the test (falls back to the test name)
+	Name        string            `json:"name"`        // Human-readable test name
+	ThreatLevel Tests.ThreatLevel `json:"threatLevel"` // Threat level reported for the finding
+}
+
+// SeverityChange describes a finding whose threat level differs between two reports.
+type SeverityChange struct {
+	Finding
+	PreviousThreatLevel Tests.ThreatLevel `json:"previousThreatLevel"` // Threat level in the older report
+}
+
+// Report holds the differences between two scans. Every slice is sorted by target and test ID.
+type Report struct {
+	Added    []Finding        `json:"added"`
+	Resolved []Finding        `json:"resolved"`
+	Changed  []SeverityChange `json:"changed"`
+}
+
+// findingKey uniquely identifies a finding across reports.
+type findingKey struct {
+	target string
+	testId string
+}
+
+// CompareFiles loads two saved reports from disk and compares them.
+//
+// Parameters:
+//   - beforePath: Path to the older report
+//   - afterPath: Path to the newer report
+//
+// Returns:
+//   - *Report: Findings added, resolved and changed between the reports
+//   - error: *Errors.Error if a report cannot be read (code 100) or decoded (code 101)
+//
+// Example:
+//
+//	report, err := Diff.CompareFiles("scan-monday.json", "scan-friday.json")
+//	if err == nil {
+//	    fmt.Printf("%d new findings\n", len(report.Added))
+//	}
+func CompareFiles(beforePath, afterPath string) (*Report, error) {
+	before, err := loadReportFile(beforePath)
+	if err != nil {
+		return nil, err
+	}
+	after, err := loadReportFile(afterPath)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(before, after), nil
+}
+
+// ParseReport decodes a saved report. Both a JSON array of TestResultWrapper objects and a
+// stream of concatenated (e.g. newline-delimited) objects are accepted.
+//
+// Parameters:
+//   - data: Raw report content
+//
+// Returns:
+//   - []types.TestResultWrapper: Decoded report entries
+//   - error: *Errors.Error (code 101) if the content is not a valid report
+func ParseReport(data []byte) ([]types.TestResultWrapper, error) {
+	trimmed := bytes.TrimSpace(data)
+	var entries []types.TestResultWrapper
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, decodeError(err)
+		}
+		return entries, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	for decoder.More() {
+		var entry types.TestResultWrapper
+		if err := decoder.Decode(&entry); err != nil {
+			return nil, decodeError(err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Compare computes the differences between two reports.
+//
+// Only successful test results are considered; end markers and process messages are ignored.
+// When a test appears multiple times for the same target, the last occurrence wins.
+//
+// Parameters:
+//   - before: Entries of the older report
+//   - after: Entries of the newer report
+//
+// Returns:
+//   - *Report: Findings added, resolved and changed between the reports
+func Compare(before, after []types.TestResultWrapper) *Report {
+	oldFindings := collectFindings(before)
+	newFindings := collectFindings(after)
+	report := &Report{}
+
+	for key, current := range newFindings {
+		previous, existed := oldFindings[key]
+		switch {
+		case !existed:
+			report.Added = append(report.Added, current)
+		case previous.ThreatLevel != current.ThreatLevel:
+			report.Changed = append(report.Changed, SeverityChange{
+				Finding:             current,
+				PreviousThreatLevel: previous.ThreatLevel,
+			})
+		}
+	}
+	for key, previous := range oldFindings {
+		if _, exists := newFindings[key]; !exists {
+			report.Resolved = append(report.Resolved, previous)
+		}
+	}
+
+	sortFindings(report.Added)
+	sortFindings(report.Resolved)
+	sort.Slice(report.Changed, func(i, j int) bool {
+		return lessFinding(report.Changed[i].Finding, report.Changed[j].Finding)
+	})
+	return report
+}
+
+// Baseline indexes the findings of a previous report, so the results of a running scan can
+// be classified as new or persistent findings as they are produced.
+type Baseline struct {
+	findings map[findingKey]Finding
+}
+
+// NewBaseline indexes the findings of a previous report. As in Compare, the last result of
+// a test for a target wins.
+//
+// Parameters:
+//   - entries: Entries of the previous report
+//
+// Returns:
+//   - *Baseline: Findings of the report, ready for lookups
+func NewBaseline(entries []types.TestResultWrapper) *Baseline {
+	return &Baseline{findings: collectFindings(entries)}
+}
+
+// LoadBaseline reads a previous report from disk and indexes its findings.
+//
+// Parameters:
+//   - path: Path to the previous report
+//
+// Returns:
+//   - *Baseline: Findings of the report
+//   - error: *Errors.Error if the report cannot be read (code 100) or decoded (code 101)
+//
+// Example:
+//
+//	baseline, err := Diff.LoadBaseline("scan-monday.json")
+//	if err == nil && !baseline.Has("https://example.com", "csp") {
+//	    fmt.Println("csp finding is new")
+//	}
+func LoadBaseline(path string) (*Baseline, error) {
+	entries, err := loadReportFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewBaseline(entries), nil
+}
+
+// Has reports whether the previous report holds a finding of the test for the target.
+// testId falls back to the test name, as in the findings of Compare.
+func (b *Baseline) Has(target, testId string) bool {
+	_, found := b.findings[findingKey{target: target, testId: testId}]
+	return found
+}
+
+// collectFindings indexes all non-passing test results of a report.
+func collectFindings(entries []types.TestResultWrapper) map[findingKey]Finding {
+	findings := make(map[findingKey]Finding)
+	for _, entry := range entries {
+		if entry.EndFlag || entry.ResultType != types.Success {
+			continue
+		}
+		testId := entry.Result.TestId
+		if testId == "" {
+			testId = entry.Result.Name
+		}
+		key := findingKey{target: entry.Target, testId: testId}
+		if entry.Result.ThreatLevel <= Tests.None {
+			// A passing result overrides an earlier finding for the same test
+			delete(findings, key)
+			continue
+		}
+		findings[key] = Finding{
+			Target:      entry.Target,
+			TestId:      testId,
+			Name:        entry.Result.Name,
+			ThreatLevel: entry.Result.ThreatLevel,
+		}
+	}
+	return findings
+}
+
+func sortFindings(findings []Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		return lessFinding(findings[i], findings[j])
+	})
+}
+
+func lessFinding(a, b Finding) bool {
+	if a.Target != b.Target {
+		return a.Target < b.Target
+	}
+	return a.TestId < b.TestId
+}
+
+func loadReportFile(path string) ([]types.TestResultWrapper, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &Errors.Error{
+			Code:        100,
+			Message:     fmt.Sprintf("Diff error occurred. This could be due to:\n- report file %s cannot be read: %v", path, err),
+			Source:      "Diff",
+			IsRetryable: false,
+		}
+	}
+	return ParseReport(data)
+}
+
+func decodeError(err error) *Errors.Error {
+	return &Errors.Error{
+		Code:        101,
+		Message:     fmt.Sprintf("Diff error occurred. This could be due to:\n- invalid report format: %v", err),
+		Source:      "Diff",
+		IsRetryable: false,
+	}
+}
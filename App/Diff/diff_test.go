@@ -0,0 +1,53 @@
+package Diff
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareFiles(t *testing.T) {
+	report, err := CompareFiles("testdata/before.json", "testdata/after.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assert.Equal(t, []Finding{
+		{Target: "example.com", TestId: "xframe", Name: "Clickjacking Protection", ThreatLevel: Tests.High},
+	}, report.Added, "Newly introduced High finding should be reported as added")
+	assert.Equal(t, []Finding{
+		{Target: "example.com", TestId: "csp", Name: "Content Security Policy", ThreatLevel: Tests.High},
+	}, report.Resolved, "Fixed CSP finding should be reported as resolved")
+	assert.Equal(t, []SeverityChange{
+		{
+			Finding:             Finding{Target: "example.com", TestId: "hsts", Name: "HSTS Header Analysis", ThreatLevel: Tests.Low},
+			PreviousThreatLevel: Tests.Medium,
+		},
+	}, report.Changed, "HSTS severity change should be reported")
+}
+
+func TestParseReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected int
+		wantErr  bool
+	}{
+		{name: "JSON array", data: `[{"target":"a","result":{"TestId":"hsts"}},{"target":"b"}]`, expected: 2},
+		{name: "Newline delimited", data: "{\"target\":\"a\"}\n{\"target\":\"b\"}\n", expected: 2},
+		{name: "Empty report", data: "  ", expected: 0},
+		{name: "Invalid JSON", data: `[{"target":`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ParseReport([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, entries, tt.expected)
+		})
+	}
+}
@@ -1,13 +1,19 @@
 package GlobalHandler
 
 import (
+	"Engine-AntiGinx/App/BuildInfo"
 	"Engine-AntiGinx/App/Errors"
 	HttpClient "Engine-AntiGinx/App/HTTP"
+	"Engine-AntiGinx/App/Registry"
 	"Engine-AntiGinx/App/Reporter"
 	"Engine-AntiGinx/App/Runner"
+	"Engine-AntiGinx/App/SelfTest"
+	"Engine-AntiGinx/App/TaskStream"
+	"Engine-AntiGinx/App/Tests"
 	parameterparser "Engine-AntiGinx/App/parser"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -52,7 +58,8 @@ func InitializeErrorHandler(cliMode bool) *ErrorHandler {
 //
 // Execution Flow:
 //  1. Sets up panic recovery via defer/recover.
-//  2. Creates and runs the CommandParser to process os.Args.
+//  2. Creates and runs the CommandParser to process os.Args. When the first argument is
+//     "--stdin", the TaskStream server handles newline-delimited JSON tasks from stdin instead.
 //  3. Initializes the ScanFormatter to transform raw parameters into an ExecutionPlan.
 //  4. Creates and runs the JobRunner to orchestrate the security tests.
 //  5. If a panic occurs, it is caught, printed to Stderr, and the process exits with code 1.
@@ -95,13 +102,50 @@ func (e *ErrorHandler) RunSafe() {
 		}
 	}()
 	args := os.Args
+	if len(args) > 1 && args[1] == "--stdin" {
+		// Task stream mode: scans are read as JSON lines from stdin instead of os.Args.
+		if exitCode := TaskStream.NewServer(os.Stdin, os.Stdout).Serve(); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return
+	}
+	if len(args) > 1 && args[1] == "version" {
+		// Version mode: build information for issue reports, no scan is run.
+		printVersion(os.Stdout)
+		return
+	}
+	if len(args) > 1 && args[1] == "list" {
+		// List mode: the registered tests and their threat level rubrics, no scan is run.
+		listTests(os.Stdout, args[2:])
+		return
+	}
+	if len(args) > 1 && args[1] == "--self-test" {
+		// Self-test mode: the full suite runs against a built-in known-good fixture.
+		if exitCode := SelfTest.NewSuite(os.Stdout).Run(); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return
+	}
 	resolver := parameterparser.CreateResolver()
 	parser, formatter := resolver.Resolve(args)
 	parsedParams := parser.Parse(args)
 	execPlan := formatter.FormatParameters(parsedParams)
-	runner := Runner.CreateJobRunner()
-	repResolver := Reporter.NewResolver()
-	runner.Orchestrate(execPlan, repResolver)
+	runnerOpts := []Runner.RunnerOption{Runner.WithResultCache(Tests.NewResultCache(0))}
+	if e.cliMode {
+		// The status line goes to stderr and only when a user is watching it in a terminal.
+		runnerOpts = append(runnerOpts, Runner.WithProgress(Runner.TerminalOutput(os.Stderr)))
+	}
+	runner := Runner.CreateJobRunner(runnerOpts...)
+	repResolver := Reporter.NewResolver(
+		Reporter.WithQuiet(execPlan.Quiet),
+		Reporter.WithFormat(execPlan.Format),
+		Reporter.WithOutput(execPlan.Output),
+		Reporter.WithReportMetadata(execPlan.Report),
+		Reporter.WithSeverityThreshold(execPlan.SeverityThreshold),
+	)
+	if exitCode := runner.Orchestrate(execPlan, repResolver); exitCode != 0 {
+		os.Exit(exitCode)
+	}
 }
 
 // printError writes the formatted error details to standard error (os.Stderr).
@@ -135,3 +179,9 @@ RETRYABLE:    %t
 		}
 	}
 }
+
+// printVersion writes the build information of the version command, counting every test
+// in the registry (plugin tests included).
+func printVersion(out io.Writer) {
+	BuildInfo.Print(out, len(Registry.GetAllTests()))
+}
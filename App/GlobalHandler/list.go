@@ -0,0 +1,86 @@
+package GlobalHandler
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Registry"
+	"Engine-AntiGinx/App/Tests"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// testListing is one test of the "list --format json" output, letting backends build their
+// test pickers and explain every threat level a test can report.
+type testListing struct {
+	Id              string              `json:"Id"`
+	Name            string              `json:"Name"`
+	Description     string              `json:"Description"`
+	Category        string              `json:"Category"`
+	CWE             string              `json:"CWE,omitempty"`
+	OWASPCategory   string              `json:"OWASPCategory,omitempty"`
+	RequiresHTTPS   bool                `json:"RequiresHTTPS"`
+	RequiresNetwork bool                `json:"RequiresNetwork"`
+	Rubric          []Tests.RubricEntry `json:"Rubric"` // Empty when not documented yet
+}
+
+// listTests writes every registered test (plugin tests included), sorted by ID, for the
+// list command: an aligned id/category/name table by default, or a JSON array with the
+// threat level rubric of every test with "--format json".
+//
+// Parameters:
+//   - out: Destination of the listing (usually stdout)
+//   - args: Arguments following "list" ("--format text" or "--format json")
+//
+// Panics:
+//   - Errors.Error with code 304: On any other argument
+func listTests(out io.Writer, args []string) {
+	format := "text"
+	if len(args) == 2 && args[0] == "--format" && (args[1] == "text" || args[1] == "json") {
+		format = args[1]
+	} else if len(args) > 0 {
+		panic(Errors.Error{
+			Code: 304,
+			Message: `Parsing error occurred. This could be due to:
+				- invalid argument passed to the list command (expected --format text or --format json)`,
+			Source:      "list",
+			IsRetryable: false,
+		})
+	}
+
+	all := Registry.GetAllTests()
+	sort.Slice(all, func(i, j int) bool { return all[i].Id < all[j].Id })
+
+	if format == "text" {
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(writer, "ID\tCATEGORY\tNAME")
+		for _, test := range all {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", test.Id, test.Category, test.Name)
+		}
+		_ = writer.Flush()
+		return
+	}
+
+	listings := make([]testListing, 0, len(all))
+	for _, test := range all {
+		rubric := Registry.Rubric(test.Id)
+		if rubric == nil {
+			rubric = []Tests.RubricEntry{}
+		}
+		listings = append(listings, testListing{
+			Id:              test.Id,
+			Name:            test.Name,
+			Description:     test.Description,
+			Category:        test.Category,
+			CWE:             test.CWE,
+			OWASPCategory:   test.OWASPCategory,
+			RequiresHTTPS:   test.RequiresHTTPS,
+			RequiresNetwork: test.RequiresNetwork,
+			Rubric:          rubric,
+		})
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(listings)
+}
@@ -0,0 +1,56 @@
+package GlobalHandler
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Registry"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestListTests_JSON(t *testing.T) {
+	var out bytes.Buffer
+	listTests(&out, []string{"--format", "json"})
+
+	var listings []struct {
+		Id     string
+		Rubric []struct {
+			ThreatLevel string
+			Condition   string
+		}
+	}
+	if err := json.Unmarshal(out.Bytes(), &listings); err != nil {
+		t.Fatalf("Expected a JSON array, got %v:\n%s", err, out.String())
+	}
+	if len(listings) != len(Registry.GetAllTests()) {
+		t.Errorf("Expected %d tests, got %d", len(Registry.GetAllTests()), len(listings))
+	}
+	for i, listing := range listings {
+		if i > 0 && listing.Id < listings[i-1].Id {
+			t.Errorf("Expected tests sorted by ID, got %q after %q", listing.Id, listings[i-1].Id)
+		}
+		if listing.Id == "hsts" && (len(listing.Rubric) == 0 || listing.Rubric[0].ThreatLevel != "None") {
+			t.Errorf("Expected the HSTS rubric starting with None, got %+v", listing.Rubric)
+		}
+	}
+}
+
+func TestListTests_Text(t *testing.T) {
+	var out bytes.Buffer
+	listTests(&out, nil)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(Registry.GetAllTests())+1 || !strings.HasPrefix(lines[0], "ID") {
+		t.Errorf("Expected a header and one line per test, got:\n%s", out.String())
+	}
+}
+
+func TestListTests_InvalidFormat(t *testing.T) {
+	defer func() {
+		if err, ok := recover().(Errors.Error); !ok || err.Code != 304 {
+			t.Errorf("Expected a parsing error, got %v", err)
+		}
+	}()
+	listTests(&bytes.Buffer{}, []string{"--format", "xml"})
+}
@@ -0,0 +1,29 @@
+package GlobalHandler
+
+import (
+	"Engine-AntiGinx/App/BuildInfo"
+	"Engine-AntiGinx/App/Registry"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPrintVersion(t *testing.T) {
+	var out bytes.Buffer
+	printVersion(&out)
+	output := out.String()
+
+	if !strings.HasPrefix(output, "Engine-AntiGinx "+BuildInfo.Version+"\n") || BuildInfo.Version == "" {
+		t.Errorf("Expected the scanner version on the first line, got:\n%s", output)
+	}
+	want := fmt.Sprintf("Registered tests: %d\n", len(Registry.GetAllTests()))
+	if len(Registry.GetAllTests()) == 0 || !strings.Contains(output, want) {
+		t.Errorf("Expected %q, got:\n%s", want, output)
+	}
+	for _, field := range []string{"Commit:", "Build date:", "Go version:"} {
+		if !strings.Contains(output, field) {
+			t.Errorf("Expected %q in the build information, got:\n%s", field, output)
+		}
+	}
+}
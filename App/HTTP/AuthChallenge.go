@@ -0,0 +1,153 @@
+package HttpClient
+
+import "strings"
+
+// AuthenticationRequiredCode is the HttpError code raised by Get when the target answers
+// 401 Unauthorized and no credentials are configured. The error carries the response, so
+// callers can read the WWW-Authenticate challenges instead of failing the scan.
+const AuthenticationRequiredCode = 103
+
+// AuthChallenge is one challenge of a WWW-Authenticate header (RFC 9110, section 11.6.1).
+//
+// Fields:
+//   - Scheme: Authentication scheme as advertised (e.g., "Basic", "Bearer", "NTLM", "Negotiate")
+//   - Params: Auth parameters keyed by lowercase name (e.g., "realm"), nil when there are none
+//   - Token68: Opaque token sent instead of parameters (e.g., a Negotiate continuation)
+type AuthChallenge struct {
+	Scheme  string            `json:"scheme"`
+	Params  map[string]string `json:"params,omitempty"`
+	Token68 string            `json:"token68,omitempty"`
+}
+
+// Realm returns the realm parameter of the challenge, or an empty string.
+func (c AuthChallenge) Realm() string {
+	return c.Params["realm"]
+}
+
+// ParseWWWAuthenticate parses the values of the WWW-Authenticate header into challenges.
+// A single value may hold several comma-separated challenges; malformed parts are skipped.
+//
+// Parameters:
+//   - values: All WWW-Authenticate header values (e.g., header.Values("WWW-Authenticate"))
+//
+// Returns:
+//   - []AuthChallenge: Challenges in the order they were advertised
+//
+// Example:
+//
+//	challenges := ParseWWWAuthenticate([]string{`Basic realm="admin", Bearer error="invalid_token"`})
+//	// challenges[0].Scheme == "Basic", challenges[0].Realm() == "admin"
+//	// challenges[1].Scheme == "Bearer", challenges[1].Params["error"] == "invalid_token"
+func ParseWWWAuthenticate(values []string) []AuthChallenge {
+	var challenges []AuthChallenge
+	for _, value := range values {
+		p := &authParser{s: value}
+		for p.pos < len(p.s) {
+			p.skip(" \t,")
+			scheme := p.token()
+			if scheme == "" {
+				// Not a token: skip the offending character
+				p.pos++
+				continue
+			}
+			challenge := AuthChallenge{Scheme: scheme}
+			p.token68(&challenge)
+			p.params(&challenge)
+			challenges = append(challenges, challenge)
+		}
+	}
+	return challenges
+}
+
+// authParser is a cursor over one WWW-Authenticate value.
+type authParser struct {
+	s   string
+	pos int
+}
+
+// skip advances past any of the given characters.
+func (p *authParser) skip(chars string) {
+	for p.pos < len(p.s) && strings.IndexByte(chars, p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// token reads a token, leniently accepting "/" so token68 values are read in one piece.
+func (p *authParser) token() string {
+	start := p.pos
+	for p.pos < len(p.s) && isAuthTokenChar(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// token68 reads the opaque token following a scheme, if the challenge has one.
+func (p *authParser) token68(challenge *AuthChallenge) {
+	start := p.pos
+	p.skip(" \t")
+	tokenStart := p.pos
+	if p.token() == "" {
+		p.pos = start
+		return
+	}
+	p.skip("=")
+	tokenEnd := p.pos
+	p.skip(" \t")
+	if p.pos < len(p.s) && p.s[p.pos] != ',' {
+		// An auth parameter (name=value) rather than a token68
+		p.pos = start
+		return
+	}
+	challenge.Token68 = p.s[tokenStart:tokenEnd]
+}
+
+// params reads the name=value parameters of a challenge, stopping before the next scheme.
+func (p *authParser) params(challenge *AuthChallenge) {
+	for p.pos < len(p.s) {
+		p.skip(" \t,")
+		start := p.pos
+		name := p.token()
+		if name == "" {
+			return
+		}
+		p.skip(" \t")
+		if p.pos >= len(p.s) || p.s[p.pos] != '=' {
+			// A token without "=" is the scheme of the next challenge
+			p.pos = start
+			return
+		}
+		p.pos++
+		p.skip(" \t")
+		if challenge.Params == nil {
+			challenge.Params = make(map[string]string)
+		}
+		challenge.Params[strings.ToLower(name)] = p.value()
+	}
+}
+
+// value reads a parameter value, either a quoted string (with backslash escapes) or a token.
+func (p *authParser) value() string {
+	if p.pos >= len(p.s) || p.s[p.pos] != '"' {
+		return p.token()
+	}
+	p.pos++
+	var value strings.Builder
+	for p.pos < len(p.s) && p.s[p.pos] != '"' {
+		if p.s[p.pos] == '\\' && p.pos+1 < len(p.s) {
+			p.pos++
+		}
+		value.WriteByte(p.s[p.pos])
+		p.pos++
+	}
+	p.pos++
+	return value.String()
+}
+
+// isAuthTokenChar reports whether c is an RFC 9110 token character or "/".
+func isAuthTokenChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-.^_`|~/", c) >= 0
+}
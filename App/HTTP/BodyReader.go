@@ -0,0 +1,89 @@
+package HttpClient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultBodyReadTimeout bounds how long Get reads a response body unless overridden with
+// WithBodyReadTimeout. It is distinct from the response timeout (DefaultResponseTimeout) so
+// that a server dripping a chunked body never stalls the scan for the whole request timeout.
+const DefaultBodyReadTimeout = 10 * time.Second
+
+// truncatedBody is the body of a response whose read was aborted at the body read
+// timeout. It holds the part received until then.
+type truncatedBody struct {
+	io.Reader
+}
+
+// Close implements io.Closer; the underlying connection was already closed on abort.
+func (truncatedBody) Close() error { return nil }
+
+// BodyTruncated reports whether the body of a response returned by Get was cut off at the
+// body read timeout because the server kept streaming (e.g., a chunked body that never ends).
+// Tests analysing the body then only see the part received before the timeout.
+//
+// Parameters:
+//   - resp: Response returned by Get
+//
+// Returns:
+//   - bool: true if the body read was aborted
+func BodyTruncated(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	_, truncated := resp.Body.(truncatedBody)
+	return truncated
+}
+
+// readBodyWithTimeout reads body until EOF or until timeout passes, whichever comes first.
+// On timeout the body is closed, which aborts the pending read and releases the connection,
+// and the data received so far is returned with truncated set. A non-positive timeout
+// reads without limit.
+//
+// Returns:
+//   - []byte: Body data received
+//   - bool: true if the read was aborted at the timeout
+//   - error: Read error other than the abort
+func readBodyWithTimeout(body io.ReadCloser, timeout time.Duration) ([]byte, bool, error) {
+	if timeout <= 0 {
+		data, err := io.ReadAll(body)
+		return data, false, err
+	}
+
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		chunk := make([]byte, 32*1024)
+		for {
+			n, err := body.Read(chunk)
+			mu.Lock()
+			buf.Write(chunk[:n])
+			mu.Unlock()
+			if err == io.EOF {
+				done <- nil
+				return
+			}
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return buf.Bytes(), false, err
+	case <-timer.C:
+		_ = body.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		return bytes.Clone(buf.Bytes()), true, nil
+	}
+}
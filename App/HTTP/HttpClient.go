@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"strconv"
@@ -26,8 +27,10 @@ import (
 //   - 100: Request creation Error
 //   - 101: Network Error (DNS, timeout, connection issues)
 //   - 102: HTTP status Error (non-200 responses)
+//   - 103: Authentication required (401 without configured credentials, see AuthenticationRequiredCode)
 //   - 200: Response body reading Error
 //   - 300: Bot protection detected
+//   - 400: Client certificate loading Error
 type HttpError struct {
 	Url         string // The URL that caused the Error
 	Code        int    // Error Code for categorization
@@ -40,8 +43,60 @@ type HttpError struct {
 // and anti-bot detection settings. This structure is modified by WrapperOption functions
 // to customize client behavior.
 type httpWrapperConfig struct {
-	headers          map[string]string // Custom HTTP headers to be sent with requests
-	antiBotDetection bool              // Enable anti-bot detection bypass features
+	headers          map[string]string     // Custom HTTP headers to be sent with requests
+	antiBotDetection bool                  // Enable anti-bot detection bypass features
+	clientCertFile   string                // PEM encoded client certificate used for mutual TLS
+	clientKeyFile    string                // PEM encoded private key matching clientCertFile
+	basicAuth        *basicAuthCredentials // Credentials for HTTP Basic authentication
+	bearerToken      string                // Token for HTTP Bearer authentication
+	sessionCookies   []*http.Cookie        // Cookies pre-seeded into the jar for authenticated scans
+	bodyReadTimeout  time.Duration         // Maximum time spent reading a response body (0 = unlimited)
+
+	dialTimeout         time.Duration // Maximum time to establish a TCP connection (0 = DefaultDialTimeout)
+	tlsHandshakeTimeout time.Duration // Maximum time of the TLS handshake (0 = DefaultTLSHandshakeTimeout)
+	responseTimeout     time.Duration // Maximum time of a whole request, body included (0 = DefaultResponseTimeout)
+
+	maxIdleConns        int             // Idle connections kept across all hosts (0 = transport default)
+	maxIdleConnsPerHost int             // Idle connections kept per host (0 = transport default)
+	idleConnTimeout     time.Duration   // Time an idle connection is kept open (0 = transport default)
+	sharedTransport     *http.Transport // Transport reused by every wrapper instead of a new one
+}
+
+// Connection pool settings used by anti-bot detection and by NewTransport unless overridden
+// with WithMaxIdleConns, WithMaxIdleConnsPerHost and WithIdleConnTimeout.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// Timeouts used unless overridden with WithDialTimeout, WithTLSHandshakeTimeout and
+// WithResponseTimeout. Connecting to a host is bounded separately from the whole request, so
+// a dead host fails fast while a slow but alive server still has time to answer.
+const (
+	DefaultDialTimeout         = 10 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+	DefaultResponseTimeout     = 30 * time.Second
+)
+
+// hasCredentials reports whether requests carry credentials: basic auth, a bearer token,
+// session cookies or a manually configured Authorization header.
+func (c httpWrapperConfig) hasCredentials() bool {
+	if c.basicAuth != nil || c.bearerToken != "" || len(c.sessionCookies) > 0 {
+		return true
+	}
+	for key := range c.headers {
+		if http.CanonicalHeaderKey(key) == "Authorization" {
+			return true
+		}
+	}
+	return false
+}
+
+// basicAuthCredentials holds the username and password used for HTTP Basic authentication.
+type basicAuthCredentials struct {
+	username string
+	password string
 }
 
 // WrapperOption is a functional option type for configuring the HTTP wrapper.
@@ -240,6 +295,320 @@ func WithAntiBotDetection() WrapperOption {
 	}
 }
 
+// WithClientCertificate configures a client certificate which is presented to servers
+// requiring mutual TLS authentication. The keypair is loaded when the wrapper is created
+// and works alongside the browser-like TLS configuration used by anti-bot detection.
+//
+// Parameters:
+//   - certFile: Path to the PEM encoded client certificate
+//   - keyFile: Path to the PEM encoded private key matching the certificate
+//
+// Returns:
+//   - WrapperOption: Configuration function that enables client certificate authentication
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithClientCertificate("client.crt", "client.key"))
+func WithClientCertificate(certFile, keyFile string) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.clientCertFile = certFile
+		cfg.clientKeyFile = keyFile
+	}
+}
+
+// WithBasicAuth configures HTTP Basic authentication for every request made by the wrapper.
+// Credentials are kept apart from the regular headers and only attached to the outgoing
+// request, so they never appear in header dumps or logs.
+//
+// Parameters:
+//   - username: Basic authentication username
+//   - password: Basic authentication password
+//
+// Returns:
+//   - WrapperOption: Configuration function that enables Basic authentication
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithBasicAuth("admin", "secret"))
+func WithBasicAuth(username, password string) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.basicAuth = &basicAuthCredentials{username: username, password: password}
+	}
+}
+
+// WithBearerToken configures HTTP Bearer authentication for every request made by the wrapper.
+// Like WithBasicAuth, the token is only attached to the outgoing request. When both are
+// configured, the bearer token takes precedence.
+//
+// Parameters:
+//   - token: Bearer token sent in the Authorization header
+//
+// Returns:
+//   - WrapperOption: Configuration function that enables Bearer authentication
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithBearerToken("eyJhbGciOi..."))
+func WithBearerToken(token string) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.bearerToken = token
+	}
+}
+
+// WithSessionCookies pre-seeds the client's cookie jar with session cookies so that the scan
+// runs as an authenticated user (e.g. against post-login pages). The cookies are scoped to the
+// host of each requested URL and are kept in the same jar used for anti-bot session handling.
+//
+// Parameters:
+//   - cookies: Session cookies to send with every request
+//
+// Returns:
+//   - WrapperOption: Configuration function that enables cookie-based session authentication
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithSessionCookies([]*http.Cookie{
+//	    {Name: "session", Value: "abc123"},
+//	}))
+func WithSessionCookies(cookies []*http.Cookie) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.sessionCookies = append(cfg.sessionCookies, cookies...)
+	}
+}
+
+// WithBodyReadTimeout bounds how long Get reads a response body, independently of the
+// connection timeout. When it passes, the read is aborted and the response keeps the part
+// received so far (see BodyTruncated). This protects the scan against servers holding a
+// chunked body open forever. The response timeout (see WithResponseTimeout) still applies to
+// the whole request, so longer values have no effect.
+//
+// Parameters:
+//   - timeout: Maximum body read time (non-positive disables the limit)
+//
+// Returns:
+//   - WrapperOption: Configuration function that sets the body read timeout
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithBodyReadTimeout(5 * time.Second))
+func WithBodyReadTimeout(timeout time.Duration) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.bodyReadTimeout = timeout
+	}
+}
+
+// WithDialTimeout bounds how long establishing the TCP connection to a host may take, so a
+// host that does not answer fails fast instead of using up the whole response timeout.
+//
+// Parameters:
+//   - timeout: Maximum connection time (non-positive keeps DefaultDialTimeout)
+//
+// Returns:
+//   - WrapperOption: Configuration function that sets the dial timeout
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithDialTimeout(3 * time.Second))
+func WithDialTimeout(timeout time.Duration) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.dialTimeout = timeout
+	}
+}
+
+// WithTLSHandshakeTimeout bounds how long the TLS handshake may take once the connection is
+// established, so a host that accepts connections but never completes the handshake fails
+// fast instead of using up the whole response timeout.
+//
+// Parameters:
+//   - timeout: Maximum handshake time (non-positive keeps DefaultTLSHandshakeTimeout)
+//
+// Returns:
+//   - WrapperOption: Configuration function that sets the TLS handshake timeout
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithTLSHandshakeTimeout(3 * time.Second))
+func WithTLSHandshakeTimeout(timeout time.Duration) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.tlsHandshakeTimeout = timeout
+	}
+}
+
+// WithResponseTimeout bounds a whole request, from connecting to reading the body, and
+// replaces the DefaultResponseTimeout of the client. Raise it for slow but alive servers;
+// dead hosts are still detected by the dial and TLS handshake timeouts.
+//
+// Parameters:
+//   - timeout: Maximum request time (non-positive keeps DefaultResponseTimeout)
+//
+// Returns:
+//   - WrapperOption: Configuration function that sets the response timeout
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithResponseTimeout(2 * time.Minute))
+func WithResponseTimeout(timeout time.Duration) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.responseTimeout = timeout
+	}
+}
+
+// WithMaxIdleConns limits the number of idle keep-alive connections the transport keeps
+// open across all hosts, bounding the file descriptors a long-running engine holds.
+//
+// Parameters:
+//   - n: Maximum idle connections (non-positive keeps the transport default)
+//
+// Returns:
+//   - WrapperOption: Configuration function that sets the idle connection limit
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithMaxIdleConns(50))
+func WithMaxIdleConns(n int) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.maxIdleConns = n
+	}
+}
+
+// WithMaxIdleConnsPerHost limits the number of idle keep-alive connections the transport
+// keeps open to a single host.
+//
+// Parameters:
+//   - n: Maximum idle connections per host (non-positive keeps the transport default)
+//
+// Returns:
+//   - WrapperOption: Configuration function that sets the per-host idle connection limit
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithMaxIdleConnsPerHost(4))
+func WithMaxIdleConnsPerHost(n int) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.maxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle keep-alive connection stays open before the
+// transport closes it.
+//
+// Parameters:
+//   - timeout: Idle connection lifetime (non-positive keeps the transport default)
+//
+// Returns:
+//   - WrapperOption: Configuration function that sets the idle connection timeout
+//
+// Example:
+//
+//	wrapper := CreateHttpWrapper(WithIdleConnTimeout(30 * time.Second))
+func WithIdleConnTimeout(timeout time.Duration) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.idleConnTimeout = timeout
+	}
+}
+
+// WithSharedTransport makes the wrapper send its requests through transport instead of a
+// transport of its own, so that consecutive scans of a long-running engine reuse one
+// connection pool. The shared transport is used as is: its TLS and pool settings come from
+// NewTransport and the wrapper's pool options are ignored. A wrapper with a client
+// certificate uses a copy of the transport, as the certificate must not leak to other scans,
+// and so does a wrapper with its own dial or TLS handshake timeout. Anti-bot detection keeps
+// a transport of its own with the browser-like TLS configuration.
+//
+// Parameters:
+//   - transport: Transport created once with NewTransport (nil disables sharing)
+//
+// Returns:
+//   - WrapperOption: Configuration function that sets the shared transport
+//
+// Example:
+//
+//	transport := NewTransport(WithMaxIdleConnsPerHost(4))
+//	wrapper := CreateHttpWrapper(WithSharedTransport(transport))
+func WithSharedTransport(transport *http.Transport) WrapperOption {
+	return func(cfg *httpWrapperConfig) {
+		cfg.sharedTransport = transport
+	}
+}
+
+// NewTransport creates a transport meant to be shared by many wrappers (see
+// WithSharedTransport). Its connection pool is bounded by DefaultMaxIdleConns,
+// DefaultMaxIdleConnsPerHost and DefaultIdleConnTimeout unless overridden by the pool
+// options, and connecting is bounded by DefaultDialTimeout and DefaultTLSHandshakeTimeout
+// unless overridden by WithDialTimeout and WithTLSHandshakeTimeout; WithAntiBotDetection
+// adds the browser-like TLS configuration.
+//
+// Parameters:
+//   - opts: Pool and connection timeout options and WithAntiBotDetection; other options are ignored
+//
+// Returns:
+//   - *http.Transport: Transport ready to be shared
+//
+// Example:
+//
+//	transport := NewTransport(WithMaxIdleConns(200), WithIdleConnTimeout(time.Minute))
+func NewTransport(opts ...WrapperOption) *http.Transport {
+	cfg := httpWrapperConfig{
+		headers:             map[string]string{},
+		maxIdleConns:        DefaultMaxIdleConns,
+		maxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		idleConnTimeout:     DefaultIdleConnTimeout,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return newTransport(cfg)
+}
+
+// newTransport builds the transport described by cfg: the browser-like TLS configuration
+// and pool of anti-bot detection, then the explicitly configured pool settings and
+// connection timeouts.
+func newTransport(cfg httpWrapperConfig) *http.Transport {
+	transport := &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: DefaultDialTimeout, KeepAlive: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout: DefaultTLSHandshakeTimeout,
+	}
+
+	// Configure TLS and other settings if anti-bot detection is enabled
+	if cfg.antiBotDetection {
+		transport.TLSClientConfig = getBrowserTLSConfig()
+
+		// Configure for HTTP/2 support like real browsers
+		transport.ForceAttemptHTTP2 = true
+		transport.MaxIdleConns = DefaultMaxIdleConns
+		transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+		transport.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	if cfg.maxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.maxIdleConns
+	}
+	if cfg.maxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.maxIdleConnsPerHost
+	}
+	if cfg.idleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.idleConnTimeout
+	}
+	setConnectTimeouts(transport, cfg)
+	return transport
+}
+
+// hasConnectTimeouts reports whether a dial or TLS handshake timeout is configured.
+func (c httpWrapperConfig) hasConnectTimeouts() bool {
+	return c.dialTimeout > 0 || c.tlsHandshakeTimeout > 0
+}
+
+// setConnectTimeouts applies the configured dial and TLS handshake timeouts to transport,
+// keeping its current values for the ones that are not configured.
+func setConnectTimeouts(transport *http.Transport, cfg httpWrapperConfig) {
+	if cfg.dialTimeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: cfg.dialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	}
+	if cfg.tlsHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = cfg.tlsHandshakeTimeout
+	}
+}
+
 // httpWrapper wraps Go's standard http.Client with additional bot protection detection
 // and anti-detection capabilities. It provides a higher-level interface for making
 // HTTP requests while handling common security scanning challenges.
@@ -254,7 +623,8 @@ type httpWrapper struct {
 //
 // By default, the wrapper uses:
 //   - Default AntiGinx user agent
-//   - 30-second timeout
+//   - 30-second response timeout (DefaultResponseTimeout)
+//   - 10-second dial and TLS handshake timeouts
 //   - Standard HTTP transport
 //
 // When anti-bot detection is enabled, it additionally configures:
@@ -263,12 +633,23 @@ type httpWrapper struct {
 //   - Cookie jar for session management
 //   - Connection pooling
 //
+// The pool options (WithMaxIdleConns, WithMaxIdleConnsPerHost, WithIdleConnTimeout) and the
+// timeout options (WithDialTimeout, WithTLSHandshakeTimeout, WithResponseTimeout) override
+// the defaults, and WithSharedTransport replaces the wrapper's own
+// transport with a shared one.
+//
+// When a client certificate is configured, the keypair is loaded and attached to the
+// transport TLS configuration.
+//
 // Parameters:
 //   - opts: Variable number of WrapperOption functions for configuration
 //
 // Returns:
 //   - *httpWrapper: Configured HTTP wrapper ready for use
 //
+// Panics:
+//   - HttpError: Code 400 if the configured client certificate cannot be loaded
+//
 // Example:
 //
 //	// Basic wrapper
@@ -285,6 +666,8 @@ func CreateHttpWrapper(opts ...WrapperOption) *httpWrapper {
 	cfg := httpWrapperConfig{
 		headers:          defaultHeaders(),
 		antiBotDetection: false,
+		bodyReadTimeout:  DefaultBodyReadTimeout,
+		responseTimeout:  DefaultResponseTimeout,
 	}
 
 	// apply optional config
@@ -292,27 +675,46 @@ func CreateHttpWrapper(opts ...WrapperOption) *httpWrapper {
 		opt(&cfg)
 	}
 
-	// Create transport with advanced configuration
-	transport := &http.Transport{}
-
-	// Configure TLS and other settings if anti-bot detection is enabled
-	if cfg.antiBotDetection {
-		transport.TLSClientConfig = getBrowserTLSConfig()
+	// Create transport with advanced configuration, unless one is shared
+	transport := cfg.sharedTransport
+	if transport == nil || cfg.antiBotDetection {
+		transport = newTransport(cfg)
+	}
+	if transport == cfg.sharedTransport && cfg.hasConnectTimeouts() {
+		transport = transport.Clone()
+		setConnectTimeouts(transport, cfg)
+	}
 
-		// Configure for HTTP/2 support like real browsers
-		transport.ForceAttemptHTTP2 = true
-		transport.MaxIdleConns = 100
-		transport.MaxIdleConnsPerHost = 10
-		transport.IdleConnTimeout = 90 * time.Second
+	if cfg.clientCertFile != "" || cfg.clientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.clientCertFile, cfg.clientKeyFile)
+		if err != nil {
+			panic(HttpError{
+				Code:        400,
+				Message:     "Failed to load client certificate: " + err.Error(),
+				Error:       err,
+				IsRetryable: false,
+			})
+		}
+		if transport == cfg.sharedTransport {
+			transport = transport.Clone()
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, cert)
 	}
 
+	responseTimeout := cfg.responseTimeout
+	if responseTimeout <= 0 {
+		responseTimeout = DefaultResponseTimeout
+	}
 	client := &http.Client{
 		Transport: transport,
-		Timeout:   30 * time.Second,
+		Timeout:   responseTimeout,
 	}
 
-	// Add cookie jar if anti-bot detection is enabled
-	if cfg.antiBotDetection {
+	// Add cookie jar if anti-bot detection or session cookies are enabled
+	if cfg.antiBotDetection || len(cfg.sessionCookies) > 0 {
 		if jar, err := cookiejar.New(nil); err == nil {
 			client.Jar = jar
 		}
@@ -324,6 +726,16 @@ func CreateHttpWrapper(opts ...WrapperOption) *httpWrapper {
 	}
 }
 
+// Client returns the underlying HTTP client, sharing the transport, TLS configuration and
+// cookie jar of the wrapper. Requests sent with it bypass the header, credential and bot
+// protection handling of Get; it is meant for secondary requests of the tests.
+//
+// Returns:
+//   - *http.Client: Client of the wrapper
+func (hw *httpWrapper) Client() *http.Client {
+	return hw.client
+}
+
 // Get performs an HTTP GET request with built-in bot protection detection and Error handling.
 // This method implements comprehensive security scanning capabilities including detection of
 // Cloudflare, CAPTCHA, and various bot protection mechanisms.
@@ -333,6 +745,7 @@ func CreateHttpWrapper(opts ...WrapperOption) *httpWrapper {
 //   - Human-like behavior simulation when anti-bot detection is enabled
 //   - Structured Error handling with panic-based Error reporting
 //   - Response body validation
+//   - Body read timeout: a body still streaming after it is truncated (see BodyTruncated)
 //
 // Error handling:
 // The method panics with HttpError containing structured Error information:
@@ -444,6 +857,18 @@ func (hw *httpWrapper) Get(url string, opts ...WrapperOption) *http.Response {
 		}
 	}
 
+	// Attach credentials last so they override any manually configured Authorization header
+	if cfg.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.bearerToken)
+	} else if cfg.basicAuth != nil {
+		req.SetBasicAuth(cfg.basicAuth.username, cfg.basicAuth.password)
+	}
+
+	// Seed session cookies for the requested host; the jar keeps them across redirects
+	if len(cfg.sessionCookies) > 0 && hw.client.Jar != nil {
+		hw.client.Jar.SetCookies(req.URL, cfg.sessionCookies)
+	}
+
 	// Set Host header explicitly (browsers do this)
 	if req.URL.Host != "" {
 		req.Header.Set("Host", req.URL.Host)
@@ -467,6 +892,22 @@ func (hw *httpWrapper) Get(url string, opts ...WrapperOption) *http.Response {
 		})
 	}
 
+	// A 401 without configured credentials is an authentication challenge rather than a
+	// failure; the response is passed along so the challenges can be reported
+	if resp.StatusCode == http.StatusUnauthorized && !cfg.hasCredentials() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Printf("HttpClient \nWarning: Failed to close response channel: %s", err.Error())
+		}
+		resp.Body = http.NoBody
+		panic(HttpError{
+			Url:         url,
+			Code:        AuthenticationRequiredCode,
+			Message:     "Authentication required: the target answered 401 (Unauthorized) and no credentials are configured",
+			Error:       resp,
+			IsRetryable: false,
+		})
+	}
+
 	// Handle HTTP Error status codes
 	if resp.StatusCode != 200 {
 		panic(HttpError{
@@ -478,8 +919,9 @@ func (hw *httpWrapper) Get(url string, opts ...WrapperOption) *http.Response {
 		})
 	}
 
-	// Read response body and reset it so downstream tests can read it
-	body, err := io.ReadAll(resp.Body)
+	// Read response body and reset it so downstream tests can read it. The read is bounded
+	// by the body read timeout; a body cut off there is kept and marked as truncated.
+	body, truncated, err := readBodyWithTimeout(resp.Body, cfg.bodyReadTimeout)
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
 			fmt.Printf("HttpClient \nWarning: Failed to close response channel: %s", err.Error())
@@ -494,23 +936,79 @@ func (hw *httpWrapper) Get(url string, opts ...WrapperOption) *http.Response {
 			IsRetryable: false,
 		})
 	}
-	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if truncated {
+		// The advertised length (often -1 for chunked bodies) is replaced by the received length
+		resp.ContentLength = int64(len(body))
+		resp.Body = truncatedBody{Reader: bytes.NewReader(body)}
+	} else {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
 
 	// Enhanced bot protection detection
-	bodyStr := string(body)
+	detectedProtections := DetectBotProtection(resp.Header, body)
+
+	// Only panic if not using anti-bot detection (in strict mode)
+	if len(detectedProtections) > 0 && !cfg.antiBotDetection {
+		detectionMsg := "Bot protection detected:\n"
+		for i, detection := range detectedProtections {
+			detectionMsg += fmt.Sprintf("  %d. %s\n", i+1, detection)
+		}
+
+		panic(HttpError{
+			Url:         url,
+			Code:        300,
+			Message:     detectionMsg,
+			Error:       resp,
+			IsRetryable: false,
+		})
+	}
+
+	return resp
+}
+
+// DetectBotProtection inspects a response for signs of bot protection services. It combines
+// the infrastructure indicators (e.g. Cloudflare headers present on every proxied response)
+// with the challenge page indicators reported by DetectChallengePage.
+//
+// Parameters:
+//   - header: Response headers
+//   - body: Response body
+//
+// Returns:
+//   - []string: Human-readable descriptions of every detected indicator (empty if none)
+func DetectBotProtection(header http.Header, body []byte) []string {
 	var detectedProtections []string
 
-	// Check for Cloudflare headers and challenges
-	if resp.Header.Get("Server") == "cloudflare" {
+	// Check for Cloudflare headers
+	if header.Get("Server") == "cloudflare" {
 		detectedProtections = append(detectedProtections, "Cloudflare Server")
 	}
-	if resp.Header.Get("CF-RAY") != "" {
-		detectedProtections = append(detectedProtections, "Cloudflare Ray ID: "+resp.Header.Get("CF-RAY"))
+	if header.Get("CF-RAY") != "" {
+		detectedProtections = append(detectedProtections, "Cloudflare Ray ID: "+header.Get("CF-RAY"))
 	}
-	if resp.Header.Get("CF-Cache-Status") != "" {
-		detectedProtections = append(detectedProtections, "Cloudflare Cache: "+resp.Header.Get("CF-Cache-Status"))
+	if header.Get("CF-Cache-Status") != "" {
+		detectedProtections = append(detectedProtections, "Cloudflare Cache: "+header.Get("CF-Cache-Status"))
 	}
-	if resp.Header.Get("CF-CHL-BCODE") != "" {
+
+	return append(detectedProtections, DetectChallengePage(header, body)...)
+}
+
+// DetectChallengePage reports indicators suggesting that a response is a bot protection
+// challenge or interstitial page rather than the real site content. Unlike DetectBotProtection
+// it ignores headers that are present on every response served through a protection provider,
+// so a regular page served via Cloudflare is not reported.
+//
+// Parameters:
+//   - header: Response headers
+//   - body: Response body
+//
+// Returns:
+//   - []string: Human-readable descriptions of every detected indicator (empty if none)
+func DetectChallengePage(header http.Header, body []byte) []string {
+	bodyStr := string(body)
+	var detectedProtections []string
+
+	if header.Get("CF-CHL-BCODE") != "" {
 		detectedProtections = append(detectedProtections, "Cloudflare Challenge")
 	}
 
@@ -546,22 +1044,5 @@ func (hw *httpWrapper) Get(url string, opts ...WrapperOption) *http.Response {
 			detectedProtections = append(detectedProtections, "Content contains: "+keyword)
 		}
 	}
-
-	// Only panic if not using anti-bot detection (in strict mode)
-	if len(detectedProtections) > 0 && !cfg.antiBotDetection {
-		detectionMsg := "Bot protection detected:\n"
-		for i, detection := range detectedProtections {
-			detectionMsg += fmt.Sprintf("  %d. %s\n", i+1, detection)
-		}
-
-		panic(HttpError{
-			Url:         url,
-			Code:        300,
-			Message:     detectionMsg,
-			Error:       resp,
-			IsRetryable: false,
-		})
-	}
-
-	return resp
+	return detectedProtections
 }
@@ -0,0 +1,482 @@
+package HttpClient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeClientCertificate generates a self-signed client certificate, writes the PEM encoded
+// certificate and key into a temporary directory and returns their paths with the parsed certificate.
+func writeClientCertificate(t *testing.T) (string, string, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "antiginx-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return certFile, keyFile, cert
+}
+
+// trustServer makes the wrapper trust the certificate of the given TLS test server.
+func trustServer(hw *httpWrapper, server *httptest.Server) {
+	transport := hw.client.Transport.(*http.Transport)
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	transport.TLSClientConfig.RootCAs = pool
+}
+
+func TestHttpWrapper_WithClientCertificate(t *testing.T) {
+	certFile, keyFile, clientCert := writeClientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		opts    []WrapperOption
+		wantErr bool
+	}{
+		{name: "Without client certificate", opts: nil, wantErr: true},
+		{name: "With client certificate", opts: []WrapperOption{WithClientCertificate(certFile, keyFile)}, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapper := CreateHttpWrapper(tt.opts...)
+			trustServer(wrapper, server)
+
+			defer func() {
+				r := recover()
+				if tt.wantErr && r == nil {
+					t.Error("Expected request to be rejected without a client certificate")
+				}
+				if !tt.wantErr && r != nil {
+					t.Errorf("Unexpected panic: %v", r)
+				}
+			}()
+			resp := wrapper.Get(server.URL)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCreateHttpWrapper_InvalidClientCertificate(t *testing.T) {
+	defer func() {
+		r := recover()
+		err, ok := r.(HttpError)
+		if !ok {
+			t.Fatalf("Expected HttpError panic, got %v", r)
+		}
+		if err.Code != 400 {
+			t.Errorf("Expected error code 400, got %d", err.Code)
+		}
+	}()
+	CreateHttpWrapper(WithClientCertificate("missing.crt", "missing.key"))
+}
+
+func TestHttpWrapper_AuthorizationOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []WrapperOption
+		expected string
+	}{
+		{name: "No credentials", opts: nil, expected: ""},
+		{name: "Basic auth", opts: []WrapperOption{WithBasicAuth("admin", "secret")}, expected: "Basic YWRtaW46c2VjcmV0"},
+		{name: "Bearer token", opts: []WrapperOption{WithBearerToken("token123")}, expected: "Bearer token123"},
+		{
+			name:     "Bearer token takes precedence over basic auth",
+			opts:     []WrapperOption{WithBasicAuth("admin", "secret"), WithBearerToken("token123")},
+			expected: "Bearer token123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Get("Authorization")
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			wrapper := CreateHttpWrapper(tt.opts...)
+			wrapper.Get(server.URL)
+
+			if received != tt.expected {
+				t.Errorf("Expected Authorization header %q, got %q", tt.expected, received)
+			}
+			if _, exists := wrapper.config.headers["Authorization"]; exists {
+				t.Error("Credentials should not be stored with the regular headers")
+			}
+		})
+	}
+}
+
+func TestHttpWrapper_WithSessionCookies(t *testing.T) {
+	received := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, c := range r.Cookies() {
+			received[c.Name] = c.Value
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	wrapper := CreateHttpWrapper(WithSessionCookies([]*http.Cookie{
+		{Name: "session", Value: "abc123"},
+		{Name: "csrf", Value: "xyz"},
+	}))
+	wrapper.Get(server.URL)
+
+	if received["session"] != "abc123" || received["csrf"] != "xyz" {
+		t.Errorf("Expected session cookies to be sent, got %v", received)
+	}
+}
+
+func TestHttpWrapper_WithBodyReadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		_, _ = w.Write([]byte("<html><body>"))
+		flusher.Flush()
+		// Drip the chunked body forever until the client goes away
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(20 * time.Millisecond):
+				_, _ = w.Write([]byte("."))
+				flusher.Flush()
+			}
+		}
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	resp := CreateHttpWrapper(WithBodyReadTimeout(200 * time.Millisecond)).Get(server.URL)
+	elapsed := time.Since(start)
+
+	if elapsed > 2*time.Second {
+		t.Errorf("Expected the body read to abort promptly, took %v", elapsed)
+	}
+	if !BodyTruncated(resp) {
+		t.Fatal("Expected the response body to be marked as truncated")
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.HasPrefix(string(body), "<html><body>") {
+		t.Errorf("Expected the received part of the body to be kept, got %q", body)
+	}
+	if resp.ContentLength != int64(len(body)) {
+		t.Errorf("Expected ContentLength %d, got %d", len(body), resp.ContentLength)
+	}
+}
+
+func TestHttpWrapper_BodyWithinTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	resp := CreateHttpWrapper(WithBodyReadTimeout(time.Second)).Get(server.URL)
+
+	if BodyTruncated(resp) {
+		t.Error("Expected a complete body not to be marked as truncated")
+	}
+}
+
+func TestHttpWrapper_ConnectionPool(t *testing.T) {
+	t.Run("Pool options are applied to the transport", func(t *testing.T) {
+		wrapper := CreateHttpWrapper(WithMaxIdleConns(20), WithMaxIdleConnsPerHost(3), WithIdleConnTimeout(15*time.Second))
+		transport := wrapper.client.Transport.(*http.Transport)
+		if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 3 || transport.IdleConnTimeout != 15*time.Second {
+			t.Errorf("Expected pool 20/3/15s, got %d/%d/%v", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+		}
+	})
+
+	t.Run("Pool options override anti-bot defaults", func(t *testing.T) {
+		wrapper := CreateHttpWrapper(WithAntiBotDetection(), WithMaxIdleConnsPerHost(2))
+		transport := wrapper.client.Transport.(*http.Transport)
+		if transport.MaxIdleConns != DefaultMaxIdleConns || transport.MaxIdleConnsPerHost != 2 {
+			t.Errorf("Expected pool %d/2, got %d/%d", DefaultMaxIdleConns, transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+		}
+	})
+
+	t.Run("NewTransport uses bounded defaults", func(t *testing.T) {
+		transport := NewTransport(WithMaxIdleConns(200))
+		if transport.MaxIdleConns != 200 || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost ||
+			transport.IdleConnTimeout != DefaultIdleConnTimeout {
+			t.Errorf("Expected pool 200/%d/%v, got %d/%d/%v", DefaultMaxIdleConnsPerHost, DefaultIdleConnTimeout,
+				transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+		}
+	})
+
+	t.Run("Shared transport is reused", func(t *testing.T) {
+		shared := NewTransport()
+		first := CreateHttpWrapper(WithSharedTransport(shared))
+		second := CreateHttpWrapper(WithSharedTransport(shared), WithMaxIdleConns(1))
+		if first.client.Transport != shared || second.client.Transport != shared {
+			t.Error("Expected both wrappers to use the shared transport")
+		}
+		if shared.MaxIdleConns != DefaultMaxIdleConns {
+			t.Errorf("Expected wrapper pool options not to modify the shared transport, got %d", shared.MaxIdleConns)
+		}
+	})
+
+	t.Run("Client certificate does not leak into the shared transport", func(t *testing.T) {
+		certFile, keyFile, _ := writeClientCertificate(t)
+		shared := NewTransport()
+		wrapper := CreateHttpWrapper(WithSharedTransport(shared), WithClientCertificate(certFile, keyFile))
+		if wrapper.client.Transport == shared {
+			t.Fatal("Expected a copy of the shared transport")
+		}
+		if shared.TLSClientConfig != nil && len(shared.TLSClientConfig.Certificates) > 0 {
+			t.Error("Expected the shared transport to stay without client certificate")
+		}
+	})
+}
+
+func TestHttpWrapper_TLSHandshakeTimeout(t *testing.T) {
+	// The host accepts the TCP connection but never answers the TLS handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				_ = conn.Close()
+			}
+		}()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	wrapper := CreateHttpWrapper(WithTLSHandshakeTimeout(200*time.Millisecond), WithResponseTimeout(10*time.Second))
+	start := time.Now()
+	defer func() {
+		elapsed := time.Since(start)
+		httpErr, ok := recover().(HttpError)
+		if !ok || httpErr.Code != 101 {
+			t.Fatalf("Expected a network HttpError, got %+v", httpErr)
+		}
+		if !strings.Contains(httpErr.Error.(error).Error(), "TLS handshake timeout") {
+			t.Errorf("Expected the TLS handshake timeout to fail the request, got %v", httpErr.Error)
+		}
+		if elapsed > 3*time.Second {
+			t.Errorf("Expected the request to fail at the handshake timeout, took %v", elapsed)
+		}
+	}()
+	wrapper.Get("https://" + listener.Addr().String())
+}
+
+func TestHttpWrapper_Timeouts(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		wrapper := CreateHttpWrapper()
+		transport := wrapper.client.Transport.(*http.Transport)
+		if wrapper.client.Timeout != DefaultResponseTimeout || transport.TLSHandshakeTimeout != DefaultTLSHandshakeTimeout {
+			t.Errorf("Expected timeouts %v/%v, got %v/%v", DefaultResponseTimeout, DefaultTLSHandshakeTimeout,
+				wrapper.client.Timeout, transport.TLSHandshakeTimeout)
+		}
+		if transport.DialContext == nil {
+			t.Error("Expected the dial to be bounded")
+		}
+	})
+
+	t.Run("Connect timeouts do not modify the shared transport", func(t *testing.T) {
+		shared := NewTransport()
+		wrapper := CreateHttpWrapper(WithSharedTransport(shared), WithTLSHandshakeTimeout(time.Second))
+		if wrapper.client.Transport == shared {
+			t.Fatal("Expected a copy of the shared transport")
+		}
+		if wrapper.client.Transport.(*http.Transport).TLSHandshakeTimeout != time.Second {
+			t.Error("Expected the copy to use the configured handshake timeout")
+		}
+		if shared.TLSHandshakeTimeout != DefaultTLSHandshakeTimeout {
+			t.Errorf("Expected the shared transport to keep %v, got %v", DefaultTLSHandshakeTimeout, shared.TLSHandshakeTimeout)
+		}
+	})
+}
+
+func TestParseWWWAuthenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected []AuthChallenge
+	}{
+		{
+			name:     "Basic with realm",
+			values:   []string{`Basic realm="Admin Area", charset="UTF-8"`},
+			expected: []AuthChallenge{{Scheme: "Basic", Params: map[string]string{"realm": "Admin Area", "charset": "UTF-8"}}},
+		},
+		{
+			name:   "Several challenges in one value",
+			values: []string{`Bearer realm="api", error="invalid_token", Basic realm="fallback"`},
+			expected: []AuthChallenge{
+				{Scheme: "Bearer", Params: map[string]string{"realm": "api", "error": "invalid_token"}},
+				{Scheme: "Basic", Params: map[string]string{"realm": "fallback"}},
+			},
+		},
+		{
+			name:     "Schemes without parameters in separate values",
+			values:   []string{"Negotiate", "NTLM"},
+			expected: []AuthChallenge{{Scheme: "Negotiate"}, {Scheme: "NTLM"}},
+		},
+		{
+			name:     "Token68",
+			values:   []string{"Negotiate oRswGaADCgEAoxIEEAEAAAA=, NTLM"},
+			expected: []AuthChallenge{{Scheme: "Negotiate", Token68: "oRswGaADCgEAoxIEEAEAAAA="}, {Scheme: "NTLM"}},
+		},
+		{
+			name:     "Escaped quote in realm",
+			values:   []string{`Digest realm="say \"hi\"", nonce=abc`},
+			expected: []AuthChallenge{{Scheme: "Digest", Params: map[string]string{"realm": `say "hi"`, "nonce": "abc"}}},
+		},
+		{name: "Empty", values: []string{""}, expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenges := ParseWWWAuthenticate(tt.values)
+			if !reflect.DeepEqual(challenges, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, challenges)
+			}
+		})
+	}
+}
+
+func TestHttpWrapper_AuthenticationRequired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="admin"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		opts         []WrapperOption
+		expectedCode int
+	}{
+		{name: "Without credentials", expectedCode: AuthenticationRequiredCode},
+		{name: "With rejected credentials", opts: []WrapperOption{WithBasicAuth("admin", "wrong")}, expectedCode: 102},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(HttpError)
+				if !ok || err.Code != tt.expectedCode {
+					t.Fatalf("Expected HttpError with code %d, got %+v", tt.expectedCode, err)
+				}
+				if tt.expectedCode != AuthenticationRequiredCode {
+					return
+				}
+				resp, ok := err.Error.(*http.Response)
+				if !ok || resp.Header.Get("WWW-Authenticate") != `Basic realm="admin"` {
+					t.Errorf("Expected the 401 response to be attached to the error, got %v", err.Error)
+				}
+			}()
+			CreateHttpWrapper(tt.opts...).Get(server.URL)
+		})
+	}
+}
+
+func TestTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+		if r.Method != http.MethodTrace {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "message/http")
+		_ = r.Write(w)
+	}))
+	defer server.Close()
+	client := CreateHttpWrapper().Client()
+
+	response, err := Trace(context.Background(), client, server.URL, http.Header{"X-Marker": {"antiginx-42"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusOK || !strings.Contains(string(body), "antiginx-42") {
+		t.Errorf("Expected the TRACE request to be echoed, got %d %q", response.StatusCode, body)
+	}
+
+	response, err = Trace(context.Background(), client, server.URL+"/moved", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusFound {
+		t.Errorf("Expected the redirect not to be followed, got %d", response.StatusCode)
+	}
+	if client.CheckRedirect != nil {
+		t.Error("Expected the redirect policy of the scan client to be left unchanged")
+	}
+}
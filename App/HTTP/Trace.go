@@ -0,0 +1,49 @@
+package HttpClient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Trace sends an HTTP TRACE request to url with the given client (typically the scan client
+// returned by Client, so the request shares its transport, TLS settings and timeouts).
+//
+// Unlike Get it returns errors instead of panicking and returns the response whatever its
+// status, as rejecting TRACE (405, 501) is the expected answer. Redirects are not followed:
+// the client would turn the TRACE request into a GET, so the redirect itself is returned.
+// Credentials configured on the wrapper are not attached; a reflected TRACE request would
+// echo them.
+//
+// Parameters:
+//   - ctx: Context bounding the request
+//   - client: Client sending the request
+//   - url: Target URL
+//   - header: Additional request headers (nil for none)
+//
+// Returns:
+//   - *http.Response: Response to the TRACE request; the caller closes its body
+//   - error: Error creating or sending the request
+//
+// Example:
+//
+//	response, err := Trace(ctx, wrapper.Client(), "https://example.com", http.Header{"X-Marker": {"42"}})
+//	if err == nil {
+//	    defer response.Body.Close()
+//	}
+func Trace(ctx context.Context, client *http.Client, url string, header http.Header) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodTrace, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
+
+	noRedirects := *client
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return noRedirects.Do(request)
+}
@@ -0,0 +1,258 @@
+// Package Harness provides a single programmatic entry point for running website tests.
+// It accepts a target plus functional options, runs the selected tests through the regular
+// formatter and job runner, and returns every result in one Result value instead of
+// streaming it to the CLI or the backend. Programs embedding the engine and alternative
+// front-ends (such as the stdin task stream) use it so that they share the same
+// parameter validation and execution path as the command line.
+//
+// Error codes:
+//   - 100: Empty target or no tests selected
+package Harness
+
+import (
+	"Engine-AntiGinx/App/CVE"
+	"Engine-AntiGinx/App/Errors"
+	HttpClient "Engine-AntiGinx/App/HTTP"
+	"Engine-AntiGinx/App/Helpers"
+	"Engine-AntiGinx/App/Reporter"
+	"Engine-AntiGinx/App/Runner"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/formatterImpl"
+	"Engine-AntiGinx/App/execution/strategy"
+	"Engine-AntiGinx/App/execution/strategy/strategyImpl"
+	"Engine-AntiGinx/App/parser/config/types"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Result is the uniform outcome of a harness run.
+type Result struct {
+	Target   string                 `json:"target"`             // Target as passed to Run
+	ScanId   string                 `json:"scanId"`             // Correlation ID of the scan (the task id when given)
+	Results  []Tests.TestResult     `json:"results"`            // Test results in the order they were produced
+	Messages []strategy.RequestInfo `json:"messages"`           // Process messages (e.g., target unreachable)
+	ExitCode int                    `json:"exitCode"`           // Exit code the CLI would return for this scan
+	TimedOut bool                   `json:"timedOut"`           // The scan deadline (--deadline) passed, Results are partial
+	Metadata map[string]any         `json:"metadata,omitempty"` // Free-form caller data set with WithMetadata
+}
+
+// Harness runs website tests and collects their results. The CVE client, the result cache
+// and the HTTP transport are shared by every run, so repeated scans of the same technology
+// reuse cached lookups, pages with identical headers reuse pure header test results and
+// all scans draw from one bounded connection pool. Run is safe for concurrent use;
+// concurrent scans of the same host reuse the pool's keep-alive connections instead of
+// repeating the TCP and TLS handshakes.
+type Harness struct {
+	formatter   execution.Formatter
+	cveClient   *CVE.CVEClient
+	resultCache *Tests.ResultCache
+	transport   *http.Transport
+}
+
+// runConfig holds the per-run settings assembled from Options.
+type runConfig struct {
+	tests    []string
+	params   []*types.CommandParameter
+	metadata map[string]any
+}
+
+// Option configures a single Run call.
+type Option func(*runConfig)
+
+// WithTests selects the registry IDs of the tests to run (e.g., "https", "hsts").
+func WithTests(ids ...string) Option {
+	return func(c *runConfig) {
+		c.tests = append(c.tests, ids...)
+	}
+}
+
+// WithParameter passes any other command line parameter (e.g., "--antiBotDetection",
+// "--auth-bearer", "--severity-threshold") to the run. Parameters are validated
+// exactly like the ones given on the command line.
+func WithParameter(name string, args ...string) Option {
+	return func(c *runConfig) {
+		c.params = append(c.params, &types.CommandParameter{Name: name, Arguments: args})
+	}
+}
+
+// WithTaskId attaches a task identifier to the run, as --taskId does on the command line.
+func WithTaskId(id string) Option {
+	return WithParameter("--taskId", id)
+}
+
+// WithMetadata stores a free-form value in the returned Result's Metadata.
+func WithMetadata(key string, value any) Option {
+	return func(c *runConfig) {
+		if c.metadata == nil {
+			c.metadata = make(map[string]any)
+		}
+		c.metadata[key] = value
+	}
+}
+
+// NewHarness creates a harness using the standard scan formatter and strategies.
+//
+// Returns:
+//   - *Harness: Harness ready to Run
+//
+// Example:
+//
+//	h := Harness.NewHarness()
+//	result, err := h.Run("example.com", Harness.WithTests("https", "hsts"))
+//	if err == nil {
+//	    fmt.Println(len(result.Results))
+//	}
+func NewHarness() *Harness {
+	return &Harness{
+		formatter:   formatterImpl.InitializeFormatter(strategyImpl.GetStrategy),
+		cveClient:   CVE.NewCVEClient(),
+		resultCache: Tests.NewResultCache(0),
+		transport:   HttpClient.NewTransport(),
+	}
+}
+
+// Run executes the selected tests against a target and waits for all results.
+// A leading http:// or https:// scheme is removed from target because the formatter
+// selects the scheme from the tests. Panics raised by the engine are recovered and
+// returned as an error, so embedding programs are never terminated by a failing scan.
+//
+// Parameters:
+//   - target: Host to scan, with or without scheme
+//   - opts: Tests, extra parameters and metadata for this run
+//
+// Returns:
+//   - *Result: Collected results (nil when err is set)
+//   - *Errors.Error: Error with code 100 for an empty target or no tests, or the error
+//     raised by parameter validation, formatting or execution
+func (h *Harness) Run(target string, opts ...Option) (result *Result, err *Errors.Error) {
+	cfg := &runConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = recoveredError(r)
+		}
+	}()
+
+	plan := h.formatter.FormatParameters(cfg.parameters(target))
+	resolver := &collectingResolver{}
+	exitCode := Runner.CreateJobRunner(Runner.WithCVEClient(h.cveClient), Runner.WithResultCache(h.resultCache),
+		Runner.WithSharedTransport(h.transport)).Orchestrate(plan, resolver)
+
+	result = &Result{
+		Target:   target,
+		Results:  []Tests.TestResult{},
+		Messages: []strategy.RequestInfo{},
+		ExitCode: exitCode,
+		Metadata: cfg.metadata,
+	}
+	if resolver.reporter != nil {
+		result.ScanId = resolver.reporter.scanId
+		result.TimedOut = resolver.reporter.timedOut
+		result.Results = append(result.Results, resolver.reporter.results...)
+		result.Messages = append(result.Messages, resolver.reporter.messages...)
+	}
+	return result, nil
+}
+
+// parameters converts the run configuration into validated command parameters.
+//
+// Panics:
+//   - Errors.Error (Code 100): The target is empty or no tests were selected.
+//   - Errors.Error: Any validation error returned by helpers.CheckParameters.
+func (c *runConfig) parameters(target string) []*types.CommandParameter {
+	target = strings.TrimPrefix(strings.TrimPrefix(target, "https://"), "http://")
+	if target == "" || len(c.tests) == 0 {
+		panic(Errors.Error{
+			Code: 100,
+			Message: `Harness error occurred. This could be due to:
+				- empty target
+				- no tests selected`,
+			Source:      "Harness",
+			IsRetryable: false,
+		})
+	}
+	params := append([]*types.CommandParameter{
+		{Name: "--target", Arguments: []string{target}},
+		{Name: "--tests", Arguments: c.tests},
+	}, c.params...)
+	if err := helpers.CheckParameters(params); err != nil {
+		panic(*err)
+	}
+	return params
+}
+
+// recoveredError maps a recovered panic value to an Errors.Error, mirroring the
+// conversions performed by the global error handler.
+func recoveredError(r any) *Errors.Error {
+	switch val := r.(type) {
+	case Errors.Error:
+		return &val
+	case *Errors.Error:
+		return val
+	case HttpClient.HttpError:
+		return &Errors.Error{
+			Code:        val.Code,
+			Message:     val.Message,
+			Source:      "Http Client",
+			IsRetryable: val.IsRetryable,
+		}
+	default:
+		return &Errors.Error{
+			Code:        999,
+			Message:     fmt.Sprintf("Panic: %v", val),
+			Source:      "Runtime/Critical",
+			IsRetryable: false,
+		}
+	}
+}
+
+// collectingResolver is a Reporter.Resolver whose reporter keeps every result in memory.
+type collectingResolver struct {
+	reporter *collectingReporter
+}
+
+// Resolve implements Reporter.Resolver.
+func (c *collectingResolver) Resolve(ch chan strategy.ResultWrapper, taskId string,
+	target string, clientTimeOut int, retryDelay int, strategies []strategy.TestStrategy) Reporter.Reporter {
+	c.reporter = &collectingReporter{channel: ch}
+	return c.reporter
+}
+
+// collectingReporter drains the result channel into test results and process messages.
+type collectingReporter struct {
+	channel  chan strategy.ResultWrapper
+	mu       sync.Mutex
+	results  []Tests.TestResult
+	messages []strategy.RequestInfo
+	scanId   string
+	timedOut bool
+}
+
+// StartListening implements Reporter.Reporter. The returned channel receives 0 once the
+// result channel is closed and every result has been collected.
+func (r *collectingReporter) StartListening() <-chan int {
+	done := make(chan int, 1)
+	go func() {
+		for res := range r.channel {
+			r.mu.Lock()
+			r.scanId = res.GetScanId()
+			if ok, val := res.GetTestResult(); ok {
+				r.timedOut = r.timedOut || val.TestId == strategy.DeadlineWarningId
+				r.results = append(r.results, *val)
+			} else if ok, info := res.GetReqInfo(); ok {
+				r.messages = append(r.messages, *info)
+			}
+			r.mu.Unlock()
+		}
+		done <- 0
+		close(done)
+	}()
+	return done
+}
@@ -0,0 +1,73 @@
+package Harness
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHarness_Run_UniformResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Frame-Options", "DENY")
+		_, _ = w.Write([]byte("<html><body>ok</body></html>"))
+	}))
+	defer server.Close()
+
+	result, err := NewHarness().Run(server.URL,
+		WithTests("https", "xframe"),
+		WithMetadata("requestedBy", "harness-test"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+
+	if result.Target != server.URL {
+		t.Errorf("Expected target %q, got %q", server.URL, result.Target)
+	}
+	if len(result.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d: %+v", len(result.Results), result.Results)
+	}
+	seen := make(map[string]bool)
+	for _, res := range result.Results {
+		if res.TestId == "" || res.Name == "" || res.Description == "" {
+			t.Errorf("Expected result to carry TestId, Name and Description, got %+v", res)
+		}
+		seen[res.TestId] = true
+	}
+	if !seen["https"] || !seen["xframe"] {
+		t.Errorf("Expected results for https and xframe, got %v", seen)
+	}
+	if len(result.Messages) != 0 {
+		t.Errorf("Expected no process messages, got %+v", result.Messages)
+	}
+	if result.Metadata["requestedBy"] != "harness-test" {
+		t.Errorf("Expected metadata to be carried through, got %v", result.Metadata)
+	}
+}
+
+func TestHarness_Run_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		opts     []Option
+		wantCode int
+	}{
+		{name: "Empty target", target: "", opts: []Option{WithTests("https")}, wantCode: 100},
+		{name: "No tests", target: "example.com", wantCode: 100},
+		{name: "Unknown test", target: "example.com", opts: []Option{WithTests("unknown")}, wantCode: 106},
+		{name: "Unknown parameter", target: "example.com", opts: []Option{WithTests("https"), WithParameter("--bogus")}, wantCode: 102},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewHarness().Run(tt.target, tt.opts...)
+			if err == nil {
+				t.Fatalf("Expected error, got result %+v", result)
+			}
+			if result != nil {
+				t.Errorf("Expected nil result on error, got %+v", result)
+			}
+			if err.Code != tt.wantCode {
+				t.Errorf("Expected error code %d, got %+v", tt.wantCode, err)
+			}
+		})
+	}
+}
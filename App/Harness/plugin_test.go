@@ -0,0 +1,50 @@
+package Harness
+
+import (
+	"Engine-AntiGinx/App/Registry"
+	"Engine-AntiGinx/App/Tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// poweredByTest is registered through the plugin hook, as an external package would do.
+func init() {
+	Registry.Register(&Tests.ResponseTest{
+		Id:   "plugin-powered-by",
+		Name: "X-Powered-By Disclosure",
+		RunTest: func(params Tests.ResponseTestParams) Tests.TestResult {
+			if value := params.Response.Header.Get("X-Powered-By"); value != "" {
+				return Tests.TestResult{Name: "X-Powered-By Disclosure", ThreatLevel: Tests.Low,
+					Description: "X-Powered-By discloses " + value}
+			}
+			return Tests.TestResult{Name: "X-Powered-By Disclosure", ThreatLevel: Tests.None,
+				Description: "X-Powered-By is not set"}
+		},
+	})
+}
+
+func TestHarness_Run_PluginTest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Powered-By", "PHP/8.1")
+		_, _ = w.Write([]byte("<html><body>ok</body></html>"))
+	}))
+	defer server.Close()
+
+	result, err := NewHarness().Run(server.URL, WithTests("https", "plugin-powered-by"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	var plugin *Tests.TestResult
+	for i := range result.Results {
+		if result.Results[i].TestId == "plugin-powered-by" {
+			plugin = &result.Results[i]
+		}
+	}
+	if plugin == nil {
+		t.Fatalf("Expected a result of the plugin test, got %+v", result.Results)
+	}
+	if plugin.ThreatLevel != Tests.Low || plugin.Description != "X-Powered-By discloses PHP/8.1" {
+		t.Errorf("Unexpected plugin result: %+v", plugin)
+	}
+}
@@ -0,0 +1,77 @@
+package Harness
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingServer starts a test server counting the TCP connections it accepts.
+func countingServer(t testing.TB) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body>ok</body></html>"))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return server, &connections
+}
+
+func TestHarness_Run_ReusesConnections(t *testing.T) {
+	server, connections := countingServer(t)
+	h := NewHarness()
+
+	for i := 0; i < 2; i++ {
+		if _, err := h.Run(server.URL, WithTests("https", "plugin-powered-by")); err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+	}
+	if got := connections.Load(); got != 1 {
+		t.Errorf("Expected both scans to share one connection, got %d", got)
+	}
+}
+
+func TestHarness_Run_ConcurrentScansShareTransport(t *testing.T) {
+	server, connections := countingServer(t)
+	h := NewHarness()
+	// Warm the pool, so the concurrent scans find an idle connection.
+	if _, err := h.Run(server.URL, WithTests("https")); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := h.Run(server.URL, WithTests("https")); err != nil {
+				t.Errorf("Unexpected error: %+v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	// Concurrent requests may open extra connections, but never more than the scans made.
+	if got := connections.Load(); got > 5 {
+		t.Errorf("Expected at most one connection per scan, got %d", got)
+	}
+}
+
+func BenchmarkHarness_Run_SharedTransport(b *testing.B) {
+	server, connections := countingServer(b)
+	h := NewHarness()
+	for b.Loop() {
+		if _, err := h.Run(server.URL, WithTests("https")); err != nil {
+			b.Fatalf("Unexpected error: %+v", err)
+		}
+	}
+	b.ReportMetric(float64(connections.Load())/float64(b.N), "conns/scan")
+}
@@ -45,9 +45,11 @@ func InitializeTargetFormatter() *TargetFormatter {
 //
 // Protocol selection logic:
 //
-//   - HTTP (http://): Used when "https" or "hsts" tests are included
-//     Rationale: These tests specifically check for HTTP→HTTPS redirects and HSTS headers,
-//     so starting with HTTP is necessary to observe the security behavior
+//   - HTTP (http://): Used when "https", "hsts", "transport" or "cookie-scheme-reuse" tests
+//     are included
+//     Rationale: These tests specifically check for HTTP→HTTPS redirects, HSTS headers and
+//     cookies set along the redirect, so starting with HTTP is necessary to observe the
+//     security behavior
 //
 //   - HTTPS (https://): Used for all other test combinations (default)
 //     Rationale: Most security tests should analyze the secure connection
@@ -101,7 +103,8 @@ func (t *TargetFormatter) Format(target string, params []string) *string {
 	}
 	builder := strings.Builder{}
 	builder.Grow(len(target) + len("https://"))
-	if t.containsParam(params, "https") || t.containsParam(params, "hsts") {
+	if t.containsParam(params, "https") || t.containsParam(params, "hsts") || t.containsParam(params, "transport") ||
+		t.containsParam(params, "cookie-scheme-reuse") {
 		builder.WriteString("http://")
 	} else {
 		builder.WriteString("https://")
@@ -0,0 +1,82 @@
+// Package helpers provides the apex/www variant lookup used by --include-www. This file
+// contains the logic deciding which hosts have a www counterpart worth scanning.
+package helpers
+
+import (
+	"net"
+	"strings"
+)
+
+// secondLevelSuffixes are common two-label public suffixes, so that "example.co.uk" and
+// "example.com.pl" are recognized as apex domains like "example.com".
+var secondLevelSuffixes = []string{
+	"co.uk", "org.uk", "ac.uk", "gov.uk", "com.au", "net.au", "org.au", "co.nz", "co.jp",
+	"com.br", "com.pl", "net.pl", "org.pl", "edu.pl", "gov.pl", "co.in", "co.za", "com.mx",
+}
+
+// WWWVariant returns the www variant of an apex target ("example.com" -> "www.example.com")
+// or the apex variant of a www target ("www.example.com" -> "example.com"). The scheme,
+// port and path of the target are kept. Subdomains other than www, IP addresses and
+// single-label hosts such as "localhost" have no variant.
+//
+// Parameters:
+//   - target: Host or URL, e.g. "example.com" or "https://www.example.com:8443/login"
+//
+// Returns:
+//   - string: The variant target, empty if there is none
+//   - bool: true if the target has a variant
+//
+// Example:
+//
+//	variant, ok := WWWVariant("https://example.com/login")
+//	// variant == "https://www.example.com/login", ok == true
+func WWWVariant(target string) (string, bool) {
+	scheme, rest := "", target
+	if i := strings.Index(target, "://"); i != -1 {
+		scheme, rest = target[:i+3], target[i+3:]
+	}
+	hostPort, path := rest, ""
+	if i := strings.IndexAny(rest, "/?#"); i != -1 {
+		hostPort, path = rest[:i], rest[i:]
+	}
+	host, port := hostPort, ""
+	if h, p, err := net.SplitHostPort(hostPort); err == nil {
+		host, port = h, ":"+p
+	}
+	if host == "" || net.ParseIP(host) != nil {
+		return "", false
+	}
+
+	var variant string
+	switch {
+	case strings.HasPrefix(strings.ToLower(host), "www."):
+		variant = host[len("www."):]
+		if !isApexHost(variant) {
+			return "", false
+		}
+	case isApexHost(host):
+		variant = "www." + host
+	default:
+		return "", false
+	}
+	return scheme + variant + port + path, true
+}
+
+// isApexHost reports whether host is a registrable domain: two labels, or three labels
+// ending in one of the secondLevelSuffixes.
+func isApexHost(host string) bool {
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(host, ".")), ".")
+	for _, label := range labels {
+		if label == "" {
+			return false
+		}
+	}
+	switch len(labels) {
+	case 2:
+		return true
+	case 3:
+		return StringInSlice(secondLevelSuffixes, labels[1]+"."+labels[2])
+	default:
+		return false
+	}
+}
@@ -0,0 +1,126 @@
+// Package Locale renders finding descriptions and remediation in the language selected with
+// --lang. Tests describe the localized portions of their results with Texts, which pair a
+// stable message id with its arguments, instead of hardcoded English strings. Messages are
+// looked up in per-language catalogs keyed by the message id; English is the default and the
+// fallback for messages missing from another catalog.
+//
+// Message ids are namespaced by the test id, e.g. "hsts.missing.description". Only the hsts
+// test reports Texts so far; the results of other tests carry plain English strings, which
+// are reported unchanged whatever the language.
+package Locale
+
+import (
+	"fmt"
+)
+
+// Lang identifies a message catalog.
+type Lang string
+
+const (
+	English Lang = "en" // Default language
+	Polish  Lang = "pl"
+)
+
+// catalogs maps every supported language to its messages. Messages are fmt format strings
+// receiving the arguments of the Text.
+var catalogs = map[Lang]map[string]string{
+	English: english,
+	Polish:  polish,
+}
+
+// Text is a localizable message: a message id and the arguments of its format string.
+// Arguments that are Texts themselves are rendered in the same language.
+type Text struct {
+	Id   string
+	Args []any
+}
+
+// NewText creates a localizable message.
+//
+// Parameters:
+//   - id: Message id present in the English catalog
+//   - args: Arguments of the message format string (strings, numbers or *Text)
+//
+// Returns:
+//   - *Text: Message ready to be rendered in any language
+//
+// Example:
+//
+//	text := NewText("hsts.remediation.preload", "max-age=31536000; includeSubDomains; preload")
+//	text.Render(Polish)
+func NewText(id string, args ...any) *Text {
+	return &Text{Id: id, Args: args}
+}
+
+// Plural creates a localizable message whose wording depends on a count. The message id is
+// suffixed with the plural category of the count in the rendering language ("one", "few",
+// "many" or "other"), and the count is passed as the only argument.
+//
+// Example:
+//
+//	Plural("hsts.max_age.year", 2).Render(English) // "2 years max-age"
+//	Plural("hsts.max_age.year", 2).Render(Polish)  // "max-age 2 lata"
+func Plural(id string, count int) *Text {
+	return &Text{Id: id, Args: []any{pluralCount(count)}}
+}
+
+// pluralCount marks the argument of a Plural text.
+type pluralCount int
+
+// Render formats the message in the given language, falling back to English when the
+// language or the message is unknown and to the message id when no catalog has it.
+//
+// Parameters:
+//   - lang: Language to render (empty means English)
+//
+// Returns:
+//   - string: Rendered message
+func (t *Text) Render(lang Lang) string {
+	if t == nil {
+		return ""
+	}
+	id := t.Id
+	args := make([]any, len(t.Args))
+	for i, arg := range t.Args {
+		switch value := arg.(type) {
+		case *Text:
+			args[i] = value.Render(lang)
+		case pluralCount:
+			id = t.Id + "." + pluralCategory(lang, int(value))
+			args[i] = int(value)
+		default:
+			args[i] = arg
+		}
+	}
+	return fmt.Sprintf(lookup(lang, id), args...)
+}
+
+// lookup returns the format string of a message id.
+func lookup(lang Lang, id string) string {
+	if message, ok := catalogs[lang][id]; ok {
+		return message
+	}
+	if message, ok := english[id]; ok {
+		return message
+	}
+	return id
+}
+
+// pluralCategory returns the CLDR plural category of a count: English distinguishes "one"
+// and "other", Polish "one", "few" (2-4, except 12-14) and "many".
+func pluralCategory(lang Lang, count int) string {
+	if lang != Polish {
+		if count == 1 {
+			return "one"
+		}
+		return "other"
+	}
+	switch {
+	case count == 1:
+		return "one"
+	case count%10 >= 2 && count%10 <= 4 && (count%100 < 12 || count%100 > 14):
+		return "few"
+	default:
+		return "many"
+	}
+}
@@ -0,0 +1,50 @@
+package Locale
+
+import "testing"
+
+func TestText_Render(t *testing.T) {
+	tests := []struct {
+		name string
+		text *Text
+		lang Lang
+		want string
+	}{
+		{name: "English", text: NewText("hsts.invalid_max_age"), lang: English,
+			want: "HSTS header present but missing or invalid max-age directive"},
+		{name: "Unknown language falls back to English", text: NewText("hsts.invalid_max_age"), lang: "de",
+			want: "HSTS header present but missing or invalid max-age directive"},
+		{name: "Unknown message renders the id", text: NewText("missing.message"), lang: Polish, want: "missing.message"},
+		{name: "Nested text", text: NewText("hsts.configured", Plural("hsts.max_age.day", 1), "", NewText("hsts.verdict.weak")), lang: English,
+			want: "HSTS header configured with 1 day max-age - Weak security configuration, consider increasing max-age"},
+		{name: "Polish one", text: Plural("hsts.max_age.year", 1), lang: Polish, want: "max-age 1 rok"},
+		{name: "Polish few", text: Plural("hsts.max_age.year", 3), lang: Polish, want: "max-age 3 lata"},
+		{name: "Polish many", text: Plural("hsts.max_age.year", 5), lang: Polish, want: "max-age 5 lat"},
+		{name: "Polish teens are many", text: Plural("hsts.max_age.month", 12), lang: Polish, want: "max-age 12 miesięcy"},
+		{name: "Polish 22 is few", text: Plural("hsts.max_age.hour", 22), lang: Polish, want: "max-age 22 godziny"},
+		{name: "English other", text: Plural("hsts.max_age.hour", 2), lang: English, want: "2 hours max-age"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.text.Render(tt.lang); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCatalogs_CoverEnglishIds(t *testing.T) {
+	for id := range polish {
+		base := id
+		for _, suffix := range []string{".one", ".few", ".many"} {
+			if len(id) > len(suffix) && id[len(id)-len(suffix):] == suffix {
+				base = id[:len(id)-len(suffix)]
+			}
+		}
+		if _, ok := english[id]; ok {
+			continue
+		}
+		if _, ok := english[base+".one"]; !ok {
+			t.Errorf("Polish message %q has no English counterpart", id)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package Locale
+
+// english is the default message catalog. Every message id must be present here.
+var english = map[string]string{
+	// hsts
+	"hsts.missing.description":  "Missing HSTS header - site vulnerable to protocol downgrade attacks and man-in-the-middle attacks",
+	"hsts.missing.remediation":  "Send the header on every HTTPS response: Strict-Transport-Security: %s",
+	"hsts.invalid_max_age":      "HSTS header present but missing or invalid max-age directive",
+	"hsts.configured":           "HSTS header configured with %s%s%s",
+	"hsts.includes":             " and includes: %s",
+	"hsts.verdict.excellent":    " - Excellent security configuration",
+	"hsts.verdict.good":         " - Good security configuration",
+	"hsts.verdict.acceptable":   " - Acceptable security configuration",
+	"hsts.verdict.weak":         " - Weak security configuration, consider increasing max-age",
+	"hsts.remediation.preload":  "Add the preload directive (Strict-Transport-Security: %s) and submit the domain to the HSTS preload list",
+	"hsts.remediation.replace":  "Replace the header with Strict-Transport-Security: %s (max-age of at least one year covering all subdomains)",
+	"hsts.max_age.year.one":     "%d year max-age",
+	"hsts.max_age.year.other":   "%d years max-age",
+	"hsts.max_age.month.one":    "%d month max-age",
+	"hsts.max_age.month.other":  "%d months max-age",
+	"hsts.max_age.day.one":      "%d day max-age",
+	"hsts.max_age.day.other":    "%d days max-age",
+	"hsts.max_age.hour.one":     "%d hour max-age",
+	"hsts.max_age.hour.other":   "%d hours max-age",
+	"hsts.max_age.second.one":   "%d seconds max-age",
+	"hsts.max_age.second.other": "%d seconds max-age",
+}
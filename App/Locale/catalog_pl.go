@@ -0,0 +1,32 @@
+package Locale
+
+// polish is the Polish message catalog. Messages missing here are rendered in English.
+var polish = map[string]string{
+	// hsts
+	"hsts.missing.description": "Brak nagłówka HSTS - witryna podatna na ataki obniżenia protokołu i ataki typu man-in-the-middle",
+	"hsts.missing.remediation": "Wysyłaj nagłówek w każdej odpowiedzi HTTPS: Strict-Transport-Security: %s",
+	"hsts.invalid_max_age":     "Nagłówek HSTS obecny, ale brakuje dyrektywy max-age lub jest ona nieprawidłowa",
+	"hsts.configured":          "Nagłówek HSTS skonfigurowany z %s%s%s",
+	"hsts.includes":            " oraz zawiera: %s",
+	"hsts.verdict.excellent":   " - Doskonała konfiguracja bezpieczeństwa",
+	"hsts.verdict.good":        " - Dobra konfiguracja bezpieczeństwa",
+	"hsts.verdict.acceptable":  " - Akceptowalna konfiguracja bezpieczeństwa",
+	"hsts.verdict.weak":        " - Słaba konfiguracja bezpieczeństwa, rozważ zwiększenie max-age",
+	"hsts.remediation.preload": "Dodaj dyrektywę preload (Strict-Transport-Security: %s) i zgłoś domenę na listę HSTS preload",
+	"hsts.remediation.replace": "Zastąp nagłówek wartością Strict-Transport-Security: %s (max-age co najmniej jeden rok, obejmujący wszystkie subdomeny)",
+	"hsts.max_age.year.one":    "max-age %d rok",
+	"hsts.max_age.year.few":    "max-age %d lata",
+	"hsts.max_age.year.many":   "max-age %d lat",
+	"hsts.max_age.month.one":   "max-age %d miesiąc",
+	"hsts.max_age.month.few":   "max-age %d miesiące",
+	"hsts.max_age.month.many":  "max-age %d miesięcy",
+	"hsts.max_age.day.one":     "max-age %d dzień",
+	"hsts.max_age.day.few":     "max-age %d dni",
+	"hsts.max_age.day.many":    "max-age %d dni",
+	"hsts.max_age.hour.one":    "max-age %d godzina",
+	"hsts.max_age.hour.few":    "max-age %d godziny",
+	"hsts.max_age.hour.many":   "max-age %d godzin",
+	"hsts.max_age.second.one":  "max-age %d sekunda",
+	"hsts.max_age.second.few":  "max-age %d sekundy",
+	"hsts.max_age.second.many": "max-age %d sekund",
+}
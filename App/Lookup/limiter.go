@@ -0,0 +1,124 @@
+// Package Lookup bounds the external lookups made by tests besides the request to the
+// target itself: NVD queries of the CVE client and DNS queries of the DNS client. A large
+// scan runs many tests on many targets at once, so without a shared bound these lookups
+// could overwhelm the resolver or the NVD API.
+//
+// Every client takes a slot of the process-wide Limiter returned by Shared for the duration
+// of a request. The number of slots is read once from the EXTERNAL_LOOKUP_CONCURRENCY
+// environment variable. Waiting for a slot honours the context of the lookup, so the time
+// spent queued counts towards the lookup's timeout.
+package Lookup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ConcurrencyEnv names the environment variable holding the maximum number of external
+// lookups running at once. 0 disables the bound.
+const ConcurrencyEnv = "EXTERNAL_LOOKUP_CONCURRENCY"
+
+// DefaultConcurrency is the bound used when ConcurrencyEnv is unset or invalid.
+const DefaultConcurrency = 8
+
+// Limiter is a counting semaphore bounding concurrent lookups. The zero value and a nil
+// Limiter do not bound anything.
+type Limiter struct {
+	slots chan struct{}
+}
+
+var (
+	sharedOnce sync.Once
+	shared     *Limiter
+)
+
+// warningOutput receives the warning about an invalid ConcurrencyEnv value. It is stderr,
+// so the warning never mixes with reports written to stdout.
+var warningOutput io.Writer = os.Stderr
+
+// NewLimiter creates a Limiter allowing limit lookups at once.
+//
+// Parameters:
+//   - limit: Maximum number of concurrent lookups (0 or less = unbounded)
+//
+// Returns:
+//   - *Limiter: Limiter ready to use
+func NewLimiter(limit int) *Limiter {
+	if limit <= 0 {
+		return &Limiter{}
+	}
+	return &Limiter{slots: make(chan struct{}, limit)}
+}
+
+// Shared returns the process-wide Limiter used by the CVE and DNS clients, sized from
+// EXTERNAL_LOOKUP_CONCURRENCY on first use. An invalid value is reported as a warning on
+// stderr and DefaultConcurrency is used instead.
+//
+// Returns:
+//   - *Limiter: Limiter shared by every external lookup of the process
+func Shared() *Limiter {
+	sharedOnce.Do(func() {
+		shared = NewLimiter(concurrencyFromEnv())
+	})
+	return shared
+}
+
+// Limit returns the maximum number of concurrent lookups, 0 when unbounded.
+func (l *Limiter) Limit() int {
+	if l == nil {
+		return 0
+	}
+	return cap(l.slots)
+}
+
+// Acquire waits for a free slot. The returned release function frees it again and may be
+// called more than once.
+//
+// Parameters:
+//   - ctx: Context bounding the wait (nil = wait without limit)
+//
+// Returns:
+//   - func(): Releases the slot
+//   - error: The context error when ctx ended before a slot was free
+//
+// Example:
+//
+//	release, err := Lookup.Shared().Acquire(ctx)
+//	if err != nil {
+//	    return nil, err
+//	}
+//	defer release()
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil || l.slots == nil {
+		return func() {}, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	select {
+	case l.slots <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-l.slots }) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// concurrencyFromEnv reads the bound from ConcurrencyEnv.
+func concurrencyFromEnv() int {
+	value := strings.TrimSpace(os.Getenv(ConcurrencyEnv))
+	if value == "" {
+		return DefaultConcurrency
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		fmt.Fprintf(warningOutput, "Lookup \nWarning: %s=%q is not a non-negative integer, using %d\n", ConcurrencyEnv, value, DefaultConcurrency)
+		return DefaultConcurrency
+	}
+	return limit
+}
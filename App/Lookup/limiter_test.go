@@ -0,0 +1,109 @@
+package Lookup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Bound(t *testing.T) {
+	limiter := NewLimiter(3)
+	var inFlight, peak atomic.Int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := limiter.Acquire(context.Background())
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			defer release()
+			current := inFlight.Add(1)
+			for {
+				seen := peak.Load()
+				if current <= seen || peak.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 3 || got == 0 {
+		t.Errorf("Expected at most 3 concurrent lookups, got %d", got)
+	}
+}
+
+func TestLimiter_AcquireHonoursContext(t *testing.T) {
+	limiter := NewLimiter(1)
+	release, _ := limiter.Acquire(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the wait to end with the context, got %v", err)
+	}
+
+	release()
+	release()
+	again, err := limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Expected the released slot to be free, got %v", err)
+	}
+	again()
+}
+
+func TestLimiter_Unbounded(t *testing.T) {
+	for _, limiter := range []*Limiter{nil, NewLimiter(0)} {
+		for i := 0; i < 100; i++ {
+			if _, err := limiter.Acquire(nil); err != nil {
+				t.Fatalf("Expected an unbounded limiter to never wait, got %v", err)
+			}
+		}
+		if limiter.Limit() != 0 {
+			t.Errorf("Expected limit 0, got %d", limiter.Limit())
+		}
+	}
+}
+
+func TestConcurrencyFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "", want: DefaultConcurrency},
+		{value: "4", want: 4},
+		{value: " 0 ", want: 0},
+		{value: "-1", want: DefaultConcurrency},
+		{value: "many", want: DefaultConcurrency},
+	}
+	for _, tt := range tests {
+		t.Setenv(ConcurrencyEnv, tt.value)
+		if got := concurrencyFromEnv(); got != tt.want {
+			t.Errorf("%s=%q: expected %d, got %d", ConcurrencyEnv, tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestConcurrencyFromEnv_WarningOutput(t *testing.T) {
+	var out bytes.Buffer
+	previous := warningOutput
+	warningOutput = &out
+	t.Cleanup(func() { warningOutput = previous })
+
+	t.Setenv(ConcurrencyEnv, "many")
+	concurrencyFromEnv()
+
+	if !bytes.Contains(out.Bytes(), []byte(ConcurrencyEnv)) {
+		t.Errorf("Expected the warning on the warning output, got %q", out.String())
+	}
+}
@@ -0,0 +1,32 @@
+// Package Plugins links externally maintained security tests into the engine without
+// forking it. A plugin is an ordinary Go package that registers its tests from init():
+//
+//	package xpoweredby
+//
+//	import (
+//	    "Engine-AntiGinx/App/Registry"
+//	    "Engine-AntiGinx/App/Tests"
+//	)
+//
+//	func init() {
+//	    Registry.Register(&Tests.ResponseTest{
+//	        Id:      "x-powered-by",
+//	        Name:    "X-Powered-By Disclosure",
+//	        RunTest: run,
+//	    }, Registry.WithSelfTestVerdict(Tests.None))
+//	}
+//
+// To enable a plugin, add a blank import of its package to the import block below and
+// rebuild. main imports this package, so every plugin's init() runs before the command
+// line is parsed and its tests are accepted by --tests, listed by help and executed by
+// the Runner like the built-in ones. See Tests.ResponseTest for the contract a test must
+// follow. WithSelfTestVerdict declares the verdict checked by --self-test; without it the
+// self-test lists the plugin test as not checked.
+package Plugins
+
+import (
+	// The registry (and with it every built-in test) is initialized before any plugin.
+	_ "Engine-AntiGinx/App/Registry"
+	// Blank imports of plugin packages go here, e.g.:
+	// _ "example.com/antiginx-plugins/xpoweredby"
+)
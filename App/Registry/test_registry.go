@@ -6,16 +6,31 @@
 // and enforces uniqueness of test IDs to prevent conflicts. All tests are indexed by
 // their string identifiers for fast O(1) lookup operations.
 //
+// Tests outside this package (plugins linked in at build time, see package Plugins) are
+// added with Register from their own init() functions and are then available to the
+// parser, the strategies and the Runner exactly like the built-in tests.
+//
 // Error codes:
 //   - 100: Duplicate test ID detected during registration
+//   - 101: Invalid test passed to Register (nil, empty ID or missing RunTest)
 package Registry
 
 import (
 	error "Engine-AntiGinx/App/Errors"
 	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/parser/config"
 	"fmt"
+	"slices"
+	"sort"
 )
 
+// testsParam is the command line parameter whose whitelist lists the registered test IDs.
+const testsParam = "--tests"
+
+// maxSuggestionDistance is the largest edit distance at which a registered test ID
+// is still considered a plausible correction for a mistyped ID.
+const maxSuggestionDistance = 2
+
 // tests is the internal central storage for all registered response tests,
 // indexed by their unique string ID. This map provides O(1) lookup performance
 // for test retrieval operations.
@@ -24,6 +39,28 @@ import (
 // and should not be modified directly outside of the registerTest function.
 var tests = make(map[string]*Tests.ResponseTest)
 
+// selfTestVerdicts holds the self-test verdicts declared by plugin tests, indexed by test ID
+// (see WithSelfTestVerdict). Built-in tests declare theirs in package SelfTest.
+var selfTestVerdicts = make(map[string]Tests.ThreatLevel)
+
+// RegisterOption is a functional option tuning the registration of a plugin test.
+type RegisterOption func(t *Tests.ResponseTest)
+
+// WithSelfTestVerdict declares the threat level the test reports for the known-good fixture
+// of the self-test mode (--self-test). A plugin test registered without it is reported as
+// not checked by the self-test.
+//
+// Parameters:
+//   - level: Expected verdict for the self-test fixture
+//
+// Returns:
+//   - RegisterOption: Option recording the verdict
+func WithSelfTestVerdict(level Tests.ThreatLevel) RegisterOption {
+	return func(t *Tests.ResponseTest) {
+		selfTestVerdicts[t.Id] = level
+	}
+}
+
 // init automatically registers default security tests when the Registry package is initialized.
 // This function runs once before main() and ensures all standard tests are available
 // for immediate use throughout the application lifecycle.
@@ -44,7 +81,8 @@ var tests = make(map[string]*Tests.ResponseTest)
 //   - SitemapSecurityTest: Analyzes sitemap.xml for dangerous path exposure to search engines
 //   - PhishingURLTest: Analyzes hostname similarity to popular domains for phishing indicators
 //
-// Additional tests can be registered by adding registerTest calls in this function.
+// Additional built-in tests can be registered by adding registerTest calls in this function;
+// external tests use Register instead.
 func init() {
 	registerTest(Tests.NewHTTPSTest())
 	registerTest(Tests.NewHSTSTest())
@@ -60,9 +98,77 @@ func init() {
 	registerTest(Tests.NewCrossOriginTest())
 	registerTest(Tests.NewSitemapSecurityTest())
 	registerTest(Tests.NewPhishingURLTest())
+	registerTest(Tests.NewCAATest())
+	registerTest(Tests.NewEmailDNSTest())
+	registerTest(Tests.NewAltSvcTest())
+	registerTest(Tests.NewCORSAllowListTest())
+	registerTest(Tests.NewAPICacheTest())
+	registerTest(Tests.NewXXSSProtectionTest())
+	registerTest(Tests.NewTransportSecurityTest())
+	registerTest(Tests.NewVaryTest())
+	registerTest(Tests.NewServerTimingTest())
+	registerTest(Tests.NewHPKPTest())
+	registerTest(Tests.NewContentDispositionTest())
+	registerTest(Tests.NewXSTTest())
+	registerTest(Tests.NewHTTPMethodsTest())
+	registerTest(Tests.NewInsecureDeserializationTest())
+	registerTest(Tests.NewCookieSchemeReuseTest())
+	registerTest(Tests.NewCustomCheckTest())
+	registerTest(Tests.NewTransferIntegrityTest())
+	registerTest(Tests.NewXPermittedCrossDomainPoliciesTest())
+	registerTest(Tests.NewWellKnownTest())
+	registerTest(Tests.NewRateLimitTest())
+	registerTest(Tests.NewTabnabbingTest())
+	registerTest(Tests.NewCORSMaxAgeTest())
+	registerTest(Tests.NewURLSecretsTest())
+	registerTest(Tests.NewJSLibrariesTest())
+}
+
+// Register is the registration hook for tests defined outside the engine. It is meant to be
+// called from the init() function of a plugin package linked into the binary (see package
+// Plugins), so the test exists before the command line is parsed.
+//
+// The test must follow the Tests.ResponseTest contract: a unique, non-empty Id (the value
+// accepted by --tests), a non-nil RunTest and CacheHeaders left nil unless the result depends
+// only on those headers. A registered test is added to the --tests whitelist, so the parser,
+// the help output and the Runner pick it up without further changes.
+//
+// Parameters:
+//   - t: Pointer to the ResponseTest instance to register
+//   - opts: Optional registration settings (e.g., WithSelfTestVerdict)
+//
+// Panics:
+//   - error.Error with code 101: If t is nil, has an empty Id or no RunTest function
+//   - error.Error with code 100: If a test with the same ID already exists in the registry
+//
+// Example:
+//
+//	package myplugin
+//
+//	func init() {
+//	    Registry.Register(&Tests.ResponseTest{
+//	        Id:      "x-powered-by",
+//	        Name:    "X-Powered-By Disclosure",
+//	        RunTest: runXPoweredBy,
+//	    }, Registry.WithSelfTestVerdict(Tests.None))
+//	}
+func Register(t *Tests.ResponseTest, opts ...RegisterOption) {
+	if t == nil || t.Id == "" || t.RunTest == nil {
+		panic(error.Error{
+			Code:        101,
+			Message:     "Registry error occurred. This could be due to:\n- registered test is nil\n- registered test has an empty Id\n- registered test has no RunTest function",
+			Source:      "Registry",
+			IsRetryable: false,
+		})
+	}
+	registerTest(t)
+	for _, opt := range opts {
+		opt(t)
+	}
 }
 
-// registerTest adds a new test instance to the internal registry with strict ID uniqueness enforcement.
+// registerTest adds a new test instance to the internal registry with strict ID uniqueness enforcement
+// and adds its ID to the --tests whitelist when it is not listed there yet.
 // This function is intended for internal use during package initialization via the init() function.
 //
 // The function performs validation to ensure no duplicate test IDs are registered, which could
@@ -90,6 +196,11 @@ func registerTest(t *Tests.ResponseTest) {
 		})
 	}
 	tests[t.Id] = t
+
+	if param, ok := config.Params[testsParam]; ok && !slices.Contains(param.Arguments, t.Id) {
+		param.Arguments = append(param.Arguments, t.Id)
+		config.Params[testsParam] = param
+	}
 }
 
 // GetTest retrieves a specific ResponseTest from the registry by its unique identifier.
@@ -127,3 +238,107 @@ func GetAllTests() []*Tests.ResponseTest {
 	}
 	return values
 }
+
+// Rubric returns the conditions under which the test with the given ID reports each threat
+// level, ordered from None upwards. It returns nil for an unknown test or a test whose
+// rubric has not been documented yet.
+//
+// Parameters:
+//   - testId: The unique string identifier of the test (e.g., "hsts")
+//
+// Returns:
+//   - []Tests.RubricEntry: Rubric of the test, sorted by threat level
+//
+// Example:
+//
+//	for _, entry := range Registry.Rubric("hsts") {
+//	    fmt.Printf("%s: %s\n", entry.ThreatLevel, entry.Condition)
+//	}
+func Rubric(testId string) []Tests.RubricEntry {
+	t, ok := tests[testId]
+	if !ok || len(t.Rubric) == 0 {
+		return nil
+	}
+	rubric := slices.Clone(t.Rubric)
+	sort.SliceStable(rubric, func(i, j int) bool { return rubric[i].ThreatLevel < rubric[j].ThreatLevel })
+	return rubric
+}
+
+// SelfTestVerdict returns the self-test verdict declared for a plugin test with
+// WithSelfTestVerdict.
+//
+// Parameters:
+//   - testId: The unique string identifier of the test
+//
+// Returns:
+//   - Tests.ThreatLevel: Expected verdict for the self-test fixture
+//   - bool: false if the test declared none
+func SelfTestVerdict(testId string) (Tests.ThreatLevel, bool) {
+	level, ok := selfTestVerdicts[testId]
+	return level, ok
+}
+
+// SuggestTestIds returns registered test IDs that are close to the given (unknown) ID,
+// ordered from the closest match. Closeness is measured with the Levenshtein edit
+// distance; only IDs within maxSuggestionDistance edits are returned.
+//
+// It is intended for building "did you mean ...?" hints when a user passes an invalid
+// test ID.
+//
+// Parameters:
+//   - testId: The unknown test ID supplied by the user (e.g., "hstss")
+//
+// Returns:
+//   - []string: Matching registered IDs, closest first (empty if nothing is close)
+//
+// Example:
+//
+//	suggestions := Registry.SuggestTestIds("hstss")
+//	// suggestions == []string{"hsts"}
+func SuggestTestIds(testId string) []string {
+	type candidate struct {
+		id       string
+		distance int
+	}
+	var candidates []candidate
+	for id := range tests {
+		if d := levenshtein(testId, id); d <= maxSuggestionDistance {
+			candidates = append(candidates, candidate{id, d})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].id < candidates[j].id
+	})
+
+	suggestions := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		suggestions = append(suggestions, c.id)
+	}
+	return suggestions
+}
+
+// levenshtein computes the edit distance between two strings, counting single-rune
+// insertions, deletions and substitutions.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,132 @@
+package Registry
+
+import (
+	error "Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/parser/config"
+	"slices"
+	"testing"
+)
+
+func TestSuggestTestIds(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Extra letter", input: "hstss", expected: "hsts"},
+		{name: "Swapped letters", input: "hsst", expected: "hsts"},
+		{name: "Missing letter", input: "xfrme", expected: "xframe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := SuggestTestIds(tt.input)
+			if len(suggestions) == 0 || suggestions[0] != tt.expected {
+				t.Errorf("Expected %q as the closest suggestion for %q, got %v", tt.expected, tt.input, suggestions)
+			}
+		})
+	}
+}
+
+func TestSuggestTestIds_NoCloseMatch(t *testing.T) {
+	if suggestions := SuggestTestIds("completely-unrelated"); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %v", suggestions)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	cases := map[[2]string]int{
+		{"", ""}:              0,
+		{"hsts", "hsts"}:      0,
+		{"hstss", "hsts"}:     1,
+		{"kitten", "sitting"}: 3,
+	}
+	for in, want := range cases {
+		if got := levenshtein(in[0], in[1]); got != want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", in[0], in[1], got, want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	test := &Tests.ResponseTest{
+		Id:   "plugin-register",
+		Name: "Plugin Register",
+		RunTest: func(params Tests.ResponseTestParams) Tests.TestResult {
+			return Tests.TestResult{Name: "Plugin Register", ThreatLevel: Tests.None}
+		},
+	}
+	Register(test, WithSelfTestVerdict(Tests.Low))
+	t.Cleanup(func() {
+		delete(tests, test.Id)
+		delete(selfTestVerdicts, test.Id)
+	})
+
+	if got, ok := GetTest(test.Id); !ok || got != test {
+		t.Fatalf("Expected registered test to be retrievable, got %v, %v", got, ok)
+	}
+	if !slices.Contains(config.Params[testsParam].Arguments, test.Id) {
+		t.Errorf("Expected %q in the --tests whitelist, got %v", test.Id, config.Params[testsParam].Arguments)
+	}
+	if level, ok := SelfTestVerdict(test.Id); !ok || level != Tests.Low {
+		t.Errorf("Expected the declared self-test verdict Low, got %v, %v", level, ok)
+	}
+	if _, ok := SelfTestVerdict("hsts"); ok {
+		t.Errorf("Expected no plugin verdict for a built-in test")
+	}
+}
+
+func TestRegister_Invalid(t *testing.T) {
+	run := func(params Tests.ResponseTestParams) Tests.TestResult { return Tests.TestResult{} }
+	cases := []struct {
+		name     string
+		test     *Tests.ResponseTest
+		wantCode int
+	}{
+		{name: "Nil test", test: nil, wantCode: 101},
+		{name: "Empty Id", test: &Tests.ResponseTest{RunTest: run}, wantCode: 101},
+		{name: "Missing RunTest", test: &Tests.ResponseTest{Id: "no-run"}, wantCode: 101},
+		{name: "Duplicate Id", test: &Tests.ResponseTest{Id: "hsts", RunTest: run}, wantCode: 100},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(error.Error)
+				if !ok || err.Code != tt.wantCode {
+					t.Errorf("Expected panic with code %d, got %v", tt.wantCode, err)
+				}
+			}()
+			Register(tt.test)
+		})
+	}
+}
+
+func TestRubric_HSTS(t *testing.T) {
+	rubric := Rubric("hsts")
+
+	levels := []Tests.ThreatLevel{}
+	for i, entry := range rubric {
+		if entry.Condition == "" {
+			t.Errorf("Expected a condition for every rubric entry, entry %d (%v) has none", i, entry.ThreatLevel)
+		}
+		if i > 0 && entry.ThreatLevel < rubric[i-1].ThreatLevel {
+			t.Errorf("Expected the rubric sorted by threat level, got %v after %v", entry.ThreatLevel, rubric[i-1].ThreatLevel)
+		}
+		if !slices.Contains(levels, entry.ThreatLevel) {
+			levels = append(levels, entry.ThreatLevel)
+		}
+	}
+	want := []Tests.ThreatLevel{Tests.None, Tests.Info, Tests.Low, Tests.Medium, Tests.High}
+	if !slices.Equal(levels, want) {
+		t.Errorf("Expected the HSTS rubric to enumerate %v, got %v", want, levels)
+	}
+}
+
+func TestRubric_Undocumented(t *testing.T) {
+	if rubric := Rubric("no-such-test"); rubric != nil {
+		t.Errorf("Expected no rubric for an unknown test, got %v", rubric)
+	}
+	if rubric := Rubric("xst"); rubric != nil {
+		t.Errorf("Expected no rubric for a test without one, got %v", rubric)
+	}
+}
@@ -9,9 +9,14 @@ package Reporter
 
 import (
 	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Reporter/types"
 	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution/strategy"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 )
 
 // banner is the ASCII art logo displayed at the start of CLI reporting.
@@ -35,6 +40,12 @@ var banner string = `
 // when multiple tests are executed.
 var separator string = `---------------------------------------------`
 
+// ANSI escape sequences used to grey out suppressed findings.
+const (
+	ansiGrey  = "\033[90m"
+	ansiReset = "\033[0m"
+)
+
 // cliReporter is a console-based reporter implementation that outputs test results
 // directly to standard output (stdout). It provides formatted, human-readable output
 // for interactive use and local development.
@@ -51,8 +62,19 @@ var separator string = `---------------------------------------------`
 //
 // Fields:
 //   - resultChannel: Receive-only channel for consuming test results
+//   - quiet: Print only the one-line summary (see EnableQuiet)
+//   - notes: Cross-test notes (e.g., cookie and CSP consistency) repeated in the summary
+//   - out: Destination of the output, os.Stdout unless changed with SetOutput
+//   - scanStarted: Start of the scan (see SetReportMetadata), zero when unknown
 type cliReporter struct {
 	resultChannel <-chan strategy.ResultWrapper
+	out           io.Writer
+	aggregator    *Aggregator
+	scanId        string
+	timedOut      bool
+	quiet         bool
+	notes         []string
+	scanStarted   time.Time
 }
 
 // InitializeCliReporter creates and returns a new instance of the CLI reporter
@@ -92,7 +114,50 @@ type cliReporter struct {
 func InitializeCliReporter(channel chan strategy.ResultWrapper) *cliReporter {
 	return &cliReporter{
 		resultChannel: channel,
+		aggregator:    NewAggregator(),
+		out:           os.Stdout,
+	}
+}
+
+// SetOutput redirects the reporter output from stdout to out, e.g. a file receiving a
+// local copy of the results sent to the backend (see teeReporter). It must be called
+// before StartListening.
+//
+// Parameters:
+//   - out: Destination of the banner, the results and the summary
+func (c *cliReporter) SetOutput(out io.Writer) {
+	c.out = out
+}
+
+// SetReportMetadata records the start of the scan, so the summary can report the overall
+// scan duration: the Runner closes the result channel as soon as the scan is over.
+func (c *cliReporter) SetReportMetadata(metadata types.ReportMetadata) {
+	c.scanStarted = metadata.ScanTime
+}
+
+// scanDuration returns the time elapsed since the scan started, zero when unknown.
+func (c *cliReporter) scanDuration() time.Duration {
+	if c.scanStarted.IsZero() {
+		return 0
 	}
+	return time.Since(c.scanStarted)
+}
+
+// EnableQuiet switches the reporter to summary-only output (--quiet): the banner and the
+// individual findings are not printed, and the scan ends with a single line holding the
+// overall grade and the per-severity counts. Messages about targets that could not be
+// tested are still printed, so an unreachable target is not mistaken for a clean scan.
+//
+// Example:
+//
+//	reporter := InitializeCliReporter(resultChan)
+//	reporter.EnableQuiet()
+//	doneChan := reporter.StartListening()
+//
+//	// Output:
+//	// Grade: D | Critical: 0, High: 1, Medium: 0, Low: 2, Info: 1, None: 6
+func (c *cliReporter) EnableQuiet() {
+	c.quiet = true
 }
 
 // StartListening begins the asynchronous process of consuming and printing test results
@@ -103,8 +168,14 @@ func InitializeCliReporter(channel chan strategy.ResultWrapper) *cliReporter {
 //  1. Print ASCII art banner to stdout
 //  2. Print "TEST RESULT" header
 //  3. Enter processing loop (range over resultChannel)
-//  4. For each result: call printTestResult to format and display
-//  5. When channel closes: send completion signal and exit
+//  4. For each result: call printTestResult to format and display, and record it in the aggregator
+//  5. When channel closes: print the summary (grade, per-severity counts, whether the scan timed out,
+//     the scan duration and the cross-test notes), followed for a scan of several targets by the
+//     fleet summary (targets ranked worst first and the most common findings)
+//  6. Send completion signal and exit
+//
+// In quiet mode (EnableQuiet) steps 1, 2 and the per-result output are skipped and the
+// summary is a single line, printed even when no test result was received.
 //
 // Output format for each test:
 //   - Test Name
@@ -140,8 +211,10 @@ func InitializeCliReporter(channel chan strategy.ResultWrapper) *cliReporter {
 func (c *cliReporter) StartListening() <-chan int {
 	done := make(chan int)
 	go func() {
-		fmt.Println(banner)
-		fmt.Println("TEST RESULT")
+		if !c.quiet {
+			fmt.Fprintln(c.out, banner)
+			fmt.Fprintln(c.out, "TEST RESULT")
+		}
 
 		// The loop terminates automatically when c.resultChannel is closed by the sender.
 		for result := range c.resultChannel {
@@ -156,10 +229,37 @@ func (c *cliReporter) StartListening() <-chan int {
 					IsRetryable: false,
 				})
 			}
+			if scanId := result.GetScanId(); scanId != "" {
+				c.scanId = scanId
+			}
+			if target := result.GetTarget(); target != "" && (!c.quiet || okInfo) {
+				fmt.Fprintf(c.out, "Target: %s\n", target)
+			}
 			if okInfo {
-				printProcessInfo(*info)
+				printProcessInfo(c.out, *info)
 			} else {
-				printTestResult(*val)
+				if val.TestId == strategy.DeadlineWarningId {
+					c.timedOut = true
+				}
+				if val.TestId == strategy.CookieCSPConsistencyId {
+					c.notes = append(c.notes, val.Description)
+				}
+				if target := result.GetTarget(); target != "" {
+					c.aggregator.AddForTarget(target, *val)
+				} else {
+					c.aggregator.Add(*val)
+				}
+				if !c.quiet {
+					printTestResult(c.out, *val)
+				}
+			}
+		}
+		if c.quiet {
+			printSummaryLine(c.out, c.aggregator, c.timedOut)
+		} else if c.aggregator.Len() > 0 {
+			printSummary(c.out, c.aggregator, c.scanId, c.timedOut, c.scanDuration(), c.notes)
+			if fleet := c.aggregator.Fleet(); fleet != nil {
+				printFleetSummary(c.out, fleet)
 			}
 		}
 
@@ -169,7 +269,7 @@ func (c *cliReporter) StartListening() <-chan int {
 	return done
 }
 
-// printTestResult formats and prints a single test result to stdout with structured formatting.
+// printTestResult formats and prints a single test result to out with structured formatting.
 // This helper function provides consistent, human-readable output for all test results.
 //
 // Output format:
@@ -183,8 +283,12 @@ func (c *cliReporter) StartListening() <-chan int {
 //   - 4 = High (serious vulnerability)
 //   - 5 = Critical (severe vulnerability)
 //   - Description: [string] - Detailed explanation of the finding
+//   - Classification: [string] - CWE and OWASP Top 10 category, when the test provides them
+//   - Remediation and References: [string] - How to fix the finding, when the test provides it
 //   - Separator line for visual distinction
 //
+// Suppressed findings are printed in grey and tagged with "(suppressed)".
+//
 // The function is called internally by StartListening for each result received
 // from the result channel.
 //
@@ -198,16 +302,129 @@ func (c *cliReporter) StartListening() <-chan int {
 //	Threat level: 4
 //	Description: Connection uses insecure HTTP protocol - data is transmitted in plaintext
 //	---------------------------------------------
-func printTestResult(result Tests.TestResult) {
-	fmt.Printf("Test name: %s\n", result.Name)
-	fmt.Printf("Certanity: %d\n", result.Certainty)
-	fmt.Printf("Threat level %v\n", result.ThreatLevel)
-	fmt.Printf("Description: %s\n", result.Description)
-	fmt.Println(separator)
+func printTestResult(out io.Writer, result Tests.TestResult) {
+	if result.Suppressed {
+		fmt.Fprint(out, ansiGrey)
+		defer fmt.Fprint(out, ansiReset)
+		fmt.Fprintf(out, "Test name: %s (suppressed)\n", result.Name)
+	} else {
+		fmt.Fprintf(out, "Test name: %s\n", result.Name)
+	}
+	fmt.Fprintf(out, "Certanity: %d\n", result.Certainty)
+	fmt.Fprintf(out, "Threat level %v\n", result.ThreatLevel)
+	fmt.Fprintf(out, "Description: %s\n", result.Description)
+	if result.Duration > 0 {
+		fmt.Fprintf(out, "Duration: %s\n", formatDuration(result.Duration))
+	}
+	if result.CWE != "" || result.OWASPCategory != "" {
+		fmt.Fprintf(out, "Classification: %s\n", strings.Trim(result.CWE+" "+result.OWASPCategory, " "))
+	}
+	if result.Remediation != "" {
+		fmt.Fprintf(out, "Remediation: %s\n", result.Remediation)
+	}
+	for _, reference := range result.References {
+		fmt.Fprintf(out, "Reference: %s\n", reference)
+	}
+	fmt.Fprintln(out, separator)
+}
+
+// printSummary prints the scan ID, whether the scan hit its deadline, the scan duration
+// (when known), the overall grade, the number of findings per threat level, from the most
+// to the least severe, and the notes putting findings of several tests in context.
+//
+// Example output:
+//
+//	SUMMARY
+//	Scan ID: 3f2a9c0d1b4e5f60
+//	Status: TIMED OUT (partial results)
+//	Duration: 4.215s
+//	Grade: D
+//	Critical: 0, High: 1, Medium: 2, Low: 0, Info: 1, None: 6
+//	Note: Session cookies without HttpOnly (sid) are readable by scripts, but the Content-Security-Policy ...
+//	---------------------------------------------
+func printSummary(out io.Writer, agg *Aggregator, scanId string, timedOut bool, duration time.Duration, notes []string) {
+	counts := agg.Counts()
+	fmt.Fprintln(out, "SUMMARY")
+	if scanId != "" {
+		fmt.Fprintf(out, "Scan ID: %s\n", scanId)
+	}
+	if timedOut {
+		fmt.Fprintln(out, "Status: TIMED OUT (partial results)")
+	}
+	if duration > 0 {
+		fmt.Fprintf(out, "Duration: %s\n", formatDuration(duration))
+	}
+	fmt.Fprintf(out, "Grade: %s\n", agg.Grade())
+	for level := Tests.Critical; level >= Tests.None; level-- {
+		fmt.Fprintf(out, "%v: %d", level, counts[level])
+		if level > Tests.None {
+			fmt.Fprint(out, ", ")
+		}
+	}
+	fmt.Fprintln(out)
+	for _, note := range notes {
+		fmt.Fprintf(out, "Note: %s\n", note)
+	}
+	fmt.Fprintln(out, separator)
+}
+
+// printFleetSummary prints the aggregate of a scan of several targets: every target ranked
+// from the worst grade, then the findings shared by the most targets.
+//
+// Example output:
+//
+//	FLEET SUMMARY
+//	1. legacy.example.com - Grade: F (Critical: 1, High: 2, Medium: 0, Low: 1)
+//	2. www.example.com - Grade: C (Critical: 0, High: 0, Medium: 1, Low: 0)
+//	Most common findings:
+//	- HSTS Analysis (hsts), up to Medium: 2 of 2 targets
+//	---------------------------------------------
+func printFleetSummary(out io.Writer, fleet *FleetSummary) {
+	fmt.Fprintln(out, "FLEET SUMMARY")
+	for i, target := range fleet.Targets {
+		fmt.Fprintf(out, "%d. %s - Grade: %s (Critical: %d, High: %d, Medium: %d, Low: %d)\n", i+1, target.Target,
+			target.Grade, target.Counts[Tests.Critical], target.Counts[Tests.High], target.Counts[Tests.Medium], target.Counts[Tests.Low])
+	}
+	if len(fleet.CommonFindings) > 0 {
+		fmt.Fprintln(out, "Most common findings:")
+	}
+	for _, finding := range fleet.CommonFindings {
+		fmt.Fprintf(out, "- %s (%s), up to %v: %d of %d targets\n", finding.Name, finding.TestId,
+			finding.ThreatLevel, len(finding.Targets), len(fleet.Targets))
+	}
+	fmt.Fprintln(out, separator)
+}
+
+// printSummaryLine prints the quiet mode summary: the overall grade and the number of
+// findings per threat level on one line, marked when the scan hit its deadline.
+//
+// Example output:
+//
+//	Grade: D | Critical: 0, High: 1, Medium: 2, Low: 0, Info: 1, None: 6
+func printSummaryLine(out io.Writer, agg *Aggregator, timedOut bool) {
+	counts := agg.Counts()
+	parts := make([]string, 0, int(Tests.Critical)+1)
+	for level := Tests.Critical; level >= Tests.None; level-- {
+		parts = append(parts, fmt.Sprintf("%v: %d", level, counts[level]))
+	}
+	line := fmt.Sprintf("Grade: %s | %s", agg.Grade(), strings.Join(parts, ", "))
+	if timedOut {
+		line += " | TIMED OUT (partial results)"
+	}
+	fmt.Fprintln(out, line)
+}
+
+// formatDuration rounds a duration to milliseconds for display, keeping sub-millisecond
+// durations visible.
+func formatDuration(duration time.Duration) string {
+	if duration < time.Millisecond {
+		return duration.Round(time.Microsecond).String()
+	}
+	return duration.Round(time.Millisecond).String()
 }
 
-func printProcessInfo(info strategy.RequestInfo) {
-	fmt.Printf("Engine was unable to test this website\n")
-	fmt.Printf("\nTest process message: \n%s\n", info.Message)
-	fmt.Println(separator)
+func printProcessInfo(out io.Writer, info strategy.RequestInfo) {
+	fmt.Fprintf(out, "Engine was unable to test this website\n")
+	fmt.Fprintf(out, "\nTest process message: \n%s\n", info.Message)
+	fmt.Fprintln(out, separator)
 }
@@ -3,6 +3,8 @@
 // enabling polymorphic handling of different reporting strategies (CLI, HTTP backend, etc.).
 package Reporter
 
+import "Engine-AntiGinx/App/Reporter/types"
+
 // Reporter is the interface that defines the contract for all test result reporting implementations.
 // It provides a unified abstraction for consuming test results from a channel and processing them
 // according to the specific reporter's strategy.
@@ -17,6 +19,9 @@ package Reporter
 // Current implementations:
 //   - cliReporter: Outputs formatted results to stdout (console)
 //   - backendReporter: Sends results to external HTTP backend with retry logic
+//   - teeReporter: Fans results out to several reporters (e.g., backend and a local copy)
+//   - junitReporter: Writes the results as a JUnit XML report for CI systems
+//   - htmlReporter: Writes the results as a standalone HTML report with a report header
 //
 // Expected behavior:
 //   - StartListening() should spawn a goroutine for asynchronous processing
@@ -24,6 +29,8 @@ package Reporter
 //   - Processing should continue until the input channel is closed
 //   - The returned channel should signal completion and provide error/failure count
 //   - Implementations should handle graceful shutdown without data loss
+//   - New implementations are added to TestReporter_Contract (contract_test.go), which
+//     checks these expectations against every reporter
 //
 // Example usage:
 //
@@ -69,3 +76,12 @@ type Reporter interface {
 	//   - <-chan int: Completion signal channel with failure count
 	StartListening() <-chan int
 }
+
+// MetadataReceiver is implemented by reporters rendering a report header (e.g., the HTML
+// reporter). The Runner passes them the header fields it knows (scan time, target, scanner
+// version) before starting them; reporters without a header do not implement it.
+type MetadataReceiver interface {
+	// SetReportMetadata completes the report header. Empty fields keep the values the
+	// reporter was created with.
+	SetReportMetadata(metadata types.ReportMetadata)
+}
@@ -0,0 +1,350 @@
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Diff"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
+	"sort"
+	"sync"
+)
+
+// Grade is an overall letter score summarising the security posture of a scanned target.
+type Grade string
+
+const (
+	GradeA Grade = "A" // No findings above Info
+	GradeB Grade = "B" // Worst finding is Low
+	GradeC Grade = "C" // Worst finding is Medium
+	GradeD Grade = "D" // Worst finding is High
+	GradeF Grade = "F" // At least one Critical finding
+)
+
+// Aggregator collects test results from concurrent producers and derives the summary data
+// shared by every reporter: per-severity counts, an overall grade and a sorted result list.
+//
+// Suppressed results are kept in the result list but excluded from counts and grading.
+// Results added with AddForTarget are also summarised per target (see Fleet), for scans of
+// a targets file. The zero value is not usable; create instances with NewAggregator. All
+// methods are safe for concurrent use.
+type Aggregator struct {
+	mu       sync.Mutex
+	results  []Tests.TestResult
+	baseline *Diff.Baseline
+
+	targets       []string // Targets in the order their first result arrived
+	targetResults map[string][]Tests.TestResult
+}
+
+// fleetTopFindings caps the number of findings listed in FleetSummary.CommonFindings.
+const fleetTopFindings = 10
+
+// TargetSummary is the overall posture of one target of a fleet scan.
+//
+// Fields:
+//   - Target: Scanned target
+//   - Grade: Overall grade of the target's unsuppressed results
+//   - Counts: Unsuppressed results keyed by threat level
+//   - Findings: Number of unsuppressed findings (Low or worse)
+type TargetSummary struct {
+	Target   string
+	Grade    Grade
+	Counts   map[Tests.ThreatLevel]int
+	Findings int
+}
+
+// FleetFinding is a finding reported for one or more targets of a fleet scan.
+//
+// Fields:
+//   - TestId: ID of the test reporting the finding
+//   - Name: Name of the test
+//   - ThreatLevel: Worst threat level reported for the finding across targets
+//   - Targets: Targets with the finding, in scan order
+type FleetFinding struct {
+	TestId      string
+	Name        string
+	ThreatLevel Tests.ThreatLevel
+	Targets     []string
+}
+
+// FleetSummary is the aggregate report of a scan of several targets, so security teams can
+// prioritise the weakest targets and the issues shared across the fleet.
+//
+// Fields:
+//   - Targets: Every target, worst first (see Fleet)
+//   - CommonFindings: The most widespread findings, at most fleetTopFindings
+type FleetSummary struct {
+	Targets        []TargetSummary
+	CommonFindings []FleetFinding
+}
+
+// NewAggregator creates an empty Aggregator.
+//
+// Returns:
+//   - *Aggregator: Aggregator ready to accept results
+//
+// Example:
+//
+//	agg := NewAggregator()
+//	agg.Add(result)
+//	fmt.Printf("Grade: %s\n", agg.Grade())
+func NewAggregator() *Aggregator {
+	return &Aggregator{}
+}
+
+// Add records a single test result.
+//
+// Parameters:
+//   - result: Test result to include in the aggregate
+func (a *Aggregator) Add(result Tests.TestResult) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.results = append(a.results, result)
+}
+
+// AddForTarget records a single test result of target, counting it both in the overall
+// aggregate and in the target's summary.
+//
+// Parameters:
+//   - target: Target the result belongs to
+//   - result: Test result to include in the aggregate
+func (a *Aggregator) AddForTarget(target string, result Tests.TestResult) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.results = append(a.results, result)
+	if a.targetResults == nil {
+		a.targetResults = make(map[string][]Tests.TestResult)
+	}
+	if _, known := a.targetResults[target]; !known {
+		a.targets = append(a.targets, target)
+	}
+	a.targetResults[target] = append(a.targetResults[target], result)
+}
+
+// SetBaseline sets the previous report Status compares results with.
+//
+// Parameters:
+//   - baseline: Findings of the previous report (nil disables statuses)
+func (a *Aggregator) SetBaseline(baseline *Diff.Baseline) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.baseline = baseline
+}
+
+// Status classifies a result of target against the baseline: a result above None is a new
+// or a persistent finding, a passing result of a test that failed in the baseline is a
+// resolved one. Suppression does not affect the status.
+//
+// Parameters:
+//   - target: Target the result belongs to
+//   - result: Test result to classify
+//
+// Returns:
+//   - types.FindingStatus: Status of the result, empty without a baseline or when the test
+//     passes now and passed before
+//
+// Example:
+//
+//	agg.SetBaseline(Diff.NewBaseline(previousReport))
+//	agg.Status("https://example.com", result) // types.StatusNew if result is a new finding
+func (a *Aggregator) Status(target string, result Tests.TestResult) types.FindingStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.baseline == nil {
+		return ""
+	}
+	testId := result.TestId
+	if testId == "" {
+		testId = result.Name
+	}
+	known := a.baseline.Has(target, testId)
+	switch {
+	case result.ThreatLevel > Tests.None && known:
+		return types.StatusPersistent
+	case result.ThreatLevel > Tests.None:
+		return types.StatusNew
+	case known:
+		return types.StatusResolved
+	default:
+		return ""
+	}
+}
+
+// Counts returns the number of unsuppressed results for every threat level.
+// Levels without results are reported with a count of zero.
+//
+// Returns:
+//   - map[Tests.ThreatLevel]int: Result count keyed by threat level
+func (a *Aggregator) Counts() map[Tests.ThreatLevel]int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return countResults(a.results)
+}
+
+// countResults counts the unsuppressed results for every threat level.
+func countResults(results []Tests.TestResult) map[Tests.ThreatLevel]int {
+	counts := make(map[Tests.ThreatLevel]int, int(Tests.Critical)+1)
+	for level := Tests.None; level <= Tests.Critical; level++ {
+		counts[level] = 0
+	}
+	for _, result := range results {
+		if !result.Suppressed {
+			counts[result.ThreatLevel]++
+		}
+	}
+	return counts
+}
+
+// Grade derives the overall grade from the most severe unsuppressed result.
+//
+// Grading:
+//   - A: None or Info only
+//   - B: Worst finding Low
+//   - C: Worst finding Medium
+//   - D: Worst finding High
+//   - F: Any Critical finding
+//
+// Returns:
+//   - Grade: Overall letter grade
+func (a *Aggregator) Grade() Grade {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return gradeResults(a.results)
+}
+
+// gradeResults derives the grade of the most severe unsuppressed result (see Grade).
+func gradeResults(results []Tests.TestResult) Grade {
+	worst := Tests.None
+	for _, result := range results {
+		if !result.Suppressed && result.ThreatLevel > worst {
+			worst = result.ThreatLevel
+		}
+	}
+	switch {
+	case worst >= Tests.Critical:
+		return GradeF
+	case worst == Tests.High:
+		return GradeD
+	case worst == Tests.Medium:
+		return GradeC
+	case worst == Tests.Low:
+		return GradeB
+	default:
+		return GradeA
+	}
+}
+
+// Results returns a copy of all collected results ordered by descending threat level,
+// then by test ID and name, so reporters render the most severe findings first.
+//
+// Returns:
+//   - []Tests.TestResult: Sorted copy of the collected results
+func (a *Aggregator) Results() []Tests.TestResult {
+	a.mu.Lock()
+	sorted := append([]Tests.TestResult(nil), a.results...)
+	a.mu.Unlock()
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ThreatLevel != sorted[j].ThreatLevel {
+			return sorted[i].ThreatLevel > sorted[j].ThreatLevel
+		}
+		if sorted[i].TestId != sorted[j].TestId {
+			return sorted[i].TestId < sorted[j].TestId
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// Len returns the number of collected results, including suppressed ones.
+func (a *Aggregator) Len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.results)
+}
+
+// Fleet summarises the results added with AddForTarget when they cover more than one
+// target. Targets are ranked worst first: by grade, then by their number of Critical, High,
+// Medium and Low findings, then by name. Common findings are the unsuppressed results of
+// Low or worse, grouped by test and ordered by the number of affected targets, then by
+// threat level and test ID.
+//
+// Returns:
+//   - *FleetSummary: Aggregate of the fleet, nil when fewer than two targets were scanned
+//
+// Example:
+//
+//	if fleet := agg.Fleet(); fleet != nil {
+//	    fmt.Printf("Weakest target: %s (%s)\n", fleet.Targets[0].Target, fleet.Targets[0].Grade)
+//	}
+func (a *Aggregator) Fleet() *FleetSummary {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.targets) < 2 {
+		return nil
+	}
+
+	fleet := &FleetSummary{Targets: make([]TargetSummary, 0, len(a.targets))}
+	findings := make(map[string]*FleetFinding)
+	for _, target := range a.targets {
+		results := a.targetResults[target]
+		summary := TargetSummary{Target: target, Grade: gradeResults(results), Counts: countResults(results)}
+		for level := Tests.Low; level <= Tests.Critical; level++ {
+			summary.Findings += summary.Counts[level]
+		}
+		fleet.Targets = append(fleet.Targets, summary)
+
+		for _, result := range results {
+			if result.Suppressed || result.ThreatLevel < Tests.Low {
+				continue
+			}
+			key := result.TestId
+			if key == "" {
+				key = result.Name
+			}
+			finding, ok := findings[key]
+			if !ok {
+				finding = &FleetFinding{TestId: result.TestId, Name: result.Name}
+				findings[key] = finding
+			}
+			finding.ThreatLevel = max(finding.ThreatLevel, result.ThreatLevel)
+			if len(finding.Targets) == 0 || finding.Targets[len(finding.Targets)-1] != target {
+				finding.Targets = append(finding.Targets, target)
+			}
+		}
+	}
+
+	sort.SliceStable(fleet.Targets, func(i, j int) bool {
+		ti, tj := fleet.Targets[i], fleet.Targets[j]
+		if ti.Grade != tj.Grade {
+			return ti.Grade > tj.Grade // "F" sorts after "A"
+		}
+		for level := Tests.Critical; level >= Tests.Low; level-- {
+			if ti.Counts[level] != tj.Counts[level] {
+				return ti.Counts[level] > tj.Counts[level]
+			}
+		}
+		return ti.Target < tj.Target
+	})
+
+	fleet.CommonFindings = make([]FleetFinding, 0, len(findings))
+	for _, finding := range findings {
+		fleet.CommonFindings = append(fleet.CommonFindings, *finding)
+	}
+	sort.Slice(fleet.CommonFindings, func(i, j int) bool {
+		fi, fj := fleet.CommonFindings[i], fleet.CommonFindings[j]
+		if len(fi.Targets) != len(fj.Targets) {
+			return len(fi.Targets) > len(fj.Targets)
+		}
+		if fi.ThreatLevel != fj.ThreatLevel {
+			return fi.ThreatLevel > fj.ThreatLevel
+		}
+		if fi.TestId != fj.TestId {
+			return fi.TestId < fj.TestId
+		}
+		return fi.Name < fj.Name
+	})
+	if len(fleet.CommonFindings) > fleetTopFindings {
+		fleet.CommonFindings = fleet.CommonFindings[:fleetTopFindings]
+	}
+	return fleet
+}
@@ -0,0 +1,135 @@
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Diff"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregator_ConcurrentAdd(t *testing.T) {
+	agg := NewAggregator()
+	levels := []Tests.ThreatLevel{Tests.None, Tests.Low, Tests.Medium, Tests.High}
+	const perLevel = 50
+
+	var wg sync.WaitGroup
+	for _, level := range levels {
+		for i := 0; i < perLevel; i++ {
+			wg.Add(1)
+			go func(level Tests.ThreatLevel, i int) {
+				defer wg.Done()
+				agg.Add(Tests.TestResult{TestId: fmt.Sprintf("%v-%d", level, i), ThreatLevel: level})
+			}(level, i)
+		}
+	}
+	wg.Wait()
+
+	counts := agg.Counts()
+	for _, level := range levels {
+		assert.Equal(t, perLevel, counts[level], "Unexpected count for %v", level)
+	}
+	assert.Equal(t, 0, counts[Tests.Critical])
+	assert.Equal(t, len(levels)*perLevel, agg.Len())
+	assert.Equal(t, GradeD, agg.Grade())
+
+	results := agg.Results()
+	assert.Equal(t, Tests.High, results[0].ThreatLevel, "Most severe results should come first")
+	assert.Equal(t, Tests.None, results[len(results)-1].ThreatLevel)
+}
+
+func TestAggregator_Grade(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []Tests.TestResult
+		expected Grade
+	}{
+		{name: "No results", results: nil, expected: GradeA},
+		{name: "Info only", results: []Tests.TestResult{{ThreatLevel: Tests.Info}}, expected: GradeA},
+		{name: "Low", results: []Tests.TestResult{{ThreatLevel: Tests.Low}, {ThreatLevel: Tests.None}}, expected: GradeB},
+		{name: "Medium", results: []Tests.TestResult{{ThreatLevel: Tests.Medium}, {ThreatLevel: Tests.Low}}, expected: GradeC},
+		{name: "Critical", results: []Tests.TestResult{{ThreatLevel: Tests.Critical}, {ThreatLevel: Tests.High}}, expected: GradeF},
+		{
+			name:     "Suppressed findings are not graded",
+			results:  []Tests.TestResult{{ThreatLevel: Tests.Critical, Suppressed: true}, {ThreatLevel: Tests.Low}},
+			expected: GradeB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := NewAggregator()
+			for _, r := range tt.results {
+				agg.Add(r)
+			}
+			assert.Equal(t, tt.expected, agg.Grade())
+		})
+	}
+}
+
+func TestAggregator_Status(t *testing.T) {
+	agg := NewAggregator()
+	assert.Equal(t, types.FindingStatus(""), agg.Status("https://example.com", Tests.TestResult{TestId: "csp", ThreatLevel: Tests.High}),
+		"Without a baseline no status is set")
+
+	agg.SetBaseline(Diff.NewBaseline([]types.TestResultWrapper{
+		{Target: "https://example.com", ResultType: types.Success, Result: Tests.TestResult{TestId: "hsts", ThreatLevel: Tests.Medium}},
+		{Target: "https://example.com", ResultType: types.Success, Result: Tests.TestResult{TestId: "xframe", ThreatLevel: Tests.Low}},
+		{Target: "https://example.com", ResultType: types.Success, Result: Tests.TestResult{TestId: "csp", ThreatLevel: Tests.None}},
+		{Target: "https://other.example", ResultType: types.Success, Result: Tests.TestResult{TestId: "cors-allow", ThreatLevel: Tests.High}},
+	}))
+
+	tests := []struct {
+		name   string
+		target string
+		result Tests.TestResult
+		want   types.FindingStatus
+	}{
+		{name: "Finding absent from the baseline", target: "https://example.com", result: Tests.TestResult{TestId: "csp", ThreatLevel: Tests.High}, want: types.StatusNew},
+		{name: "Finding of another target", target: "https://example.com", result: Tests.TestResult{TestId: "cors-allow", ThreatLevel: Tests.High}, want: types.StatusNew},
+		{name: "Finding in the baseline", target: "https://example.com", result: Tests.TestResult{TestId: "hsts", ThreatLevel: Tests.High}, want: types.StatusPersistent},
+		{name: "Fixed finding", target: "https://example.com", result: Tests.TestResult{TestId: "xframe", ThreatLevel: Tests.None}, want: types.StatusResolved},
+		{name: "Still passing", target: "https://example.com", result: Tests.TestResult{TestId: "csp", ThreatLevel: Tests.None}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, agg.Status(tt.target, tt.result))
+		})
+	}
+}
+
+func TestAggregator_Fleet(t *testing.T) {
+	agg := NewAggregator()
+	agg.Add(Tests.TestResult{TestId: "https", ThreatLevel: Tests.High})
+	assert.Nil(t, agg.Fleet(), "Results without a target are not a fleet")
+
+	agg = NewAggregator()
+	agg.AddForTarget("a.example", Tests.TestResult{TestId: "hsts", Name: "HSTS", ThreatLevel: Tests.Medium})
+	agg.AddForTarget("a.example", Tests.TestResult{TestId: "csp", Name: "CSP", ThreatLevel: Tests.Low})
+	agg.AddForTarget("b.example", Tests.TestResult{TestId: "hsts", Name: "HSTS", ThreatLevel: Tests.High})
+	agg.AddForTarget("b.example", Tests.TestResult{TestId: "xframe", Name: "X-Frame", ThreatLevel: Tests.High, Suppressed: true})
+	agg.AddForTarget("c.example", Tests.TestResult{TestId: "csp", Name: "CSP", ThreatLevel: Tests.Medium})
+	agg.AddForTarget("c.example", Tests.TestResult{TestId: "hsts", Name: "HSTS", ThreatLevel: Tests.Info})
+
+	fleet := agg.Fleet()
+	if assert.NotNil(t, fleet) {
+		var ranking []string
+		for _, target := range fleet.Targets {
+			ranking = append(ranking, target.Target+" "+string(target.Grade))
+		}
+		// a and c share grade C; a has one more Low finding.
+		assert.Equal(t, []string{"b.example D", "a.example C", "c.example C"}, ranking)
+		assert.Equal(t, 2, fleet.Targets[1].Findings)
+
+		if assert.Len(t, fleet.CommonFindings, 2) {
+			assert.Equal(t, "hsts", fleet.CommonFindings[0].TestId)
+			assert.Equal(t, Tests.High, fleet.CommonFindings[0].ThreatLevel)
+			assert.Equal(t, []string{"a.example", "b.example"}, fleet.CommonFindings[0].Targets, "Info results are not findings")
+			assert.Equal(t, "csp", fleet.CommonFindings[1].TestId)
+		}
+	}
+	assert.Equal(t, 6, agg.Len())
+	assert.Equal(t, GradeD, agg.Grade())
+}
@@ -8,15 +8,19 @@
 //   - Error classification (retryable vs. fatal errors)
 //   - Concurrent processing of results and retries
 //   - Configurable retry limits and timeouts
+//   - Circuit breaker that fast-fails submissions during sustained backend outages
 //
 // Error codes:
 //   - 100: JSON marshaling error (not retryable)
 //   - 101: HTTP request creation error (not retryable)
 //   - 102: Network error (retryable)
 //   - 103: HTTP status error (retryable for 5xx, not retryable for 4xx)
+//   - 104: Circuit breaker open (not retryable)
+//   - 105: Pre-flight health check failed (retryable)
 package Reporter
 
 import (
+	"Engine-AntiGinx/App/Diff"
 	"Engine-AntiGinx/App/Errors"
 	"Engine-AntiGinx/App/Reporter/types"
 	"Engine-AntiGinx/App/Tests"
@@ -27,7 +31,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"sync"
+	"net/url"
 	"time"
 )
 
@@ -50,16 +54,33 @@ import (
 //   - testId: ID of test from RabbitMQ
 //   - maxRetries: Maximum retry attempts for failed submissions (default: 2)
 //   - httpClient: HTTP client with configured timeout (default: 5 seconds)
+//   - breaker: Circuit breaker guarding the backend against request storms during outages
+//   - progress: Results sent so far, summarised into every submission (nil disables progress)
+//   - history: Classifies every result against the previous report (nil disables statuses)
+//   - signingKey: Key every submission is signed with (nil disables signing)
 type backendReporter struct {
 	resultChannel chan strategy.ResultWrapper
 	backendURL    string
 	testId        string
 	target        string
+	scanId        string // Correlation ID of the scan, taken from the received results
 	maxRetries    int
 	retryDelay    int
 	httpClient    *http.Client
+	breaker       *circuitBreaker
+	progress      *Aggregator
+	history       *Aggregator
+	signingKey    []byte
 }
 
+const (
+	// defaultBreakerThreshold is the number of consecutive failed submissions after which
+	// the circuit opens.
+	defaultBreakerThreshold = 5
+	// defaultBreakerCooldown is how long the circuit stays open before a trial submission.
+	defaultBreakerCooldown = 30 * time.Second
+)
+
 // retryResult is an internal wrapper structure used to track the state of a failed submission
 // in the retry queue. It encapsulates both the original test result and metadata about
 // the retry attempt to enforce retry limits and prevent infinite retry loops.
@@ -80,6 +101,7 @@ type retryResult struct {
 //   - HTTP timeout: 5 seconds
 //   - Max retries: 2 attempts
 //   - Retry delay: 2 seconds (hardcoded in tryToSendOrEnqueue)
+//   - Circuit breaker: opens after 5 consecutive failures, 30 second cooldown
 //
 // The reporter must be started by calling StartListening() to begin processing results.
 //
@@ -100,9 +122,137 @@ type retryResult struct {
 //	failedCount := <-doneChan
 //	fmt.Printf("Processing complete. Failed uploads: %d\n", failedCount)
 func InitializeBackendReporter(channel chan strategy.ResultWrapper, backendURL string, testId string, target string, clientTimeOut int, retryDelay int) *backendReporter {
-	return &backendReporter{channel, backendURL, testId, target, 2, retryDelay, &http.Client{
-		Timeout: time.Duration(clientTimeOut) * time.Second,
-	}}
+	return &backendReporter{
+		resultChannel: channel,
+		backendURL:    backendURL,
+		testId:        testId,
+		target:        target,
+		maxRetries:    2,
+		retryDelay:    retryDelay,
+		httpClient: &http.Client{
+			Timeout: time.Duration(clientTimeOut) * time.Second,
+		},
+		breaker: newCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown),
+	}
+}
+
+// CheckHealth performs a pre-flight request against the backend before the scan starts, so
+// that an unreachable backend is detected immediately instead of after the first result fails.
+//
+// The health endpoint is resolved against the backend URL (e.g. BACK_URL
+// "http://api:8080/results" with healthPath "/health" checks "http://api:8080/health")
+// and must answer a GET request with a 2xx status code.
+//
+// Parameters:
+//   - healthPath: Path of the health endpoint on the backend host
+//
+// Returns:
+//   - *Errors.Error: nil if the backend is healthy, otherwise a retryable error (code 105)
+//     allowing the daemon to requeue the task without running the scan
+func (b *backendReporter) CheckHealth(healthPath string) *Errors.Error {
+	healthErr := func(reason string) *Errors.Error {
+		return &Errors.Error{
+			Code: 105,
+			Message: fmt.Sprintf(`Reporter error occurred. This could be due to:
+				- backend health check failed: %s`, reason),
+			Source:      "Reporter",
+			IsRetryable: true,
+		}
+	}
+
+	base, err := url.Parse(b.backendURL)
+	if err != nil {
+		return healthErr("invalid backend URL")
+	}
+	healthURL := base.ResolveReference(&url.URL{Path: healthPath})
+
+	res, err := b.httpClient.Get(healthURL.String())
+	if err != nil {
+		return healthErr(fmt.Sprintf("backend %s is unreachable", healthURL.Host))
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			log.Printf("BACKEND REPORTER\nwarning: failed to close response body: %s", err.Error())
+		}
+	}()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return healthErr(fmt.Sprintf("status code %d", res.StatusCode))
+	}
+	return nil
+}
+
+// EnableProgress makes the reporter attach a running partial summary (types.Progress) to
+// every submission, so the backend can render live progress while tests are still running.
+// Results are still POSTed one by one as soon as they are produced; the summary covers
+// every test result received up to and including the submitted one.
+//
+// Example:
+//
+//	reporter := InitializeBackendReporter(resultChan, backendURL, taskId, target, 5, 2)
+//	reporter.EnableProgress()
+//	doneChan := reporter.StartListening()
+func (b *backendReporter) EnableProgress() {
+	b.progress = NewAggregator()
+}
+
+// EnableBaseline makes the reporter mark every submitted result with its status against
+// the previous report of the target (types.FindingStatus), so the backend can highlight
+// findings introduced since then.
+//
+// Parameters:
+//   - baseline: Findings of the previous report
+//
+// Example:
+//
+//	baseline, err := Diff.LoadBaseline("previous-report.json")
+//	if err == nil {
+//	    reporter.EnableBaseline(baseline)
+//	}
+func (b *backendReporter) EnableBaseline(baseline *Diff.Baseline) {
+	b.history = NewAggregator()
+	b.history.SetBaseline(baseline)
+}
+
+// EnableSigning makes the reporter sign every submission, including the final one carrying
+// the end flag, with an HMAC of the wrapper in its Signature field (see types.Sign), so the
+// backend can verify the results were not altered in transit.
+//
+// Parameters:
+//   - key: Signing key, typically types.SigningKey()
+//
+// Example:
+//
+//	if key := types.SigningKey(); key != nil {
+//	    reporter.EnableSigning(key)
+//	}
+func (b *backendReporter) EnableSigning(key []byte) {
+	b.signingKey = key
+}
+
+// findingStatus classifies a result of target against the previous report, or returns an
+// empty status when no baseline is configured.
+func (b *backendReporter) findingStatus(target string, result Tests.TestResult) types.FindingStatus {
+	if b.history == nil {
+		return ""
+	}
+	return b.history.Status(target, result)
+}
+
+// progressSnapshot summarises the results received so far, or returns nil when progress
+// streaming is disabled.
+func (b *backendReporter) progressSnapshot() *types.Progress {
+	if b.progress == nil {
+		return nil
+	}
+	counts := make(map[string]int)
+	for level, count := range b.progress.Counts() {
+		counts[level.String()] = count
+	}
+	return &types.Progress{
+		Completed: b.progress.Len(),
+		Grade:     string(b.progress.Grade()),
+		Counts:    counts,
+	}
 }
 
 // StartListening initiates the asynchronous background processing loop that consumes
@@ -118,16 +268,19 @@ func InitializeBackendReporter(channel chan strategy.ResultWrapper, backendURL s
 // Graceful shutdown sequence:
 //  1. Producer closes resultChannel signaling no more results
 //  2. Reporter processes all remaining results in the channel
-//  3. Reporter waits for all sleeping retry goroutines (via retryWg)
-//  4. Reporter processes any new retries added by sleeping goroutines
-//  5. Reporter sends final failure count and exits
+//  3. Reporter keeps receiving from retryChan until every scheduled retry has come back
+//     and been processed (retries may schedule further retries)
+//  4. Reporter sends final failure count and exits
+//
+// The loop never waits for the sleeping retry goroutines without also receiving from
+// retryChan, so a retry goroutine cannot block on a full queue at shutdown.
 //
 // This ensures zero data loss during shutdown - all results are either
 // successfully submitted or counted as failures.
 //
 // Retry mechanism:
 //   - Buffered retry channel (capacity: 10) prevents blocking
-//   - WaitGroup tracks sleeping retry goroutines
+//   - The loop counts the retries still sleeping (pendingRetries)
 //   - 2-second delay between retry attempts
 //   - Retryable errors are re-queued up to maxRetries limit
 //
@@ -158,32 +311,28 @@ func (b *backendReporter) StartListening() <-chan int {
 
 	// Buffered channel prevents the retry logic from blocking the main loop
 	retryChan := make(chan retryResult, 10)
-	var retryWg sync.WaitGroup
 
 	go func() {
 		failedUploads := 0
+		// pendingRetries counts the retries scheduled but not yet received from retryChan.
+		pendingRetries := 0
 		inputOpen := true
 		for {
 			// Shutdown Condition:
-			// If the main input is closed AND there are no pending retries in the queue...
-
-			if !inputOpen && len(retryChan) == 0 {
-				// ...wait for any sleeping retry goroutines to finish...
-				retryWg.Wait()
-
-				// ...and double-check if they added anything new to the queue.
-				if len(retryChan) == 0 {
-					if failedUploads == 0 {
-						b.sendLastWithFlag(
-							types.TestResultWrapper{
-								Target:  b.target,
-								TestId:  b.testId,
-								Result:  Tests.TestResult{},
-								EndFlag: true,
-							}, &failedUploads)
-					}
-					break
+			// The main input is closed AND every scheduled retry has been processed.
+			if !inputOpen && pendingRetries == 0 {
+				if failedUploads == 0 {
+					b.sendLastWithFlag(
+						types.TestResultWrapper{
+							Target:   b.target,
+							TestId:   b.testId,
+							ScanId:   b.scanId,
+							Result:   Tests.TestResult{},
+							EndFlag:  true,
+							Progress: b.progressSnapshot(),
+						}, &failedUploads)
 				}
+				break
 			}
 
 			select {
@@ -195,21 +344,28 @@ func (b *backendReporter) StartListening() <-chan int {
 					// allowing the loop to continue processing retries.
 					b.resultChannel = nil
 				} else if pres, info := res.GetReqInfo(); pres {
+					b.rememberScanId(res)
 					b.sendLastWithFlag(
 						types.TestResultWrapper{
-							Target:      b.target,
+							Target:      b.resultTarget(res),
 							TestId:      b.testId,
+							ScanId:      res.GetScanId(),
 							Result:      Tests.TestResult{},
 							EndFlag:     false,
 							ResultType:  types.Message,
 							ProcessInfo: *info,
 						}, &failedUploads)
 				} else {
-					b.tryToSendOrEnqueue(res, 0, retryChan, &retryWg, &failedUploads)
+					b.rememberScanId(res)
+					if ok, val := res.GetTestResult(); ok && b.progress != nil {
+						b.progress.Add(*val)
+					}
+					b.tryToSendOrEnqueue(res, 0, retryChan, &pendingRetries, &failedUploads)
 				}
 				// Priority 2: Retries
 			case res := <-retryChan:
-				b.tryToSendOrEnqueue(res.result, res.attNum, retryChan, &retryWg, &failedUploads)
+				pendingRetries--
+				b.tryToSendOrEnqueue(res.result, res.attNum, retryChan, &pendingRetries, &failedUploads)
 			}
 		}
 
@@ -244,24 +400,29 @@ func (b *backendReporter) StartListening() <-chan int {
 //   - result: The test result to submit
 //   - attNumber: Current attempt number (0-based)
 //   - retryChan: Channel for re-queuing failed results
-//   - retryWg: WaitGroup for tracking sleeping retry goroutines
+//   - pendingRetries: Counter of scheduled retries, incremented for every retry spawned
 //   - failedUploads: Pointer to counter for permanent failures
-func (b *backendReporter) tryToSendOrEnqueue(result strategy.ResultWrapper, attNumber int, retryChan chan retryResult, retryWg *sync.WaitGroup, failedUploads *int) {
+func (b *backendReporter) tryToSendOrEnqueue(result strategy.ResultWrapper, attNumber int, retryChan chan retryResult, pendingRetries *int, failedUploads *int) {
 	ok, val := result.GetTestResult()
 	if !ok {
 		*failedUploads++
 		return
 	}
+	target := b.resultTarget(result)
 	resultWrapper := types.TestResultWrapper{
-		Target:     b.target,
+		Target:     target,
 		TestId:     b.testId,
+		ScanId:     result.GetScanId(),
 		Result:     *val,
+		FindingID:  types.FindingID(target, *val),
 		EndFlag:    false,
 		ResultType: types.Success,
 		ProcessInfo: strategy.RequestInfo{
 			Message: "Test completed successfully",
 			Code:    0,
 		},
+		Progress: b.progressSnapshot(),
+		Status:   b.findingStatus(target, *val),
 	}
 	err := b.sendToBackend(resultWrapper)
 	if err == nil {
@@ -275,11 +436,10 @@ func (b *backendReporter) tryToSendOrEnqueue(result strategy.ResultWrapper, attN
 		shouldRetry = customErr.IsRetryable
 	}
 	if shouldRetry && attNumber < b.maxRetries {
-		retryWg.Add(1)
+		*pendingRetries++
 
 		// Non-blocking backoff strategy
 		go func() {
-			defer retryWg.Done()
 			time.Sleep(time.Duration(b.retryDelay) * time.Second)
 			retryChan <- retryResult{
 				result: result,
@@ -291,6 +451,22 @@ func (b *backendReporter) tryToSendOrEnqueue(result strategy.ResultWrapper, attN
 	}
 }
 
+// resultTarget returns the target a result belongs to. Results of batch scans are tagged
+// with their own target; untagged results belong to the reporter's target.
+func (b *backendReporter) resultTarget(result strategy.ResultWrapper) string {
+	if target := result.GetTarget(); target != "" {
+		return target
+	}
+	return b.target
+}
+
+// rememberScanId keeps the scan ID of a received result for the final end-of-scan message.
+func (b *backendReporter) rememberScanId(result strategy.ResultWrapper) {
+	if scanId := result.GetScanId(); scanId != "" {
+		b.scanId = scanId
+	}
+}
+
 // sendToBackend performs the actual HTTP POST request to the configured backend endpoint
 // with comprehensive error handling and classification. This method executes the complete
 // HTTP request lifecycle from marshaling to response validation.
@@ -311,6 +487,11 @@ func (b *backendReporter) tryToSendOrEnqueue(result strategy.ResultWrapper, attN
 //   - 400, 401, 403: Not retryable (client errors, auth issues)
 //   - 404, 405, etc.: Not retryable (client errors)
 //   - 500-599: Retryable (server errors, temporary outages)
+//   - Code 104 (Circuit Open): Not retryable - the backend failed repeatedly and the
+//     circuit breaker rejected the submission without performing a request
+//
+// Only retryable failures (network and 5xx errors) count towards opening the circuit;
+// any response the backend actually processed closes it again.
 //
 // The method returns structured Errors.Error objects that include the IsRetryable flag,
 // allowing the retry logic to make intelligent decisions about whether to re-attempt
@@ -339,8 +520,19 @@ func (b *backendReporter) sendToBackend(result types.TestResultWrapper) error {
 		return err
 	}
 
+	if !b.breaker.Allow() {
+		return &Errors.Error{
+			Code: 104,
+			Message: `Reporter error occurred. This could be due to:
+				- backend circuit breaker is open after repeated failures`,
+			Source:      "Reporter",
+			IsRetryable: false,
+		}
+	}
+
 	res, err2 := b.httpClient.Do(req)
 	if err2 != nil {
+		b.breaker.RecordFailure()
 		return &Errors.Error{
 			Code: 102,
 			Message: `Reporter error occurred. This could be due to:
@@ -358,8 +550,14 @@ func (b *backendReporter) sendToBackend(result types.TestResultWrapper) error {
 	err3 := b.handleRetryLogic(res)
 
 	if err3 != nil {
+		if err3.IsRetryable {
+			b.breaker.RecordFailure()
+		} else {
+			b.breaker.RecordSuccess()
+		}
 		return err3
 	}
+	b.breaker.RecordSuccess()
 	return nil
 }
 func (b *backendReporter) handleRetryLogic(response *http.Response) *Errors.Error {
@@ -376,7 +574,14 @@ func (b *backendReporter) handleRetryLogic(response *http.Response) *Errors.Erro
 	}
 }
 func (b *backendReporter) prepareReqWithErrHandling(result types.TestResultWrapper) (*http.Request, *Errors.Error) {
-	marshalled, err := json.Marshal(result)
+	var err error
+	if b.signingKey != nil {
+		err = result.Sign(b.signingKey)
+	}
+	var marshalled []byte
+	if err == nil {
+		marshalled, err = json.Marshal(result)
+	}
 	if err != nil {
 		return nil, &Errors.Error{
 			Code: 100,
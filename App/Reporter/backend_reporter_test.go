@@ -1,11 +1,19 @@
 package Reporter
 
 import (
+	"Engine-AntiGinx/App/Diff"
+	"Engine-AntiGinx/App/Reporter/types"
 	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution/strategy"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type backendReporterTest struct {
@@ -106,3 +114,144 @@ func TestBackendReporter_StartListening(t *testing.T) {
 		})
 	}
 }
+
+func TestBackendReporter_CircuitBreakerFastFailsDuringCooldown(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls.Add(1)
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resChan := make(chan strategy.ResultWrapper)
+	reporter := InitializeBackendReporter(resChan, server.URL, "test-id", "target", 0, 0)
+	reporter.breaker = newCircuitBreaker(3, time.Hour)
+	done := reporter.StartListening()
+
+	const results = 5
+	for i := 0; i < results; i++ {
+		resChan <- strategy.WrapStrategyResult(&Tests.TestResult{Name: "Test scan"}, nil, nil)
+	}
+	close(resChan)
+	failedUploads := <-done
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("Expected backend to be called 3 times before the circuit opened, got %d", got)
+	}
+	if failedUploads != results {
+		t.Errorf("Expected all %d results to be counted as failures, got %d", results, failedUploads)
+	}
+}
+
+func TestBackendReporter_NoGoroutineLeakAfterRetries(t *testing.T) {
+	// More failed results than the retry queue holds, so the retries sleeping at shutdown
+	// cannot all be queued at once.
+	const results = 15
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if calls.Add(1) <= results {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	goroutines := runtime.NumGoroutine()
+
+	resChan := make(chan strategy.ResultWrapper)
+	reporter := InitializeBackendReporter(resChan, server.URL, "test-id", "target", 5, 1)
+	reporter.breaker = newCircuitBreaker(results+1, time.Hour)
+	done := reporter.StartListening()
+	for i := 0; i < results; i++ {
+		resChan <- strategy.WrapStrategyResult(&Tests.TestResult{Name: "Test scan"}, nil, nil)
+	}
+	close(resChan)
+
+	select {
+	case failedUploads := <-done:
+		if failedUploads != 0 {
+			t.Errorf("Expected every retried result to be uploaded, got %d failures", failedUploads)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Reporter did not finish after the retries")
+	}
+
+	reporter.httpClient.CloseIdleConnections()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if leaked := runtime.NumGoroutine() - goroutines; leaked > 0 {
+		t.Errorf("Expected no goroutines left after done, %d still running", leaked)
+	}
+}
+
+func TestBackendReporter_BaselineStatus(t *testing.T) {
+	var mu sync.Mutex
+	statuses := map[string]types.FindingStatus{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var submission types.TestResultWrapper
+		if err := json.NewDecoder(request.Body).Decode(&submission); err == nil && !submission.EndFlag {
+			mu.Lock()
+			statuses[submission.Result.TestId] = submission.Status
+			mu.Unlock()
+		}
+	}))
+	defer server.Close()
+
+	resChan := make(chan strategy.ResultWrapper)
+	reporter := InitializeBackendReporter(resChan, server.URL, "test-id", "target", 1, 0)
+	reporter.EnableBaseline(Diff.NewBaseline([]types.TestResultWrapper{
+		{Target: "target", ResultType: types.Success, Result: Tests.TestResult{TestId: "hsts", ThreatLevel: Tests.Medium}},
+	}))
+	done := reporter.StartListening()
+	resChan <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "csp", ThreatLevel: Tests.High}, nil, nil)
+	resChan <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "hsts", ThreatLevel: Tests.Medium}, nil, nil)
+	close(resChan)
+	if failures := <-done; failures != 0 {
+		t.Fatalf("Expected no failures, got %d", failures)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if statuses["csp"] != types.StatusNew {
+		t.Errorf("Expected the finding absent from the baseline to be %q, got %q", types.StatusNew, statuses["csp"])
+	}
+	if statuses["hsts"] != types.StatusPersistent {
+		t.Errorf("Expected the baseline finding to be %q, got %q", types.StatusPersistent, statuses["hsts"])
+	}
+}
+
+func TestBackendReporter_SignsSubmissions(t *testing.T) {
+	key := []byte("compliance-key")
+	var mu sync.Mutex
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	resChan := make(chan strategy.ResultWrapper)
+	reporter := InitializeBackendReporter(resChan, server.URL, "test-id", "target", 1, 0)
+	reporter.EnableSigning(key)
+	done := reporter.StartListening()
+	resChan <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "csp", ThreatLevel: Tests.High}, nil, nil)
+	close(resChan)
+	if failures := <-done; failures != 0 {
+		t.Fatalf("Expected no failures, got %d", failures)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("Expected the result and the end flag submissions, got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if valid, err := types.Verify(key, body); err != nil || !valid {
+			t.Errorf("Expected submission %d to carry a valid signature, got %v (%v): %s", i, valid, err, body)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package Reporter
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitState describes the current state of a circuitBreaker.
+type circuitState int
+
+const (
+	circuitClosed   circuitState = iota // Requests flow normally
+	circuitOpen                         // Requests fast-fail until the cooldown elapses
+	circuitHalfOpen                     // A single trial request probes for recovery
+)
+
+// circuitBreaker protects the backend reporter from flooding an unavailable backend
+// with doomed requests during an outage.
+//
+// State machine:
+//   - Closed: every request is allowed; consecutive failures are counted
+//   - Open: entered after failureThreshold consecutive failures; every request is
+//     rejected without touching the network until cooldown has elapsed
+//   - Half-open: after the cooldown a single trial request is allowed; success closes
+//     the circuit, failure re-opens it for another cooldown window
+//
+// The breaker is safe for concurrent use.
+type circuitBreaker struct {
+	mu                  sync.Mutex
+	state               circuitState
+	failureThreshold    int
+	cooldown            time.Duration
+	consecutiveFailures int
+	openedAt            time.Time
+	now                 func() time.Time
+}
+
+// newCircuitBreaker creates a closed circuit breaker that opens after failureThreshold
+// consecutive failures and stays open for the given cooldown.
+//
+// Parameters:
+//   - failureThreshold: Consecutive failures required to open the circuit
+//   - cooldown: Time the circuit stays open before a trial request is allowed
+//
+// Returns:
+//   - *circuitBreaker: Breaker in the closed state
+func newCircuitBreaker(failureThreshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		state:            circuitClosed,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+	}
+}
+
+// Allow reports whether a request may be sent. An open circuit whose cooldown has
+// elapsed transitions to half-open and admits exactly one trial request.
+//
+// Returns:
+//   - bool: true if the request may proceed, false if it should fast-fail
+func (cb *circuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.cooldown {
+			return false
+		}
+		cb.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// A trial request is already in flight
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the circuit and resets the consecutive failure counter.
+func (cb *circuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.state = circuitClosed
+	cb.consecutiveFailures = 0
+}
+
+// RecordFailure counts a failed request. The circuit opens once the threshold is
+// reached, or immediately when the half-open trial request fails.
+func (cb *circuitBreaker) RecordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.consecutiveFailures++
+	if cb.state == circuitHalfOpen || cb.consecutiveFailures >= cb.failureThreshold {
+		cb.state = circuitOpen
+		cb.openedAt = cb.now()
+	}
+}
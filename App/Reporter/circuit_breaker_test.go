@@ -0,0 +1,48 @@
+package Reporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_StateTransitions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb := newCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+
+	cb.RecordFailure()
+	if !cb.Allow() {
+		t.Fatal("Expected circuit to stay closed below the failure threshold")
+	}
+	cb.RecordFailure()
+	if cb.Allow() {
+		t.Fatal("Expected circuit to open after reaching the failure threshold")
+	}
+
+	now = now.Add(30 * time.Second)
+	if cb.Allow() {
+		t.Fatal("Expected circuit to stay open during cooldown")
+	}
+
+	now = now.Add(31 * time.Second)
+	if !cb.Allow() {
+		t.Fatal("Expected a trial request once the cooldown elapsed")
+	}
+	if cb.Allow() {
+		t.Fatal("Expected only a single trial request while half-open")
+	}
+
+	cb.RecordFailure()
+	if cb.Allow() {
+		t.Fatal("Expected failed trial request to re-open the circuit")
+	}
+
+	now = now.Add(time.Minute)
+	if !cb.Allow() {
+		t.Fatal("Expected a second trial request after another cooldown")
+	}
+	cb.RecordSuccess()
+	if !cb.Allow() || !cb.Allow() {
+		t.Error("Expected successful trial request to close the circuit")
+	}
+}
@@ -0,0 +1,208 @@
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// contractTimeout bounds every step of the Reporter contract a conforming reporter must
+// complete promptly.
+const contractTimeout = 5 * time.Second
+
+// reporterContract describes a Reporter implementation checked by testReporterContract.
+//
+// Fields:
+//   - name: Subtest name
+//   - setUp: Starts the dependencies of the reporter (e.g., a stub backend) and returns the
+//     constructor of the reporter and a function releasing the dependencies
+//   - result: Builds a result the reporter accepts
+//   - wantFailures: Failure count expected after the given number of results (nil for none)
+type reporterContract struct {
+	name         string
+	setUp        func(t *testing.T) (newReporter func(chan strategy.ResultWrapper) Reporter, tearDown func())
+	result       func() strategy.ResultWrapper
+	wantFailures func(results int) int
+}
+
+// testReporterContract checks the behaviour the Runner relies on for any Reporter:
+//   - StartListening does not block
+//   - Every result sent is consumed, so the Runner never blocks on the result channel
+//   - Closing the channel yields exactly one failure count, the expected one
+//   - No goroutine is left running once the failure count has been received
+func testReporterContract(t *testing.T, contract reporterContract) {
+	t.Helper()
+	for _, results := range []int{0, 3} {
+		newReporter, tearDown := contract.setUp(t)
+		goroutines := runtime.NumGoroutine()
+
+		ch := make(chan strategy.ResultWrapper)
+		reporter := newReporter(ch)
+
+		started := make(chan (<-chan int), 1)
+		go func() { started <- reporter.StartListening() }()
+		var done <-chan int
+		select {
+		case done = <-started:
+		case <-time.After(contractTimeout):
+			t.Fatalf("StartListening blocked")
+		}
+
+		for i := 0; i < results; i++ {
+			select {
+			case ch <- contract.result():
+			case <-time.After(contractTimeout):
+				t.Fatalf("Result %d of %d was not consumed", i+1, results)
+			}
+		}
+		close(ch)
+
+		select {
+		case failures := <-done:
+			want := 0
+			if contract.wantFailures != nil {
+				want = contract.wantFailures(results)
+			}
+			if failures != want {
+				t.Errorf("With %d result(s): expected %d failure(s), got %d", results, want, failures)
+			}
+		case <-time.After(contractTimeout):
+			t.Fatalf("With %d result(s): no failure count after the channel was closed", results)
+		}
+		select {
+		case failures, ok := <-done:
+			if ok {
+				t.Errorf("With %d result(s): second failure count %d sent", results, failures)
+			}
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		tearDown()
+		deadline := time.Now().Add(contractTimeout)
+		for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if leaked := runtime.NumGoroutine() - goroutines; leaked > 0 {
+			t.Errorf("With %d result(s): %d goroutine(s) still running after the reporter finished", results, leaked)
+		}
+	}
+}
+
+// stubBackend starts a backend answering every submission with status and returns its URL.
+func stubBackend(status int) (string, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = io.Copy(io.Discard, request.Body)
+		writer.WriteHeader(status)
+	}))
+	return server.URL, server.Close
+}
+
+func testResultWrapper() strategy.ResultWrapper {
+	return strategy.WrapStrategyResult(&Tests.TestResult{Name: "Contract scan", ThreatLevel: Tests.Low}, nil, nil)
+}
+
+func noTearDown() {}
+
+// rejectedUploads is the failure count of a backend reporter whose backend rejects every
+// upload: each result fails, and without results the empty end-of-scan submission does.
+func rejectedUploads(results int) int {
+	if results == 0 {
+		return 1
+	}
+	return results
+}
+
+func TestReporter_Contract(t *testing.T) {
+	contracts := []reporterContract{
+		{
+			name: "CLI reporter",
+			setUp: func(t *testing.T) (func(chan strategy.ResultWrapper) Reporter, func()) {
+				return func(ch chan strategy.ResultWrapper) Reporter {
+					cli := InitializeCliReporter(ch)
+					cli.SetOutput(io.Discard)
+					return cli
+				}, noTearDown
+			},
+			result: testResultWrapper,
+		},
+		{
+			name: "Help reporter",
+			setUp: func(t *testing.T) (func(chan strategy.ResultWrapper) Reporter, func()) {
+				return func(ch chan strategy.ResultWrapper) Reporter { return NewHelpReporter(ch) }, noTearDown
+			},
+			result: func() strategy.ResultWrapper {
+				return strategy.WrapStrategyResult(nil, &strategy.HelpStrategyResult{}, nil)
+			},
+		},
+		{
+			name: "Backend reporter",
+			setUp: func(t *testing.T) (func(chan strategy.ResultWrapper) Reporter, func()) {
+				url, closeBackend := stubBackend(http.StatusOK)
+				return func(ch chan strategy.ResultWrapper) Reporter {
+					return InitializeBackendReporter(ch, url, "test-id", "target", 1, 0)
+				}, closeBackend
+			},
+			result: testResultWrapper,
+		},
+		{
+			name: "Backend reporter with rejected uploads",
+			setUp: func(t *testing.T) (func(chan strategy.ResultWrapper) Reporter, func()) {
+				url, closeBackend := stubBackend(http.StatusBadRequest)
+				return func(ch chan strategy.ResultWrapper) Reporter {
+					return InitializeBackendReporter(ch, url, "test-id", "target", 1, 0)
+				}, closeBackend
+			},
+			result:       testResultWrapper,
+			wantFailures: rejectedUploads,
+		},
+		{
+			name: "JUnit reporter",
+			setUp: func(t *testing.T) (func(chan strategy.ResultWrapper) Reporter, func()) {
+				return func(ch chan strategy.ResultWrapper) Reporter {
+					return InitializeJUnitReporter(ch, io.Discard, "target", nil)
+				}, noTearDown
+			},
+			result: testResultWrapper,
+		},
+		{
+			name: "HTML reporter",
+			setUp: func(t *testing.T) (func(chan strategy.ResultWrapper) Reporter, func()) {
+				return func(ch chan strategy.ResultWrapper) Reporter {
+					return InitializeHTMLReporter(ch, io.Discard, "target", types.ReportMetadata{})
+				}, noTearDown
+			},
+			result: testResultWrapper,
+		},
+		{
+			name: "Tee reporter",
+			setUp: func(t *testing.T) (func(chan strategy.ResultWrapper) Reporter, func()) {
+				url, closeBackend := stubBackend(http.StatusBadRequest)
+				return func(ch chan strategy.ResultWrapper) Reporter {
+					return NewTeeReporter(ch,
+						func(output chan strategy.ResultWrapper) Reporter {
+							return InitializeBackendReporter(output, url, "test-id", "target", 1, 0)
+						},
+						func(output chan strategy.ResultWrapper) Reporter {
+							cli := InitializeCliReporter(output)
+							cli.SetOutput(io.Discard)
+							return cli
+						},
+					)
+				}, closeBackend
+			},
+			result:       testResultWrapper,
+			wantFailures: rejectedUploads,
+		},
+	}
+	for _, contract := range contracts {
+		t.Run(contract.name, func(t *testing.T) {
+			testReporterContract(t, contract)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Package Reporter provides multiple reporting implementations for test results.
+// This file contains the HTML reporter which writes the results of a scan as a standalone
+// HTML document with a report header (title, logo, scan time, target, scanner version and
+// operator), meant to be shared as is or printed to PDF (--format html).
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// htmlDefaultTitle is the report title when no --report-title is given.
+const htmlDefaultTitle = "AntiGinx Security Report"
+
+// htmlReportTemplate renders the report. html/template escapes every value, so server
+// supplied descriptions cannot inject markup into the report.
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+header { display: flex; align-items: center; gap: 1em; border-bottom: 2px solid #444; margin-bottom: 1.5em; }
+header img { max-height: 64px; }
+dl.report-meta { display: grid; grid-template-columns: max-content auto; gap: 0.2em 1em; }
+dl.report-meta dt { font-weight: bold; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.4em; text-align: left; vertical-align: top; }
+.threat-Critical, .threat-High { color: #b00020; font-weight: bold; }
+.threat-Medium { color: #c75000; }
+.suppressed { color: #888; }
+</style>
+</head>
+<body>
+<header>
+{{if .LogoURL}}<img src="{{.LogoURL}}" alt="Logo">{{end}}
+<h1>{{.Title}}</h1>
+</header>
+<dl class="report-meta">
+{{if .Target}}<dt>Target</dt><dd class="report-target">{{.Target}}</dd>{{end}}
+{{if .ScanTime}}<dt>Scan time</dt><dd class="report-scan-time">{{.ScanTime}}</dd>{{end}}
+{{if .ScannerVersion}}<dt>Scanner version</dt><dd>{{.ScannerVersion}}</dd>{{end}}
+{{if .Operator}}<dt>Operator</dt><dd>{{.Operator}}</dd>{{end}}
+</dl>
+{{range .Sections}}
+<section>
+<h2>{{.Target}}</h2>
+{{if .Error}}<p class="load-error">Engine was unable to test this website: {{.Error}}</p>{{end}}
+{{if .Findings}}
+<table>
+<tr><th>Test</th><th>Threat level</th><th>Certainty</th><th>Description</th><th>Remediation</th></tr>
+{{range .Findings}}<tr{{if .Suppressed}} class="suppressed"{{end}}>
+<td>{{.Name}}</td>
+<td class="threat-{{.ThreatLevel}}">{{.ThreatLevel}}{{if .Suppressed}} (suppressed){{end}}</td>
+<td>{{.Certainty}}%</td>
+<td>{{.Description}}</td>
+<td>{{.Remediation}}{{range .References}}<br><a href="{{.}}">{{.}}</a>{{end}}</td>
+</tr>
+{{end}}</table>
+{{end}}
+</section>
+{{end}}
+</body>
+</html>
+`))
+
+// htmlReport is the value htmlReportTemplate is executed with.
+type htmlReport struct {
+	Title          string
+	LogoURL        template.URL
+	Target         string
+	ScanTime       string
+	ScannerVersion string
+	Operator       string
+	Sections       []*htmlSection
+}
+
+// htmlSection holds the results of one target.
+type htmlSection struct {
+	Target   string
+	Error    string
+	Findings []Tests.TestResult
+}
+
+// htmlReporter collects the results of a scan and writes them as one HTML document once
+// the result channel is closed.
+//
+// Fields:
+//   - resultChannel: Receive-only channel for consuming test results
+//   - out: Destination of the HTML document
+//   - target: Section name of results not tagged with a target (single target scans)
+//   - metadata: Report header, set by the Runner through SetReportMetadata
+//   - closers: Resources (e.g., the --output file) closed once the document is written
+type htmlReporter struct {
+	resultChannel <-chan strategy.ResultWrapper
+	out           io.Writer
+	target        string
+	metadata      types.ReportMetadata
+	closers       []io.Closer
+}
+
+// InitializeHTMLReporter creates a reporter writing the results received on channel as an
+// HTML document to out.
+//
+// Parameters:
+//   - channel: The input channel where test results are published by the Runner
+//   - out: Destination of the HTML document (stdout or the --output file)
+//   - target: Target of the scan, naming the section of untagged results
+//   - metadata: Report header fields known when the reporter is created (e.g., title)
+//
+// Returns:
+//   - *htmlReporter: Configured reporter instance ready to start listening
+//
+// Example:
+//
+//	reporter := InitializeHTMLReporter(resultChan, file, "https://example.com", types.ReportMetadata{Title: "Q3 audit"})
+//	doneChan := reporter.StartListening()
+//	// ... send results and close resultChan
+//	<-doneChan // The HTML document has been written
+func InitializeHTMLReporter(channel chan strategy.ResultWrapper, out io.Writer, target string,
+	metadata types.ReportMetadata) *htmlReporter {
+	return &htmlReporter{
+		resultChannel: channel,
+		out:           out,
+		target:        target,
+		metadata:      metadata,
+	}
+}
+
+// SetReportMetadata completes the report header with the fields known to the Runner (scan
+// time, target, scanner version). Empty fields keep the values given at creation.
+func (h *htmlReporter) SetReportMetadata(metadata types.ReportMetadata) {
+	if metadata.Title != "" {
+		h.metadata.Title = metadata.Title
+	}
+	if metadata.LogoURL != "" {
+		h.metadata.LogoURL = metadata.LogoURL
+	}
+	if metadata.Operator != "" {
+		h.metadata.Operator = metadata.Operator
+	}
+	if metadata.Target != "" {
+		h.metadata.Target = metadata.Target
+	}
+	if !metadata.ScanTime.IsZero() {
+		h.metadata.ScanTime = metadata.ScanTime
+	}
+	if metadata.ScannerVersion != "" {
+		h.metadata.ScannerVersion = metadata.ScannerVersion
+	}
+}
+
+// closeWhenDone registers a resource closed after the document has been written.
+func (h *htmlReporter) closeWhenDone(closer io.Closer) {
+	h.closers = append(h.closers, closer)
+}
+
+// StartListening consumes the results until the channel is closed, then writes the HTML
+// document. The returned channel receives 1 when the document could not be written,
+// 0 otherwise.
+//
+// Returns:
+//   - <-chan int: Completion signal channel with the failure count
+func (h *htmlReporter) StartListening() <-chan int {
+	done := make(chan int)
+	go func() {
+		var sections []*htmlSection
+		byTarget := make(map[string]*htmlSection)
+		for result := range h.resultChannel {
+			ok, val := result.GetTestResult()
+			okInfo, info := result.GetReqInfo()
+			if !ok && !okInfo {
+				panic(Errors.Error{
+					Code: 100,
+					Message: `HTML Reporter error occurred. This could be due to:
+								- fatal error`,
+					Source:      "HTML Reporter",
+					IsRetryable: false,
+				})
+			}
+			name := result.GetTarget()
+			if name == "" {
+				name = h.target
+			}
+			section, exists := byTarget[name]
+			if !exists {
+				section = &htmlSection{Target: name}
+				byTarget[name] = section
+				sections = append(sections, section)
+			}
+			if okInfo {
+				section.Error = info.Message
+				continue
+			}
+			section.Findings = append(section.Findings, *val)
+		}
+
+		failures := 0
+		if err := h.write(sections); err != nil {
+			fmt.Printf("HTML Reporter \nWarning: Failed to write the HTML report: %s", err.Error())
+			failures = 1
+		}
+		for _, closer := range h.closers {
+			if err := closer.Close(); err != nil {
+				fmt.Printf("HTML Reporter \nWarning: Failed to close the HTML report: %s", err.Error())
+				failures = 1
+			}
+		}
+		done <- failures
+	}()
+	return done
+}
+
+// write renders the report header and the sections to the reporter output.
+func (h *htmlReporter) write(sections []*htmlSection) error {
+	report := htmlReport{
+		Title:          h.metadata.Title,
+		LogoURL:        htmlLogoURL(h.metadata.LogoURL),
+		Target:         h.metadata.Target,
+		ScannerVersion: h.metadata.ScannerVersion,
+		Operator:       h.metadata.Operator,
+		Sections:       sections,
+	}
+	if report.Title == "" {
+		report.Title = htmlDefaultTitle
+	}
+	if report.Target == "" {
+		report.Target = h.target
+	}
+	if !h.metadata.ScanTime.IsZero() {
+		report.ScanTime = h.metadata.ScanTime.UTC().Format(time.RFC3339)
+	}
+	return htmlReportTemplate.Execute(h.out, report)
+}
+
+// htmlLogoURL allows http(s) logos and inline data:image URIs, which html/template would
+// otherwise reject. Any other URL is dropped.
+func htmlLogoURL(logo string) template.URL {
+	lower := strings.ToLower(strings.TrimSpace(logo))
+	if strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") ||
+		strings.HasPrefix(lower, "data:image/") {
+		return template.URL(strings.TrimSpace(logo))
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runHTMLReporter sends results through an HTML reporter with the given header and returns
+// the written document.
+func runHTMLReporter(t *testing.T, metadata types.ReportMetadata, results ...strategy.ResultWrapper) string {
+	t.Helper()
+	var out bytes.Buffer
+	ch := make(chan strategy.ResultWrapper)
+	reporter := InitializeHTMLReporter(ch, &out, "https://example.com", types.ReportMetadata{Title: "Q3 audit"})
+	reporter.SetReportMetadata(metadata)
+	done := reporter.StartListening()
+	for _, result := range results {
+		ch <- result
+	}
+	close(ch)
+	if failures := <-done; failures != 0 {
+		t.Fatalf("Expected the report to be written, got %d failure(s)", failures)
+	}
+	return out.String()
+}
+
+// htmlHeader returns the part of the document before the first target section.
+func htmlHeader(document string) string {
+	header, _, _ := strings.Cut(document, "<section>")
+	return header
+}
+
+func TestHTMLReporter_Header(t *testing.T) {
+	scanTime := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	document := runHTMLReporter(t, types.ReportMetadata{
+		Target:         "https://example.com",
+		ScanTime:       scanTime,
+		ScannerVersion: "1.4.0",
+		Operator:       "Blue team",
+		LogoURL:        "https://cdn.example.com/logo.png",
+	}, junitResult("https", Tests.High, "Connection uses insecure HTTP protocol"))
+
+	header := htmlHeader(document)
+	for _, want := range []string{
+		"<h1>Q3 audit</h1>",
+		`<dd class="report-target">https://example.com</dd>`,
+		`<dd class="report-scan-time">2026-10-16T09:30:00Z</dd>`,
+		"1.4.0",
+		"Blue team",
+		`<img src="https://cdn.example.com/logo.png"`,
+	} {
+		if !strings.Contains(header, want) {
+			t.Errorf("Expected the report header to contain %q, got:\n%s", want, header)
+		}
+	}
+	if !strings.Contains(document, "Connection uses insecure HTTP protocol") {
+		t.Errorf("Expected the finding in the report, got:\n%s", document)
+	}
+}
+
+func TestHTMLReporter_OptionalHeaderFields(t *testing.T) {
+	var out bytes.Buffer
+	ch := make(chan strategy.ResultWrapper)
+	done := InitializeHTMLReporter(ch, &out, "https://example.com", types.ReportMetadata{}).StartListening()
+	close(ch)
+	<-done
+
+	header := htmlHeader(out.String())
+	if !strings.Contains(header, "<h1>"+htmlDefaultTitle+"</h1>") {
+		t.Errorf("Expected the default title, got:\n%s", header)
+	}
+	if !strings.Contains(header, `<dd class="report-target">https://example.com</dd>`) {
+		t.Errorf("Expected the reporter target as fallback, got:\n%s", header)
+	}
+	for _, unwanted := range []string{"<img", "Scan time", "Operator", "Scanner version"} {
+		if strings.Contains(header, unwanted) {
+			t.Errorf("Expected no %q without metadata, got:\n%s", unwanted, header)
+		}
+	}
+}
+
+func TestHTMLReporter_EscapesContent(t *testing.T) {
+	document := runHTMLReporter(t, types.ReportMetadata{LogoURL: "javascript:alert(1)"},
+		junitResult("xss", Tests.Medium, `<script>alert("x")</script>`))
+
+	if strings.Contains(document, "<script>") {
+		t.Errorf("Expected the description to be escaped, got:\n%s", document)
+	}
+	if strings.Contains(document, "javascript:") || strings.Contains(document, "<img") {
+		t.Errorf("Expected the javascript: logo to be dropped, got:\n%s", document)
+	}
+}
+
+func TestResolver_Resolve_HTMLOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html")
+	resolver := NewResolver(WithFormat(types.FormatHTML), WithOutput(path),
+		WithReportMetadata(types.ReportMetadata{Title: "Nightly scan"}))
+	ch := make(chan strategy.ResultWrapper)
+
+	reporter := resolver.Resolve(ch, "", "https://example.com", 5, 2, []strategy.TestStrategy{MockCliPrefStrategy{}})
+	if _, ok := reporter.(MetadataReceiver); !ok {
+		t.Fatalf("Expected an HTML reporter accepting report metadata, got %T", reporter)
+	}
+	done := reporter.StartListening()
+	ch <- junitResult("https", Tests.Critical, "Plain HTTP")
+	close(ch)
+	<-done
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the report file to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "<h1>Nightly scan</h1>") || !strings.Contains(string(data), "Plain HTTP") {
+		t.Errorf("Expected the titled report with the finding, got:\n%s", data)
+	}
+}
@@ -0,0 +1,276 @@
+// Package Reporter provides multiple reporting implementations for test results.
+// This file contains the JUnit reporter which writes the results of a scan as JUnit XML,
+// rendered natively by most CI systems (--format junit).
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// junitDefaultThreshold is the threat level from which findings are JUnit failures when
+// no --severity-threshold is given.
+const junitDefaultThreshold = Tests.High
+
+// junitTestSuites is the root element of a JUnit report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr,omitempty"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite groups the testcases of one target.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is one test of the scan.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out,omitempty"`
+}
+
+// junitMessage is the failure, error or skipped element of a testcase.
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",cdata"`
+}
+
+// junitOutput is the system-out element of a testcase, holding the full finding.
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+// junitReporter collects the results of a scan and writes them as one JUnit XML document
+// once the result channel is closed.
+//
+// Mapping:
+//   - Every target is a <testsuite>, every test result a <testcase> named after the test
+//     with classname "antiginx.<test id>"
+//   - Unsuppressed findings at or above the threshold are <failure> elements with the
+//     description as message and the threat level as type
+//   - Suppressed findings and tests skipped as not applicable are <skipped>
+//   - Targets that could not be tested are a "load" testcase with an <error>
+//   - Test durations are the time attribute of the testcases, the scan duration that of
+//     the root element (when the Runner passed the scan start, see SetReportMetadata)
+//
+// Fields:
+//   - resultChannel: Receive-only channel for consuming test results
+//   - out: Destination of the XML document
+//   - target: Suite name of results not tagged with a target (single target scans)
+//   - threshold: Threat level from which findings are failures
+//   - closers: Resources (e.g., the --output file) closed once the document is written
+//   - scanStarted: Start of the scan, zero when unknown
+type junitReporter struct {
+	resultChannel <-chan strategy.ResultWrapper
+	out           io.Writer
+	target        string
+	threshold     Tests.ThreatLevel
+	closers       []io.Closer
+	scanStarted   time.Time
+}
+
+// InitializeJUnitReporter creates a reporter writing the results received on channel as
+// JUnit XML to out.
+//
+// Parameters:
+//   - channel: The input channel where test results are published by the Runner
+//   - out: Destination of the XML document (stdout or the --output file)
+//   - target: Target of the scan, naming the suite of untagged results
+//   - threshold: Minimum threat level of a failure (nil uses junitDefaultThreshold)
+//
+// Returns:
+//   - *junitReporter: Configured reporter instance ready to start listening
+//
+// Example:
+//
+//	reporter := InitializeJUnitReporter(resultChan, os.Stdout, "https://example.com", nil)
+//	doneChan := reporter.StartListening()
+//	// ... send results and close resultChan
+//	<-doneChan // The XML document has been written
+func InitializeJUnitReporter(channel chan strategy.ResultWrapper, out io.Writer, target string,
+	threshold *Tests.ThreatLevel) *junitReporter {
+	level := junitDefaultThreshold
+	if threshold != nil {
+		level = *threshold
+	}
+	return &junitReporter{
+		resultChannel: channel,
+		out:           out,
+		target:        target,
+		threshold:     level,
+	}
+}
+
+// SetReportMetadata records the start of the scan, so the report holds the scan duration.
+func (j *junitReporter) SetReportMetadata(metadata types.ReportMetadata) {
+	j.scanStarted = metadata.ScanTime
+}
+
+// closeWhenDone registers a resource closed after the document has been written.
+func (j *junitReporter) closeWhenDone(closer io.Closer) {
+	j.closers = append(j.closers, closer)
+}
+
+// StartListening consumes the results until the channel is closed, then writes the
+// JUnit document. The returned channel receives 1 when the document could not be
+// written, 0 otherwise.
+//
+// Returns:
+//   - <-chan int: Completion signal channel with the failure count
+func (j *junitReporter) StartListening() <-chan int {
+	done := make(chan int)
+	go func() {
+		var suites []*junitTestSuite
+		byTarget := make(map[string]*junitTestSuite)
+		for result := range j.resultChannel {
+			ok, val := result.GetTestResult()
+			okInfo, info := result.GetReqInfo()
+			if !ok && !okInfo {
+				panic(Errors.Error{
+					Code: 100,
+					Message: `JUnit Reporter error occurred. This could be due to:
+								- fatal error`,
+					Source:      "JUnit Reporter",
+					IsRetryable: false,
+				})
+			}
+			name := result.GetTarget()
+			if name == "" {
+				name = j.target
+			}
+			suite, exists := byTarget[name]
+			if !exists {
+				suite = &junitTestSuite{Name: name, TestCases: []junitTestCase{}}
+				byTarget[name] = suite
+				suites = append(suites, suite)
+			}
+			if okInfo {
+				suite.add(junitTestCase{
+					Name:      "Load target",
+					ClassName: "antiginx.load",
+					Error:     &junitMessage{Message: "Engine was unable to test this website", Text: info.Message},
+				})
+				continue
+			}
+			suite.add(j.testCase(*val))
+		}
+
+		failures := 0
+		if err := j.write(suites); err != nil {
+			fmt.Printf("JUnit Reporter \nWarning: Failed to write the JUnit report: %s", err.Error())
+			failures = 1
+		}
+		for _, closer := range j.closers {
+			if err := closer.Close(); err != nil {
+				fmt.Printf("JUnit Reporter \nWarning: Failed to close the JUnit report: %s", err.Error())
+				failures = 1
+			}
+		}
+		done <- failures
+	}()
+	return done
+}
+
+// testCase maps a test result to a testcase, failing it when the finding reaches the
+// threshold and skipping it when suppressed.
+func (j *junitReporter) testCase(result Tests.TestResult) junitTestCase {
+	testCase := junitTestCase{
+		Name:      result.Name,
+		ClassName: "antiginx." + result.TestId,
+		Time:      junitSeconds(result.Duration),
+		SystemOut: &junitOutput{Text: fmt.Sprintf("Threat level: %v\nCertainty: %d\nDescription: %s", result.ThreatLevel, result.Certainty, result.Description)},
+	}
+	switch {
+	case result.Suppressed:
+		testCase.Skipped = &junitMessage{Message: "Suppressed finding"}
+	case result.Skipped:
+		testCase.Skipped = &junitMessage{Message: "Not applicable", Text: result.Description}
+	case result.ThreatLevel.AtLeast(j.threshold):
+		text := []string{result.Description}
+		if result.Remediation != "" {
+			text = append(text, "Remediation: "+result.Remediation)
+		}
+		for _, reference := range result.References {
+			text = append(text, "Reference: "+reference)
+		}
+		testCase.Failure = &junitMessage{
+			Message: result.Description,
+			Type:    result.ThreatLevel.String(),
+			Text:    strings.Join(text, "\n"),
+		}
+	}
+	return testCase
+}
+
+// add appends a testcase to the suite and updates its counters.
+func (s *junitTestSuite) add(testCase junitTestCase) {
+	s.TestCases = append(s.TestCases, testCase)
+	s.Tests++
+	switch {
+	case testCase.Failure != nil:
+		s.Failures++
+	case testCase.Error != nil:
+		s.Errors++
+	case testCase.Skipped != nil:
+		s.Skipped++
+	}
+}
+
+// write encodes the suites as an indented JUnit document to the reporter output.
+func (j *junitReporter) write(suites []*junitTestSuite) error {
+	report := junitTestSuites{Name: "AntiGinx", Suites: make([]junitTestSuite, 0, len(suites))}
+	if !j.scanStarted.IsZero() {
+		report.Time = junitSeconds(time.Since(j.scanStarted))
+	}
+	for _, suite := range suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, *suite)
+	}
+	if _, err := io.WriteString(j.out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(j.out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(j.out, "\n")
+	return err
+}
+
+// junitSeconds formats a duration as the seconds of a JUnit time attribute, empty when the
+// duration is unknown.
+func junitSeconds(duration time.Duration) string {
+	if duration <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(duration.Seconds(), 'f', 3, 64)
+}
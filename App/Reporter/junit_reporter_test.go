@@ -0,0 +1,135 @@
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// runJUnitReporter sends results through a JUnit reporter and decodes the written report.
+func runJUnitReporter(t *testing.T, threshold *Tests.ThreatLevel, results ...strategy.ResultWrapper) junitTestSuites {
+	t.Helper()
+	var out bytes.Buffer
+	ch := make(chan strategy.ResultWrapper)
+	done := InitializeJUnitReporter(ch, &out, "https://example.com", threshold).StartListening()
+	for _, result := range results {
+		ch <- result
+	}
+	close(ch)
+	if failures := <-done; failures != 0 {
+		t.Fatalf("Expected the report to be written, got %d failure(s)", failures)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Expected valid JUnit XML, got %v:\n%s", err, out.String())
+	}
+	return report
+}
+
+func junitResult(testId string, level Tests.ThreatLevel, description string) strategy.ResultWrapper {
+	return strategy.WrapStrategyResult(&Tests.TestResult{
+		TestId:      testId,
+		Name:        testId + " test",
+		ThreatLevel: level,
+		Description: description,
+	}, nil, nil)
+}
+
+func TestJUnitReporter_TestCases(t *testing.T) {
+	report := runJUnitReporter(t, nil,
+		junitResult("https", Tests.High, "Connection uses insecure HTTP protocol"),
+		junitResult("hsts", Tests.Low, "HSTS max-age is short"),
+		junitResult("csp", Tests.None, "CSP is strict"),
+	)
+
+	if len(report.Suites) != 1 || report.Suites[0].Name != "https://example.com" {
+		t.Fatalf("Expected one suite for the target, got %+v", report.Suites)
+	}
+	cases := report.Suites[0].TestCases
+	if len(cases) != 3 || report.Tests != 3 {
+		t.Fatalf("Expected one testcase per test, got %d (tests=%d)", len(cases), report.Tests)
+	}
+	if cases[0].ClassName != "antiginx.https" || cases[0].Failure == nil {
+		t.Fatalf("Expected the High finding to be a failure, got %+v", cases[0])
+	}
+	if cases[0].Failure.Message != "Connection uses insecure HTTP protocol" || cases[0].Failure.Type != "High" {
+		t.Errorf("Expected the description as message and High as type, got %+v", cases[0].Failure)
+	}
+	if cases[1].Failure != nil || cases[2].Failure != nil {
+		t.Errorf("Expected findings below High to pass, got %+v and %+v", cases[1].Failure, cases[2].Failure)
+	}
+	if report.Failures != 1 || report.Suites[0].Failures != 1 {
+		t.Errorf("Expected 1 failure, got %d (suite %d)", report.Failures, report.Suites[0].Failures)
+	}
+}
+
+func TestJUnitReporter_ThresholdAndSuppressions(t *testing.T) {
+	threshold := Tests.Low
+	suppressed := &Tests.TestResult{TestId: "xframe", Name: "xframe test", ThreatLevel: Tests.Medium, Suppressed: true}
+	report := runJUnitReporter(t, &threshold,
+		junitResult("hsts", Tests.Low, "HSTS max-age is short"),
+		strategy.WrapStrategyResult(suppressed, nil, nil),
+		strategy.WrapStrategyResult(nil, nil, &strategy.RequestInfo{Message: "connection refused"}).WithTarget("https://down.example"),
+	)
+
+	if report.Failures != 1 || report.Skipped != 1 || report.Errors != 1 {
+		t.Errorf("Expected 1 failure, 1 skipped and 1 error, got %d, %d and %d", report.Failures, report.Skipped, report.Errors)
+	}
+	if len(report.Suites) != 2 || report.Suites[1].Name != "https://down.example" {
+		t.Errorf("Expected a suite for the unreachable target, got %+v", report.Suites)
+	}
+}
+
+func TestResolver_Resolve_JUnitOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	resolver := NewResolver(WithFormat(types.FormatJUnit), WithOutput(path))
+	ch := make(chan strategy.ResultWrapper)
+
+	reporter := resolver.Resolve(ch, "", "https://example.com", 5, 2, []strategy.TestStrategy{MockCliPrefStrategy{}})
+	if _, ok := reporter.(*junitReporter); !ok {
+		t.Fatalf("Expected a JUnit reporter, got %T", reporter)
+	}
+	done := reporter.StartListening()
+	ch <- junitResult("https", Tests.Critical, "Plain HTTP")
+	close(ch)
+	<-done
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the report file to be written: %v", err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil || report.Failures != 1 {
+		t.Errorf("Expected a report with one failure, got %+v (%v)", report, err)
+	}
+}
+
+func TestJUnitReporter_Durations(t *testing.T) {
+	var out bytes.Buffer
+	ch := make(chan strategy.ResultWrapper)
+	reporter := InitializeJUnitReporter(ch, &out, "https://example.com", nil)
+	reporter.SetReportMetadata(types.ReportMetadata{ScanTime: time.Now().Add(-2 * time.Second)})
+	done := reporter.StartListening()
+	ch <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "hsts", Name: "hsts test", Duration: 1500 * time.Millisecond}, nil, nil)
+	close(ch)
+	<-done
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Expected valid JUnit XML, got %v", err)
+	}
+	if got := report.Suites[0].TestCases[0].Time; got != "1.500" {
+		t.Errorf("Expected the test duration as testcase time, got %q", got)
+	}
+	if seconds, err := strconv.ParseFloat(report.Time, 64); err != nil || seconds < 2 {
+		t.Errorf("Expected the scan duration of at least 2s as report time, got %q", report.Time)
+	}
+}
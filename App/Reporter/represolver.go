@@ -1,28 +1,103 @@
 package Reporter
 
 import (
+	"Engine-AntiGinx/App/Diff"
 	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution/strategy"
+	"io"
 	"os"
 )
 
-type ConcreteResolver struct{}
+type ConcreteResolver struct {
+	quiet     bool
+	format    types.ReportFormat
+	output    string
+	threshold *Tests.ThreatLevel
+	metadata  types.ReportMetadata
+}
+
+// ResolverOption is a functional option type for configuring a ConcreteResolver.
+type ResolverOption func(*ConcreteResolver)
+
+// WithQuiet makes the resolved CLI reporter print only the one-line scan summary (--quiet)
+// instead of every finding. Help and backend reporters are not affected.
+func WithQuiet(quiet bool) ResolverOption {
+	return func(r *ConcreteResolver) {
+		r.quiet = quiet
+	}
+}
+
+// WithFormat selects how the local reporter renders the results (--format): the CLI output
+// (FormatText or empty), a JUnit XML report (FormatJUnit) or an HTML report (FormatHTML).
+// Help and backend reporters are not affected.
+func WithFormat(format types.ReportFormat) ResolverOption {
+	return func(r *ConcreteResolver) {
+		r.format = format
+	}
+}
+
+// WithOutput writes the JUnit or HTML report to the file at path (--output) instead of stdout.
+// The file is created or truncated when the reporter is resolved.
+func WithOutput(path string) ResolverOption {
+	return func(r *ConcreteResolver) {
+		r.output = path
+	}
+}
+
+// WithSeverityThreshold sets the threat level from which findings are JUnit failures
+// (--severity-threshold). Nil keeps the JUnit default (High).
+func WithSeverityThreshold(threshold *Tests.ThreatLevel) ResolverOption {
+	return func(r *ConcreteResolver) {
+		r.threshold = threshold
+	}
+}
+
+// WithReportMetadata sets the user-supplied report header fields (--report-title,
+// --report-logo, --operator) of the HTML report.
+func WithReportMetadata(metadata types.ReportMetadata) ResolverOption {
+	return func(r *ConcreteResolver) {
+		r.metadata = metadata
+	}
+}
 
 // NewResolver initializes and returns a new instance of the ConcreteResolver struct.
 //
+// Parameters:
+//   - opts: Optional resolver settings (e.g., WithQuiet, WithFormat)
+//
 // Returns:
 //   - *ConcreteResolver: A pointer to the newly created resolver instance
-func NewResolver() *ConcreteResolver {
-	return &ConcreteResolver{}
+func NewResolver(opts ...ResolverOption) *ConcreteResolver {
+	resolver := &ConcreteResolver{}
+	for _, opt := range opts {
+		opt(resolver)
+	}
+	return resolver
 }
 
 // Resolve determines and initializes the appropriate Reporter implementation based on
 // the provided strategies and environment configuration.
 //
 // The resolution logic follows this priority order:
-// 1. If strategies prefer a HelpReporter, it returns a new HelpReporter.
-// 2. If the "BACK_URL" environment variable is set, it returns an initialized BackendReporter.
-// 3. Otherwise, it defaults to returning an InitializeCliReporter.
+//  1. If strategies prefer a HelpReporter, it returns a new HelpReporter.
+//  2. If the "BACK_URL" environment variable is set, it returns an initialized BackendReporter.
+//     When "BACK_HEALTH_PATH" is also set (non-empty), the backend health endpoint is checked first.
+//     An unhealthy backend panics with a retryable Errors.Error (code 105) so the daemon
+//     can requeue the task before any test is run.
+//     When "BACK_PROGRESS" is "true", every submission carries a running partial summary.
+//     When "BACK_BASELINE" is a path to a previous report, every result is marked as a new,
+//     persistent or resolved finding against it.
+//     When "REPORT_SIGNING_KEY" is set, every submission carries an HMAC signature (see types.Sign).
+//     When "BACK_TEE" is set, results also go to a local CLI reporter (see teeReporter):
+//     "stdout" prints them, any other value is a file path the CLI output is written to.
+//  3. If the resolver was created WithFormat(types.FormatJUnit), it returns a JUnit reporter
+//     writing to stdout or to the WithOutput file.
+//  4. If the resolver was created WithFormat(types.FormatHTML), it returns an HTML reporter
+//     writing to stdout or to the WithOutput file, with the WithReportMetadata header.
+//  5. Otherwise, it defaults to returning an InitializeCliReporter, in quiet mode when
+//     the resolver was created WithQuiet.
 //
 // Parameters:
 //   - ch: The channel used for transmitting strategy result wrappers
@@ -33,7 +108,10 @@ func NewResolver() *ConcreteResolver {
 //   - strategies: A slice of test strategies to be validated and used for reporting decisions
 //
 // Returns:
-//   - Reporter: An interface satisfying the Reporter contract (Help, Backend, or CLI)
+//   - Reporter: An interface satisfying the Reporter contract (Help, Backend, Tee, JUnit, HTML or CLI)
+//
+// Panics:
+//   - Errors.Error: Code 102 when the --output file cannot be created
 func (r *ConcreteResolver) Resolve(ch chan strategy.ResultWrapper, taskId string,
 	target string, clientTimeOut int, retryDelay int, strategies []strategy.TestStrategy) Reporter {
 	prefReporter := r.checkStrategies(strategies)
@@ -42,10 +120,154 @@ func (r *ConcreteResolver) Resolve(ch chan strategy.ResultWrapper, taskId string
 		return NewHelpReporter(ch)
 	}
 	if v, exists := os.LookupEnv("BACK_URL"); exists {
-		return InitializeBackendReporter(ch, v, taskId, target, clientTimeOut, retryDelay)
+		tee := os.Getenv("BACK_TEE")
+		if tee == "" {
+			return r.backendReporter(ch, v, taskId, target, clientTimeOut, retryDelay)
+		}
+		return r.teeReporter(ch, tee, v, taskId, target, clientTimeOut, retryDelay)
 	}
 
-	return InitializeCliReporter(ch)
+	if r.format == types.FormatJUnit {
+		return r.junitReporter(ch, target)
+	}
+	if r.format == types.FormatHTML {
+		return r.htmlReporter(ch, target)
+	}
+
+	reporter := InitializeCliReporter(ch)
+	if r.quiet {
+		reporter.EnableQuiet()
+	}
+	return reporter
+}
+
+// junitReporter initializes the JUnit reporter writing to stdout, or to the WithOutput
+// file, which is created or truncated and closed once the report is written.
+//
+// Panics:
+//   - Errors.Error: Code 102 when the --output file cannot be created
+func (r *ConcreteResolver) junitReporter(ch chan strategy.ResultWrapper, target string) *junitReporter {
+	if r.output == "" {
+		return InitializeJUnitReporter(ch, os.Stdout, target, r.threshold)
+	}
+	file := r.createOutput()
+	reporter := InitializeJUnitReporter(ch, file, target, r.threshold)
+	reporter.closeWhenDone(file)
+	return reporter
+}
+
+// htmlReporter initializes the HTML reporter writing to stdout, or to the WithOutput
+// file, which is created or truncated and closed once the report is written.
+//
+// Panics:
+//   - Errors.Error: Code 102 when the --output file cannot be created
+func (r *ConcreteResolver) htmlReporter(ch chan strategy.ResultWrapper, target string) *htmlReporter {
+	if r.output == "" {
+		return InitializeHTMLReporter(ch, os.Stdout, target, r.metadata)
+	}
+	file := r.createOutput()
+	reporter := InitializeHTMLReporter(ch, file, target, r.metadata)
+	reporter.closeWhenDone(file)
+	return reporter
+}
+
+// createOutput creates or truncates the WithOutput file.
+//
+// Panics:
+//   - Errors.Error: Code 102 when the file cannot be created
+func (r *ConcreteResolver) createOutput() *os.File {
+	file, err := os.Create(r.output)
+	if err != nil {
+		panic(Errors.Error{
+			Code:        102,
+			Message:     "Reporter ConcreteResolver error occurred. Cannot create the --output file: " + err.Error(),
+			Source:      "Reporter ConcreteResolver",
+			IsRetryable: false,
+		})
+	}
+	return file
+}
+
+// backendReporter initializes the backend reporter, enabling progress summaries
+// (BACK_PROGRESS), finding statuses (BACK_BASELINE) and signing (REPORT_SIGNING_KEY) and
+// checking the backend health (BACK_HEALTH_PATH) when configured.
+//
+// Panics:
+//   - Errors.Error: Code 103 when the BACK_BASELINE report cannot be loaded
+//   - Errors.Error: Retryable (code 105) when the backend health check fails
+func (r *ConcreteResolver) backendReporter(ch chan strategy.ResultWrapper, backendURL string, taskId string,
+	target string, clientTimeOut int, retryDelay int) *backendReporter {
+	reporter := InitializeBackendReporter(ch, backendURL, taskId, target, clientTimeOut, retryDelay)
+	if os.Getenv("BACK_PROGRESS") == "true" {
+		reporter.EnableProgress()
+	}
+	if baselinePath := os.Getenv("BACK_BASELINE"); baselinePath != "" {
+		baseline, err := Diff.LoadBaseline(baselinePath)
+		if err != nil {
+			panic(Errors.Error{
+				Code:        103,
+				Message:     "Reporter ConcreteResolver error occurred. Cannot load the BACK_BASELINE report: " + err.Error(),
+				Source:      "Reporter ConcreteResolver",
+				IsRetryable: false,
+			})
+		}
+		reporter.EnableBaseline(baseline)
+	}
+	if key := types.SigningKey(); key != nil {
+		reporter.EnableSigning(key)
+	}
+	if healthPath := os.Getenv("BACK_HEALTH_PATH"); healthPath != "" {
+		if err := reporter.CheckHealth(healthPath); err != nil {
+			panic(*err)
+		}
+	}
+	return reporter
+}
+
+// teeReporter wraps the backend reporter and a CLI reporter writing to stdout (tee is
+// "stdout") or to the file at path tee, so the results sent to the backend can be compared
+// with a local copy while debugging a backend integration. The file is created or truncated
+// and closed when the reporters are done.
+//
+// Panics:
+//   - Errors.Error: Code 102 when the tee file cannot be created
+//   - Errors.Error: Code 103 when the BACK_BASELINE report cannot be loaded
+//   - Errors.Error: Retryable (code 105) when the backend health check fails
+func (r *ConcreteResolver) teeReporter(ch chan strategy.ResultWrapper, tee string, backendURL string, taskId string,
+	target string, clientTimeOut int, retryDelay int) *teeReporter {
+	// The CLI reporter is built after the backend reporter, so no file is created when the
+	// backend health check fails.
+	var file *os.File
+	reporter := NewTeeReporter(ch,
+		func(output chan strategy.ResultWrapper) Reporter {
+			return r.backendReporter(output, backendURL, taskId, target, clientTimeOut, retryDelay)
+		},
+		func(output chan strategy.ResultWrapper) Reporter {
+			var out io.Writer = os.Stdout
+			if tee != "stdout" {
+				var err error
+				if file, err = os.Create(tee); err != nil {
+					panic(Errors.Error{
+						Code:        102,
+						Message:     "Reporter ConcreteResolver error occurred. Cannot create the BACK_TEE file: " + err.Error(),
+						Source:      "Reporter ConcreteResolver",
+						IsRetryable: false,
+					})
+				}
+				out = file
+			}
+			cli := InitializeCliReporter(output)
+			cli.SetOutput(out)
+			if r.quiet {
+				cli.EnableQuiet()
+			}
+			return cli
+		},
+	)
+	if file != nil {
+		reporter.closeWhenDone(file)
+	}
+	return reporter
 }
 
 // checkStrategies validates that all provided strategies share the same preferred reporter type.
@@ -3,6 +3,8 @@ package Reporter
 import (
 	"Engine-AntiGinx/App/Errors"
 	"Engine-AntiGinx/App/execution/strategy"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"sync"
 	"testing"
@@ -119,3 +121,50 @@ func TestResolver_Resolve(t *testing.T) {
 		})
 	}
 }
+
+func TestResolver_Resolve_BackendHealthCheck(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer healthy.Close()
+
+	unreachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	unreachableURL := unreachable.URL
+	unreachable.Close()
+
+	tests := []struct {
+		name       string
+		backendURL string
+		wantErr    bool
+	}{
+		{name: "Healthy backend", backendURL: healthy.URL + "/results", wantErr: false},
+		{name: "Unreachable backend", backendURL: unreachableURL + "/results", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BACK_URL", tt.backendURL)
+			t.Setenv("BACK_HEALTH_PATH", "/health")
+			defer func() {
+				r := recover()
+				if !tt.wantErr {
+					if r != nil {
+						t.Errorf("Unexpected panic %v", r)
+					}
+					return
+				}
+				err, ok := r.(Errors.Error)
+				if !ok {
+					t.Fatalf("Expected Errors.Error panic, got %v", r)
+				}
+				assert.Equal(t, 105, err.Code)
+				assert.True(t, err.IsRetryable, "Unreachable backend should be reported as retryable")
+			}()
+
+			reporter := NewResolver().Resolve(make(chan strategy.ResultWrapper), "test", "test", 1, 0,
+				[]strategy.TestStrategy{MockCliPrefStrategy{}})
+			assert.IsType(t, &backendReporter{}, reporter)
+		})
+	}
+}
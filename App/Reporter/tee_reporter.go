@@ -0,0 +1,106 @@
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/execution/strategy"
+	"io"
+)
+
+// teeReporter fans every result out to several reporters, e.g. the backend reporter and a
+// CLI reporter writing a local copy of the results (BACK_TEE). Each wrapped reporter gets
+// its own channel, so a slow backend upload does not hold back the local output beyond
+// the channel buffer.
+//
+// Fields:
+//   - resultChannel: Receive-only channel for consuming test results
+//   - outputs: Input channels of the wrapped reporters, closed when resultChannel is
+//   - reporters: Wrapped reporters, started by StartListening
+//   - closers: Resources (e.g., the tee file) closed once every reporter is done
+type teeReporter struct {
+	resultChannel <-chan strategy.ResultWrapper
+	outputs       []chan strategy.ResultWrapper
+	reporters     []Reporter
+	closers       []io.Closer
+}
+
+// teeBufferSize is the capacity of the channel of each wrapped reporter.
+const teeBufferSize = 16
+
+// NewTeeReporter creates a reporter delivering every result received on channel to each
+// reporter built by the given constructors. Every constructor receives the channel its
+// reporter must consume.
+//
+// Parameters:
+//   - channel: The input channel where test results are published by the Runner
+//   - builders: Constructors of the wrapped reporters
+//
+// Returns:
+//   - *teeReporter: Reporter ready to start listening
+//
+// Example:
+//
+//	reporter := NewTeeReporter(resultChan,
+//	    func(ch chan strategy.ResultWrapper) Reporter {
+//	        return InitializeBackendReporter(ch, backendURL, taskId, target, 10, 5)
+//	    },
+//	    func(ch chan strategy.ResultWrapper) Reporter { return InitializeCliReporter(ch) },
+//	)
+//	failures := <-reporter.StartListening() // Sum of the failures of both reporters
+func NewTeeReporter(channel chan strategy.ResultWrapper, builders ...func(chan strategy.ResultWrapper) Reporter) *teeReporter {
+	tee := &teeReporter{resultChannel: channel}
+	for _, build := range builders {
+		output := make(chan strategy.ResultWrapper, teeBufferSize)
+		tee.outputs = append(tee.outputs, output)
+		tee.reporters = append(tee.reporters, build(output))
+	}
+	return tee
+}
+
+// SetReportMetadata passes the report header to every wrapped reporter accepting it.
+func (t *teeReporter) SetReportMetadata(metadata types.ReportMetadata) {
+	for _, reporter := range t.reporters {
+		if receiver, ok := reporter.(MetadataReceiver); ok {
+			receiver.SetReportMetadata(metadata)
+		}
+	}
+}
+
+// closeWhenDone registers a resource closed after every wrapped reporter has finished.
+func (t *teeReporter) closeWhenDone(closer io.Closer) {
+	t.closers = append(t.closers, closer)
+}
+
+// StartListening starts every wrapped reporter and forwards each result to all of them
+// until the input channel is closed. The returned channel receives the sum of the failure
+// counts of the wrapped reporters once all of them have finished.
+//
+// Returns:
+//   - <-chan int: Completion signal channel with the total failure count
+func (t *teeReporter) StartListening() <-chan int {
+	done := make(chan int)
+	doneChannels := make([]<-chan int, len(t.reporters))
+	for i, reporter := range t.reporters {
+		doneChannels[i] = reporter.StartListening()
+	}
+
+	go func() {
+		for result := range t.resultChannel {
+			for _, output := range t.outputs {
+				output <- result
+			}
+		}
+		for _, output := range t.outputs {
+			close(output)
+		}
+
+		failures := 0
+		for _, reporterDone := range doneChannels {
+			failures += <-reporterDone
+		}
+		for _, closer := range t.closers {
+			_ = closer.Close()
+		}
+		done <- failures
+	}()
+	return done
+}
@@ -0,0 +1,129 @@
+package Reporter
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingBackend is a stub backend keeping the bodies of the results posted to it.
+type recordingBackend struct {
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (b *recordingBackend) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	b.mu.Lock()
+	b.bodies = append(b.bodies, string(body))
+	b.mu.Unlock()
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (b *recordingBackend) received(substring string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, body := range b.bodies {
+		if strings.Contains(body, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTeeReporter_DeliversToEveryReporter(t *testing.T) {
+	backend := &recordingBackend{}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	var local bytes.Buffer
+	resChan := make(chan strategy.ResultWrapper)
+	reporter := NewTeeReporter(resChan,
+		func(ch chan strategy.ResultWrapper) Reporter {
+			return InitializeBackendReporter(ch, server.URL, "test-id", "target", 0, 0)
+		},
+		func(ch chan strategy.ResultWrapper) Reporter {
+			cli := InitializeCliReporter(ch)
+			cli.SetOutput(&local)
+			return cli
+		},
+	)
+	done := reporter.StartListening()
+	resChan <- strategy.WrapStrategyResult(&Tests.TestResult{Name: "Tee scan", ThreatLevel: Tests.High}, nil, nil)
+	close(resChan)
+
+	if failures := <-done; failures != 0 {
+		t.Errorf("Expected no failures, got %d", failures)
+	}
+	if !backend.received("Tee scan") {
+		t.Errorf("Expected the result to reach the backend, got %v", backend.bodies)
+	}
+	if !strings.Contains(local.String(), "Test name: Tee scan") {
+		t.Errorf("Expected the result in the local output, got %q", local.String())
+	}
+}
+
+func TestTeeReporter_SumsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	resChan := make(chan strategy.ResultWrapper)
+	reporter := NewTeeReporter(resChan,
+		func(ch chan strategy.ResultWrapper) Reporter {
+			return InitializeBackendReporter(ch, server.URL, "test-id", "target", 0, 0)
+		},
+		func(ch chan strategy.ResultWrapper) Reporter {
+			cli := InitializeCliReporter(ch)
+			cli.SetOutput(io.Discard)
+			return cli
+		},
+	)
+	done := reporter.StartListening()
+	resChan <- strategy.WrapStrategyResult(&Tests.TestResult{Name: "Tee scan"}, nil, nil)
+	close(resChan)
+
+	if failures := <-done; failures != 1 {
+		t.Errorf("Expected the backend failure to be reported, got %d", failures)
+	}
+}
+
+func TestResolver_ResolveTeeFile(t *testing.T) {
+	backend := &recordingBackend{}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "results.txt")
+	t.Setenv("BACK_URL", server.URL)
+	t.Setenv("BACK_TEE", path)
+
+	resChan := make(chan strategy.ResultWrapper)
+	reporter := NewResolver().Resolve(resChan, "test-id", "target", 0, 0, []strategy.TestStrategy{MockCliPrefStrategy{}})
+	if _, ok := reporter.(*teeReporter); !ok {
+		t.Fatalf("Expected a tee reporter, got %T", reporter)
+	}
+	done := reporter.StartListening()
+	resChan <- strategy.WrapStrategyResult(&Tests.TestResult{Name: "Tee scan"}, nil, nil)
+	close(resChan)
+	<-done
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the tee file: %v", err)
+	}
+	if !strings.Contains(string(content), "Test name: Tee scan") {
+		t.Errorf("Expected the result in the tee file, got %q", content)
+	}
+	if !backend.received("Tee scan") {
+		t.Error("Expected the result to reach the backend")
+	}
+}
@@ -16,13 +16,49 @@ const (
 // Fields:
 //   - Target: Given target
 //   - TestId: id related to full scan
+//   - ScanId: correlation id of the scan, shared with the engine logs
 //   - Result: Core data of test
+//   - FindingID: Stable identifier of the finding across scans (see FindingID), set on test results
 //   - EndFlag: Check if engine finished its job
+//   - Progress: Running summary of the scan, sent only when progress streaming is enabled
+//   - Status: Whether the result is a new, persistent or resolved finding compared with the
+//     previous report of the target, sent only when a baseline report is configured
+//   - Signature: HMAC-SHA256 of the wrapper (see Sign), sent only when REPORT_SIGNING_KEY is set
 type TestResultWrapper struct {
 	Target      string               `json:"target"`
 	TestId      string               `json:"testId"`
+	ScanId      string               `json:"scanId,omitempty"`
 	Result      Tests.TestResult     `json:"result"`
+	FindingID   string               `json:"findingId,omitempty"`
 	EndFlag     bool                 `json:"endFlag"`
 	ResultType  ResultType           `json:"resultType"`
 	ProcessInfo strategy.RequestInfo `json:"message"`
+	Progress    *Progress            `json:"progress,omitempty"`
+	Status      FindingStatus        `json:"status,omitempty"`
+	Signature   string               `json:"signature,omitempty"`
+}
+
+// FindingStatus classifies a test result against the previous report of the same target, so
+// the backend can highlight regressions. Results neither failing now nor in the previous
+// report have no status (empty).
+type FindingStatus string
+
+const (
+	StatusNew        FindingStatus = "new"        // Finding absent from the previous report
+	StatusPersistent FindingStatus = "persistent" // Finding already present in the previous report
+	StatusResolved   FindingStatus = "resolved"   // Test passes now but was a finding in the previous report
+)
+
+// Progress is the running summary of a scan attached to every result the backend reporter
+// sends when progress streaming is enabled (BACK_PROGRESS), so the backend can show live
+// progress before the end flag arrives.
+//
+// Fields:
+//   - Completed: Number of test results produced so far, including this one
+//   - Grade: Overall grade of the results produced so far
+//   - Counts: Unsuppressed results so far keyed by threat level name (e.g., "High")
+type Progress struct {
+	Completed int            `json:"completed"`
+	Grade     string         `json:"grade"`
+	Counts    map[string]int `json:"counts"`
 }
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+)
+
+// findingIdLength is the number of hex characters of a finding ID (128 bits of the hash).
+const findingIdLength = 32
+
+// volatileNumbers matches the numbers embedded in descriptions (ages, counts, days left)
+// that change between scans without changing the finding itself.
+var volatileNumbers = regexp.MustCompile(`[0-9]+`)
+
+// FindingID returns a deterministic identifier of the finding reported by result on target,
+// so backends and diff tooling can deduplicate the same issue across scans.
+//
+// The ID is a hash of the target, the test ID and a finding-specific discriminator: the
+// message id of a localized description, otherwise the description with its numbers
+// masked, so values that drift between scans (e.g., days until expiry) keep the ID stable
+// while different findings of the same test differ.
+//
+// Parameters:
+//   - target: Target the result belongs to
+//   - result: Test result to identify
+//
+// Returns:
+//   - string: Lowercase hex ID, empty for results without a test ID or name
+//
+// Example:
+//
+//	id := FindingID("https://example.com", result)
+//	// id == "3f0c...": identical for the same finding in every scan of the target
+func FindingID(target string, result Tests.TestResult) string {
+	testId := result.TestId
+	if testId == "" {
+		testId = result.Name
+	}
+	if testId == "" {
+		return ""
+	}
+	discriminator := volatileNumbers.ReplaceAllString(result.Description, "#")
+	if result.DescriptionText != nil {
+		discriminator = result.DescriptionText.Id
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{target, testId, discriminator} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:findingIdLength]
+}
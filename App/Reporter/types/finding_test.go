@@ -0,0 +1,76 @@
+package types
+
+import (
+	"Engine-AntiGinx/App/Locale"
+	"Engine-AntiGinx/App/Tests"
+	"strings"
+	"testing"
+)
+
+func TestFindingID_StableAcrossRuns(t *testing.T) {
+	firstRun := Tests.TestResult{
+		TestId:      "ssl",
+		Name:        "SSL Certificate Security",
+		ThreatLevel: Tests.Medium,
+		Description: "Certificate expires in 23 days",
+		Metadata:    map[string]any{"days_left": 23},
+	}
+	secondRun := firstRun
+	secondRun.Description = "Certificate expires in 16 days"
+	secondRun.Metadata = map[string]any{"days_left": 16}
+
+	first := FindingID("https://example.com", firstRun)
+	if len(first) != findingIdLength {
+		t.Fatalf("Expected a %d character ID, got %q", findingIdLength, first)
+	}
+	if second := FindingID("https://example.com", secondRun); second != first {
+		t.Errorf("Expected the same finding to keep its ID across runs, got %q and %q", first, second)
+	}
+}
+
+func TestFindingID_DifferentFindings(t *testing.T) {
+	base := Tests.TestResult{TestId: "hsts", ThreatLevel: Tests.Low, Description: "HSTS max-age is short"}
+	otherDescription := base
+	otherDescription.Description = "HSTS header lacks includeSubDomains"
+	otherTest := base
+	otherTest.TestId = "csp"
+
+	ids := map[string]string{
+		"base":              FindingID("https://example.com", base),
+		"other target":      FindingID("https://example.org", base),
+		"other description": FindingID("https://example.com", otherDescription),
+		"other test":        FindingID("https://example.com", otherTest),
+	}
+	seen := map[string]string{}
+	for name, id := range ids {
+		if previous, exists := seen[id]; exists {
+			t.Errorf("Expected %q and %q to have different IDs, both got %q", previous, name, id)
+		}
+		seen[id] = name
+	}
+}
+
+func TestFindingID_LocalizedDescription(t *testing.T) {
+	first := Tests.TestResult{TestId: "hsts"}
+	first.SetDescription(Locale.NewText("hsts.missing.description"))
+	second := first
+	second.Description = "rendered in another language"
+
+	if FindingID("example.com", first) != FindingID("example.com", second) {
+		t.Error("Expected the message id of a localized description to identify the finding")
+	}
+	if FindingID("example.com", Tests.TestResult{}) != "" {
+		t.Error("Expected no ID for a result without test ID or name")
+	}
+}
+
+func TestTestResultWrapper_MarshalJSON_FindingID(t *testing.T) {
+	wrapper := TestResultWrapper{Target: "example.com", FindingID: "abc"}
+	data, err := wrapper.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `"findingId":"abc"`) {
+		t.Errorf("Expected findingId in the JSON, got %s", data)
+	}
+}
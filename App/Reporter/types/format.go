@@ -0,0 +1,11 @@
+package types
+
+// ReportFormat selects how the local reporter renders the results of a scan (--format).
+// Results sent to a backend (BACK_URL) are not affected.
+type ReportFormat string
+
+const (
+	FormatText  ReportFormat = "text"  // Human-readable console output (default)
+	FormatJUnit ReportFormat = "junit" // JUnit XML for CI test reporting, one testcase per test
+	FormatHTML  ReportFormat = "html"  // Standalone HTML report with a header (title, logo, scan time, target)
+)
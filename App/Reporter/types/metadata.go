@@ -0,0 +1,215 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// metadataTypeKey is the discriminator key injected into every metadata envelope.
+// Its value is the ID of the test that produced the result (e.g., "hsts", "csp").
+const metadataTypeKey = "type"
+
+// MarshalJSON implements custom JSON marshaling for TestResultWrapper, normalizing the
+// heterogeneous TestResult.Metadata into an envelope carrying a "type" discriminator.
+//
+// Each test stores a different metadata shape (maps, structs, nil), so the backend
+// cannot deserialize it without knowing which test produced it. The envelope keeps
+// the native fields of the metadata object and adds the test ID under "type":
+//
+//	{"type": "cross-origin-x", "hasCOEP": true, ...}
+//
+// Metadata that does not encode to a JSON object (arrays, scalars) is placed under
+// a "value" key instead, and results without metadata still carry the discriminator.
+//
+// Next to the result, "metadataPaths" holds the same metadata flattened to JSON pointer
+// paths (see FlattenMetadata), so a generic UI can list the details of any finding:
+//
+//	{"result": {...}, "metadataPaths": {"/hasCOEP": true, "/issues/0": "..."}, ...}
+//
+// Returns:
+//   - []byte: JSON-encoded wrapper with normalized metadata
+//   - error: Error from marshaling the metadata or wrapper
+func (w TestResultWrapper) MarshalJSON() ([]byte, error) {
+	type wrapperAlias TestResultWrapper
+	alias := struct {
+		wrapperAlias
+		MetadataPaths map[string]any `json:"metadataPaths,omitempty"`
+	}{wrapperAlias: wrapperAlias(w)}
+
+	envelope, err := NormalizeMetadata(w.Result.TestId, w.Result.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	alias.Result.Metadata = envelope
+	if alias.MetadataPaths, err = FlattenMetadata(envelope); err != nil {
+		return nil, err
+	}
+	delete(alias.MetadataPaths, "/"+metadataTypeKey)
+	return json.Marshal(alias)
+}
+
+// FlattenMetadata flattens test metadata into a map from JSON pointer paths (RFC 6901) to
+// values, so its details can be rendered without knowing how the test nests them. Every
+// string, number, boolean and null of the encoded metadata gets its own entry, and so do
+// empty arrays and objects. Object keys are escaped as pointer tokens ("~" as "~0", "/"
+// as "~1") and array elements are addressed by index. Numbers keep their encoded form.
+//
+// Parameters:
+//   - metadata: Raw metadata value stored in TestResult.Metadata
+//
+// Returns:
+//   - map[string]any: Values keyed by their pointer (nil when the metadata encodes to null)
+//   - error: Error if the metadata cannot be encoded
+//
+// Example:
+//
+//	FlattenMetadata(map[string]any{"directives": map[string][]string{"script-src": {"'self'"}}})
+//	// map[string]any{"/directives/script-src/0": "'self'"}
+func FlattenMetadata(metadata any) (map[string]any, error) {
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if decoded == nil {
+		return nil, nil
+	}
+	paths := map[string]any{}
+	flattenValue(paths, "", decoded)
+	return paths, nil
+}
+
+// flattenValue adds value and, for non-empty arrays and objects, its elements to paths.
+func flattenValue(paths map[string]any, pointer string, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		if len(value) == 0 {
+			paths[pointer] = value
+		}
+		for key, element := range value {
+			flattenValue(paths, pointer+"/"+pointerEscaper.Replace(key), element)
+		}
+	case []any:
+		if len(value) == 0 {
+			paths[pointer] = value
+		}
+		for index, element := range value {
+			flattenValue(paths, pointer+"/"+strconv.Itoa(index), element)
+		}
+	default:
+		paths[pointer] = value
+	}
+}
+
+// pointerEscaper escapes an object key as a JSON pointer reference token.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// NormalizeMetadata wraps raw test metadata into an envelope with a "type" discriminator
+// set to the given test ID, as described on TestResultWrapper.MarshalJSON.
+//
+// Parameters:
+//   - testId: ID of the test that produced the metadata
+//   - metadata: Raw metadata value stored in TestResult.Metadata
+//
+// Returns:
+//   - json.RawMessage: Encoded envelope, or JSON null when there is neither a test ID nor metadata
+//   - error: Error if the metadata cannot be marshaled
+func NormalizeMetadata(testId string, metadata any) (json.RawMessage, error) {
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if testId == "" && metadata == nil {
+		return raw, nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	trimmed := bytes.TrimSpace(raw)
+	switch {
+	case bytes.Equal(trimmed, []byte("null")):
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		if err := json.Unmarshal(trimmed, &fields); err != nil {
+			return nil, err
+		}
+	default:
+		fields["value"] = trimmed
+	}
+
+	discriminator, err := json.Marshal(testId)
+	if err != nil {
+		return nil, err
+	}
+	fields[metadataTypeKey] = discriminator
+	return json.Marshal(fields)
+}
+
+// MetadataLevel controls how much per-finding metadata reporters emit (--metadata).
+type MetadataLevel string
+
+const (
+	MetadataNone    MetadataLevel = "none"    // Drop metadata entirely
+	MetadataSummary MetadataLevel = "summary" // Keep top-level scalar fields (counts, flags, values)
+	MetadataFull    MetadataLevel = "full"    // Keep metadata unchanged (default)
+)
+
+// ReduceMetadata trims test metadata to the given level. In summary mode the metadata is
+// encoded to JSON and only the top-level numbers, booleans and strings of an object are
+// kept, so raw arrays and nested objects (e.g., full record lists) are dropped. An empty
+// or unknown level is treated as MetadataFull.
+//
+// Parameters:
+//   - metadata: Raw metadata value stored in TestResult.Metadata
+//   - level: Requested metadata level
+//
+// Returns:
+//   - any: Reduced metadata (nil for MetadataNone, or when nothing is left in summary mode)
+//
+// Example:
+//
+//	ReduceMetadata(map[string]any{"spf_count": 1, "issues": []string{"..."}}, MetadataSummary)
+//	// map[string]any{"spf_count": 1.0}
+func ReduceMetadata(metadata any, level MetadataLevel) any {
+	switch level {
+	case MetadataNone:
+		return nil
+	case MetadataSummary:
+	default:
+		return metadata
+	}
+
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil
+	}
+	fields, ok := decoded.(map[string]any)
+	if !ok {
+		if _, isArray := decoded.([]any); isArray {
+			return nil
+		}
+		return decoded
+	}
+
+	summary := make(map[string]any, len(fields))
+	for key, value := range fields {
+		switch value.(type) {
+		case []any, map[string]any:
+		default:
+			summary[key] = value
+		}
+	}
+	if len(summary) == 0 {
+		return nil
+	}
+	return summary
+}
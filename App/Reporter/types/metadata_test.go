@@ -0,0 +1,170 @@
+package types
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type sampleAnalysis struct {
+	HasCOEP   bool   `json:"hasCOEP"`
+	COEPValue string `json:"coepValue"`
+}
+
+func decodeMetadata(t *testing.T, wrapper TestResultWrapper) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(wrapper)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	var decoded struct {
+		Result struct {
+			Metadata map[string]any `json:"Metadata"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	return decoded.Result.Metadata
+}
+
+func TestTestResultWrapper_MarshalJSON_MetadataEnvelope(t *testing.T) {
+	hsts := TestResultWrapper{
+		Target: "example.com",
+		Result: Tests.TestResult{
+			TestId:   "hsts",
+			Name:     "HSTS Header Analysis",
+			Metadata: map[string]interface{}{"max_age": 31536000, "preload": true},
+		},
+	}
+	crossOrigin := TestResultWrapper{
+		Target: "example.com",
+		Result: Tests.TestResult{
+			TestId:   "cross-origin-x",
+			Name:     "Cross-Origin Security Headers Analysis",
+			Metadata: &sampleAnalysis{HasCOEP: true, COEPValue: "require-corp"},
+		},
+	}
+
+	hstsMeta := decodeMetadata(t, hsts)
+	if hstsMeta["type"] != "hsts" {
+		t.Errorf("Expected discriminator %q, got %v", "hsts", hstsMeta["type"])
+	}
+	if hstsMeta["max_age"] != float64(31536000) || hstsMeta["preload"] != true {
+		t.Errorf("Expected native HSTS fields to be preserved, got %v", hstsMeta)
+	}
+
+	coMeta := decodeMetadata(t, crossOrigin)
+	if coMeta["type"] != "cross-origin-x" {
+		t.Errorf("Expected discriminator %q, got %v", "cross-origin-x", coMeta["type"])
+	}
+	if coMeta["hasCOEP"] != true || coMeta["coepValue"] != "require-corp" {
+		t.Errorf("Expected native cross-origin fields to be preserved, got %v", coMeta)
+	}
+}
+
+func TestNormalizeMetadata_NonObjectValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		testId   string
+		metadata any
+		expected string
+	}{
+		{name: "Nil metadata keeps discriminator", testId: "https", metadata: nil, expected: `{"type":"https"}`},
+		{name: "Slice metadata nested under value", testId: "sitemap", metadata: []string{"/admin"}, expected: `{"type":"sitemap","value":["/admin"]}`},
+		{name: "Empty result stays null", testId: "", metadata: nil, expected: `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeMetadata(tt.testId, tt.metadata)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFlattenMetadata_CSPAnalysis(t *testing.T) {
+	response := &http.Response{Header: http.Header{
+		"Content-Security-Policy": []string{"default-src 'self'; script-src 'self' https://cdn.example.com; object-src 'none'"},
+	}}
+	result := Tests.NewCSPTest().Run(Tests.ResponseTestParams{Response: response})
+
+	paths, err := FlattenMetadata(result.Metadata)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]any{
+		"/hasCSP":                  true,
+		"/directives/script-src/0": "'self'",
+		"/directives/script-src/1": "https://cdn.example.com",
+		"/directives/object-src/0": "'none'",
+	}
+	for pointer, value := range expected {
+		if paths[pointer] != value {
+			t.Errorf("Expected %s = %v, got %v", pointer, value, paths[pointer])
+		}
+	}
+	if _, ok := paths["/policyStrength"].(json.Number); !ok {
+		t.Errorf("Expected the policy strength to keep its number, got %T", paths["/policyStrength"])
+	}
+	for pointer, value := range paths {
+		switch value := value.(type) {
+		case map[string]any:
+			if len(value) > 0 {
+				t.Errorf("Expected only leaves, got an object at %s", pointer)
+			}
+		case []any:
+			if len(value) > 0 {
+				t.Errorf("Expected only leaves, got an array at %s", pointer)
+			}
+		}
+	}
+}
+
+func TestFlattenMetadata_PointerEscaping(t *testing.T) {
+	paths, err := FlattenMetadata(map[string]any{
+		"headers": map[string]string{"a/b": "slash", "c~d": "tilde"},
+		"issues":  []string{},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]any{"/headers/a~1b": "slash", "/headers/c~0d": "tilde", "/issues": []any{}}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected %v, got %v", expected, paths)
+	}
+
+	if paths, _ := FlattenMetadata(nil); paths != nil {
+		t.Errorf("Expected no paths for nil metadata, got %v", paths)
+	}
+}
+
+func TestTestResultWrapper_MarshalJSON_MetadataPaths(t *testing.T) {
+	wrapper := TestResultWrapper{
+		Target: "example.com",
+		Result: Tests.TestResult{
+			TestId:   "sitemap",
+			Metadata: []string{"/admin"},
+		},
+	}
+	data, err := json.Marshal(wrapper)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	var decoded struct {
+		MetadataPaths map[string]any `json:"metadataPaths"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	// Paths address the envelope, without its discriminator
+	if expected := map[string]any{"/value/0": "/admin"}; !reflect.DeepEqual(decoded.MetadataPaths, expected) {
+		t.Errorf("Expected %v, got %v", expected, decoded.MetadataPaths)
+	}
+}
@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// ReportMetadata is the header of a user-facing report: who scanned what, when and with
+// which scanner build. Every field is optional; reporters leave out the empty ones.
+//
+// Fields:
+//   - Title: Report title (--report-title, empty uses the reporter default)
+//   - LogoURL: URL or data: URI of a logo shown next to the title (--report-logo)
+//   - Operator: Person or team that ran the scan (--operator)
+//   - Target: Target of the scan, set by the Runner
+//   - ScanTime: Start of the scan, set by the Runner
+//   - ScannerVersion: Version of the scanner build, set by the Runner
+type ReportMetadata struct {
+	Title          string
+	LogoURL        string
+	Operator       string
+	Target         string
+	ScanTime       time.Time
+	ScannerVersion string
+}
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+// SigningKeyEnv is the environment variable holding the key JSON reports are signed with.
+// When it is set (non-empty), every result submitted to the backend and every task stream
+// envelope carries an HMAC-SHA256 signature in its "signature" field.
+const SigningKeyEnv = "REPORT_SIGNING_KEY"
+
+// signatureKey is the JSON field holding the signature of a signed document.
+const signatureKey = "signature"
+
+// SigningKey returns the key of SigningKeyEnv, or nil when reports are not signed.
+func SigningKey() []byte {
+	if key := os.Getenv(SigningKeyEnv); key != "" {
+		return []byte(key)
+	}
+	return nil
+}
+
+// Sign computes the signature of a JSON document: the hex-encoded HMAC-SHA256 of its
+// canonical form, that is the document without its top-level "signature" field, with object
+// keys sorted, no insignificant whitespace and no HTML escaping. Numbers keep their encoded
+// form, so a consumer in any language can rebuild the signed bytes from the received JSON.
+//
+// Parameters:
+//   - key: Signing key (see SigningKey)
+//   - v: Document to sign; it must encode to a JSON object
+//
+// Returns:
+//   - string: Hex-encoded signature
+//   - error: Error if v cannot be encoded to a JSON object
+//
+// Example:
+//
+//	signature, err := types.Sign(types.SigningKey(), envelope)
+func Sign(key []byte, v any) (string, error) {
+	document, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	canonical, _, err := canonicalDocument(document)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(canonical)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Verify reports whether the "signature" field of a signed JSON document matches its content.
+// Any field changed, added or removed after signing makes the verification fail.
+//
+// Parameters:
+//   - key: Key the document was signed with
+//   - document: Received JSON object
+//
+// Returns:
+//   - bool: true if the document carries a valid signature
+//   - error: Error if the document is not a JSON object
+//
+// Example:
+//
+//	valid, err := types.Verify(key, requestBody)
+//	if err != nil || !valid {
+//	    // reject the submission
+//	}
+func Verify(key []byte, document []byte) (bool, error) {
+	canonical, signature, err := canonicalDocument(document)
+	if err != nil {
+		return false, err
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || signature == "" {
+		return false, nil
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(canonical)
+	return hmac.Equal(mac.Sum(nil), expected), nil
+}
+
+// canonicalDocument returns the canonical form of a JSON object (see Sign) and the value of
+// its "signature" field.
+func canonicalDocument(document []byte) ([]byte, string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, "", err
+	}
+	if fields == nil {
+		return nil, "", errors.New("signed document is not a JSON object")
+	}
+	signature, _ := fields[signatureKey].(string)
+	delete(fields, signatureKey)
+
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(fields); err != nil {
+		return nil, "", err
+	}
+	return bytes.TrimSuffix(canonical.Bytes(), []byte("\n")), signature, nil
+}
+
+// Sign sets the Signature of the wrapper (see Sign), replacing a previous one.
+//
+// Parameters:
+//   - key: Signing key (see SigningKey)
+//
+// Returns:
+//   - error: Error if the wrapper cannot be encoded
+func (w *TestResultWrapper) Sign(key []byte) error {
+	w.Signature = ""
+	signature, err := Sign(key, w)
+	if err != nil {
+		return err
+	}
+	w.Signature = signature
+	return nil
+}
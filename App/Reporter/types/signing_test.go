@@ -0,0 +1,86 @@
+package types
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSign_VerifiesSignedReport(t *testing.T) {
+	key := []byte("compliance-key")
+	wrapper := TestResultWrapper{
+		Target:     "https://example.com",
+		TestId:     "task-1",
+		ScanId:     "scan-1",
+		FindingID:  "f-123",
+		ResultType: Success,
+		Result: Tests.TestResult{
+			TestId:      "hsts",
+			Name:        "HSTS <Header> Analysis",
+			ThreatLevel: Tests.Medium,
+			Certainty:   95,
+			Description: "max-age is 0.5 days & too short",
+			Metadata:    map[string]any{"max_age": 43200, "ratio": 0.125},
+		},
+	}
+	if err := wrapper.Sign(key); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if len(wrapper.Signature) != 64 {
+		t.Fatalf("Expected a hex HMAC-SHA256 signature, got %q", wrapper.Signature)
+	}
+	report, err := json.Marshal(wrapper)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if valid, err := Verify(key, report); err != nil || !valid {
+		t.Fatalf("Expected the signed report to verify, got %v (%v)", valid, err)
+	}
+	if valid, _ := Verify([]byte("other-key"), report); valid {
+		t.Errorf("Expected the report not to verify with another key")
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(fields map[string]any)
+	}{
+		{"Threat level lowered", func(fields map[string]any) {
+			fields["result"].(map[string]any)["ThreatLevel"] = json.Number("0")
+		}},
+		{"Description changed", func(fields map[string]any) {
+			fields["result"].(map[string]any)["Description"] = "all good"
+		}},
+		{"Metadata changed", func(fields map[string]any) {
+			fields["result"].(map[string]any)["Metadata"].(map[string]any)["max_age"] = json.Number("31536000")
+		}},
+		{"Field added", func(fields map[string]any) {
+			fields["status"] = "resolved"
+		}},
+		{"Signature removed", func(fields map[string]any) {
+			delete(fields, "signature")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := json.NewDecoder(strings.NewReader(string(report)))
+			decoder.UseNumber()
+			var fields map[string]any
+			if err := decoder.Decode(&fields); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			tt.mutate(fields)
+			tampered, _ := json.Marshal(fields)
+			if valid, err := Verify(key, tampered); err != nil || valid {
+				t.Errorf("Expected the tampered report to fail verification, got %v (%v)", valid, err)
+			}
+		})
+	}
+}
+
+func TestVerify_RejectsNonObject(t *testing.T) {
+	if _, err := Verify([]byte("key"), []byte(`["not", "an", "object"]`)); err == nil {
+		t.Errorf("Expected an error for a document that is not a JSON object")
+	}
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// DescriptionTemplate rewrites finding descriptions through a user-supplied Go text/template
+// (--description-template), so consumers can localize or reformat findings without changing
+// test code. The template is executed with a DescriptionData value, giving access to every
+// TestResult field (e.g., {{.TestId}}, {{.ThreatLevel}}, {{.Description}}) and the target.
+type DescriptionTemplate struct {
+	tmpl *template.Template
+}
+
+// DescriptionData is the value a DescriptionTemplate is executed with.
+type DescriptionData struct {
+	Tests.TestResult
+	Target string // Target the finding was reported for
+}
+
+// ParseDescriptionTemplate compiles a description template.
+//
+// Parameters:
+//   - text: Go text/template source, e.g. "[{{.TestId}}] {{.Description}}"
+//
+// Returns:
+//   - *DescriptionTemplate: Compiled template ready to render descriptions
+//   - error: Template syntax error
+//
+// Example:
+//
+//	tmpl, err := ParseDescriptionTemplate("[{{.TestId}}] {{.Description}}")
+func ParseDescriptionTemplate(text string) (*DescriptionTemplate, error) {
+	tmpl, err := template.New("description").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &DescriptionTemplate{tmpl: tmpl}, nil
+}
+
+// Render executes the template for a finding and returns the new description. The output
+// is made safe for every reporter format: control characters (e.g., terminal escape
+// sequences carried by server supplied values) are removed except newlines and tabs, and
+// invalid UTF-8 is replaced, so the CLI prints it verbatim and the backend reporter can
+// encode it as JSON. If the template fails for this finding, the original description is
+// kept so no finding is lost.
+//
+// Parameters:
+//   - result: Finding whose description is rendered
+//   - target: Target the finding was reported for
+//
+// Returns:
+//   - string: Rendered description
+func (t *DescriptionTemplate) Render(result Tests.TestResult, target string) string {
+	var out strings.Builder
+	if err := t.tmpl.Execute(&out, DescriptionData{TestResult: result, Target: target}); err != nil {
+		return result.Description
+	}
+	return sanitizeDescription(out.String())
+}
+
+// sanitizeDescription strips control characters other than newlines and tabs and replaces
+// invalid UTF-8 sequences.
+func sanitizeDescription(text string) string {
+	text = strings.ToValidUTF8(text, "�")
+	return strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
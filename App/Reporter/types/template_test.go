@@ -0,0 +1,44 @@
+package types
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"testing"
+)
+
+func TestDescriptionTemplate_Render(t *testing.T) {
+	result := Tests.TestResult{
+		TestId:      "hsts",
+		ThreatLevel: Tests.Medium,
+		Description: "HSTS header missing",
+	}
+	tests := []struct {
+		name     string
+		template string
+		result   Tests.TestResult
+		want     string
+	}{
+		{name: "Prepends test id", template: "[{{.TestId}}] {{.Description}}", result: result, want: "[hsts] HSTS header missing"},
+		{name: "Threat level and target", template: "{{.Target}}: {{.ThreatLevel}} - {{.Description}}", result: result,
+			want: "example.com: Medium - HSTS header missing"},
+		{name: "Control characters are removed", template: "{{.Description}}",
+			result: Tests.TestResult{Description: "Server: nginx\x1b[31m\nred"}, want: "Server: nginx[31m\nred"},
+		{name: "Failing template keeps description", template: "{{.Description.Missing}}", result: result, want: "HSTS header missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseDescriptionTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("Unexpected parse error: %v", err)
+			}
+			if got := tmpl.Render(tt.result, "example.com"); got != tt.want {
+				t.Errorf("Expected description %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDescriptionTemplate_Invalid(t *testing.T) {
+	if _, err := ParseDescriptionTemplate("{{.TestId"); err == nil {
+		t.Error("Expected a parse error for an unterminated action")
+	}
+}
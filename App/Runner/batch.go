@@ -0,0 +1,122 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/strategy"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// maxConcurrentTargets caps the number of targets scanned at the same time in batch mode,
+// so a large targets file does not open an unbounded number of connections.
+const maxConcurrentTargets = 4
+
+// invalidTargetCode is the RequestInfo code reported for rejected targets file lines.
+const invalidTargetCode = 500
+
+// targetPanicCode is the RequestInfo code reported for a target whose scan panicked with
+// a value other than an Errors.Error (which reports its own code).
+const targetPanicCode = 999
+
+// scanTargets executes the plan's strategies against every target of a batch scan.
+//
+// Rejected targets file lines are published first as RequestInfo results. Targets are then
+// scanned concurrently (at most maxConcurrentTargets at a time) and every result is tagged
+// with the target that produced it before being forwarded to the results channel.
+//
+// A panic raised while scanning a target ends the scan of that target only: it is reported
+// as a RequestInfo result of the target, like a rejected targets file line, and the other
+// targets are scanned as usual.
+//
+// Targets not yet started when the scan context is done are skipped. Every finished target
+// is counted on the scan's progress line.
+//
+// Parameters:
+//   - scanCtx: Scan context passed to the strategies, cancelled at the scan deadline
+//   - execPlan: Execution plan of the scan
+//   - targets: Targets to scan (the plan's Targets, after collapsing apex/www redirects)
+//   - channel: Results channel consumed by the reporter
+//   - progress: Status line of the scan (nil when disabled)
+func (j *jobRunner) scanTargets(scanCtx context.Context, execPlan *execution.Plan, targets []string, channel chan strategy.ResultWrapper, progress *progress) {
+	for _, invalid := range execPlan.InvalidTargets {
+		channel <- strategy.WrapStrategyResult(nil, nil, &strategy.RequestInfo{
+			Message: invalid,
+			Code:    invalidTargetCode,
+		})
+	}
+
+	sem := make(chan struct{}, maxConcurrentTargets)
+	var targetsWg sync.WaitGroup
+
+	for _, target := range targets {
+		sem <- struct{}{}
+		if scanCtx.Err() != nil {
+			<-sem
+			break
+		}
+		targetsWg.Add(1)
+		go func(target string) {
+			defer targetsWg.Done()
+			defer func() { <-sem }()
+			defer progress.targetFinished()
+			defer func() {
+				if r := recover(); r != nil {
+					channel <- strategy.WrapStrategyResult(nil, nil, targetPanicInfo(target, r)).WithTarget(target)
+				}
+			}()
+			j.scanTarget(scanCtx, execPlan, target, channel)
+		}(target)
+	}
+	targetsWg.Wait()
+}
+
+// targetPanicInfo converts a panic raised while scanning target into the RequestInfo
+// reported for it.
+func targetPanicInfo(target string, r any) *strategy.RequestInfo {
+	switch err := r.(type) {
+	case Errors.Error:
+		return &strategy.RequestInfo{Message: fmt.Sprintf("Scan of %s failed: %s", target, err.Message), Code: err.Code}
+	case *Errors.Error:
+		return &strategy.RequestInfo{Message: fmt.Sprintf("Scan of %s failed: %s", target, err.Message), Code: err.Code}
+	default:
+		return &strategy.RequestInfo{Message: fmt.Sprintf("Scan of %s failed: %v", target, r), Code: targetPanicCode}
+	}
+}
+
+// scanTarget runs all strategies of the plan against a single target and forwards the
+// results, tagged with the target, to the shared results channel. The results of tests
+// already started are forwarded even when a strategy panics.
+func (j *jobRunner) scanTarget(scanCtx context.Context, execPlan *execution.Plan, target string, channel chan<- strategy.ResultWrapper) {
+	targetChannel := make(chan strategy.ResultWrapper, resultBufferSize)
+	forwarded := make(chan struct{})
+	go func() {
+		defer close(forwarded)
+		for res := range targetChannel {
+			channel <- res.WithTarget(target)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(targetChannel)
+		<-forwarded
+	}()
+	for _, s := range execPlan.Strategies {
+		ctx := execPlan.Contexts[s.GetName()]
+		ctx.Target = target
+		ctx.CVEClient = j.cveClient
+		ctx.DisableCVE = execPlan.NoCVE
+		ctx.CVEYears = execPlan.CVEYears
+		ctx.CVEMinSeverity = execPlan.CVEMinSeverity
+		ctx.Context = scanCtx
+		ctx.ResultCache = j.resultCache
+		ctx.CustomRules = execPlan.CustomRules
+		ctx.IgnoredHeaders = execPlan.IgnoredHeaders
+		ctx.Artifacts = execPlan.Artifacts
+		ctx.ClientOptions = j.clientOptions(ctx.ClientOptions)
+		s.Execute(ctx, targetChannel, &wg, execPlan.AntiBotFlag)
+	}
+}
@@ -0,0 +1,106 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"fmt"
+	"strings"
+)
+
+// Test IDs whose results are correlated by cookieCSPConsistency.
+const (
+	cookieTestId = "cookie-sec"
+	cspTestId    = "csp"
+)
+
+// cookieCSPConsistency correlates the cookie and CSP analyses of every target. Session
+// cookies without HttpOnly are flagged by cookie-sec because injected scripts can steal
+// them; when the same response carries a CSP blocking inline scripts, that theft needs a
+// CSP bypass first. Rather than letting both results read as independent weaknesses, a note
+// putting the cookie finding in context is emitted. It is only used by the result gate's
+// goroutine and needs no locking.
+type cookieCSPConsistency struct {
+	targets []string
+	cookies map[string][]string
+	csp     map[string]bool
+}
+
+// newCookieCSPConsistency creates an empty correlation.
+func newCookieCSPConsistency() *cookieCSPConsistency {
+	return &cookieCSPConsistency{cookies: make(map[string][]string), csp: make(map[string]bool)}
+}
+
+// add records the analysis of a cookie-sec or csp result of target. Other results,
+// suppressed results and results without analysis metadata are ignored.
+func (c *cookieCSPConsistency) add(target string, result *Tests.TestResult) {
+	if result.Suppressed {
+		return
+	}
+	switch result.TestId {
+	case cookieTestId:
+		analysis, ok := result.Metadata.(Tests.CookieSecurityAnalysis)
+		if !ok {
+			return
+		}
+		if names := analysis.ScriptReadableSessionCookies(); len(names) > 0 {
+			c.track(target)
+			c.cookies[target] = names
+		}
+	case cspTestId:
+		analysis, ok := result.Metadata.(Tests.CSPAnalysis)
+		if !ok {
+			return
+		}
+		c.track(target)
+		c.csp[target] = analysis.BlocksInlineScripts()
+	}
+}
+
+// track remembers the order in which targets first reported a correlated result.
+func (c *cookieCSPConsistency) track(target string) {
+	if _, cookies := c.cookies[target]; cookies {
+		return
+	}
+	if _, csp := c.csp[target]; csp {
+		return
+	}
+	c.targets = append(c.targets, target)
+}
+
+// results builds one consistency note per target whose script-readable session cookies are
+// mitigated by a CSP blocking inline scripts. Results of a single-target scan carry no target.
+func (c *cookieCSPConsistency) results() []strategy.ResultWrapper {
+	var wrappers []strategy.ResultWrapper
+	for _, target := range c.targets {
+		names := c.cookies[target]
+		if len(names) == 0 || !c.csp[target] {
+			continue
+		}
+		wrapper := strategy.WrapStrategyResult(cookieCSPConsistencyResult(names), nil, nil)
+		if target != "" {
+			wrapper = wrapper.WithTarget(target)
+		}
+		wrappers = append(wrappers, wrapper)
+	}
+	return wrappers
+}
+
+// cookieCSPConsistencyResult builds the note explaining how the CSP mitigates the theft of
+// the named cookies. It is informational, so the cookie finding is not counted twice.
+func cookieCSPConsistencyResult(names []string) *Tests.TestResult {
+	return &Tests.TestResult{
+		TestId:      strategy.CookieCSPConsistencyId,
+		Name:        "Cookie and CSP Consistency",
+		Certainty:   80,
+		ThreatLevel: Tests.Info,
+		Metadata: map[string]any{
+			"cookies":              names,
+			"blocks_inline_script": true,
+			"correlated_tests":     []string{cookieTestId, cspTestId},
+		},
+		Description: fmt.Sprintf("Session cookies without HttpOnly (%s) are readable by scripts, but the "+
+			"Content-Security-Policy blocks inline scripts, so stealing them through injected XSS first requires "+
+			"a CSP bypass. The practical risk of the %s finding is reduced by the CSP rather than adding to it; "+
+			"HttpOnly remains the direct fix.", strings.Join(names, ", "), cookieTestId),
+	}
+}
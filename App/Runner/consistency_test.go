@@ -0,0 +1,82 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Reporter"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/strategy"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// responseStrategy runs response tests against a fixed response and publishes their results.
+type responseStrategy struct {
+	MockStrategy
+	response *http.Response
+	tests    []*Tests.ResponseTest
+}
+
+func (s *responseStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	for _, test := range s.tests {
+		result := test.Run(Tests.ResponseTestParams{Response: s.response, DisableCVE: true})
+		channel <- strategy.WrapStrategyResult(&result, nil, nil)
+	}
+}
+
+func TestJobRunner_Orchestrate_CookieCSPConsistency(t *testing.T) {
+	if _, isSet := os.LookupEnv("BACK_URL"); isSet {
+		_ = os.Unsetenv("BACK_URL")
+	}
+	tests := []struct {
+		name     string
+		csp      string
+		wantNote bool
+	}{
+		{name: "Strict CSP mitigates cookie theft", csp: "default-src 'self'; script-src 'self'; object-src 'none'", wantNote: true},
+		{name: "Inline scripts allowed", csp: "default-src 'self'; script-src 'self' 'unsafe-inline'", wantNote: false},
+		{name: "No CSP", wantNote: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Set-Cookie": {"sessionid=3f9a7c1e5b2d4086a1c9e7f3b5d20c48; Secure; SameSite=Strict; Path=/"},
+				},
+				Request: &http.Request{Header: http.Header{}},
+			}
+			if tt.csp != "" {
+				response.Header.Set("Content-Security-Policy", tt.csp)
+			}
+			plan := &execution.Plan{
+				Target: "example.com",
+				Strategies: []strategy.TestStrategy{&responseStrategy{
+					MockStrategy: MockStrategy{Name: "--tests"},
+					response:     response,
+					tests:        []*Tests.ResponseTest{Tests.NewCookieSecurityTest(), Tests.NewCSPTest()},
+				}},
+				Contexts: map[string]strategy.TestContext{"--tests": {Target: "example.com"}},
+			}
+			runner := CreateJobRunner(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+			output := captureStdout(t, func() {
+				runner.Orchestrate(plan, Reporter.NewResolver())
+			})
+
+			_, summary, found := strings.Cut(output, "SUMMARY")
+			if !found {
+				t.Fatalf("Expected a summary, got %q", output)
+			}
+			note := strings.Contains(summary, "Note: Session cookies without HttpOnly (sessionid)") &&
+				strings.Contains(summary, "blocks inline scripts")
+			if note != tt.wantNote {
+				t.Errorf("Expected note in summary: %v, got summary %q", tt.wantNote, summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"context"
+	"fmt"
+	"time"
+)
+
+// scanContext creates the context shared by every test of a scan. With a positive
+// deadline the context is cancelled once it passes, otherwise it is only cancelled by
+// the returned cancel function.
+func scanContext(deadline time.Duration) (context.Context, context.CancelFunc) {
+	if deadline <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), deadline)
+}
+
+// forwardUntilDone runs produce in a separate goroutine and forwards the results it
+// writes to out until produce returns or ctx is done, whichever happens first.
+//
+// When ctx is done first, the results already queued are forwarded and those of still
+// running tests are discarded: produce keeps
+// writing into its own channel, which is drained in the background until it returns, so
+// out can be closed safely. A panic raised by produce is re-raised in the calling
+// goroutine so it reaches the global error handler, unless the deadline passed before.
+//
+// Parameters:
+//   - ctx: Scan context bounding the forwarding
+//   - produce: Executes the strategies, writing their results into the given channel
+//   - out: Channel consumed by the result gate
+//
+// Returns:
+//   - bool: true if ctx was done before produce returned (the results are partial)
+func forwardUntilDone(ctx context.Context, produce func(chan strategy.ResultWrapper), out chan<- strategy.ResultWrapper) bool {
+	in := make(chan strategy.ResultWrapper, resultBufferSize)
+	var recovered any
+	go func() {
+		defer close(in)
+		defer func() {
+			recovered = recover()
+		}()
+		produce(in)
+	}()
+
+	for {
+		select {
+		case res, ok := <-in:
+			if !ok {
+				if recovered != nil {
+					panic(recovered)
+				}
+				return false
+			}
+			out <- res
+		case <-ctx.Done():
+			// Results already queued were completed in time and are still reported.
+			for queued := len(in); queued > 0; queued-- {
+				res, ok := <-in
+				if !ok {
+					break
+				}
+				out <- res
+			}
+			go func() {
+				for range in {
+					// Results of tests finishing after the deadline are dropped.
+				}
+			}()
+			return true
+		}
+	}
+}
+
+// deadlineWarning builds the scan-wide warning result published when the deadline passed.
+func deadlineWarning(deadline time.Duration) strategy.ResultWrapper {
+	return strategy.WrapStrategyResult(&Tests.TestResult{
+		TestId:      strategy.DeadlineWarningId,
+		Name:        "Scan Deadline",
+		Certainty:   100,
+		ThreatLevel: Tests.Info,
+		Metadata:    map[string]any{"deadline": deadline.String()},
+		Description: fmt.Sprintf("The scan deadline of %s passed before every test finished. "+
+			"Tests still running were cancelled and the reported results are partial.", deadline),
+	}, nil, nil)
+}
@@ -0,0 +1,30 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"fmt"
+)
+
+// failFastError is the cancellation cause of a scan stopped by --fail-fast.
+type failFastError struct {
+	testId string // Test that reported the Critical finding
+}
+
+func (e *failFastError) Error() string {
+	return fmt.Sprintf("critical finding reported by %s, scan stopped (fail-fast)", e.testId)
+}
+
+// failFastWarning builds the scan-wide warning result published when a Critical finding
+// stopped the scan.
+func failFastWarning(testId string) strategy.ResultWrapper {
+	return strategy.WrapStrategyResult(&Tests.TestResult{
+		TestId:      strategy.FailFastWarningId,
+		Name:        "Scan Stopped Early",
+		Certainty:   100,
+		ThreatLevel: Tests.Info,
+		Metadata:    map[string]any{"test_id": testId},
+		Description: fmt.Sprintf("The %s test reported a Critical finding and --fail-fast stopped the scan. "+
+			"Tests still running were cancelled and the reported results are partial.", testId),
+	}, nil, nil)
+}
@@ -0,0 +1,81 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/strategy"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// criticalStrategy reports a Critical finding right away and runs a slow test which
+// finishes after slowDelay unless the scan context is cancelled first.
+type criticalStrategy struct {
+	delayedStrategy
+	slowDelay time.Duration
+}
+
+func (c *criticalStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		channel <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "critical", ThreatLevel: Tests.Critical}, nil, nil)
+	}()
+	go func() {
+		defer wg.Done()
+		select {
+		case <-time.After(c.slowDelay):
+			channel <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "slow", ThreatLevel: Tests.Low}, nil, nil)
+		case <-ctx.Context.Done():
+			c.cancelled <- "slow"
+		}
+	}()
+}
+
+func TestJobRunner_Orchestrate_FailFast(t *testing.T) {
+	newPlan := func(failFast bool, s strategy.TestStrategy) *execution.Plan {
+		return &execution.Plan{
+			Target:     "example.com",
+			Strategies: []strategy.TestStrategy{s},
+			Contexts:   map[string]strategy.TestContext{"--tests": {Target: "example.com"}},
+			FailFast:   failFast,
+		}
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Critical finding cancels slow tests", func(t *testing.T) {
+		s := &criticalStrategy{delayedStrategy: delayedStrategy{cancelled: make(chan string, 1)}, slowDelay: 10 * time.Second}
+		resolver := &testIdResolver{}
+
+		start := time.Now()
+		CreateJobRunner(WithLogger(logger)).Orchestrate(newPlan(true, s), resolver)
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Fatalf("Expected the scan to stop at the Critical finding, it took %v", elapsed)
+		}
+
+		want := []string{"critical", strategy.FailFastWarningId}
+		if !reflect.DeepEqual(resolver.testIds, want) {
+			t.Errorf("Expected partial results %v, got %v", want, resolver.testIds)
+		}
+		select {
+		case <-s.cancelled:
+		case <-time.After(time.Second):
+			t.Error("Expected the slow test to observe the cancelled scan context")
+		}
+	})
+
+	t.Run("Without fail-fast every test finishes", func(t *testing.T) {
+		s := &criticalStrategy{delayedStrategy: delayedStrategy{cancelled: make(chan string, 1)}, slowDelay: 50 * time.Millisecond}
+		resolver := &testIdResolver{}
+
+		CreateJobRunner(WithLogger(logger)).Orchestrate(newPlan(false, s), resolver)
+
+		if want := []string{"critical", "slow"}; !reflect.DeepEqual(resolver.testIds, want) {
+			t.Errorf("Expected results %v, got %v", want, resolver.testIds)
+		}
+	})
+}
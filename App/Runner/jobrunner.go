@@ -20,13 +20,26 @@
 package Runner
 
 import (
+	"Engine-AntiGinx/App/BuildInfo"
+	"Engine-AntiGinx/App/CVE"
 	error "Engine-AntiGinx/App/Errors"
+	HttpClient "Engine-AntiGinx/App/HTTP"
 	"Engine-AntiGinx/App/Reporter"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution"
 	"Engine-AntiGinx/App/execution/strategy"
-	"fmt"
-	//"os"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
 	"sync"
+	"time"
 )
 
 // jobRunner is the central orchestrator responsible for coordinating the entire test execution
@@ -43,14 +56,95 @@ import (
 // The runner uses a fan-out concurrency pattern where a single HTTP response is shared
 // among multiple test worker goroutines, enabling efficient parallel processing without
 // redundant HTTP requests.
-type jobRunner struct{}
+//
+// The runner also owns the CVE client shared by every test it executes, so vulnerability
+// lookups are cached and rate limited in one place instead of per test, and the logger
+// whose records of every scan carry the scan's correlation ID ("scan_id"). An optional
+// result cache lets pure header tests reuse results across responses with identical headers,
+// and an optional progress output receives a status line while a scan runs. Scans with
+// --include-www probe targets for apex/www redirects through the redirect probe client. An
+// optional shared transport lets consecutive scans reuse one HTTP connection pool, and the
+// overflow policy decides whether results wait for a slow reporter or are dropped.
+type jobRunner struct {
+	cveClient           *CVE.CVEClient
+	logger              *slog.Logger
+	resultCache         *Tests.ResultCache
+	progressOutput      io.Writer
+	redirectProbeClient *http.Client
+	sharedTransport     *http.Transport
+	overflowPolicy      OverflowPolicy
+}
+
+// RunnerOption is a functional option type for configuring a jobRunner.
+type RunnerOption func(*jobRunner)
+
+// WithCVEClient injects the CVE client shared by every test of the runner's scans,
+// e.g. one pointed at an NVD mirror or shared between several runners.
+func WithCVEClient(client *CVE.CVEClient) RunnerOption {
+	return func(j *jobRunner) {
+		j.cveClient = client
+	}
+}
+
+// WithLogger sets the structured logger used for the runner's log records. Every record
+// of a scan carries its correlation ID in the "scan_id" attribute.
+func WithLogger(logger *slog.Logger) RunnerOption {
+	return func(j *jobRunner) {
+		j.logger = logger
+	}
+}
+
+// WithResultCache enables reuse of pure header test results (see Tests.ResultCache) for
+// every scan of the runner, e.g. many near-identical pages of a targets file served by
+// the same CDN configuration. Without it every test runs for every response.
+func WithResultCache(cache *Tests.ResultCache) RunnerOption {
+	return func(j *jobRunner) {
+		j.resultCache = cache
+	}
+}
+
+// WithProgress draws a status line with the completed targets and the last reported test
+// to out while a scan runs, and erases it when the scan finishes. Pass
+// TerminalOutput(os.Stderr) so the line never ends up in redirected output or on stdout,
+// where the reports are written. A nil out disables the status line (the default).
+func WithProgress(out io.Writer) RunnerOption {
+	return func(j *jobRunner) {
+		j.progressOutput = out
+	}
+}
+
+// WithRedirectProbeClient sets the client used by --include-www scans to check whether an
+// apex target and its www variant redirect to each other, e.g. one with a custom transport
+// or proxy. Redirects are never followed, whatever the client's CheckRedirect. Without it
+// a default client with a 10 second timeout is used.
+func WithRedirectProbeClient(client *http.Client) RunnerOption {
+	return func(j *jobRunner) {
+		j.redirectProbeClient = client
+	}
+}
+
+// WithSharedTransport makes every scan of the runner fetch its targets through transport
+// (see HttpClient.WithSharedTransport), so long-running engines such as the stdin task
+// stream keep one bounded connection pool instead of opening new connections for every
+// scan. Create it once with HttpClient.NewTransport.
+func WithSharedTransport(transport *http.Transport) RunnerOption {
+	return func(j *jobRunner) {
+		j.sharedTransport = transport
+	}
+}
 
 // CreateJobRunner initializes and returns a new instance of jobRunner ready to orchestrate
 // test execution. This factory function provides the entry point for creating the main
 // application controller.
 //
-// The returned runner is stateless and can be used to orchestrate multiple test execution
-// sessions if needed, though typically only one instance is created per application run.
+// The returned runner can be used to orchestrate multiple test execution sessions if
+// needed, though typically only one instance is created per application run. Unless
+// WithCVEClient is given, a new CVE client is created and shared by all of its scans.
+// Without WithLogger, records are written as text to stderr, so that reports written to
+// stdout (--format junit or html without --output) stay parseable.
+//
+// Parameters:
+//   - opts: Optional runner settings (e.g., WithCVEClient)
 //
 // Returns:
 //   - *jobRunner: A new runner instance ready to call Orchestrate()
@@ -59,8 +153,18 @@ type jobRunner struct{}
 //
 //	runner := CreateJobRunner()
 //	runner.Orchestrate(execPlan)
-func CreateJobRunner() *jobRunner {
-	return &jobRunner{}
+func CreateJobRunner(opts ...RunnerOption) *jobRunner {
+	runner := &jobRunner{}
+	for _, opt := range opts {
+		opt(runner)
+	}
+	if runner.cveClient == nil {
+		runner.cveClient = CVE.NewCVEClient()
+	}
+	if runner.logger == nil {
+		runner.logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+	}
+	return runner
 }
 
 // Orchestrate is the main execution method that coordinates all components to perform
@@ -90,12 +194,39 @@ func CreateJobRunner() *jobRunner {
 //     - Iterates through the ordered list of strategies in the Plan.
 //     - Triggers the Execute method for each strategy, passing the specific context,
 //     result channel, and synchronization primitives.
+//     - In batch mode (Plan.Targets set) the strategies run once per target, at most
+//     maxConcurrentTargets targets at a time, and results are tagged with their target.
 //
 //  6. Graceful Shutdown:
 //     - Blocks until all strategy-level goroutines signal completion (wg.Wait).
 //     - Closes the result channel to signal the reporter that no more data is coming.
 //     - Blocks until the reporter processes remaining results and closes the doneChannel.
-//     - Reports any failed uploads (e.g., network issues during backend reporting) to Stderr.
+//     - Logs any failed uploads (e.g., network issues during backend reporting).
+//
+//  7. Exit Code:
+//     - Results pass through a gate marking findings listed in the plan's Suppressions.
+//     - If an unsuppressed finding reaches the plan's SeverityThreshold, FindingsExitCode is returned.
+//
+//  8. Deadline:
+//     - With a plan Deadline, the scan context shared by every test is cancelled once it
+//     passes. Results produced so far are reported, later ones are discarded and a
+//     scan-wide warning result (strategy.DeadlineWarningId) marks the scan as timed out.
+//
+//     - With the plan's FailFast, the first unsuppressed Critical finding cancels the scan
+//     context in the same way; a warning result (strategy.FailFastWarningId) marks the
+//     results as partial.
+//
+//  9. Correlation:
+//     - Every result and log record is tagged with the plan's ScanId, or a generated ID
+//     when the plan has none.
+//
+//  10. Progress:
+//     - With WithProgress, a status line of completed targets and the last reported test
+//     is redrawn for every result and erased before Orchestrate returns.
+//
+//  11. Apex and www variants:
+//     - With the plan's IncludeWWW, an apex/www pair of targets where one redirects to the
+//     other is collapsed into the redirect destination before the batch scan starts.
 //
 // Concurrency Architecture:
 //   - Producer-Consumer: Test strategies (producers) feed results into a shared buffered channel.
@@ -108,9 +239,13 @@ func CreateJobRunner() *jobRunner {
 // Parameters:
 //   - execPlan: A pre-formatted execution plan containing the target, taskId, and strategies.
 //
+// Returns:
+//   - int: Process exit code, 0 on success or FindingsExitCode when the severity threshold is breached.
+//
 // Panics:
 //   - error.Error (Code 100): No tests found in the execution plan.
 //   - error.Error (Code 101): BACK_URL is set, but TaskId is missing or empty.
+//   - error.Error (Code 102): BACK_URL is set, but results may be dropped (OverflowDrop).
 //
 // Example:
 //
@@ -121,7 +256,8 @@ func CreateJobRunner() *jobRunner {
 //	    TaskId: "uuid-123",
 //	}
 //	runner.Orchestrate(plan)
-func (j *jobRunner) Orchestrate(execPlan *execution.Plan, repResolver Reporter.Resolver) {
+func (j *jobRunner) Orchestrate(execPlan *execution.Plan, repResolver Reporter.Resolver) int {
+	scanStarted := time.Now()
 	target := execPlan.Target
 	contexts := execPlan.Contexts
 	flag := execPlan.AntiBotFlag
@@ -148,28 +284,127 @@ func (j *jobRunner) Orchestrate(execPlan *execution.Plan, repResolver Reporter.R
 		})
 	}
 
+	overflow := j.scanOverflowPolicy(execPlan)
+
+	scanId := execPlan.ScanId
+	if scanId == "" {
+		scanId = newScanId()
+	}
+	logger := j.logger.With("scan_id", scanId)
+	logger.Debug("scan started", "target", target, "strategies", len(strategies))
+
 	// Create a buffered channel to prevent blocking test execution if the reporter is slow.
+	// Once the reporter falls further behind, the overflow policy applies.
 	var wg sync.WaitGroup
-	channel := make(chan strategy.ResultWrapper, 100)
+	channel := make(chan strategy.ResultWrapper, resultBufferSize)
+	reporterChannel := make(chan strategy.ResultWrapper, resultBufferSize)
 
 	// Determine which reporter to use based on environment configuration.
-	reporter := repResolver.Resolve(channel, execPlan.TaskId, target,
+	reporter := repResolver.Resolve(reporterChannel, execPlan.TaskId, target,
 		5, 2, strategies)
+	if receiver, ok := reporter.(Reporter.MetadataReceiver); ok {
+		receiver.SetReportMetadata(reportMetadata(execPlan, scanStarted))
+	}
+
+	// Route results through the gate which applies suppressions and the severity threshold.
+	gate := newResultGate(target, scanId, execPlan.SeverityThreshold, execPlan.Suppressions, execPlan.MetadataLevel,
+		execPlan.Lang, execPlan.DescriptionTemplate)
+
+	// The scan context is cancelled at the deadline, abandoning outstanding tests.
+	scanCtx, cancel := scanContext(execPlan.Deadline)
+	defer cancel()
+	if execPlan.FailFast {
+		// The gate cancels the scan context on the first Critical finding.
+		var stop context.CancelCauseFunc
+		scanCtx, stop = context.WithCancelCause(scanCtx)
+		defer stop(nil)
+		gate.failFast = func(testId string) { stop(&failFastError{testId: testId}) }
+	}
+	targets := execPlan.Targets
+	if execPlan.IncludeWWW {
+		targets = j.collapseWWWRedirects(scanCtx, targets, logger)
+	}
+	totalTargets := max(len(targets), 1)
+	gate.progress = newProgress(j.progressOutput, totalTargets)
+	gate.overflow = overflow
+	gateDone := gate.forward(channel, reporterChannel)
 
 	// Start the reporter in a separate goroutine.
 	// doneChannel will receive a signal (count of failed uploads) when reporting is finished.
 	doneChannel := reporter.StartListening()
 
-	for _, val := range strategies {
-		val.Execute(contexts[val.GetName()], channel, &wg, flag)
+	timedOut := forwardUntilDone(scanCtx, func(results chan strategy.ResultWrapper) {
+		if len(targets) > 0 {
+			// Batch mode: every target is scanned with the same strategies.
+			j.scanTargets(scanCtx, execPlan, targets, results, gate.progress)
+			return
+		}
+		for _, val := range strategies {
+			ctx := contexts[val.GetName()]
+			ctx.CVEClient = j.cveClient
+			ctx.DisableCVE = execPlan.NoCVE
+			ctx.CVEYears = execPlan.CVEYears
+			ctx.CVEMinSeverity = execPlan.CVEMinSeverity
+			ctx.Context = scanCtx
+			ctx.ResultCache = j.resultCache
+			ctx.CustomRules = execPlan.CustomRules
+			ctx.IgnoredHeaders = execPlan.IgnoredHeaders
+			ctx.Artifacts = execPlan.Artifacts
+			ctx.ClientOptions = j.clientOptions(ctx.ClientOptions)
+			val.Execute(ctx, results, &wg, flag)
+		}
+		// Wait for all test goroutines to finish producing results.
+		wg.Wait()
+		gate.progress.targetFinished()
+	}, channel)
+	var stoppedEarly *failFastError
+	switch {
+	case timedOut && errors.As(context.Cause(scanCtx), &stoppedEarly):
+		logger.Warn("critical finding, scan stopped early", "test_id", stoppedEarly.testId)
+		channel <- failFastWarning(stoppedEarly.testId)
+	case timedOut:
+		logger.Warn("scan deadline exceeded, reporting partial results", "deadline", execPlan.Deadline)
+		channel <- deadlineWarning(execPlan.Deadline)
 	}
-	// Wait for all test goroutines to finish producing results.
-	wg.Wait()
 	close(channel)
+	<-gateDone
+	gate.progress.finish()
+	if gate.dropped > 0 {
+		logger.Warn("reporter fell behind, results dropped", "dropped", gate.dropped)
+	}
 
 	// Block until the reporter processes all remaining items and shuts down.
 	failedUploads := <-doneChannel
 	if failedUploads > 0 {
-		fmt.Printf("Engine failed to send %d requests", failedUploads)
+		logger.Warn("engine failed to send results", "failed_uploads", failedUploads)
+	}
+	exitCode := gate.exitCode()
+	logger.Debug("scan finished", "exit_code", exitCode, "duration", time.Since(scanStarted))
+	return exitCode
+}
+
+// clientOptions returns the HTTP client options of a strategy context extended with the
+// runner's shared transport. The slice is copied, as contexts share their options.
+func (j *jobRunner) clientOptions(opts []HttpClient.WrapperOption) []HttpClient.WrapperOption {
+	if j.sharedTransport == nil {
+		return opts
 	}
+	return append(slices.Clone(opts), HttpClient.WithSharedTransport(j.sharedTransport))
+}
+
+// newScanId generates a random correlation ID for a scan started without a task ID.
+func newScanId() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// reportMetadata builds the report header of a scan: the user-supplied fields of the plan
+// completed with the target, the scan start time and the scanner version.
+func reportMetadata(execPlan *execution.Plan, scanTime time.Time) types.ReportMetadata {
+	metadata := execPlan.Report
+	metadata.Target = execPlan.Target
+	metadata.ScanTime = scanTime
+	metadata.ScannerVersion = BuildInfo.Version
+	return metadata
 }
@@ -1,10 +1,28 @@
 package Runner
 
 import (
+	"Engine-AntiGinx/App/CVE"
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Locale"
+	"Engine-AntiGinx/App/Reporter"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Suppression"
+	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution"
 	"Engine-AntiGinx/App/execution/strategy"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // Will be used when a factory pattern appears in project
@@ -101,3 +119,674 @@ func TestJobRunner_Orchestrate(t *testing.T) {
 	}
 
 }
+
+func TestJobRunner_Orchestrate_ExitCode(t *testing.T) {
+	if _, isSet := os.LookupEnv("BACK_URL"); isSet {
+		_ = os.Unsetenv("BACK_URL")
+	}
+	high := Tests.High
+	critical := Tests.Critical
+	suppressions, err := Suppression.Parse([]byte(`[{"target": "example.com", "testId": "hsts"}]`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		threshold    *Tests.ThreatLevel
+		suppressions *Suppression.List
+		expectedCode int
+	}{
+		{name: "No threshold", threshold: nil, expectedCode: 0},
+		{name: "High finding reaches threshold", threshold: &high, expectedCode: FindingsExitCode},
+		{name: "High finding below threshold", threshold: &critical, expectedCode: 0},
+		{name: "Suppressed high finding", threshold: &high, suppressions: suppressions, expectedCode: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &execution.Plan{
+				Target:     "https://example.com",
+				Strategies: []strategy.TestStrategy{&MockStrategy{Name: "--tests", TestId: "hsts", ThreatLevel: Tests.High}},
+				Contexts: map[string]strategy.TestContext{
+					"--tests": {Target: "https://example.com", Args: []string{"hsts"}},
+				},
+				SeverityThreshold: tt.threshold,
+				Suppressions:      tt.suppressions,
+			}
+
+			code := CreateJobRunner().Orchestrate(plan, &MockResolver{})
+			if code != tt.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", tt.expectedCode, code)
+			}
+		})
+	}
+}
+
+// capturingResolver resolves a reporter which records the target of every result.
+type capturingResolver struct {
+	mu      sync.Mutex
+	targets map[string]int
+	infos   int
+}
+
+func (c *capturingResolver) Resolve(ch chan strategy.ResultWrapper, taskId string,
+	target string, clientTimeOut int, retryDelay int, strategies []strategy.TestStrategy) Reporter.Reporter {
+	return &capturingReporter{ch: ch, resolver: c}
+}
+
+type capturingReporter struct {
+	ch       chan strategy.ResultWrapper
+	resolver *capturingResolver
+}
+
+func (c *capturingReporter) StartListening() <-chan int {
+	done := make(chan int)
+	go func() {
+		for res := range c.ch {
+			c.resolver.mu.Lock()
+			if ok, _ := res.GetReqInfo(); ok {
+				c.resolver.infos++
+			} else {
+				c.resolver.targets[res.GetTarget()]++
+			}
+			c.resolver.mu.Unlock()
+		}
+		done <- 0
+	}()
+	return done
+}
+
+func TestJobRunner_Orchestrate_Targets(t *testing.T) {
+	if _, isSet := os.LookupEnv("BACK_URL"); isSet {
+		_ = os.Unsetenv("BACK_URL")
+	}
+	plan := &execution.Plan{
+		Target:     "example.com",
+		Strategies: []strategy.TestStrategy{&MockStrategy{Name: "--tests"}},
+		Contexts: map[string]strategy.TestContext{
+			"--tests": {Target: "example.com", Args: []string{"hsts"}},
+		},
+		Targets:        []string{"example.com", "example.org"},
+		InvalidTargets: []string{`Invalid target on line 3 of targets file: "not a target"`},
+	}
+	resolver := &capturingResolver{targets: map[string]int{}}
+
+	CreateJobRunner().Orchestrate(plan, resolver)
+
+	if resolver.targets["example.com"] != 1 || resolver.targets["example.org"] != 1 {
+		t.Errorf("Expected one result tagged with each target, got %v", resolver.targets)
+	}
+	if resolver.infos != 1 {
+		t.Errorf("Expected invalid target to be reported once, got %d", resolver.infos)
+	}
+}
+
+// panickingStrategy panics while scanning the target named panicTarget.
+type panickingStrategy struct {
+	MockStrategy
+	panicTarget string
+}
+
+func (p *panickingStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	if ctx.Target == p.panicTarget {
+		panic("strategy failed")
+	}
+	p.MockStrategy.Execute(ctx, channel, wg, antiBotFlag)
+}
+
+func TestJobRunner_Orchestrate_TargetPanic(t *testing.T) {
+	if _, isSet := os.LookupEnv("BACK_URL"); isSet {
+		_ = os.Unsetenv("BACK_URL")
+	}
+	plan := &execution.Plan{
+		Target:     "example.com",
+		Strategies: []strategy.TestStrategy{&panickingStrategy{MockStrategy: MockStrategy{Name: "--tests"}, panicTarget: "broken.example"}},
+		Contexts: map[string]strategy.TestContext{
+			"--tests": {Target: "example.com", Args: []string{"hsts"}},
+		},
+		Targets: []string{"example.com", "broken.example", "example.org"},
+	}
+	resolver := &capturingResolver{targets: map[string]int{}}
+
+	CreateJobRunner(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))).Orchestrate(plan, resolver)
+
+	if resolver.targets["example.com"] != 1 || resolver.targets["example.org"] != 1 {
+		t.Errorf("Expected the other targets to be scanned, got %v", resolver.targets)
+	}
+	if resolver.infos != 1 {
+		t.Errorf("Expected the failed target to be reported once, got %d", resolver.infos)
+	}
+
+	info := targetPanicInfo("broken.example", Errors.Error{Code: 100, Message: "boom"})
+	if info.Code != 100 || info.Message != "Scan of broken.example failed: boom" {
+		t.Errorf("Expected the code and message of the Errors.Error, got %+v", info)
+	}
+}
+
+// cveRecordingStrategy records the CVE client it receives through its context.
+type cveRecordingStrategy struct {
+	MockStrategy
+	mu      *sync.Mutex
+	clients *[]*CVE.CVEClient
+}
+
+func (c *cveRecordingStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	c.mu.Lock()
+	*c.clients = append(*c.clients, ctx.CVEClient)
+	c.mu.Unlock()
+	c.MockStrategy.Execute(ctx, channel, wg, antiBotFlag)
+}
+
+func TestJobRunner_Orchestrate_SharedCVEClient(t *testing.T) {
+	var mu sync.Mutex
+	var clients []*CVE.CVEClient
+	plan := &execution.Plan{
+		Target: "example.com",
+		Strategies: []strategy.TestStrategy{
+			&cveRecordingStrategy{MockStrategy: MockStrategy{Name: "--tests"}, mu: &mu, clients: &clients},
+			&cveRecordingStrategy{MockStrategy: MockStrategy{Name: "--all"}, mu: &mu, clients: &clients},
+		},
+		Contexts: map[string]strategy.TestContext{
+			"--tests": {Target: "example.com", Args: []string{"serv-h-a"}},
+			"--all":   {Target: "example.com"},
+		},
+	}
+	shared := CVE.NewCVEClient()
+
+	CreateJobRunner(WithCVEClient(shared)).Orchestrate(plan, &MockResolver{})
+	plan.Targets = []string{"example.com", "example.org"}
+	CreateJobRunner(WithCVEClient(shared)).Orchestrate(plan, &MockResolver{})
+
+	if len(clients) != 6 {
+		t.Fatalf("Expected 6 strategy executions, got %d", len(clients))
+	}
+	for i, client := range clients {
+		if client != shared {
+			t.Errorf("Execution %d received %p, expected the injected client %p", i, client, shared)
+		}
+	}
+}
+
+func TestCreateJobRunner_DefaultCVEClient(t *testing.T) {
+	if CreateJobRunner().cveClient == nil {
+		t.Error("Expected runner to create a CVE client when none is injected")
+	}
+}
+
+func TestCreateJobRunner_DefaultLoggerKeepsStdoutClean(t *testing.T) {
+	stdout := captureStdout(t, func() {
+		CreateJobRunner().logger.Info("scan started")
+	})
+	if stdout != "" {
+		t.Errorf("Expected log records to stay off stdout, where reports are written, got %q", stdout)
+	}
+}
+
+func TestResultGate_MetadataLevel(t *testing.T) {
+	metadata := map[string]any{"spf_count": 2, "dmarc_policy": "reject", "issues": []string{"multiple SPF records"}}
+	tests := []struct {
+		level    types.MetadataLevel
+		expected any
+	}{
+		{level: types.MetadataNone, expected: nil},
+		{level: types.MetadataSummary, expected: map[string]any{"spf_count": float64(2), "dmarc_policy": "reject"}},
+		{level: types.MetadataFull, expected: metadata},
+		{level: "", expected: metadata},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.level), func(t *testing.T) {
+			in := make(chan strategy.ResultWrapper, 1)
+			out := make(chan strategy.ResultWrapper, 1)
+			in <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "email-dns", Metadata: metadata}, nil, nil)
+			close(in)
+
+			<-newResultGate("example.com", "scan-1", nil, nil, tt.level, "", nil).forward(in, out)
+
+			_, result := (<-out).GetTestResult()
+			if !reflect.DeepEqual(result.Metadata, tt.expected) {
+				t.Errorf("Expected metadata %v, got %v", tt.expected, result.Metadata)
+			}
+		})
+	}
+}
+
+func TestResultGate_Lang(t *testing.T) {
+	hsts := Tests.NewHSTSTest().Run(Tests.ResponseTestParams{Response: &http.Response{Header: http.Header{}}})
+	template, _ := types.ParseDescriptionTemplate("[{{.TestId}}] {{.Description}}")
+
+	in := make(chan strategy.ResultWrapper, 1)
+	out := make(chan strategy.ResultWrapper, 1)
+	in <- strategy.WrapStrategyResult(&hsts, nil, nil)
+	close(in)
+
+	<-newResultGate("example.com", "scan-1", nil, nil, "", Locale.Polish, template).forward(in, out)
+
+	_, result := (<-out).GetTestResult()
+	expected := "[hsts] Brak nagłówka HSTS - witryna podatna na ataki obniżenia protokołu i ataki typu man-in-the-middle"
+	if result.Description != expected {
+		t.Errorf("Expected description %q, got %q", expected, result.Description)
+	}
+	if !strings.HasPrefix(result.Remediation, "Wysyłaj nagłówek") {
+		t.Errorf("Expected Polish remediation, got %q", result.Remediation)
+	}
+}
+
+// scanIdResolver resolves a reporter which records the scan ID of every result.
+type scanIdResolver struct {
+	mu      sync.Mutex
+	scanIds []string
+}
+
+func (s *scanIdResolver) Resolve(ch chan strategy.ResultWrapper, taskId string,
+	target string, clientTimeOut int, retryDelay int, strategies []strategy.TestStrategy) Reporter.Reporter {
+	return &scanIdReporter{ch: ch, resolver: s}
+}
+
+type scanIdReporter struct {
+	ch       chan strategy.ResultWrapper
+	resolver *scanIdResolver
+}
+
+func (s *scanIdReporter) StartListening() <-chan int {
+	done := make(chan int, 1)
+	go func() {
+		for res := range s.ch {
+			s.resolver.mu.Lock()
+			s.resolver.scanIds = append(s.resolver.scanIds, res.GetScanId())
+			s.resolver.mu.Unlock()
+		}
+		done <- 0
+	}()
+	return done
+}
+
+func TestJobRunner_Orchestrate_ScanId(t *testing.T) {
+	newPlan := func(scanId string) *execution.Plan {
+		return &execution.Plan{
+			Target:     "example.com",
+			Strategies: []strategy.TestStrategy{&MockStrategy{Name: "--tests", TestId: "hsts"}},
+			Contexts: map[string]strategy.TestContext{
+				"--tests": {Target: "example.com", Args: []string{"hsts"}},
+			},
+			ScanId: scanId,
+		}
+	}
+
+	t.Run("Task ID is propagated", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		resolver := &scanIdResolver{}
+
+		CreateJobRunner(WithLogger(logger)).Orchestrate(newPlan("task-42"), resolver)
+
+		if len(resolver.scanIds) != 1 || resolver.scanIds[0] != "task-42" {
+			t.Errorf("Expected result wrapper tagged with task-42, got %v", resolver.scanIds)
+		}
+		var record map[string]any
+		line, _, _ := bytes.Cut(logs.Bytes(), []byte("\n"))
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("Expected a JSON log record, got %q: %v", logs.String(), err)
+		}
+		if record["scan_id"] != "task-42" {
+			t.Errorf("Expected log record with scan_id task-42, got %v", record)
+		}
+	})
+
+	t.Run("ID is generated without task", func(t *testing.T) {
+		resolver := &scanIdResolver{}
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		CreateJobRunner(WithLogger(logger)).Orchestrate(newPlan(""), resolver)
+
+		if len(resolver.scanIds) != 1 || resolver.scanIds[0] == "" {
+			t.Errorf("Expected a generated scan ID, got %v", resolver.scanIds)
+		}
+	})
+}
+
+// delayedStrategy produces one result per delay, each after waiting for its delay unless
+// the scan context is cancelled first.
+type delayedStrategy struct {
+	delays    []time.Duration
+	cancelled chan string
+}
+
+func (d *delayedStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	for _, delay := range d.delays {
+		wg.Add(1)
+		go func(testId string, delay time.Duration) {
+			defer wg.Done()
+			select {
+			case <-time.After(delay):
+				channel <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: testId, Name: testId}, nil, nil)
+			case <-ctx.Context.Done():
+				d.cancelled <- testId
+			}
+		}(delay.String(), delay)
+	}
+}
+
+func (d *delayedStrategy) GetName() string {
+	return "--tests"
+}
+
+func (d *delayedStrategy) GetPreferredReporterType() strategy.ReporterType {
+	return strategy.CLIReporter
+}
+
+// testIdResolver resolves a reporter which records the test ID of every result.
+type testIdResolver struct {
+	mu      sync.Mutex
+	testIds []string
+}
+
+func (r *testIdResolver) Resolve(ch chan strategy.ResultWrapper, taskId string,
+	target string, clientTimeOut int, retryDelay int, strategies []strategy.TestStrategy) Reporter.Reporter {
+	return &testIdReporter{ch: ch, resolver: r}
+}
+
+type testIdReporter struct {
+	ch       chan strategy.ResultWrapper
+	resolver *testIdResolver
+}
+
+func (r *testIdReporter) StartListening() <-chan int {
+	done := make(chan int, 1)
+	go func() {
+		for res := range r.ch {
+			if ok, val := res.GetTestResult(); ok {
+				r.resolver.mu.Lock()
+				r.resolver.testIds = append(r.resolver.testIds, val.TestId)
+				r.resolver.mu.Unlock()
+			}
+		}
+		done <- 0
+	}()
+	return done
+}
+
+func TestJobRunner_Orchestrate_Deadline(t *testing.T) {
+	newPlan := func(deadline time.Duration, s strategy.TestStrategy) *execution.Plan {
+		return &execution.Plan{
+			Target:     "example.com",
+			Strategies: []strategy.TestStrategy{s},
+			Contexts:   map[string]strategy.TestContext{"--tests": {Target: "example.com"}},
+			Deadline:   deadline,
+		}
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Deadline cancels slow tests", func(t *testing.T) {
+		slow := &delayedStrategy{delays: []time.Duration{time.Millisecond, 10 * time.Second}, cancelled: make(chan string, 1)}
+		resolver := &testIdResolver{}
+
+		start := time.Now()
+		CreateJobRunner(WithLogger(logger)).Orchestrate(newPlan(100*time.Millisecond, slow), resolver)
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Fatalf("Expected the scan to end at its deadline, it took %v", elapsed)
+		}
+
+		want := []string{"1ms", strategy.DeadlineWarningId}
+		if !reflect.DeepEqual(resolver.testIds, want) {
+			t.Errorf("Expected partial results %v, got %v", want, resolver.testIds)
+		}
+		select {
+		case testId := <-slow.cancelled:
+			if testId != "10s" {
+				t.Errorf("Expected the 10s test to be cancelled, got %s", testId)
+			}
+		case <-time.After(time.Second):
+			t.Error("Expected the slow test to observe the cancelled scan context")
+		}
+	})
+
+	t.Run("Scan finishing in time", func(t *testing.T) {
+		fast := &delayedStrategy{delays: []time.Duration{time.Millisecond}, cancelled: make(chan string, 1)}
+		resolver := &testIdResolver{}
+
+		CreateJobRunner(WithLogger(logger)).Orchestrate(newPlan(10*time.Second, fast), resolver)
+
+		if want := []string{"1ms"}; !reflect.DeepEqual(resolver.testIds, want) {
+			t.Errorf("Expected results %v without deadline warning, got %v", want, resolver.testIds)
+		}
+	})
+}
+
+// gatedStrategy produces a "first" result immediately and a "second" one only after
+// release is closed, or after a timeout (recorded in timedOut).
+type gatedStrategy struct {
+	release  chan struct{}
+	timedOut atomic.Bool
+}
+
+func (g *gatedStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		channel <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "first", ThreatLevel: Tests.High}, nil, nil)
+	}()
+	go func() {
+		defer wg.Done()
+		select {
+		case <-g.release:
+		case <-time.After(5 * time.Second):
+			g.timedOut.Store(true)
+		}
+		channel <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "second", ThreatLevel: Tests.Low}, nil, nil)
+	}()
+}
+
+func (g *gatedStrategy) GetName() string {
+	return "--tests"
+}
+
+func (g *gatedStrategy) GetPreferredReporterType() strategy.ReporterType {
+	return strategy.CLIReporter
+}
+
+func TestJobRunner_Orchestrate_IncrementalBackendDelivery(t *testing.T) {
+	gated := &gatedStrategy{release: make(chan struct{})}
+	var mu sync.Mutex
+	var received []types.TestResultWrapper
+	var releaseOnce sync.Once
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var wrapper types.TestResultWrapper
+		if err := json.NewDecoder(r.Body).Decode(&wrapper); err != nil {
+			t.Errorf("Unexpected body: %v", err)
+		}
+		mu.Lock()
+		received = append(received, wrapper)
+		mu.Unlock()
+		// The second test only finishes once the first result reached the backend.
+		releaseOnce.Do(func() { close(gated.release) })
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+	t.Setenv("BACK_URL", backend.URL)
+	t.Setenv("BACK_PROGRESS", "true")
+
+	plan := &execution.Plan{
+		Target:     "example.com",
+		Strategies: []strategy.TestStrategy{gated},
+		Contexts:   map[string]strategy.TestContext{"--tests": {Target: "example.com"}},
+		TaskId:     "task-1",
+	}
+	CreateJobRunner(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))).Orchestrate(plan, Reporter.NewResolver())
+
+	if gated.timedOut.Load() {
+		t.Fatal("Expected the first result to reach the backend while the second test was still running")
+	}
+	if len(received) != 3 {
+		t.Fatalf("Expected two results and the end flag, got %+v", received)
+	}
+	wantIds := []string{"first", "second", ""}
+	wantCompleted := []int{1, 2, 2}
+	for i, wrapper := range received {
+		if wrapper.Result.TestId != wantIds[i] {
+			t.Errorf("Expected submission %d to be %q, got %q", i, wantIds[i], wrapper.Result.TestId)
+		}
+		if wrapper.Progress == nil || wrapper.Progress.Completed != wantCompleted[i] {
+			t.Errorf("Expected submission %d with %d completed results, got %+v", i, wantCompleted[i], wrapper.Progress)
+		}
+	}
+	if progress := received[0].Progress; progress != nil && (progress.Grade != "D" || progress.Counts["High"] != 1) {
+		t.Errorf("Expected partial summary with grade D and one High finding, got %+v", progress)
+	}
+	if !received[2].EndFlag {
+		t.Error("Expected the end flag after all results")
+	}
+}
+
+// captureStdout returns everything fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+	fn()
+	_ = writer.Close()
+	captured, _ := io.ReadAll(reader)
+	return string(captured)
+}
+
+func TestJobRunner_Orchestrate_Quiet(t *testing.T) {
+	if _, isSet := os.LookupEnv("BACK_URL"); isSet {
+		_ = os.Unsetenv("BACK_URL")
+	}
+	threshold := Tests.High
+	plan := &execution.Plan{
+		Target:            "example.com",
+		Strategies:        []strategy.TestStrategy{&MockStrategy{Name: "--tests", TestId: "hsts", ThreatLevel: Tests.High}},
+		Contexts:          map[string]strategy.TestContext{"--tests": {Target: "example.com", Args: []string{"hsts"}}},
+		SeverityThreshold: &threshold,
+		Quiet:             true,
+	}
+	runner := CreateJobRunner(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	var exitCode int
+	output := captureStdout(t, func() {
+		exitCode = runner.Orchestrate(plan, Reporter.NewResolver(Reporter.WithQuiet(plan.Quiet)))
+	})
+
+	if exitCode != FindingsExitCode {
+		t.Errorf("Expected exit code %d for a High finding, got %d", FindingsExitCode, exitCode)
+	}
+	want := "Grade: D | Critical: 0, High: 1, Medium: 0, Low: 0, Info: 0, None: 0\n"
+	if output != want {
+		t.Errorf("Expected only the summary line %q, got %q", want, output)
+	}
+}
+
+func TestJobRunner_Orchestrate_ScanDuration(t *testing.T) {
+	if _, isSet := os.LookupEnv("BACK_URL"); isSet {
+		_ = os.Unsetenv("BACK_URL")
+	}
+	plan := &execution.Plan{
+		Target:     "example.com",
+		Strategies: []strategy.TestStrategy{&MockStrategy{Name: "--tests", TestId: "hsts", ThreatLevel: Tests.Low}},
+		Contexts:   map[string]strategy.TestContext{"--tests": {Target: "example.com", Args: []string{"hsts"}}},
+	}
+	runner := CreateJobRunner(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	output := captureStdout(t, func() {
+		runner.Orchestrate(plan, Reporter.NewResolver())
+	})
+
+	_, summary, found := strings.Cut(output, "SUMMARY\n")
+	if !found {
+		t.Fatalf("Expected a summary, got:\n%s", output)
+	}
+	var duration time.Duration
+	for _, line := range strings.Split(summary, "\n") {
+		if value, ok := strings.CutPrefix(line, "Duration: "); ok {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				t.Fatalf("Expected a Go duration, got %q: %v", value, err)
+			}
+			duration = parsed
+		}
+	}
+	if duration <= 0 {
+		t.Errorf("Expected a positive overall scan duration in the summary, got:\n%s", summary)
+	}
+}
+
+// fleetStubStrategy reports, for every target it is executed for, the results of its stub.
+type fleetStubStrategy struct {
+	MockStrategy
+	stubs map[string][]Tests.TestResult
+}
+
+func (f *fleetStubStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	wg.Add(1)
+	defer wg.Done()
+	for _, result := range f.stubs[ctx.Target] {
+		result := result
+		channel <- strategy.WrapStrategyResult(&result, nil, nil)
+	}
+}
+
+// cliBufferResolver resolves a CLI reporter writing to out.
+type cliBufferResolver struct {
+	out *bytes.Buffer
+}
+
+func (c *cliBufferResolver) Resolve(ch chan strategy.ResultWrapper, taskId string,
+	target string, clientTimeOut int, retryDelay int, strategies []strategy.TestStrategy) Reporter.Reporter {
+	reporter := Reporter.InitializeCliReporter(ch)
+	reporter.SetOutput(c.out)
+	return reporter
+}
+
+func TestJobRunner_Orchestrate_FleetSummary(t *testing.T) {
+	if _, isSet := os.LookupEnv("BACK_URL"); isSet {
+		_ = os.Unsetenv("BACK_URL")
+	}
+	missingHSTS := Tests.TestResult{TestId: "hsts", Name: "HSTS Header Analysis", ThreatLevel: Tests.Medium}
+	stubs := map[string][]Tests.TestResult{
+		"shop.example.com": {
+			missingHSTS,
+			{TestId: "csp", Name: "Content Security Policy", ThreatLevel: Tests.None},
+		},
+		"legacy.example.com": {
+			missingHSTS,
+			{TestId: "ssl-cert", Name: "SSL Certificate Security", ThreatLevel: Tests.Critical},
+		},
+		"docs.example.com": {
+			{TestId: "hsts", Name: "HSTS Header Analysis", ThreatLevel: Tests.None},
+			{TestId: "csp", Name: "Content Security Policy", ThreatLevel: Tests.Low},
+		},
+	}
+	plan := &execution.Plan{
+		Target:     "shop.example.com",
+		Strategies: []strategy.TestStrategy{&fleetStubStrategy{MockStrategy: MockStrategy{Name: "--tests"}, stubs: stubs}},
+		Contexts: map[string]strategy.TestContext{
+			"--tests": {Target: "shop.example.com", Args: []string{"hsts", "csp", "ssl-cert"}},
+		},
+		Targets: []string{"shop.example.com", "legacy.example.com", "docs.example.com"},
+	}
+	var out bytes.Buffer
+
+	CreateJobRunner().Orchestrate(plan, &cliBufferResolver{out: &out})
+
+	output := out.String()
+	fleet := output[strings.Index(output, "FLEET SUMMARY"):]
+	wantLines := []string{
+		"1. legacy.example.com - Grade: F (Critical: 1, High: 0, Medium: 1, Low: 0)",
+		"2. shop.example.com - Grade: C (Critical: 0, High: 0, Medium: 1, Low: 0)",
+		"3. docs.example.com - Grade: B (Critical: 0, High: 0, Medium: 0, Low: 1)",
+		"- HSTS Header Analysis (hsts), up to Medium: 2 of 3 targets",
+	}
+	last := 0
+	for _, line := range wantLines {
+		index := strings.Index(fleet, line)
+		if index < last {
+			t.Fatalf("Expected %q in order in the fleet summary, got:\n%s", line, fleet)
+		}
+		last = index
+	}
+}
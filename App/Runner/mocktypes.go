@@ -30,16 +30,19 @@ func (mRes *MockResolver) Resolve(ch chan strategy.ResultWrapper, taskId string,
 }
 
 type MockStrategy struct {
-	Name string
+	Name        string
+	TestId      string
+	ThreatLevel Tests.ThreatLevel
 }
 
 func (m *MockStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
 	wg.Add(1)
 	defer wg.Done()
 	testResult := Tests.TestResult{
+		TestId:      m.TestId,
 		Name:        "Mock test",
 		Certainty:   0,
-		ThreatLevel: 0,
+		ThreatLevel: m.ThreatLevel,
 		Metadata:    nil,
 		Description: "Mock test",
 	}
@@ -0,0 +1,78 @@
+package Runner
+
+import (
+	error "Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/strategy"
+	"os"
+)
+
+// resultBufferSize is the capacity of the channels carrying results from the strategies
+// through the result gate to the reporter.
+const resultBufferSize = 100
+
+// OverflowPolicy decides what the result gate does with a test result when the reporter
+// channel is full, i.e. when tests produce results faster than the reporter consumes them.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until the reporter has room for the result (backpressure). Tests
+	// writing results are slowed down to the pace of the reporter and no result is lost.
+	// This is the default and the right choice for the backend reporter, which must
+	// receive every result, and for the CLI reporter, which prints faster than tests run.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDrop discards a test result that does not fit into the full reporter
+	// channel and logs a warning with the number of dropped results at the end of the
+	// scan. Dropped results still count towards the exit code (--severity-threshold).
+	// Messages about untestable targets, the deadline and fail-fast warnings and the
+	// summaries are never dropped. Selected with --drop-on-overflow; scans reporting to
+	// the backend (BACK_URL) reject it.
+	OverflowDrop
+)
+
+// WithOverflowPolicy sets what happens to results when the reporter falls behind (see
+// OverflowPolicy). Without it the runner applies OverflowBlock.
+func WithOverflowPolicy(policy OverflowPolicy) RunnerOption {
+	return func(j *jobRunner) {
+		j.overflowPolicy = policy
+	}
+}
+
+// scanOverflowPolicy returns the overflow policy of a scan: OverflowDrop when the plan
+// asks for it (--drop-on-overflow), otherwise the runner's policy.
+//
+// Panics:
+//   - error.Error (Code 102): The policy is OverflowDrop while BACK_URL is set; the
+//     backend reporter must receive every result.
+func (j *jobRunner) scanOverflowPolicy(execPlan *execution.Plan) OverflowPolicy {
+	policy := j.overflowPolicy
+	if execPlan.DropOnOverflow {
+		policy = OverflowDrop
+	}
+	if _, exists := os.LookupEnv("BACK_URL"); exists && policy == OverflowDrop {
+		panic(error.Error{
+			Code: 102,
+			Message: `Runner error occurred. This could be due to:
+					- Results cannot be dropped while BACK_URL is set, the backend must receive every result`,
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	return policy
+}
+
+// send delivers res to the reporter channel according to the gate's overflow policy.
+// Under OverflowDrop a test result meeting a full channel is counted in dropped instead.
+func (g *resultGate) send(out chan<- strategy.ResultWrapper, res strategy.ResultWrapper) {
+	if ok, val := res.GetTestResult(); ok && val.TestId != strategy.DeadlineWarningId && val.TestId != strategy.FailFastWarningId &&
+		g.overflow == OverflowDrop {
+		select {
+		case out <- res:
+		default:
+			g.dropped++
+		}
+		return
+	}
+	out <- res
+}
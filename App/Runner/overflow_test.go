@@ -0,0 +1,107 @@
+package Runner
+
+import (
+	error "Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/strategy"
+	"testing"
+	"time"
+)
+
+// saturate sends a message and results test results through a gate whose reporter channel
+// holds capacity results and is not read until the gate has finished or blocked.
+func saturate(t *testing.T, policy OverflowPolicy, results, capacity int) (*resultGate, <-chan struct{}, chan strategy.ResultWrapper) {
+	t.Helper()
+	in := make(chan strategy.ResultWrapper, results+1)
+	out := make(chan strategy.ResultWrapper, capacity)
+	in <- strategy.WrapStrategyResult(nil, nil, &strategy.RequestInfo{Message: "unreachable", Code: 101})
+	for i := 0; i < results; i++ {
+		in <- strategy.WrapStrategyResult(&Tests.TestResult{TestId: "hsts", ThreatLevel: Tests.High}, nil, nil)
+	}
+	close(in)
+
+	threshold := Tests.High
+	gate := newResultGate("example.com", "scan-1", &threshold, nil, "", "", nil)
+	gate.overflow = policy
+	return gate, gate.forward(in, out), out
+}
+
+func TestResultGate_OverflowDrop(t *testing.T) {
+	const results, capacity = 10, 3
+	gate, done, out := saturate(t, OverflowDrop, results, capacity)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Gate blocked on the full reporter channel despite OverflowDrop")
+	}
+	// The message takes one slot of the reporter channel.
+	if want := results - (capacity - 1); gate.dropped != want {
+		t.Errorf("Expected %d dropped results, got %d", want, gate.dropped)
+	}
+	if gate.exitCode() != FindingsExitCode {
+		t.Error("Expected dropped findings to still fail the severity threshold")
+	}
+
+	var messages int
+	for res := range out {
+		if ok, _ := res.GetReqInfo(); ok {
+			messages++
+		}
+	}
+	if messages != 1 {
+		t.Errorf("Expected the untestable target message to be kept, got %d", messages)
+	}
+}
+
+func TestResultGate_OverflowBlock(t *testing.T) {
+	const results, capacity = 10, 3
+	gate, done, out := saturate(t, OverflowBlock, results, capacity)
+
+	select {
+	case <-done:
+		t.Fatal("Gate finished while the reporter channel was full, results were not held back")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	delivered := 0
+	for res := range out {
+		if ok, _ := res.GetTestResult(); ok {
+			delivered++
+		}
+	}
+	<-done
+	if delivered != results {
+		t.Errorf("Expected all %d results delivered, got %d", results, delivered)
+	}
+	if gate.dropped != 0 {
+		t.Errorf("Expected no dropped results, got %d", gate.dropped)
+	}
+}
+
+func TestJobRunner_ScanOverflowPolicy(t *testing.T) {
+	t.Setenv("BACK_URL", "")
+	runner := CreateJobRunner()
+	if policy := runner.scanOverflowPolicy(&execution.Plan{}); policy != OverflowBlock {
+		t.Errorf("Expected OverflowBlock by default, got %d", policy)
+	}
+
+	for name, tc := range map[string]struct {
+		runner *jobRunner
+		plan   *execution.Plan
+	}{
+		"plan":   {runner: CreateJobRunner(), plan: &execution.Plan{DropOnOverflow: true}},
+		"runner": {runner: CreateJobRunner(WithOverflowPolicy(OverflowDrop)), plan: &execution.Plan{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(error.Error)
+				if !ok || err.Code != 102 {
+					t.Errorf("Expected OverflowDrop to be rejected with code 102 in backend mode, got %v", err)
+				}
+			}()
+			tc.runner.scanOverflowPolicy(tc.plan)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package Runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// progress renders a single, continuously rewritten status line showing how many targets
+// of a scan are completed and which test reported last, e.g.
+//
+//	[1/3 targets] example.org: hsts
+//
+// It is driven by the runner: the result gate reports every test result and batch scans
+// report every finished target. All methods are safe for concurrent use and do nothing on a
+// nil progress, so scans without a progress output need no checks.
+type progress struct {
+	mu        sync.Mutex
+	out       io.Writer
+	total     int
+	completed int
+	current   string
+}
+
+// newProgress creates the status line of a scan over total targets, or nil when out is nil.
+func newProgress(out io.Writer, total int) *progress {
+	if out == nil {
+		return nil
+	}
+	return &progress{out: out, total: total}
+}
+
+// testFinished records the test that just reported a result and redraws the line.
+func (p *progress) testFinished(target, testId string) {
+	if p == nil || testId == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current = target + ": " + testId
+	p.render()
+}
+
+// targetFinished counts a completed target and redraws the line.
+func (p *progress) targetFinished() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed = min(p.completed+1, p.total)
+	p.render()
+}
+
+// finish erases the status line so it does not remain above the final report.
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = io.WriteString(p.out, "\r\033[K")
+}
+
+// render rewrites the status line in place. The caller holds p.mu.
+func (p *progress) render() {
+	_, _ = fmt.Fprintf(p.out, "\r\033[K[%d/%d targets] %s", p.completed, p.total, p.current)
+}
+
+// TerminalOutput returns file when it is an interactive terminal and nil otherwise, e.g.
+// when it is redirected to a file or a pipe. It is meant for WithProgress, so the status
+// line is only drawn for a user watching the scan.
+//
+// Parameters:
+//   - file: Candidate progress output (typically os.Stderr)
+//
+// Returns:
+//   - io.Writer: file if it is a character device, nil otherwise
+//
+// Example:
+//
+//	runner := CreateJobRunner(WithProgress(TerminalOutput(os.Stderr)))
+func TerminalOutput(file *os.File) io.Writer {
+	if file == nil {
+		return nil
+	}
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	return file
+}
@@ -0,0 +1,70 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/strategy"
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestJobRunner_Orchestrate_Progress(t *testing.T) {
+	plan := &execution.Plan{
+		Target:     "example.com",
+		Strategies: []strategy.TestStrategy{&MockStrategy{Name: "--tests", TestId: "hsts"}},
+		Contexts: map[string]strategy.TestContext{
+			"--tests": {Target: "example.com", Args: []string{"hsts"}},
+		},
+		Targets: []string{"example.com", "example.org"},
+	}
+
+	var stderr bytes.Buffer
+	runner := CreateJobRunner(WithProgress(&stderr), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	stdout := captureStdout(t, func() { runner.Orchestrate(plan, &MockResolver{}) })
+
+	progress := stderr.String()
+	for _, want := range []string{"[2/2 targets]", "example.com: hsts", "example.org: hsts"} {
+		if !strings.Contains(progress, want) {
+			t.Errorf("Expected progress output to contain %q, got %q", want, progress)
+		}
+	}
+	if !strings.HasSuffix(progress, "\r\033[K") {
+		t.Errorf("Expected the status line to be erased at the end, got %q", progress)
+	}
+	if strings.Contains(stdout, "targets]") {
+		t.Errorf("Expected no progress on stdout, got %q", stdout)
+	}
+}
+
+func TestTerminalOutput_Redirected(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	for name, candidate := range map[string]*os.File{"File": file, "Pipe": writer, "Nil": nil} {
+		if out := TerminalOutput(candidate); out != nil {
+			t.Errorf("%s: expected no progress output when redirected, got %v", name, out)
+		}
+	}
+}
+
+func TestProgress_Nil(t *testing.T) {
+	var p *progress
+	p.testFinished("example.com", "hsts")
+	p.targetFinished()
+	p.finish()
+	if newProgress(nil, 1) != nil {
+		t.Error("Expected no progress without an output")
+	}
+}
@@ -0,0 +1,138 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Locale"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Suppression"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+)
+
+// FindingsExitCode is the process exit code returned by Orchestrate when an unsuppressed
+// finding reaches the configured severity threshold. It differs from the exit code 1 used
+// for engine errors so CI pipelines can tell failed scans and failed security gates apart.
+const FindingsExitCode = 2
+
+// resultGate sits between the strategies and the reporter. It marks findings listed in the
+// suppressions file. It tags every result with the scan ID. It trims the metadata of the
+// results to the requested level. It renders descriptions and remediation in the requested
+// language. It rewrites descriptions through the description template. It tracks whether
+// any unsuppressed finding reached the severity threshold. It also merges the technologies
+// detected by all tests into one technology stack summary per target and correlates the
+// cookie and CSP analyses into a consistency note.
+//
+// Fields:
+//   - target: Scanned target used to match suppression entries
+//   - scanId: Correlation ID attached to every forwarded result
+//   - threshold: Minimum threat level failing the scan (nil disables the check)
+//   - suppressions: Accepted findings (nil suppresses nothing)
+//   - metadataLevel: Amount of metadata passed on to the reporter
+//   - lang: Language of descriptions and remediation (empty keeps English)
+//   - descriptionTemplate: Template rewriting every description (nil keeps them unchanged)
+//   - progress: Status line updated with every test result (nil when disabled)
+//   - technologies: Technologies reported by the tests, merged per target
+//   - cookieCSP: Cookie and CSP analyses, correlated per target
+//   - overflow: What to do with test results when the reporter channel is full
+//   - dropped: Test results discarded under OverflowDrop
+//   - breached: Set once an unsuppressed finding reaches the threshold
+//   - failFast: Called with the test ID of every unsuppressed Critical finding (nil when
+//     --fail-fast is off)
+type resultGate struct {
+	target              string
+	scanId              string
+	threshold           *Tests.ThreatLevel
+	suppressions        *Suppression.List
+	metadataLevel       types.MetadataLevel
+	lang                Locale.Lang
+	descriptionTemplate *types.DescriptionTemplate
+	progress            *progress
+	technologies        *technologyInventory
+	cookieCSP           *cookieCSPConsistency
+	overflow            OverflowPolicy
+	dropped             int
+	breached            bool
+	failFast            func(testId string)
+}
+
+// newResultGate creates a gate for a single scan.
+func newResultGate(target, scanId string, threshold *Tests.ThreatLevel, suppressions *Suppression.List,
+	metadataLevel types.MetadataLevel, lang Locale.Lang, descriptionTemplate *types.DescriptionTemplate) *resultGate {
+	return &resultGate{
+		target:              target,
+		scanId:              scanId,
+		threshold:           threshold,
+		suppressions:        suppressions,
+		metadataLevel:       metadataLevel,
+		lang:                lang,
+		descriptionTemplate: descriptionTemplate,
+		technologies:        newTechnologyInventory(),
+		cookieCSP:           newCookieCSPConsistency(),
+	}
+}
+
+// forward copies every result from in to out, inspecting test results on the way.
+// Once in is closed and drained, the technology stack summary of every target with detected
+// technologies and the cookie and CSP consistency notes are sent and the out channel is closed.
+//
+// Parameters:
+//   - in: Channel fed by the strategies
+//   - out: Channel consumed by the reporter
+//
+// Returns:
+//   - <-chan struct{}: Closed after all results were forwarded; exitCode is safe to read afterwards
+func (g *resultGate) forward(in <-chan strategy.ResultWrapper, out chan<- strategy.ResultWrapper) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(out)
+		for res := range in {
+			if ok, val := res.GetTestResult(); ok {
+				target := res.GetTarget()
+				if target == "" {
+					target = g.target
+				}
+				g.inspect(target, val)
+				g.technologies.add(res.GetTarget(), val.TestId, val.Technologies)
+				g.cookieCSP.add(res.GetTarget(), val)
+				val.Localize(g.lang)
+				if g.descriptionTemplate != nil {
+					val.Description = g.descriptionTemplate.Render(*val, target)
+				}
+				val.Metadata = types.ReduceMetadata(val.Metadata, g.metadataLevel)
+				g.progress.testFinished(target, val.TestId)
+			}
+			g.send(out, res.WithScanId(g.scanId))
+		}
+		summaries := append(g.technologies.results(), g.cookieCSP.results()...)
+		for _, res := range summaries {
+			if _, val := res.GetTestResult(); val != nil {
+				val.Metadata = types.ReduceMetadata(val.Metadata, g.metadataLevel)
+			}
+			out <- res.WithScanId(g.scanId)
+		}
+	}()
+	return done
+}
+
+// inspect marks a suppressed result, records a threshold breach and stops the scan on a
+// Critical finding when fail-fast is on.
+func (g *resultGate) inspect(target string, result *Tests.TestResult) {
+	if g.suppressions.IsSuppressed(target, result.TestId) {
+		result.Suppressed = true
+		return
+	}
+	if g.threshold != nil && result.ThreatLevel.AtLeast(*g.threshold) {
+		g.breached = true
+	}
+	if g.failFast != nil && result.ThreatLevel >= Tests.Critical {
+		g.failFast(result.TestId)
+	}
+}
+
+// exitCode returns FindingsExitCode if the threshold was breached, 0 otherwise.
+func (g *resultGate) exitCode() int {
+	if g.breached {
+		return FindingsExitCode
+	}
+	return 0
+}
@@ -0,0 +1,119 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// technologyInventory merges the technologies reported by every test of a scan, per target,
+// so a technology detected by several tests is listed once with the best-known version.
+// It is only used by the result gate's goroutine and needs no locking.
+type technologyInventory struct {
+	targets []string
+	stacks  map[string][]*Tests.Technology
+}
+
+// newTechnologyInventory creates an empty inventory.
+func newTechnologyInventory() *technologyInventory {
+	return &technologyInventory{stacks: make(map[string][]*Tests.Technology)}
+}
+
+// add merges the technologies detected by one test into the stack of target. Names are
+// matched case-insensitively; the first spelling seen and the first CVE summary reported
+// are kept.
+func (inv *technologyInventory) add(target, testId string, technologies []Tests.Technology) {
+	if len(technologies) == 0 {
+		return
+	}
+	stack, known := inv.stacks[target]
+	if !known {
+		inv.targets = append(inv.targets, target)
+	}
+	for _, detected := range technologies {
+		i := slices.IndexFunc(stack, func(t *Tests.Technology) bool { return strings.EqualFold(t.Name, detected.Name) })
+		if i == -1 {
+			stack = append(stack, &Tests.Technology{Name: detected.Name})
+			i = len(stack) - 1
+		}
+		merged := stack[i]
+		merged.Version = bestVersion(merged.Version, detected.Version)
+		if merged.CVE == nil {
+			merged.CVE = detected.CVE
+		}
+		if testId != "" && !slices.Contains(merged.Sources, testId) {
+			merged.Sources = append(merged.Sources, testId)
+		}
+	}
+	inv.stacks[target] = stack
+}
+
+// results builds one technology stack summary result per target that had detections, in
+// the order the targets first reported one. Results of a single-target scan carry no target.
+func (inv *technologyInventory) results() []strategy.ResultWrapper {
+	wrappers := make([]strategy.ResultWrapper, 0, len(inv.targets))
+	for _, target := range inv.targets {
+		technologies := make([]Tests.Technology, 0, len(inv.stacks[target]))
+		for _, technology := range inv.stacks[target] {
+			technologies = append(technologies, *technology)
+		}
+		slices.SortFunc(technologies, func(a, b Tests.Technology) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+		wrapper := strategy.WrapStrategyResult(technologyStackResult(technologies), nil, nil)
+		if target != "" {
+			wrapper = wrapper.WithTarget(target)
+		}
+		wrappers = append(wrappers, wrapper)
+	}
+	return wrappers
+}
+
+// technologyStackResult builds the summary result listing a target's merged technologies.
+// Its metadata is the technology inventory of the target: every technology with its
+// version, the tests that detected it and its CVE summary (see Tests.CVESummary).
+func technologyStackResult(technologies []Tests.Technology) *Tests.TestResult {
+	names := make([]string, 0, len(technologies))
+	for _, technology := range technologies {
+		name := technology.Name
+		if technology.Version != "" {
+			name += " " + technology.Version
+		}
+		if technology.CVE != nil && technology.CVE.Count > 0 {
+			name += fmt.Sprintf(" (%d CVEs, max CVSS %.1f)", technology.CVE.Count, technology.CVE.MaxScore)
+		}
+		names = append(names, name)
+	}
+	return &Tests.TestResult{
+		TestId:       strategy.TechnologyStackId,
+		Name:         "Technology Stack",
+		Certainty:    100,
+		ThreatLevel:  Tests.None,
+		Metadata:     map[string]any{"technologies": technologies},
+		Description:  fmt.Sprintf("Technologies detected across all tests: %s.", strings.Join(names, ", ")),
+		Technologies: technologies,
+	}
+}
+
+// bestVersion returns the more specific of two detected versions of the same technology:
+// a disclosed version wins over an undisclosed one, and a version extending the other
+// (e.g., "1.18.0" over "1.18") or having more components wins. On a tie the known
+// version is kept.
+func bestVersion(known, detected string) string {
+	switch {
+	case detected == "" || detected == known:
+		return known
+	case known == "":
+		return detected
+	case strings.HasPrefix(detected, known):
+		return detected
+	case strings.HasPrefix(known, detected):
+		return known
+	case strings.Count(detected, ".") > strings.Count(known, "."):
+		return detected
+	default:
+		return known
+	}
+}
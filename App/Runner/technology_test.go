@@ -0,0 +1,162 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/Reporter"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/strategy"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// technologyStrategy publishes one result per test ID, each reporting the given technologies.
+type technologyStrategy struct {
+	MockStrategy
+	detections map[string][]Tests.Technology
+}
+
+func (s *technologyStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	for testId, technologies := range s.detections {
+		channel <- strategy.WrapStrategyResult(&Tests.TestResult{
+			TestId:       testId,
+			Name:         testId,
+			Description:  testId,
+			Technologies: technologies,
+		}, nil, nil)
+	}
+}
+
+// stackResolver resolves a reporter which records every test result.
+type stackResolver struct {
+	mu      sync.Mutex
+	results []Tests.TestResult
+}
+
+func (r *stackResolver) Resolve(ch chan strategy.ResultWrapper, taskId string,
+	target string, clientTimeOut int, retryDelay int, strategies []strategy.TestStrategy) Reporter.Reporter {
+	return &stackReporter{ch: ch, resolver: r}
+}
+
+type stackReporter struct {
+	ch       chan strategy.ResultWrapper
+	resolver *stackResolver
+}
+
+func (r *stackReporter) StartListening() <-chan int {
+	done := make(chan int, 1)
+	go func() {
+		for res := range r.ch {
+			if ok, val := res.GetTestResult(); ok {
+				r.resolver.mu.Lock()
+				r.resolver.results = append(r.resolver.results, *val)
+				r.resolver.mu.Unlock()
+			}
+		}
+		done <- 0
+	}()
+	return done
+}
+
+func TestJobRunner_Orchestrate_TechnologyStack(t *testing.T) {
+	plan := &execution.Plan{
+		Target: "example.com",
+		Strategies: []strategy.TestStrategy{&technologyStrategy{
+			MockStrategy: MockStrategy{Name: "--tests"},
+			detections: map[string][]Tests.Technology{
+				"serv-h-a": {{Name: "Nginx", Version: "1.18"}, {Name: "PHP", Version: "7.4.3"}},
+				"ssl-cert": {{Name: "nginx", Version: "1.18.0"}},
+			},
+		}},
+		Contexts: map[string]strategy.TestContext{"--tests": {Target: "example.com"}},
+	}
+	resolver := &stackResolver{}
+	CreateJobRunner(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))).Orchestrate(plan, resolver)
+
+	var stack *Tests.TestResult
+	for i := range resolver.results {
+		if resolver.results[i].TestId == strategy.TechnologyStackId {
+			if stack != nil {
+				t.Fatal("Expected a single technology stack summary")
+			}
+			stack = &resolver.results[i]
+		}
+	}
+	if stack == nil {
+		t.Fatalf("Expected a technology stack summary, got %+v", resolver.results)
+	}
+	if len(stack.Technologies) != 2 {
+		t.Fatalf("Expected nginx and PHP once each, got %+v", stack.Technologies)
+	}
+	nginx := stack.Technologies[0]
+	// Detections arrive in map order, so either spelling may be kept.
+	if !strings.EqualFold(nginx.Name, "nginx") || nginx.Version != "1.18.0" {
+		t.Errorf("Expected nginx with the best-known version 1.18.0, got %+v", nginx)
+	}
+	if len(nginx.Sources) != 2 {
+		t.Errorf("Expected nginx to be attributed to both tests, got %v", nginx.Sources)
+	}
+}
+
+func TestJobRunner_Orchestrate_TechnologyInventoryJSON(t *testing.T) {
+	cves := &Tests.CVESummary{Count: 3, High: 1, Medium: 2, MaxScore: 9.8, RiskLevel: "HIGH", TopCVEs: []string{"CVE-2021-23017"}}
+	plan := &execution.Plan{
+		Target: "example.com",
+		Strategies: []strategy.TestStrategy{&technologyStrategy{
+			MockStrategy: MockStrategy{Name: "--tests"},
+			detections: map[string][]Tests.Technology{
+				"serv-h-a": {{Name: "Nginx", Version: "1.18.0", CVE: cves}},
+			},
+		}},
+		Contexts: map[string]strategy.TestContext{"--tests": {Target: "example.com"}},
+	}
+	resolver := &stackResolver{}
+	CreateJobRunner(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))).Orchestrate(plan, resolver)
+
+	var inventory []byte
+	for _, result := range resolver.results {
+		if result.TestId == strategy.TechnologyStackId {
+			data, err := json.Marshal(result.Metadata)
+			if err != nil {
+				t.Fatalf("Inventory is not serializable: %v", err)
+			}
+			inventory = data
+			if !strings.Contains(result.Description, "Nginx 1.18.0 (3 CVEs, max CVSS 9.8)") {
+				t.Errorf("Expected the CVE counts in the description, got %q", result.Description)
+			}
+		}
+	}
+	var decoded struct {
+		Technologies []Tests.Technology `json:"technologies"`
+	}
+	if err := json.Unmarshal(inventory, &decoded); err != nil {
+		t.Fatalf("Expected a technology inventory, got %s", inventory)
+	}
+	if len(decoded.Technologies) != 1 || decoded.Technologies[0].CVE == nil {
+		t.Fatalf("Expected Nginx with its CVE summary, got %s", inventory)
+	}
+	if got := *decoded.Technologies[0].CVE; got.Count != 3 || got.High != 1 || got.Medium != 2 || got.MaxScore != 9.8 {
+		t.Errorf("Expected the CVE counts of Nginx, got %+v", got)
+	}
+}
+
+func TestBestVersion(t *testing.T) {
+	tests := []struct {
+		known, detected, expected string
+	}{
+		{known: "", detected: "1.18.0", expected: "1.18.0"},
+		{known: "1.18.0", detected: "", expected: "1.18.0"},
+		{known: "1.18", detected: "1.18.0", expected: "1.18.0"},
+		{known: "1.18.0", detected: "1.18", expected: "1.18.0"},
+		{known: "2", detected: "1.18.0", expected: "1.18.0"},
+		{known: "1.18.0", detected: "1.20.1", expected: "1.18.0"},
+	}
+	for _, tt := range tests {
+		if got := bestVersion(tt.known, tt.detected); got != tt.expected {
+			t.Errorf("bestVersion(%q, %q) = %q, want %q", tt.known, tt.detected, got, tt.expected)
+		}
+	}
+}
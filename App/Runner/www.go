@@ -0,0 +1,119 @@
+package Runner
+
+import (
+	helpers "Engine-AntiGinx/App/Helpers"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// wwwProbeTimeout bounds the request checking whether a target redirects to its variant.
+const wwwProbeTimeout = 10 * time.Second
+
+// collapseWWWRedirects removes the redundant member of every apex/www pair of a scan with
+// --include-www. When one member redirects to the other, both would report the same site,
+// so only the redirect destination is scanned. Members that do not redirect to each other
+// are both kept and reported as distinct targets. When they redirect to each other (a
+// redirect loop) the first listed member is dropped and its variant scanned.
+//
+// Parameters:
+//   - ctx: Scan context bounding the redirect probes
+//   - targets: Batch targets including the variants added by the formatter
+//   - logger: Scan logger recording every collapsed target
+//
+// Returns:
+//   - []string: Targets to scan, in their original order
+func (j *jobRunner) collapseWWWRedirects(ctx context.Context, targets []string, logger *slog.Logger) []string {
+	positions := make(map[string]int, len(targets))
+	for i, target := range targets {
+		positions[strings.ToLower(target)] = i
+	}
+
+	dropped := make([]bool, len(targets))
+	for i, target := range targets {
+		variant, ok := helpers.WWWVariant(target)
+		if !ok {
+			continue
+		}
+		k, paired := positions[strings.ToLower(variant)]
+		if !paired || k < i {
+			// Each pair is probed once, from its first member.
+			continue
+		}
+		switch {
+		case j.redirectsTo(ctx, target, variant):
+			dropped[i] = true
+			logger.Info("target redirects to its www variant, scanning the variant only", "target", target, "variant", variant)
+		case j.redirectsTo(ctx, variant, target):
+			dropped[k] = true
+			logger.Info("target redirects to its www variant, scanning the variant only", "target", variant, "variant", target)
+		}
+	}
+
+	collapsed := make([]string, 0, len(targets))
+	for i, target := range targets {
+		if !dropped[i] {
+			collapsed = append(collapsed, target)
+		}
+	}
+	return collapsed
+}
+
+// redirectsTo reports whether fetching target answers with a redirect whose Location points
+// to the host of destination. Bare hosts are requested over https. Failed requests count
+// as no redirect, so both targets are kept and the scan reports the failure.
+func (j *jobRunner) redirectsTo(ctx context.Context, target, destination string) bool {
+	requestURL, err := url.Parse(withScheme(target))
+	if err != nil {
+		return false
+	}
+	destinationURL, err := url.Parse(withScheme(destination))
+	if err != nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, wwwProbeTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return false
+	}
+	response, err := j.wwwProbeClient().Do(request)
+	if err != nil {
+		return false
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode < 300 || response.StatusCode >= 400 {
+		return false
+	}
+	location, err := response.Location()
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(location.Hostname(), destinationURL.Hostname())
+}
+
+// wwwProbeClient returns the client of the redirect probes (see WithRedirectProbeClient)
+// configured not to follow redirects, so the first Location header can be inspected.
+func (j *jobRunner) wwwProbeClient() *http.Client {
+	probe := http.Client{Timeout: wwwProbeTimeout}
+	if j.redirectProbeClient != nil {
+		probe = *j.redirectProbeClient
+	}
+	probe.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &probe
+}
+
+// withScheme prefixes a bare host with https://, leaving URLs unchanged.
+func withScheme(target string) string {
+	if strings.Contains(target, "://") {
+		return target
+	}
+	return "https://" + target
+}
@@ -0,0 +1,102 @@
+package Runner
+
+import (
+	"Engine-AntiGinx/App/execution"
+	"Engine-AntiGinx/App/execution/strategy"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// targetRecorder records the target of every scan it is executed for.
+type targetRecorder struct {
+	MockStrategy
+	mu      sync.Mutex
+	targets []string
+}
+
+func (s *targetRecorder) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
+	s.mu.Lock()
+	s.targets = append(s.targets, ctx.Target)
+	s.mu.Unlock()
+	s.MockStrategy.Execute(ctx, channel, wg, antiBotFlag)
+}
+
+func TestJobRunner_Orchestrate_IncludeWWW(t *testing.T) {
+	tests := []struct {
+		name         string
+		apexRedirect string // Location sent by the apex server, empty to serve the page
+		wwwRedirect  string // Location sent by the www server, empty to serve the page
+		expected     []string
+	}{
+		{
+			name:     "Distinct sites are both scanned",
+			expected: []string{"http://example.test", "http://www.example.test"},
+		},
+		{
+			name:         "Apex redirecting to www is collapsed",
+			apexRedirect: "http://www.example.test/",
+			expected:     []string{"http://www.example.test"},
+		},
+		{
+			name:        "www redirecting to apex is collapsed",
+			wwwRedirect: "http://example.test/",
+			expected:    []string{"http://example.test"},
+		},
+		{
+			name:         "Redirect loop keeps one target",
+			apexRedirect: "http://www.example.test/",
+			wwwRedirect:  "http://example.test/",
+			expected:     []string{"http://www.example.test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := func(location string) *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if location != "" {
+						http.Redirect(w, r, location, http.StatusMovedPermanently)
+						return
+					}
+					_, _ = io.WriteString(w, "<html></html>")
+				}))
+			}
+			apex, www := server(tt.apexRedirect), server(tt.wwwRedirect)
+			defer apex.Close()
+			defer www.Close()
+
+			// The probe client resolves the apex and www host names to the test servers.
+			addresses := map[string]string{"example.test:80": apex.Listener.Addr().String(), "www.example.test:80": www.Listener.Addr().String()}
+			var dialer net.Dialer
+			probeClient := &http.Client{Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					return dialer.DialContext(ctx, network, addresses[addr])
+				},
+			}}
+
+			recorder := &targetRecorder{MockStrategy: MockStrategy{Name: "--tests", TestId: "hsts"}}
+			plan := &execution.Plan{
+				Target:     "http://example.test",
+				Targets:    []string{"http://example.test", "http://www.example.test"},
+				IncludeWWW: true,
+				Strategies: []strategy.TestStrategy{recorder},
+				Contexts:   map[string]strategy.TestContext{"--tests": {Target: "http://example.test"}},
+			}
+			runner := CreateJobRunner(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+				WithRedirectProbeClient(probeClient))
+			runner.Orchestrate(plan, &MockResolver{})
+
+			sort.Strings(recorder.targets)
+			if !reflect.DeepEqual(recorder.targets, tt.expected) {
+				t.Errorf("Expected scanned targets %v, got %v", tt.expected, recorder.targets)
+			}
+		})
+	}
+}
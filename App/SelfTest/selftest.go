@@ -0,0 +1,246 @@
+// Package SelfTest implements the engine's self-test mode (--self-test). It starts an
+// internal HTTP server answering with a known, hardened set of headers, runs every
+// registered test against it and compares each verdict with the expected one. Operators
+// use it to verify that a deployment works end-to-end, and it doubles as a CLI smoke test:
+// the engine exits with a non-zero code when any test regressed.
+//
+// The fixture is served over plain HTTP on a loopback address, so the verdicts of tests
+// depending on TLS or DNS reflect that (e.g., "https" reports High, DNS based tests skip
+// IP targets).
+package SelfTest
+
+import (
+	"Engine-AntiGinx/App/Registry"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"time"
+)
+
+// testTimeout bounds every test run against the fixture, including its secondary requests.
+const testTimeout = 15 * time.Second
+
+// fixtureHeaders are the response headers served by the self-test fixture.
+var fixtureHeaders = map[string]string{
+	"Content-Type":                      "text/html; charset=utf-8",
+	"Cache-Control":                     "no-store",
+	"Vary":                              "Accept-Encoding",
+	"Strict-Transport-Security":         "max-age=63072000; includeSubDomains; preload",
+	"Content-Security-Policy":           "default-src 'self'; script-src 'self'; style-src 'self'; img-src 'self'; form-action 'self'; object-src 'none'; base-uri 'self'; frame-ancestors 'none'",
+	"X-Frame-Options":                   "DENY",
+	"X-Content-Type-Options":            "nosniff",
+	"X-XSS-Protection":                  "0",
+	"Referrer-Policy":                   "strict-origin-when-cross-origin",
+	"Permissions-Policy":                "camera=(), microphone=(), geolocation=()",
+	"Cross-Origin-Opener-Policy":        "same-origin",
+	"Cross-Origin-Embedder-Policy":      "require-corp",
+	"Cross-Origin-Resource-Policy":      "same-origin",
+	"X-Permitted-Cross-Domain-Policies": "none",
+	"Set-Cookie":                        "session=3f9a7c1e5b2d4086a1c9e7f3b5d20c48; Path=/; Secure; HttpOnly; SameSite=Strict",
+}
+
+// fixtureBody is the HTML page served by the self-test fixture.
+const fixtureBody = `<!DOCTYPE html>
+<html lang="en">
+<head><title>AntiGinx self-test</title></head>
+<body><h1>AntiGinx self-test</h1><p>Known-good fixture page.</p></body>
+</html>
+`
+
+// expectedVerdicts is the threat level every built-in test must report for the fixture.
+// Plugin tests declare theirs with Registry.WithSelfTestVerdict; a test without an expected
+// verdict is reported as not checked.
+var expectedVerdicts = map[string]Tests.ThreatLevel{
+	"alt-svc":                Tests.Info, // HTTP/3 is not advertised
+	"api-cache":              Tests.Info, // Not a JSON response
+	"caa":                    Tests.Info, // Loopback IP target, not applicable
+	"content-disposition":    Tests.None,
+	"cookie-scheme-reuse":    Tests.None,
+	"cookie-sec":             Tests.None,
+	"cors-allow":             Tests.Info, // No CORS allow lists
+	"cors-max-age":           Tests.None, // CORS not enabled
+	"cross-origin-x":         Tests.None,
+	"csp":                    Tests.None,
+	"custom":                 Tests.None, // No custom rules
+	"email-dns":              Tests.Info, // Loopback IP target, not applicable
+	"hpkp":                   Tests.None,
+	"hsts":                   Tests.None,
+	"http-methods":           Tests.None,
+	"https":                  Tests.High, // Fixture is served over plain HTTP
+	"insecure-deser":         Tests.None,
+	"js-libs":                Tests.None,
+	"js-obf":                 Tests.None,
+	"permissions-policy":     Tests.Info,
+	"phishing-url":           Tests.None,
+	"rate-limit":             Tests.Info, // No rate limiting, but not an authentication page
+	"referrer-policy":        Tests.None,
+	"serv-h-a":               Tests.None,
+	"server-timing":          Tests.None,
+	"sitemap":                Tests.None,
+	"ssl-cert":               Tests.Info, // Not HTTPS, not applicable
+	"tabnabbing":             Tests.None,
+	"transfer-integrity":     Tests.None,
+	"transport":              Tests.High, // Follows "https"
+	"url-secrets":            Tests.None,
+	"vary":                   Tests.None,
+	"well-known":             Tests.None,
+	"x-content-type-options": Tests.None,
+	"x-xss":                  Tests.None,
+	"xframe":                 Tests.None,
+	"xpcdp":                  Tests.None,
+	"xst":                    Tests.None,
+}
+
+// noExpectationMessage explains why a test without an expected verdict was not checked.
+const noExpectationMessage = "no expected verdict declared, not checked"
+
+// Outcome is the self-test result of a single test.
+type Outcome struct {
+	TestId   string
+	Expected Tests.ThreatLevel
+	Actual   Tests.ThreatLevel
+	Passed   bool
+	Skipped  bool   // No expected verdict declared, the test was not run
+	Message  string // Failure reason (empty when passed)
+}
+
+// Suite runs the registered tests against the fixture and reports their outcomes.
+type Suite struct {
+	out            io.Writer
+	getAllTests    func() []*Tests.ResponseTest
+	expected       map[string]Tests.ThreatLevel
+	pluginVerdicts func(testId string) (Tests.ThreatLevel, bool) // Verdicts declared at registration (nil = none)
+}
+
+// NewSuite creates a self-test suite over every registered test.
+//
+// Parameters:
+//   - out: Stream receiving the human-readable report (typically os.Stdout)
+//
+// Returns:
+//   - *Suite: Suite ready to Run
+//
+// Example:
+//
+//	os.Exit(SelfTest.NewSuite(os.Stdout).Run())
+func NewSuite(out io.Writer) *Suite {
+	return &Suite{
+		out:            out,
+		getAllTests:    Registry.GetAllTests,
+		expected:       expectedVerdicts,
+		pluginVerdicts: Registry.SelfTestVerdict,
+	}
+}
+
+// Run starts the fixture server, runs every test against it and prints one line per test
+// followed by a summary. Tests without an expected verdict are listed as not checked.
+//
+// Returns:
+//   - int: 0 when every checked test reported its expected verdict, 1 otherwise
+func (s *Suite) Run() int {
+	outcomes := s.Check()
+	failed, skipped := 0, 0
+	for _, outcome := range outcomes {
+		status := "PASS"
+		if outcome.Skipped {
+			status = "SKIP"
+			skipped++
+		} else if !outcome.Passed {
+			status = "FAIL"
+			failed++
+		}
+		line := fmt.Sprintf("%s  %-24s expected %-8s got %s", status, outcome.TestId, outcome.Expected, outcome.Actual)
+		if outcome.Message == noExpectationMessage {
+			line = fmt.Sprintf("%s  %-24s", status, outcome.TestId)
+		}
+		if outcome.Message != "" {
+			line += " (" + outcome.Message + ")"
+		}
+		_, _ = fmt.Fprintln(s.out, line)
+	}
+	summary := fmt.Sprintf("\nSelf-test: %d passed, %d failed", len(outcomes)-failed-skipped, failed)
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d not checked", skipped)
+	}
+	_, _ = fmt.Fprintln(s.out, summary)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// Check runs every test against the fixture and returns the outcomes ordered by test id.
+// A failure to load the fixture is reported as a failed outcome of every test.
+func (s *Suite) Check() []Outcome {
+	server := httptest.NewServer(http.HandlerFunc(serveFixture))
+	defer server.Close()
+
+	tests := s.getAllTests()
+	sort.Slice(tests, func(i, j int) bool { return tests[i].Id < tests[j].Id })
+
+	outcomes := make([]Outcome, 0, len(tests))
+	for _, test := range tests {
+		outcomes = append(outcomes, s.check(test, server.URL))
+	}
+	return outcomes
+}
+
+// check runs a single test against a freshly loaded fixture response, so a test consuming
+// the body does not affect the next one.
+func (s *Suite) check(test *Tests.ResponseTest, url string) (outcome Outcome) {
+	expected, known := s.expected[test.Id]
+	if !known && s.pluginVerdicts != nil {
+		expected, known = s.pluginVerdicts(test.Id)
+	}
+	outcome = Outcome{TestId: test.Id, Expected: expected}
+	if !known {
+		outcome.Skipped = true
+		outcome.Message = noExpectationMessage
+		return outcome
+	}
+
+	response, reqInfo := strategy.LoadWebsiteContent(url, false)
+	if reqInfo.Code != 0 {
+		outcome.Message = "fixture could not be loaded: " + reqInfo.Message
+		return outcome
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			outcome.Passed = false
+			outcome.Message = fmt.Sprintf("test panicked: %v", r)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	result := test.Run(Tests.ResponseTestParams{Response: response, DisableCVE: true, Context: ctx})
+
+	outcome.Actual = result.ThreatLevel
+	outcome.Passed = result.ThreatLevel == expected
+	if !outcome.Passed {
+		outcome.Message = result.Description
+	}
+	return outcome
+}
+
+// serveFixture answers every request with the known-good fixture page, and advertises the
+// password change page under /.well-known/change-password.
+func serveFixture(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/.well-known/change-password" {
+		http.Redirect(w, r, "/account/password", http.StatusFound)
+		return
+	}
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	for name, value := range fixtureHeaders {
+		w.Header().Set(name, value)
+	}
+	_, _ = io.WriteString(w, fixtureBody)
+}
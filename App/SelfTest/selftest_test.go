@@ -0,0 +1,92 @@
+package SelfTest
+
+import (
+	"Engine-AntiGinx/App/Registry"
+	"Engine-AntiGinx/App/Tests"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSuite_Run_Fixture(t *testing.T) {
+	var out bytes.Buffer
+	if code := NewSuite(&out).Run(); code != 0 {
+		t.Fatalf("Run() = %d, want 0; report:\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "0 failed") {
+		t.Errorf("report missing summary:\n%s", out.String())
+	}
+}
+
+func TestSuite_Check_EveryTestDeclared(t *testing.T) {
+	outcomes := NewSuite(io.Discard).Check()
+	if len(outcomes) != len(Registry.GetAllTests()) {
+		t.Fatalf("got %d outcomes, want one per registered test", len(outcomes))
+	}
+	for _, outcome := range outcomes {
+		if !outcome.Passed {
+			t.Errorf("%s: expected %s, got %s (%s)", outcome.TestId, outcome.Expected, outcome.Actual, outcome.Message)
+		}
+	}
+}
+
+func TestSuite_Run_Regression(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected map[string]Tests.ThreatLevel
+		message  string
+	}{
+		{
+			name:     "wrong verdict",
+			expected: map[string]Tests.ThreatLevel{"https": Tests.None},
+			message:  "expected None",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			suite := &Suite{
+				out:         &out,
+				getAllTests: func() []*Tests.ResponseTest { return []*Tests.ResponseTest{Tests.NewHTTPSTest()} },
+				expected:    tt.expected,
+			}
+			if code := suite.Run(); code != 1 {
+				t.Fatalf("Run() = %d, want 1", code)
+			}
+			if !strings.Contains(out.String(), "FAIL  https") || !strings.Contains(out.String(), tt.message) {
+				t.Errorf("report missing failure:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestSuite_Run_UndeclaredTestNotChecked(t *testing.T) {
+	var out bytes.Buffer
+	suite := &Suite{
+		out:         &out,
+		getAllTests: func() []*Tests.ResponseTest { return []*Tests.ResponseTest{Tests.NewHTTPSTest()} },
+		expected:    map[string]Tests.ThreatLevel{},
+	}
+	if code := suite.Run(); code != 0 {
+		t.Fatalf("Run() = %d, want 0; report:\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "SKIP  https") || !strings.Contains(out.String(), "1 not checked") {
+		t.Errorf("report missing the unchecked test:\n%s", out.String())
+	}
+}
+
+func TestSuite_Check_PluginVerdict(t *testing.T) {
+	suite := &Suite{
+		out:         io.Discard,
+		getAllTests: func() []*Tests.ResponseTest { return []*Tests.ResponseTest{Tests.NewHTTPSTest()} },
+		expected:    map[string]Tests.ThreatLevel{},
+		pluginVerdicts: func(testId string) (Tests.ThreatLevel, bool) {
+			return Tests.High, testId == "https"
+		},
+	}
+	outcomes := suite.Check()
+	if len(outcomes) != 1 || outcomes[0].Skipped || !outcomes[0].Passed {
+		t.Errorf("Expected the declared plugin verdict to be checked, got %+v", outcomes)
+	}
+}
@@ -0,0 +1,132 @@
+// Package Suppression loads baseline files listing accepted findings. Suppressed findings are
+// still reported (marked as suppressed) but do not count towards the severity threshold that
+// decides the engine's exit code, so teams can acknowledge known issues without failing CI.
+//
+// A suppressions file is a JSON array of entries:
+//
+//	[
+//	  {"target": "example.com", "testId": "hsts", "reason": "HSTS handled by CDN"},
+//	  {"testId": "serv-h-a"}
+//	]
+//
+// An entry without a target applies to every target.
+//
+// Error codes:
+//   - 100: Suppressions file reading error
+//   - 101: Suppressions file decoding error
+//   - 102: Entry without testId
+package Suppression
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Entry describes a single accepted finding.
+type Entry struct {
+	Target string `json:"target"`           // Target the finding applies to (empty matches every target)
+	TestId string `json:"testId"`           // Registry ID of the suppressed test (e.g., "hsts")
+	Reason string `json:"reason,omitempty"` // Optional justification kept for reviewers
+}
+
+// List is a set of suppression entries. A nil List suppresses nothing.
+type List struct {
+	entries []Entry
+}
+
+// Load reads and parses a suppressions file.
+//
+// Parameters:
+//   - path: Path to the JSON suppressions file
+//
+// Returns:
+//   - *List: Parsed suppressions
+//   - *Errors.Error: Error with code 100 if the file cannot be read, 101/102 if it is invalid
+//
+// Example:
+//
+//	list, err := Suppression.Load("baseline.json")
+//	if err == nil && list.IsSuppressed("example.com", "hsts") {
+//	    // finding accepted by the team
+//	}
+func Load(path string) (*List, *Errors.Error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &Errors.Error{
+			Code:        100,
+			Message:     fmt.Sprintf("Suppression error occurred. This could be due to:\n- suppressions file %s cannot be read: %v", path, err),
+			Source:      "Suppression",
+			IsRetryable: false,
+		}
+	}
+	return Parse(data)
+}
+
+// Parse decodes suppressions from JSON content.
+//
+// Parameters:
+//   - data: JSON array of suppression entries
+//
+// Returns:
+//   - *List: Parsed suppressions
+//   - *Errors.Error: Error with code 101 on malformed JSON, 102 if an entry has no testId
+func Parse(data []byte) (*List, *Errors.Error) {
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, &Errors.Error{
+			Code:        101,
+			Message:     fmt.Sprintf("Suppression error occurred. This could be due to:\n- invalid suppressions file format: %v", err),
+			Source:      "Suppression",
+			IsRetryable: false,
+		}
+	}
+	for i, entry := range entries {
+		if strings.TrimSpace(entry.TestId) == "" {
+			return nil, &Errors.Error{
+				Code:        102,
+				Message:     fmt.Sprintf("Suppression error occurred. This could be due to:\n- suppression entry %d has no testId", i),
+				Source:      "Suppression",
+				IsRetryable: false,
+			}
+		}
+	}
+	return &List{entries: entries}, nil
+}
+
+// IsSuppressed reports whether the finding of the given test on the given target is accepted.
+// Targets are compared case-insensitively, ignoring the URL scheme and trailing slashes, so
+// "https://Example.com/" matches an entry for "example.com".
+//
+// Parameters:
+//   - target: Scanned target
+//   - testId: Registry ID of the test that produced the finding
+//
+// Returns:
+//   - bool: true if a matching suppression entry exists
+func (l *List) IsSuppressed(target, testId string) bool {
+	if l == nil {
+		return false
+	}
+	normalized := normalizeTarget(target)
+	for _, entry := range l.entries {
+		if entry.TestId != testId {
+			continue
+		}
+		if entry.Target == "" || normalizeTarget(entry.Target) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTarget reduces a target to a comparable host/path form.
+func normalizeTarget(target string) string {
+	t := strings.ToLower(strings.TrimSpace(target))
+	if _, rest, found := strings.Cut(t, "://"); found {
+		t = rest
+	}
+	return strings.TrimRight(t, "/")
+}
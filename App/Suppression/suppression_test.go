@@ -0,0 +1,68 @@
+package Suppression
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestList_IsSuppressed(t *testing.T) {
+	list, err := Parse([]byte(`[
+		{"target": "example.com", "testId": "hsts", "reason": "handled by CDN"},
+		{"testId": "serv-h-a"}
+	]`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		target   string
+		testId   string
+		expected bool
+	}{
+		{target: "example.com", testId: "hsts", expected: true},
+		{target: "https://Example.com/", testId: "hsts", expected: true},
+		{target: "other.com", testId: "hsts", expected: false},
+		{target: "other.com", testId: "serv-h-a", expected: true},
+		{target: "example.com", testId: "csp", expected: false},
+	}
+	for _, tt := range tests {
+		if got := list.IsSuppressed(tt.target, tt.testId); got != tt.expected {
+			t.Errorf("IsSuppressed(%q, %q) = %t, want %t", tt.target, tt.testId, got, tt.expected)
+		}
+	}
+
+	var empty *List
+	if empty.IsSuppressed("example.com", "hsts") {
+		t.Error("Nil list should not suppress anything")
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	missingId := filepath.Join(dir, "missing.json")
+	_ = os.WriteFile(invalid, []byte(`{"testId":`), 0o600)
+	_ = os.WriteFile(missingId, []byte(`[{"target":"example.com"}]`), 0o600)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+	}{
+		{name: "Missing file", path: filepath.Join(dir, "absent.json"), expectedCode: 100},
+		{name: "Invalid JSON", path: invalid, expectedCode: 101},
+		{name: "Entry without testId", path: missingId, expectedCode: 102},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.path)
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if err.Code != tt.expectedCode {
+				t.Errorf("Expected error code %d, got %d", tt.expectedCode, err.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package TaskStream
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Harness"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/execution/strategy"
+)
+
+// Envelope is the JSON document written to the output stream for every task.
+// Results use the same TestResultWrapper shape the backend reporter sends, with
+// TestId set to the task id and ScanId to the scan's correlation ID.
+type Envelope struct {
+	Id       string                    `json:"id"`              // Task id echoed from the input line
+	Target   string                    `json:"target"`          // Target as given in the task
+	Results  []types.TestResultWrapper `json:"results"`         // Process messages followed by test results
+	ExitCode int                       `json:"exitCode"`        // Exit code the scan would have produced on the CLI
+	TimedOut bool                      `json:"timedOut"`        // The scan deadline passed, Results are partial
+	Error    *Errors.Error             `json:"error,omitempty"` // Set when the task could not be completed
+
+	// Signature is the HMAC of the envelope (see types.Sign), set when REPORT_SIGNING_KEY is.
+	Signature string `json:"signature,omitempty"`
+}
+
+// wrapResults converts a harness result into backend-style result wrappers.
+func wrapResults(taskId string, result *Harness.Result) []types.TestResultWrapper {
+	wrapped := make([]types.TestResultWrapper, 0, len(result.Messages)+len(result.Results))
+	for _, info := range result.Messages {
+		wrapped = append(wrapped, types.TestResultWrapper{
+			Target:      result.Target,
+			TestId:      taskId,
+			ScanId:      result.ScanId,
+			ResultType:  types.Message,
+			ProcessInfo: info,
+		})
+	}
+	for _, res := range result.Results {
+		wrapped = append(wrapped, types.TestResultWrapper{
+			Target:     result.Target,
+			TestId:     taskId,
+			ScanId:     result.ScanId,
+			Result:     res,
+			FindingID:  types.FindingID(result.Target, res),
+			ResultType: types.Success,
+			ProcessInfo: strategy.RequestInfo{
+				Message: "Test completed successfully",
+				Code:    0,
+			},
+		})
+	}
+	return wrapped
+}
@@ -0,0 +1,149 @@
+// Package TaskStream implements the engine's stdin task mode. Instead of reading a single scan
+// from the command line or a RabbitMQ message, the engine reads newline-delimited JSON task
+// objects from an input stream, runs each scan in turn and writes one result envelope per task
+// to an output stream. This allows orchestrators written in any language to drive the engine
+// over a pipe without a message broker.
+//
+// A task line looks like:
+//
+//	{"id": "task-1", "target_url": "example.com", "tests": ["https", "hsts"]}
+//
+// and produces a single envelope line:
+//
+//	{"id": "task-1", "target": "example.com", "results": [...], "exitCode": 0}
+//
+// Errors raised while processing a task (invalid JSON, unknown test, network failure) are
+// reported in the envelope's "error" field and do not stop processing of later tasks.
+//
+// When REPORT_SIGNING_KEY is set, every envelope carries a "signature" field with the HMAC
+// of the rest of the envelope (see types.Sign), so consumers can detect tampering.
+//
+// Error codes:
+//   - 100: Task line decoding error
+//   - 102: Input stream reading error
+//
+// Tasks without target_url or tests are rejected by the harness (Harness error code 100).
+package TaskStream
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Harness"
+	reporterTypes "Engine-AntiGinx/App/Reporter/types"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxTaskLineSize bounds the size of a single task line read from the input stream.
+const maxTaskLineSize = 1024 * 1024
+
+// Task describes a single scan requested over the input stream.
+type Task struct {
+	Id        string   `json:"id"`         // Caller-chosen identifier echoed back in the envelope
+	TargetUrl string   `json:"target_url"` // Target to scan, with or without http(s):// scheme
+	Tests     []string `json:"tests"`      // Registry IDs of the tests to run (e.g., "https", "hsts")
+}
+
+// Server reads tasks from an input stream and writes result envelopes to an output stream.
+type Server struct {
+	in         io.Reader
+	out        io.Writer
+	harness    *Harness.Harness
+	signingKey []byte // Key envelopes are signed with (nil disables signing)
+}
+
+// NewServer creates a task stream server running tasks through the standard harness.
+//
+// Parameters:
+//   - in: Stream of newline-delimited JSON tasks (typically os.Stdin)
+//   - out: Stream receiving newline-delimited JSON envelopes (typically os.Stdout)
+//
+// Returns:
+//   - *Server: Server ready to Serve
+//
+// Example:
+//
+//	server := TaskStream.NewServer(os.Stdin, os.Stdout)
+//	os.Exit(server.Serve())
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:         in,
+		out:        out,
+		harness:    Harness.NewHarness(),
+		signingKey: reporterTypes.SigningKey(),
+	}
+}
+
+// Serve processes tasks until the input stream is exhausted. Each non-empty line is handled
+// independently, so a failing task is reported in its envelope and the next task still runs.
+//
+// Returns:
+//   - int: 0 when the whole input stream was consumed, 1 if it could not be read
+func (s *Server) Serve() int {
+	scanner := bufio.NewScanner(s.in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTaskLineSize)
+	encoder := json.NewEncoder(s.out)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		_ = encoder.Encode(s.sign(s.handle(line)))
+	}
+	if err := scanner.Err(); err != nil {
+		_ = encoder.Encode(s.sign(Envelope{
+			Error: &Errors.Error{
+				Code:        102,
+				Message:     fmt.Sprintf("Task stream error occurred. This could be due to:\n- input stream cannot be read: %v", err),
+				Source:      "Task Stream",
+				IsRetryable: false,
+			},
+		}))
+		return 1
+	}
+	return 0
+}
+
+// sign sets the signature of the envelope when signing is enabled. An envelope that cannot
+// be encoded is left unsigned; the encoder reports the same failure.
+func (s *Server) sign(envelope Envelope) Envelope {
+	if s.signingKey == nil {
+		return envelope
+	}
+	if signature, err := reporterTypes.Sign(s.signingKey, envelope); err == nil {
+		envelope.Signature = signature
+	}
+	return envelope
+}
+
+// handle decodes and runs a single task line through the harness.
+func (s *Server) handle(line []byte) Envelope {
+	var task Task
+	if err := json.Unmarshal(line, &task); err != nil {
+		return Envelope{
+			Error: &Errors.Error{
+				Code:        100,
+				Message:     fmt.Sprintf("Task stream error occurred. This could be due to:\n- invalid task JSON: %v", err),
+				Source:      "Task Stream",
+				IsRetryable: false,
+			},
+		}
+	}
+	envelope := Envelope{Id: task.Id, Target: task.TargetUrl, Results: []reporterTypes.TestResultWrapper{}}
+
+	opts := []Harness.Option{Harness.WithTests(task.Tests...)}
+	if task.Id != "" {
+		opts = append(opts, Harness.WithTaskId(task.Id))
+	}
+	result, err := s.harness.Run(task.TargetUrl, opts...)
+	if err != nil {
+		envelope.Error = err
+		return envelope
+	}
+	envelope.ExitCode = result.ExitCode
+	envelope.TimedOut = result.TimedOut
+	envelope.Results = wrapResults(task.Id, result)
+	return envelope
+}
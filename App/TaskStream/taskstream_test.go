@@ -0,0 +1,92 @@
+package TaskStream
+
+import (
+	reporterTypes "Engine-AntiGinx/App/Reporter/types"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_Serve_WritesEnvelopePerTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body>ok</body></html>"))
+	}))
+	defer server.Close()
+
+	input := strings.NewReader(`{"id":"task-1","target_url":"` + server.URL + `","tests":["https"]}` + "\n")
+	var output bytes.Buffer
+
+	if code := NewServer(input, &output).Serve(); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected exactly one envelope, got %d: %q", len(lines), output.String())
+	}
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(lines[0]), &envelope); err != nil {
+		t.Fatalf("Envelope is not valid JSON: %v", err)
+	}
+	if envelope.Id != "task-1" {
+		t.Errorf("Expected envelope id task-1, got %q", envelope.Id)
+	}
+	if envelope.Error != nil {
+		t.Fatalf("Unexpected envelope error: %+v", envelope.Error)
+	}
+	if len(envelope.Results) != 1 {
+		t.Fatalf("Expected one result, got %d: %+v", len(envelope.Results), envelope.Results)
+	}
+	result := envelope.Results[0]
+	if result.TestId != "task-1" || result.Result.TestId != "https" {
+		t.Errorf("Expected https result tagged with task-1, got %+v", result)
+	}
+}
+
+func TestServer_Serve_ReportsErrorsAndContinues(t *testing.T) {
+	input := strings.NewReader("not json\n\n" + `{"id":"task-2","target_url":"example.com","tests":["unknown"]}` + "\n")
+	var output bytes.Buffer
+
+	if code := NewServer(input, &output).Serve(); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected two envelopes, got %d: %q", len(lines), output.String())
+	}
+	wantCodes := []int{100, 106}
+	for i, line := range lines {
+		var envelope Envelope
+		if err := json.Unmarshal([]byte(line), &envelope); err != nil {
+			t.Fatalf("Envelope %d is not valid JSON: %v", i, err)
+		}
+		if envelope.Error == nil {
+			t.Fatalf("Expected envelope %d to carry an error", i)
+		}
+		if envelope.Error.Code != wantCodes[i] {
+			t.Errorf("Expected envelope %d error code %d, got %+v", i, wantCodes[i], envelope.Error)
+		}
+	}
+}
+
+func TestServer_Serve_SignsEnvelopes(t *testing.T) {
+	t.Setenv(reporterTypes.SigningKeyEnv, "compliance-key")
+	var output bytes.Buffer
+
+	if code := NewServer(strings.NewReader("not json\n"), &output).Serve(); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	envelope := bytes.TrimSpace(output.Bytes())
+	if valid, err := reporterTypes.Verify([]byte("compliance-key"), envelope); err != nil || !valid {
+		t.Errorf("Expected a valid envelope signature, got %v (%v): %s", valid, err, envelope)
+	}
+	tampered := bytes.Replace(envelope, []byte(`"Code":100`), []byte(`"Code":0`), 1)
+	if valid, _ := reporterTypes.Verify([]byte("compliance-key"), tampered); valid {
+		t.Errorf("Expected the altered envelope to fail verification: %s", tampered)
+	}
+}
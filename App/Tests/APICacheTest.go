@@ -0,0 +1,162 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the API cache test that checks whether credentialed JSON responses
+// may be stored by browsers or intermediary caches.
+package Tests
+
+import (
+	"mime"
+	"strings"
+)
+
+// NewAPICacheTest creates a new ResponseTest that flags cacheable JSON responses carrying
+// session or authentication data. API responses returned for an authenticated user often
+// contain personal data; without "Cache-Control: no-store" proxies, CDNs and the browser
+// cache may keep them and serve them to other users of the same cache.
+//
+// The test evaluates:
+//   - Content-Type: application/json or any "+json" media type
+//   - Credentials: Set-Cookie in the response, Cookie or Authorization in the request
+//   - Cache-Control directives: no-store, private, no-cache, public, max-age, s-maxage
+//
+// Threat level assessment:
+//   - None (0): Credentialed JSON response with "no-store"
+//   - Info (1): Not a JSON response, or JSON without credentials
+//   - Low (2): Credentialed JSON response limited by "private" or "no-cache" but without "no-store"
+//   - Medium (3): Credentialed JSON response cacheable by intermediaries
+//
+// Returns:
+//   - *ResponseTest: Configured API cache test ready for execution
+//
+// Example usage:
+//
+//	apiCacheTest := NewAPICacheTest()
+//	result := apiCacheTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata includes the content type and the parsed Cache-Control directives
+func NewAPICacheTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "api-cache",
+		Name:          "API Response Caching",
+		Description:   "Checks that credentialed JSON API responses are sent with Cache-Control: no-store",
+		Category:      "Headers",
+		CWE:           "CWE-524",
+		OWASPCategory: "A04:2021-Insecure Design",
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeAPICaching(params)
+			threatLevel := evaluateAPICacheThreatLevel(metadata)
+
+			return TestResult{
+				Name:        "API Response Caching",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateAPICacheDescription(metadata, threatLevel),
+			}
+		},
+	}
+}
+
+// analyzeAPICaching collects the content type, credentials and caching directives of a response.
+//
+// Parameters:
+//   - params: Test parameters holding the response (and the request that produced it)
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "content_type" (string): Media type of the response, lower-cased
+//   - "json" (bool): Response is JSON
+//   - "credential_sources" ([]string): Headers carrying credentials (e.g., "Set-Cookie", "Authorization")
+//   - "cache_control" (string): Raw Cache-Control header value
+//   - "directives" (map[string]string): Parsed Cache-Control directives
+//   - "cacheable" (bool): Response may be stored by some cache (no "no-store")
+//   - "shared_cacheable" (bool): Response may be stored by intermediaries (no "no-store" or "private")
+//
+// Example:
+//
+//	metadata := analyzeAPICaching(ResponseTestParams{Response: jsonResponse})
+//	// metadata["directives"] == map[string]string{"max-age": "600", "public": ""}
+func analyzeAPICaching(params ResponseTestParams) map[string]interface{} {
+	response := params.Response
+	contentType, _, err := mime.ParseMediaType(HeaderValue(response.Header, "Content-Type"))
+	if err != nil {
+		contentType = ""
+	}
+	isJSON := contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+
+	credentialSources := []string{}
+	if len(HeaderValues(response.Header, "Set-Cookie")) > 0 {
+		credentialSources = append(credentialSources, "Set-Cookie")
+	}
+	if response.Request != nil {
+		for _, name := range []string{"Cookie", "Authorization"} {
+			if response.Request.Header.Get(name) != "" {
+				credentialSources = append(credentialSources, name)
+			}
+		}
+	}
+
+	cacheControl := strings.Join(HeaderValues(response.Header, "Cache-Control"), ", ")
+	directives := parseCacheControl(cacheControl)
+	_, noStore := directives["no-store"]
+	_, private := directives["private"]
+
+	return map[string]interface{}{
+		"content_type":       contentType,
+		"json":               isJSON,
+		"credential_sources": credentialSources,
+		"cache_control":      cacheControl,
+		"directives":         directives,
+		"cacheable":          !noStore,
+		"shared_cacheable":   !noStore && !private,
+	}
+}
+
+// parseCacheControl splits a Cache-Control value into lower-cased directives and their
+// (unquoted) arguments. Directives without an argument map to an empty string.
+func parseCacheControl(value string) map[string]string {
+	directives := map[string]string{}
+	for _, directive := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			directives[name] = strings.Trim(strings.TrimSpace(arg), `"`)
+		}
+	}
+	return directives
+}
+
+// evaluateAPICacheThreatLevel maps the API caching analysis to a threat level.
+func evaluateAPICacheThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch {
+	case !metadata["json"].(bool) || len(metadata["credential_sources"].([]string)) == 0:
+		return Info
+	case metadata["shared_cacheable"].(bool):
+		return Medium
+	case metadata["cacheable"].(bool):
+		return Low
+	default:
+		return None
+	}
+}
+
+// generateAPICacheDescription builds a human-readable summary of the API caching analysis.
+func generateAPICacheDescription(metadata map[string]interface{}, threatLevel ThreatLevel) string {
+	contentType := metadata["content_type"].(string)
+	cacheControl := metadata["cache_control"].(string)
+	if cacheControl == "" {
+		cacheControl = "none"
+	}
+	switch {
+	case !metadata["json"].(bool):
+		return "Response is not JSON (" + contentType + "), API caching rules do not apply"
+	case len(metadata["credential_sources"].([]string)) == 0:
+		return "JSON response without cookies or authorization, caching does not expose user data"
+	case threatLevel == Medium:
+		return "Credentialed JSON response may be stored by intermediary caches (Cache-Control: " + cacheControl +
+			"), add Cache-Control: no-store"
+	case threatLevel == Low:
+		return "Credentialed JSON response is kept out of shared caches but may be stored by the browser (Cache-Control: " +
+			cacheControl + "), add Cache-Control: no-store"
+	default:
+		return "Credentialed JSON response is sent with Cache-Control: no-store"
+	}
+}
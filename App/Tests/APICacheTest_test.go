@@ -0,0 +1,57 @@
+package Tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAPICacheTest(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		cacheControl   string
+		setCookie      bool
+		authorization  bool
+		wantThreat     ThreatLevel
+		wantDirectives []string
+	}{
+		{name: "Cacheable JSON with cookie", contentType: "application/json; charset=utf-8",
+			cacheControl: "public, max-age=600", setCookie: true, wantThreat: Medium, wantDirectives: []string{"public", "max-age"}},
+		{name: "JSON without Cache-Control and authorization", contentType: "application/json",
+			authorization: true, wantThreat: Medium},
+		{name: "No-store JSON", contentType: "application/problem+json",
+			cacheControl: "no-store, max-age=0", setCookie: true, wantThreat: None, wantDirectives: []string{"no-store", "max-age"}},
+		{name: "Private JSON", contentType: "application/json",
+			cacheControl: "private, max-age=60", authorization: true, wantThreat: Low, wantDirectives: []string{"private", "max-age"}},
+		{name: "JSON without credentials", contentType: "application/json", cacheControl: "max-age=600", wantThreat: Info},
+		{name: "HTML with cookie", contentType: "text/html", cacheControl: "max-age=600", setCookie: true, wantThreat: Info},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodGet, "https://api.example.com/me", nil)
+			response := &http.Response{Header: http.Header{}, Request: request}
+			response.Header.Set("Content-Type", tt.contentType)
+			if tt.cacheControl != "" {
+				response.Header.Set("Cache-Control", tt.cacheControl)
+			}
+			if tt.setCookie {
+				response.Header.Set("Set-Cookie", "session=abc; Secure; HttpOnly")
+			}
+			if tt.authorization {
+				request.Header.Set("Authorization", "Bearer token")
+			}
+
+			result := NewAPICacheTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			directives := result.Metadata.(map[string]interface{})["directives"].(map[string]string)
+			for _, directive := range tt.wantDirectives {
+				if _, ok := directives[directive]; !ok {
+					t.Errorf("Expected directive %q in %v", directive, directives)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the Alt-Svc test that reports alternative services (RFC 7838)
+// advertised by the target, in particular HTTP/3 endpoints.
+package Tests
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// altSvcDefaultMaxAge is the freshness lifetime (in seconds) used when "ma" is omitted.
+const altSvcDefaultMaxAge = 86400
+
+// altSvcEntry is a single alternative service parsed from the Alt-Svc header.
+type altSvcEntry struct {
+	Protocol string `json:"protocol"` // ALPN protocol ID (e.g., "h3", "h2")
+	Host     string `json:"host"`     // Alternative host, empty for the origin host
+	Port     int    `json:"port"`     // Alternative port
+	MaxAge   int    `json:"max_age"`  // Freshness lifetime in seconds
+	Persist  bool   `json:"persist"`  // persist=1, survives network changes
+}
+
+// NewAltSvcTest creates a new ResponseTest that parses the Alt-Svc header to detect
+// advertised HTTP/3 endpoints and validate their parameters.
+//
+// The test evaluates:
+//   - Presence of the Alt-Svc header
+//   - HTTP/3 ("h3" and draft "h3-NN") alternatives
+//   - Syntax of every alternative, including the "ma" (max-age) and "persist" parameters
+//
+// Threat level assessment:
+//   - None (0): Well-formed Alt-Svc advertising HTTP/3
+//   - Info (1): No Alt-Svc header, "clear", or no HTTP/3 alternative advertised
+//   - Low (2): Malformed Alt-Svc header, clients ignore the invalid alternatives
+//
+// Returns:
+//   - *ResponseTest: Configured Alt-Svc test ready for execution
+//
+// Example usage:
+//
+//	altSvcTest := NewAltSvcTest()
+//	result := altSvcTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata lists advertised protocols and ports
+func NewAltSvcTest() *ResponseTest {
+	return &ResponseTest{
+		Id:           "alt-svc",
+		Name:         "Alt-Svc / HTTP/3 Advertisement",
+		Description:  "Parses the Alt-Svc header to detect advertised HTTP/3 endpoints and validate their max-age",
+		Category:     "Protocol",
+		CacheHeaders: []string{"Alt-Svc"},
+		RunTest: func(params ResponseTestParams) TestResult {
+			header := strings.TrimSpace(strings.Join(HeaderValues(params.Response.Header, "Alt-Svc"), ", "))
+			if header == "" {
+				return TestResult{
+					Name:        "Alt-Svc / HTTP/3 Advertisement",
+					Certainty:   100,
+					ThreatLevel: Info,
+					Metadata:    nil,
+					Description: "No Alt-Svc header, HTTP/3 is not advertised",
+				}
+			}
+
+			metadata := analyzeAltSvcHeader(header)
+			threatLevel := evaluateAltSvcThreatLevel(metadata)
+
+			return TestResult{
+				Name:        "Alt-Svc / HTTP/3 Advertisement",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateAltSvcDescription(metadata),
+			}
+		},
+	}
+}
+
+// analyzeAltSvcHeader parses an Alt-Svc header value into structured metadata.
+//
+// Parameters:
+//   - header: Raw Alt-Svc header value (multiple header lines joined with commas)
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "alt_svc" (string): Raw header value
+//   - "clear" (bool): Header is "clear", invalidating previously advertised alternatives
+//   - "alternatives" ([]altSvcEntry): Well-formed alternatives
+//   - "protocols" ([]string): Distinct advertised protocol IDs
+//   - "ports" ([]int): Distinct advertised ports
+//   - "http3" (bool): An HTTP/3 alternative is advertised
+//   - "errors" ([]string): Reasons why alternatives were rejected
+//
+// Example:
+//
+//	metadata := analyzeAltSvcHeader(`h3=":443"; ma=86400, h2="alt.example.com:8443"`)
+//	// metadata["protocols"] == []string{"h3", "h2"}, metadata["ports"] == []int{443, 8443}
+func analyzeAltSvcHeader(header string) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"alt_svc":      header,
+		"clear":        false,
+		"alternatives": []altSvcEntry{},
+		"protocols":    []string{},
+		"ports":        []int{},
+		"http3":        false,
+		"errors":       []string{},
+	}
+	if strings.EqualFold(header, "clear") {
+		metadata["clear"] = true
+		return metadata
+	}
+
+	var entries []altSvcEntry
+	var errs []string
+	protocols := []string{}
+	ports := []int{}
+	seenProtocols := map[string]bool{}
+	seenPorts := map[int]bool{}
+	for _, raw := range splitAltSvcList(header, ',') {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		entry, err := parseAltSvcEntry(raw)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		entries = append(entries, entry)
+		if !seenProtocols[entry.Protocol] {
+			seenProtocols[entry.Protocol] = true
+			protocols = append(protocols, entry.Protocol)
+		}
+		if !seenPorts[entry.Port] {
+			seenPorts[entry.Port] = true
+			ports = append(ports, entry.Port)
+		}
+		if entry.Protocol == "h3" || strings.HasPrefix(entry.Protocol, "h3-") {
+			metadata["http3"] = true
+		}
+	}
+
+	if entries != nil {
+		metadata["alternatives"] = entries
+	}
+	if errs != nil {
+		metadata["errors"] = errs
+	}
+	metadata["protocols"] = protocols
+	metadata["ports"] = ports
+	return metadata
+}
+
+// parseAltSvcEntry parses a single alternative such as `h3="alt.example.com:443"; ma=3600; persist=1`.
+func parseAltSvcEntry(raw string) (altSvcEntry, error) {
+	parts := splitAltSvcList(raw, ';')
+	protocolID, authority, ok := strings.Cut(strings.TrimSpace(parts[0]), "=")
+	if !ok || protocolID == "" {
+		return altSvcEntry{}, fmt.Errorf("alternative %q has no protocol-id=\"authority\" pair", raw)
+	}
+	protocol, err := url.PathUnescape(protocolID)
+	if err != nil {
+		return altSvcEntry{}, fmt.Errorf("alternative %q has an invalid protocol-id", raw)
+	}
+
+	if len(authority) < 2 || authority[0] != '"' || authority[len(authority)-1] != '"' {
+		return altSvcEntry{}, fmt.Errorf("alternative %q has an unquoted authority", raw)
+	}
+	authority = authority[1 : len(authority)-1]
+	colon := strings.LastIndex(authority, ":")
+	if colon == -1 {
+		return altSvcEntry{}, fmt.Errorf("alternative %q has no port", raw)
+	}
+	port, err := strconv.Atoi(authority[colon+1:])
+	if err != nil || port < 1 || port > 65535 {
+		return altSvcEntry{}, fmt.Errorf("alternative %q has an invalid port", raw)
+	}
+
+	entry := altSvcEntry{
+		Protocol: protocol,
+		Host:     authority[:colon],
+		Port:     port,
+		MaxAge:   altSvcDefaultMaxAge,
+	}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "ma":
+			maxAge, err := strconv.Atoi(value)
+			if err != nil || maxAge < 0 || strings.HasPrefix(value, "+") {
+				return altSvcEntry{}, fmt.Errorf("alternative %q has an invalid ma value %q", raw, value)
+			}
+			entry.MaxAge = maxAge
+		case "persist":
+			entry.Persist = value == "1"
+		}
+	}
+	return entry, nil
+}
+
+// splitAltSvcList splits s on sep, ignoring separators inside quoted strings.
+func splitAltSvcList(s string, sep rune) []string {
+	var parts []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case r == sep && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(parts, current.String())
+}
+
+// evaluateAltSvcThreatLevel maps the Alt-Svc analysis to a threat level.
+func evaluateAltSvcThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	if len(metadata["errors"].([]string)) > 0 {
+		return Low
+	}
+	if metadata["http3"].(bool) {
+		return None
+	}
+	return Info
+}
+
+// generateAltSvcDescription builds a human-readable summary of the Alt-Svc analysis.
+func generateAltSvcDescription(metadata map[string]interface{}) string {
+	if errs := metadata["errors"].([]string); len(errs) > 0 {
+		return "Malformed Alt-Svc header: " + strings.Join(errs, "; ")
+	}
+	if metadata["clear"].(bool) {
+		return "Alt-Svc header clears all alternative services, HTTP/3 is not advertised"
+	}
+	protocols := strings.Join(metadata["protocols"].([]string), ", ")
+	if metadata["http3"].(bool) {
+		return "HTTP/3 is advertised via Alt-Svc (protocols: " + protocols + ")"
+	}
+	return "Alt-Svc advertises alternative services without HTTP/3 (protocols: " + protocols + ")"
+}
@@ -0,0 +1,83 @@
+package Tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAltSvcTest(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		wantThreat    ThreatLevel
+		wantProtocols []string
+		wantPorts     []int
+	}{
+		{name: "Missing header", header: "", wantThreat: Info},
+		{name: "HTTP/3 advertised", header: `h3=":443"; ma=86400, h3-29=":443"; ma=86400`,
+			wantThreat: None, wantProtocols: []string{"h3", "h3-29"}, wantPorts: []int{443}},
+		{name: "Alternative host and persist", header: `h3="alt.example.com:8443"; ma=3600; persist=1, h2=":443"`,
+			wantThreat: None, wantProtocols: []string{"h3", "h2"}, wantPorts: []int{8443, 443}},
+		{name: "Without HTTP/3", header: `h2=":8443"`, wantThreat: Info, wantProtocols: []string{"h2"}, wantPorts: []int{8443}},
+		{name: "Clear", header: "clear", wantThreat: Info},
+		{name: "Invalid max-age", header: `h3=":443"; ma=-5`, wantThreat: Low},
+		{name: "Non-numeric max-age", header: `h3=":443"; ma=soon`, wantThreat: Low},
+		{name: "Unquoted authority", header: `h3=:443`, wantThreat: Low},
+		{name: "Missing authority", header: `h3`, wantThreat: Low},
+		{name: "Invalid port", header: `h3=":70000"`, wantThreat: Low},
+		{name: "One valid one malformed", header: `h3=":443", h2="nohost"`, wantThreat: Low,
+			wantProtocols: []string{"h3"}, wantPorts: []int{443}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				response.Header.Set("Alt-Svc", tt.header)
+			}
+
+			result := NewAltSvcTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			if tt.wantProtocols == nil {
+				return
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			protocols := metadata["protocols"].([]string)
+			ports := metadata["ports"].([]int)
+			if len(protocols) != len(tt.wantProtocols) || len(ports) != len(tt.wantPorts) {
+				t.Fatalf("Expected protocols %v and ports %v, got %v and %v", tt.wantProtocols, tt.wantPorts, protocols, ports)
+			}
+			for i := range protocols {
+				if protocols[i] != tt.wantProtocols[i] {
+					t.Errorf("Expected protocols %v, got %v", tt.wantProtocols, protocols)
+				}
+			}
+			for i := range ports {
+				if ports[i] != tt.wantPorts[i] {
+					t.Errorf("Expected ports %v, got %v", tt.wantPorts, ports)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAltSvcEntry(t *testing.T) {
+	entry, err := parseAltSvcEntry(`h3="alt.example.com:443"; ma=3600; persist=1`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := altSvcEntry{Protocol: "h3", Host: "alt.example.com", Port: 443, MaxAge: 3600, Persist: true}
+	if entry != want {
+		t.Errorf("Expected %+v, got %+v", want, entry)
+	}
+
+	entry, err = parseAltSvcEntry(`h3%2D29=":443"`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entry.Protocol != "h3-29" || entry.MaxAge != altSvcDefaultMaxAge {
+		t.Errorf("Expected percent-decoded h3-29 with default max-age, got %+v", entry)
+	}
+}
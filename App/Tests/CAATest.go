@@ -0,0 +1,214 @@
+// Package Tests provides security testing functionality for Engine-AntiGinx.
+//
+// # CAATest Module
+//
+// This module looks up the DNS Certification Authority Authorization (CAA) records of the
+// target (RFC 8659). CAA records tell certificate authorities which of them may issue
+// certificates for a domain; without them any publicly trusted CA may do so, which widens
+// the attack surface for mis-issued certificates.
+//
+// The lookup climbs the DNS tree like a CA would: the records of the closest ancestor
+// publishing CAA (e.g., example.com for www.example.com) apply to the target.
+//
+//	Result:
+//	  Lookup failed                        → Info (certainty 50)
+//	  IP address target                    → Info (CAA not applicable)
+//	  No CAA / no "issue" property         → Info (issuance unrestricted)
+//	  CAA present but malformed            → Low
+//	  Well-formed CAA restricting issuance → None
+package Tests
+
+import (
+	"Engine-AntiGinx/App/DNS"
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// caaIssuerPattern matches the issuer domain part of an "issue"/"issuewild" value.
+var caaIssuerPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+
+// caaKnownTags lists property tags defined by RFC 8659 and its extensions.
+var caaKnownTags = map[string]bool{
+	"issue":        true,
+	"issuewild":    true,
+	"iodef":        true,
+	"issuemail":    true,
+	"issuevmc":     true,
+	"contactemail": true,
+	"contactphone": true,
+}
+
+// caaCriticalFlag marks a property which CAs must understand to issue.
+const caaCriticalFlag = 128
+
+// NewCAATest creates a test reporting whether certificate issuance for the target is
+// restricted to specific certificate authorities through DNS CAA records.
+//
+// Metadata includes:
+//   - host: Analyzed hostname
+//   - caa_domain: Domain at which the applicable CAA records were found (empty if none)
+//   - records: Raw CAA records
+//   - issuers: CAs authorized by "issue" properties
+//   - wildcard_issuers: CAs authorized by "issuewild" properties
+//   - iodef: Incident reporting URLs
+//   - issuance_restricted: true when at least one "issue" property is present
+//   - issuance_forbidden: true when an empty "issue" value forbids all issuance
+//   - problems: Malformed or unknown critical properties
+//
+// Example:
+//
+//	caaTest := NewCAATest()
+//	result := caaTest.Run(ResponseTestParams{Response: httpResponse})
+//	if result.ThreatLevel == Info {
+//	    fmt.Println("Any CA may issue certificates for this domain")
+//	}
+func NewCAATest() *ResponseTest {
+	return newCAATest(DNS.NewClient())
+}
+
+// newCAATest creates the CAA test with the given resolver, allowing lookups to be stubbed.
+func newCAATest(resolver DNS.Resolver) *ResponseTest {
+	return &ResponseTest{
+		Id:          "caa",
+		Name:        "DNS CAA Record Analysis",
+		Description: "Checks whether DNS CAA records restrict which certificate authorities may issue certificates",
+		Category:    "DNS",
+		RunTest: func(params ResponseTestParams) TestResult {
+			host := strings.ToLower(strings.TrimSuffix(params.Response.Request.URL.Hostname(), "."))
+			if host == "" || net.ParseIP(host) != nil {
+				return TestResult{
+					Name:        "DNS CAA Record Analysis",
+					Certainty:   100,
+					ThreatLevel: Info,
+					Metadata:    map[string]any{"host": host},
+					Description: "Target is not a domain name, CAA records are not applicable",
+				}
+			}
+
+			domain, records, err := lookupApplicableCAA(params.scanContext(), resolver, host)
+			if err != nil {
+				return TestResult{
+					Name:        "DNS CAA Record Analysis",
+					Certainty:   50,
+					ThreatLevel: Info,
+					Metadata:    map[string]any{"host": host, "error": err.Error()},
+					Description: fmt.Sprintf("CAA records could not be looked up: %v", err),
+				}
+			}
+
+			analysis := analyzeCAARecords(host, domain, records)
+			threat := evaluateCAAThreatLevel(analysis)
+			return TestResult{
+				Name:        "DNS CAA Record Analysis",
+				Certainty:   100,
+				ThreatLevel: threat,
+				Metadata:    analysis,
+				Description: generateCAADescription(analysis, threat),
+			}
+		},
+	}
+}
+
+// lookupApplicableCAA returns the CAA records of host or of its closest ancestor that
+// publishes any, stopping before the top-level domain.
+func lookupApplicableCAA(ctx context.Context, resolver DNS.Resolver, host string) (string, []DNS.CAARecord, error) {
+	labels := strings.Split(host, ".")
+	for i := 0; i < len(labels)-1; i++ {
+		domain := strings.Join(labels[i:], ".")
+		records, err := resolver.LookupCAA(ctx, domain)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(records) > 0 {
+			return domain, records, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// analyzeCAARecords collects issuers and problems from the applicable CAA records.
+func analyzeCAARecords(host, domain string, records []DNS.CAARecord) map[string]any {
+	issuers := []string{}
+	wildcardIssuers := []string{}
+	iodef := []string{}
+	problems := []string{}
+	hasIssue := false
+	issuanceForbidden := false
+
+	for _, record := range records {
+		switch record.Tag {
+		case "issue", "issuewild":
+			issuer := strings.ToLower(strings.TrimSpace(strings.SplitN(record.Value, ";", 2)[0]))
+			if issuer != "" && !caaIssuerPattern.MatchString(issuer) {
+				problems = append(problems, fmt.Sprintf("malformed %s value %q", record.Tag, record.Value))
+				continue
+			}
+			if record.Tag == "issue" {
+				hasIssue = true
+				if issuer == "" {
+					issuanceForbidden = true
+				} else {
+					issuers = append(issuers, issuer)
+				}
+			} else if issuer != "" {
+				wildcardIssuers = append(wildcardIssuers, issuer)
+			}
+		case "iodef":
+			iodef = append(iodef, record.Value)
+		default:
+			if record.Flag&caaCriticalFlag != 0 && !caaKnownTags[record.Tag] {
+				problems = append(problems, fmt.Sprintf("unknown critical property %q", record.Tag))
+			}
+		}
+	}
+	if len(issuers) > 0 {
+		// An empty issue value only forbids issuance when no CA is listed.
+		issuanceForbidden = false
+	}
+
+	return map[string]any{
+		"host":                host,
+		"caa_domain":          domain,
+		"records":             records,
+		"issuers":             uniqueStrings(issuers),
+		"wildcard_issuers":    uniqueStrings(wildcardIssuers),
+		"iodef":               iodef,
+		"issuance_restricted": hasIssue,
+		"issuance_forbidden":  issuanceForbidden,
+		"problems":            problems,
+	}
+}
+
+// evaluateCAAThreatLevel maps the CAA analysis to a threat level.
+func evaluateCAAThreatLevel(analysis map[string]any) ThreatLevel {
+	if len(analysis["problems"].([]string)) > 0 {
+		return Low
+	}
+	if !analysis["issuance_restricted"].(bool) {
+		return Info
+	}
+	return None
+}
+
+// generateCAADescription builds a human-readable summary of the CAA analysis.
+func generateCAADescription(analysis map[string]any, threat ThreatLevel) string {
+	switch threat {
+	case Low:
+		return fmt.Sprintf("CAA records at %s are malformed (%s), certificate authorities may ignore or refuse them",
+			analysis["caa_domain"], strings.Join(analysis["problems"].([]string), "; "))
+	case Info:
+		if analysis["caa_domain"] == "" {
+			return "No CAA records found, any certificate authority may issue certificates for this domain"
+		}
+		return fmt.Sprintf("CAA records at %s do not contain an issue property, issuance is not restricted", analysis["caa_domain"])
+	default:
+		if analysis["issuance_forbidden"].(bool) {
+			return fmt.Sprintf("CAA records at %s forbid certificate issuance by any certificate authority", analysis["caa_domain"])
+		}
+		return fmt.Sprintf("CAA records at %s restrict certificate issuance to: %s",
+			analysis["caa_domain"], strings.Join(analysis["issuers"].([]string), ", "))
+	}
+}
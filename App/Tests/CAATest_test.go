@@ -0,0 +1,158 @@
+package Tests
+
+import (
+	"Engine-AntiGinx/App/DNS"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// stubResolver answers DNS lookups from fixed maps and records queried domains.
+type stubResolver struct {
+	records map[string][]DNS.CAARecord
+	txt     map[string][]string
+	err     error
+	queried []string
+}
+
+func (s *stubResolver) LookupCAA(_ context.Context, domain string) ([]DNS.CAARecord, error) {
+	s.queried = append(s.queried, domain)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.records[domain], nil
+}
+
+func (s *stubResolver) LookupTXT(_ context.Context, domain string) ([]string, error) {
+	s.queried = append(s.queried, domain)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.txt[domain], nil
+}
+
+func responseFor(t *testing.T, rawURL string) *http.Response {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("Invalid URL %q: %v", rawURL, err)
+	}
+	return &http.Response{Request: &http.Request{URL: u}}
+}
+
+func TestCAATest(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		records     map[string][]DNS.CAARecord
+		err         error
+		wantThreat  ThreatLevel
+		wantIssuers []string
+	}{
+		{
+			name:       "No CAA records",
+			target:     "https://www.example.com",
+			wantThreat: Info,
+		},
+		{
+			name:   "CAA inherited from parent domain",
+			target: "https://www.example.com",
+			records: map[string][]DNS.CAARecord{
+				"example.com": {
+					{Tag: "issue", Value: "letsencrypt.org"},
+					{Tag: "issue", Value: "pki.goog; cansignhttpexchanges=yes"},
+					{Tag: "iodef", Value: "mailto:security@example.com"},
+				},
+			},
+			wantThreat:  None,
+			wantIssuers: []string{"letsencrypt.org", "pki.goog"},
+		},
+		{
+			name:   "Issuance forbidden",
+			target: "https://example.com",
+			records: map[string][]DNS.CAARecord{
+				"example.com": {{Tag: "issue", Value: ";"}},
+			},
+			wantThreat:  None,
+			wantIssuers: []string{},
+		},
+		{
+			name:   "Only iodef does not restrict issuance",
+			target: "https://example.com",
+			records: map[string][]DNS.CAARecord{
+				"example.com": {{Tag: "iodef", Value: "mailto:security@example.com"}},
+			},
+			wantThreat: Info,
+		},
+		{
+			name:   "Malformed issuer",
+			target: "https://example.com",
+			records: map[string][]DNS.CAARecord{
+				"example.com": {{Tag: "issue", Value: "not a domain!"}},
+			},
+			wantThreat: Low,
+		},
+		{
+			name:   "Unknown critical property",
+			target: "https://example.com",
+			records: map[string][]DNS.CAARecord{
+				"example.com": {{Tag: "issue", Value: "letsencrypt.org"}, {Flag: 128, Tag: "future"}},
+			},
+			wantThreat: Low,
+		},
+		{
+			name:       "Lookup failure",
+			target:     "https://example.com",
+			err:        errors.New("timeout"),
+			wantThreat: Info,
+		},
+		{
+			name:       "IP address target",
+			target:     "https://192.0.2.1",
+			wantThreat: Info,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &stubResolver{records: tt.records, err: tt.err}
+			result := newCAATest(resolver).Run(ResponseTestParams{Response: responseFor(t, tt.target)})
+
+			if result.TestId != "caa" {
+				t.Errorf("Expected TestId caa, got %q", result.TestId)
+			}
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			if tt.wantIssuers == nil {
+				return
+			}
+			metadata := result.Metadata.(map[string]any)
+			issuers := metadata["issuers"].([]string)
+			if len(issuers) != len(tt.wantIssuers) {
+				t.Fatalf("Expected issuers %v, got %v", tt.wantIssuers, issuers)
+			}
+			for i := range issuers {
+				if issuers[i] != tt.wantIssuers[i] {
+					t.Errorf("Expected issuers %v, got %v", tt.wantIssuers, issuers)
+				}
+			}
+		})
+	}
+}
+
+func TestCAATest_StopsBeforeTopLevelDomain(t *testing.T) {
+	resolver := &stubResolver{}
+	newCAATest(resolver).Run(ResponseTestParams{Response: responseFor(t, "https://a.b.example.com")})
+
+	want := []string{"a.b.example.com", "b.example.com", "example.com"}
+	if len(resolver.queried) != len(want) {
+		t.Fatalf("Expected lookups %v, got %v", want, resolver.queried)
+	}
+	for i := range want {
+		if resolver.queried[i] != want[i] {
+			t.Errorf("Expected lookups %v, got %v", want, resolver.queried)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the CORS allow-list test that analyzes the methods and request headers
+// a server permits for cross-origin requests (Access-Control-Allow-Methods and
+// Access-Control-Allow-Headers) together with Access-Control-Allow-Credentials.
+package Tests
+
+import (
+	"strings"
+)
+
+// corsRiskyMethods are methods which should never be allowed for cross-origin requests.
+var corsRiskyMethods = map[string]bool{
+	"TRACE":   true,
+	"CONNECT": true,
+}
+
+// NewCORSAllowListTest creates a new ResponseTest that analyzes the CORS allow-lists of
+// methods and request headers. Wildcards in these lists let any cross-origin page issue
+// state-changing requests with arbitrary headers, which is dangerous when the server also
+// allows credentialed (cookie bearing) cross-origin requests.
+//
+// The test evaluates:
+//   - Access-Control-Allow-Methods: advertised methods, "*" wildcard, TRACE/CONNECT
+//   - Access-Control-Allow-Headers: advertised request headers, "*" wildcard
+//   - Access-Control-Allow-Credentials: whether credentialed requests are allowed
+//
+// Threat level assessment:
+//   - None (0): Explicit method and header lists
+//   - Info (1): No allow-lists advertised, or wildcards without credentials
+//   - Low (2): Wildcard headers with credentials, or TRACE/CONNECT allowed
+//   - Medium (3): Wildcard methods with credentials
+//
+// Returns:
+//   - *ResponseTest: Configured CORS allow-list test ready for execution
+//
+// Example usage:
+//
+//	corsTest := NewCORSAllowListTest()
+//	result := corsTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata lists the advertised methods and headers
+func NewCORSAllowListTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "cors-allow",
+		Name:          "CORS Allowed Methods and Headers",
+		Description:   "Analyzes Access-Control-Allow-Methods and Access-Control-Allow-Headers for wildcards combined with credentialed CORS",
+		Category:      "Headers",
+		CWE:           "CWE-942",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"Access-Control-Allow-Methods", "Access-Control-Allow-Headers", "Access-Control-Allow-Credentials", "Access-Control-Allow-Origin"},
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeCORSAllowLists(HeaderValues(params.Response.Header, "Access-Control-Allow-Methods"),
+				HeaderValues(params.Response.Header, "Access-Control-Allow-Headers"),
+				HeaderValue(params.Response.Header, "Access-Control-Allow-Credentials"),
+				HeaderValue(params.Response.Header, "Access-Control-Allow-Origin"))
+			threatLevel := evaluateCORSAllowListThreatLevel(metadata)
+
+			return TestResult{
+				Name:        "CORS Allowed Methods and Headers",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateCORSAllowListDescription(metadata, threatLevel),
+			}
+		},
+	}
+}
+
+// analyzeCORSAllowLists parses the CORS allow-list headers into structured metadata.
+//
+// Parameters:
+//   - methodValues: Access-Control-Allow-Methods header lines
+//   - headerValues: Access-Control-Allow-Headers header lines
+//   - credentials: Access-Control-Allow-Credentials header value
+//   - origin: Access-Control-Allow-Origin header value
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "allow_methods" ([]string): Advertised methods, upper-cased
+//   - "allow_headers" ([]string): Advertised request headers, lower-cased
+//   - "allow_credentials" (bool): Credentialed requests are allowed
+//   - "allow_origin" (string): Raw Access-Control-Allow-Origin value
+//   - "methods_wildcard" (bool): Methods list contains "*"
+//   - "headers_wildcard" (bool): Headers list contains "*"
+//   - "risky_methods" ([]string): Advertised TRACE/CONNECT methods
+//   - "issues" ([]string): Detected problems
+//
+// Example:
+//
+//	metadata := analyzeCORSAllowLists([]string{"*"}, []string{"Content-Type"}, "true", "https://app.example.com")
+//	// metadata["methods_wildcard"] == true, metadata["allow_credentials"] == true
+func analyzeCORSAllowLists(methodValues, headerValues []string, credentials, origin string) map[string]interface{} {
+	methods := splitCORSList(methodValues, strings.ToUpper)
+	headers := splitCORSList(headerValues, strings.ToLower)
+	withCredentials := strings.EqualFold(strings.TrimSpace(credentials), "true")
+
+	methodsWildcard := false
+	riskyMethods := []string{}
+	for _, method := range methods {
+		if method == "*" {
+			methodsWildcard = true
+		} else if corsRiskyMethods[method] {
+			riskyMethods = append(riskyMethods, method)
+		}
+	}
+	headersWildcard := false
+	for _, header := range headers {
+		if header == "*" {
+			headersWildcard = true
+		}
+	}
+
+	issues := []string{}
+	if methodsWildcard && withCredentials {
+		issues = append(issues, "Access-Control-Allow-Methods: * combined with credentialed CORS allows any method from permitted origins")
+	}
+	if headersWildcard && withCredentials {
+		issues = append(issues, "Access-Control-Allow-Headers: * combined with credentialed CORS allows arbitrary request headers from permitted origins")
+	}
+	if len(riskyMethods) > 0 {
+		issues = append(issues, "cross-origin "+strings.Join(riskyMethods, ", ")+" requests are allowed")
+	}
+
+	return map[string]interface{}{
+		"allow_methods":     methods,
+		"allow_headers":     headers,
+		"allow_credentials": withCredentials,
+		"allow_origin":      strings.TrimSpace(origin),
+		"methods_wildcard":  methodsWildcard,
+		"headers_wildcard":  headersWildcard,
+		"risky_methods":     riskyMethods,
+		"issues":            issues,
+	}
+}
+
+// splitCORSList splits comma separated header lines into distinct, normalized tokens.
+func splitCORSList(values []string, normalize func(string) string) []string {
+	tokens := []string{}
+	seen := map[string]bool{}
+	for _, value := range values {
+		for _, token := range strings.Split(value, ",") {
+			token = normalize(strings.TrimSpace(token))
+			if token != "" && !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// evaluateCORSAllowListThreatLevel maps the CORS allow-list analysis to a threat level.
+func evaluateCORSAllowListThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	withCredentials := metadata["allow_credentials"].(bool)
+	switch {
+	case metadata["methods_wildcard"].(bool) && withCredentials:
+		return Medium
+	case len(metadata["issues"].([]string)) > 0:
+		return Low
+	case len(metadata["allow_methods"].([]string)) == 0 && len(metadata["allow_headers"].([]string)) == 0:
+		return Info
+	case metadata["methods_wildcard"].(bool) || metadata["headers_wildcard"].(bool):
+		return Info
+	default:
+		return None
+	}
+}
+
+// generateCORSAllowListDescription builds a human-readable summary of the CORS allow-list analysis.
+func generateCORSAllowListDescription(metadata map[string]interface{}, threatLevel ThreatLevel) string {
+	methods := metadata["allow_methods"].([]string)
+	headers := metadata["allow_headers"].([]string)
+	if issues := metadata["issues"].([]string); len(issues) > 0 {
+		return "Permissive CORS allow-lists: " + strings.Join(issues, "; ")
+	}
+	if len(methods) == 0 && len(headers) == 0 {
+		return "No Access-Control-Allow-Methods or Access-Control-Allow-Headers header, no cross-origin methods or headers are advertised"
+	}
+	if threatLevel == Info {
+		return "CORS allow-lists use wildcards without credentials (methods: " + strings.Join(methods, ", ") +
+			"; headers: " + strings.Join(headers, ", ") + ")"
+	}
+	return "CORS allow-lists are explicit (methods: " + strings.Join(methods, ", ") +
+		"; headers: " + strings.Join(headers, ", ") + ")"
+}
@@ -0,0 +1,62 @@
+package Tests
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCORSAllowListTest(t *testing.T) {
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		wantThreat  ThreatLevel
+		wantMethods []string
+		wantHeaders []string
+	}{
+		{name: "No CORS headers", headers: nil, wantThreat: Info, wantMethods: []string{}, wantHeaders: []string{}},
+		{name: "Explicit lists with credentials", headers: map[string]string{
+			"Access-Control-Allow-Methods":     "GET, post",
+			"Access-Control-Allow-Headers":     "Content-Type, X-Requested-With",
+			"Access-Control-Allow-Credentials": "true",
+		}, wantThreat: None, wantMethods: []string{"GET", "POST"}, wantHeaders: []string{"content-type", "x-requested-with"}},
+		{name: "Wildcard methods with credentials", headers: map[string]string{
+			"Access-Control-Allow-Methods":     "*",
+			"Access-Control-Allow-Headers":     "Content-Type",
+			"Access-Control-Allow-Credentials": "true",
+		}, wantThreat: Medium, wantMethods: []string{"*"}, wantHeaders: []string{"content-type"}},
+		{name: "Wildcard headers with credentials", headers: map[string]string{
+			"Access-Control-Allow-Methods":     "GET",
+			"Access-Control-Allow-Headers":     "*",
+			"Access-Control-Allow-Credentials": "true",
+		}, wantThreat: Low, wantMethods: []string{"GET"}, wantHeaders: []string{"*"}},
+		{name: "Wildcards without credentials", headers: map[string]string{
+			"Access-Control-Allow-Methods": "*",
+			"Access-Control-Allow-Headers": "*",
+		}, wantThreat: Info, wantMethods: []string{"*"}, wantHeaders: []string{"*"}},
+		{name: "TRACE allowed", headers: map[string]string{
+			"Access-Control-Allow-Methods": "GET, TRACE",
+		}, wantThreat: Low, wantMethods: []string{"GET", "TRACE"}, wantHeaders: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			for name, value := range tt.headers {
+				response.Header.Set(name, value)
+			}
+
+			result := NewCORSAllowListTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if methods := metadata["allow_methods"].([]string); !reflect.DeepEqual(methods, tt.wantMethods) {
+				t.Errorf("Expected methods %v, got %v", tt.wantMethods, methods)
+			}
+			if headers := metadata["allow_headers"].([]string); !reflect.DeepEqual(headers, tt.wantHeaders) {
+				t.Errorf("Expected headers %v, got %v", tt.wantHeaders, headers)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the CORS preflight caching test that sends a CORS preflight request and
+// analyzes how long browsers may cache its answer (Access-Control-Max-Age).
+package Tests
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// corsMaxAgeReferences documents CORS preflight requests and their caching.
+var corsMaxAgeReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Access-Control-Max-Age",
+	"https://fetch.spec.whatwg.org/#http-access-control-max-age",
+	"https://cheatsheetseries.owasp.org/cheatsheets/HTML5_Security_Cheat_Sheet.html#cross-origin-resource-sharing",
+}
+
+const (
+	// corsMaxAgeExtreme is the longest sensible preflight cache lifetime (one day, also the
+	// cap applied by Firefox; Chromium caps at two hours).
+	corsMaxAgeExtreme = 86400
+	// corsPreflightOrigin is the foreign origin the preflight request is sent from.
+	corsPreflightOrigin = "https://antiginx-cors-probe.invalid"
+	// corsPreflightMaxBody caps how much of the preflight response body is read.
+	corsPreflightMaxBody = 64 << 10
+)
+
+// NewCORSMaxAgeTest creates a new ResponseTest that analyzes the caching of CORS preflight
+// requests. It sends a preflight request (OPTIONS with Origin and
+// Access-Control-Request-Method: PUT) from a foreign origin to the scanned URL and reads
+// Access-Control-Max-Age from the answer, falling back to the scanned response (the only
+// source when it was loaded from a file).
+//
+// Browsers reuse a cached preflight answer for its max-age, so a very long lifetime keeps a
+// permissive CORS configuration (wildcard or reflected origin) in effect in browsers after
+// it has been fixed on the server. A missing or zero max-age is not a security issue, but
+// every non-simple cross-origin request then costs an extra preflight round trip.
+//
+// Threat level assessment:
+//   - None (0): CORS is not enabled, or preflight answers are cached for a sensible time
+//   - Info (1): Missing, zero, invalid or extreme (> 86400 s) max-age
+//   - Low (2): Extreme max-age on a permissive CORS configuration
+//
+// Returns:
+//   - *ResponseTest: Configured CORS preflight caching test ready for execution
+//
+// Example usage:
+//
+//	corsMaxAgeTest := NewCORSMaxAgeTest()
+//	result := corsMaxAgeTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["max_age"] holds the preflight cache lifetime in seconds (-1 if unknown)
+func NewCORSMaxAgeTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "cors-max-age",
+		Name:          "CORS Preflight Caching",
+		Description:   "Sends a CORS preflight request and analyzes Access-Control-Max-Age for extreme or missing preflight caching",
+		Category:      "Headers",
+		CWE:           "CWE-942",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeCORSMaxAge(params)
+			threatLevel := evaluateCORSMaxAgeThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "CORS Preflight Caching",
+				Certainty:   90,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateCORSMaxAgeDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Answer CORS preflight requests with an Access-Control-Max-Age between a few " +
+					"minutes and 86400 seconds, and only for explicitly allowed origins"
+				result.References = corsMaxAgeReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeCORSMaxAge sends the preflight request to the URL of the scanned response and
+// parses the CORS headers of its answer.
+//
+// Parameters:
+//   - params: Test parameters holding the response, the scan context and client
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "url" (string): URL the preflight request was sent to
+//   - "preflight_sent" (bool): A response to the preflight request was received
+//   - "status_code" (int): Status code of the preflight response (0 if none)
+//   - "cors_enabled" (bool): Access-Control-Allow-Origin was sent
+//   - "allow_origin" (string): Access-Control-Allow-Origin value
+//   - "allow_credentials" (bool): Credentialed requests are allowed
+//   - "origin_reflected" (bool): The foreign origin of the preflight was echoed back
+//   - "permissive" (bool): Any origin is allowed ("*", "null" or reflected)
+//   - "max_age_header" (string): Raw Access-Control-Max-Age value
+//   - "max_age_source" (string): "preflight" or "response", empty without max-age
+//   - "max_age" (int): Preflight cache lifetime in seconds, -1 if missing or invalid
+//   - "issues" ([]string): Detected problems
+//   - "error" (string): Why the preflight request failed, empty on success
+//
+// Example:
+//
+//	metadata := analyzeCORSMaxAge(ResponseTestParams{Response: httpResponse})
+//	// metadata["max_age"] == 31536000 for "Access-Control-Max-Age: 31536000"
+func analyzeCORSMaxAge(params ResponseTestParams) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"url":               "",
+		"preflight_sent":    false,
+		"status_code":       0,
+		"cors_enabled":      false,
+		"allow_origin":      "",
+		"allow_credentials": false,
+		"origin_reflected":  false,
+		"permissive":        false,
+		"max_age_header":    "",
+		"max_age_source":    "",
+		"max_age":           -1,
+		"issues":            []string{},
+		"error":             "",
+	}
+
+	var preflight http.Header
+	if params.Response != nil && params.Response.Request != nil && params.Response.Request.URL != nil {
+		target := *params.Response.Request.URL
+		target.Fragment = ""
+		metadata["url"] = target.String()
+		if params.Offline {
+			metadata["error"] = "response loaded from a file, preflight not sent"
+		} else if header, status, err := sendCORSPreflight(params, target.String()); err != nil {
+			metadata["error"] = err.Error()
+		} else {
+			preflight = header
+			metadata["preflight_sent"] = true
+			metadata["status_code"] = status
+		}
+	} else {
+		metadata["error"] = "request URL unknown"
+	}
+
+	// The preflight answer is authoritative; the scanned response is used when the server
+	// does not answer preflight requests with CORS headers.
+	header, source := preflight, "preflight"
+	if HeaderValue(header, "Access-Control-Allow-Origin") == "" && params.Response != nil {
+		header, source = params.Response.Header, "response"
+	}
+	origin := HeaderValue(header, "Access-Control-Allow-Origin")
+	if origin == "" {
+		return metadata
+	}
+	metadata["cors_enabled"] = true
+	metadata["allow_origin"] = origin
+	metadata["allow_credentials"] = strings.EqualFold(HeaderValue(header, "Access-Control-Allow-Credentials"), "true")
+	metadata["origin_reflected"] = source == "preflight" && strings.EqualFold(origin, corsPreflightOrigin)
+	metadata["permissive"] = origin == "*" || strings.EqualFold(origin, "null") || metadata["origin_reflected"].(bool)
+
+	issues := []string{}
+	raw := HeaderValue(header, "Access-Control-Max-Age")
+	if raw != "" {
+		metadata["max_age_header"] = raw
+		metadata["max_age_source"] = source
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			issues = append(issues, "Access-Control-Max-Age \""+raw+"\" is not a number of seconds, browsers ignore it")
+		} else {
+			metadata["max_age"] = seconds
+			if seconds > corsMaxAgeExtreme {
+				issues = append(issues, "preflight answers may be cached for "+strconv.Itoa(seconds)+
+					" seconds, longer than any browser honours ("+strconv.Itoa(corsMaxAgeExtreme)+")")
+			}
+		}
+	}
+	metadata["issues"] = issues
+	return metadata
+}
+
+// sendCORSPreflight sends a CORS preflight request from corsPreflightOrigin to target and
+// returns the headers and status code of the answer.
+func sendCORSPreflight(params ResponseTestParams, target string) (http.Header, int, error) {
+	request, err := http.NewRequestWithContext(params.scanContext(), http.MethodOptions, target, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	request.Header.Set("Origin", corsPreflightOrigin)
+	request.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	request.Header.Set("Access-Control-Request-Headers", "content-type")
+
+	response, err := params.httpClient().Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, corsPreflightMaxBody))
+	return response.Header, response.StatusCode, nil
+}
+
+// evaluateCORSMaxAgeThreatLevel maps the CORS preflight caching analysis to a threat level.
+func evaluateCORSMaxAgeThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	maxAge := metadata["max_age"].(int)
+	switch {
+	case !metadata["cors_enabled"].(bool):
+		return None
+	case maxAge > corsMaxAgeExtreme && metadata["permissive"].(bool):
+		return Low
+	case len(metadata["issues"].([]string)) > 0, maxAge <= 0:
+		return Info
+	default:
+		return None
+	}
+}
+
+// generateCORSMaxAgeDescription builds a human-readable summary of the CORS preflight caching analysis.
+func generateCORSMaxAgeDescription(metadata map[string]interface{}) string {
+	maxAge := metadata["max_age"].(int)
+	switch {
+	case !metadata["cors_enabled"].(bool):
+		if !metadata["preflight_sent"].(bool) && metadata["error"].(string) != "" {
+			return "CORS preflight request could not be sent (" + metadata["error"].(string) +
+				") and the response does not enable CORS"
+		}
+		return "CORS is not enabled (no Access-Control-Allow-Origin), preflight caching does not apply"
+	case len(metadata["issues"].([]string)) > 0:
+		description := "CORS preflight caching: " + strings.Join(metadata["issues"].([]string), "; ")
+		if maxAge > corsMaxAgeExtreme && metadata["permissive"].(bool) {
+			description += "; combined with the permissive Access-Control-Allow-Origin (" +
+				metadata["allow_origin"].(string) + "), a misconfiguration stays in effect in browsers long after it is fixed"
+		}
+		return description
+	case maxAge < 0:
+		return "CORS is enabled without Access-Control-Max-Age: browsers cache preflight answers for 5 seconds only, " +
+			"so most non-simple cross-origin requests need an extra preflight round trip"
+	case maxAge == 0:
+		return "Access-Control-Max-Age: 0 disables preflight caching, every non-simple cross-origin request needs " +
+			"an extra preflight round trip"
+	default:
+		return "CORS preflight answers are cached for " + strconv.Itoa(maxAge) + " seconds"
+	}
+}
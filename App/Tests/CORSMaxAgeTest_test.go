@@ -0,0 +1,105 @@
+package Tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// serveCORSPreflight starts a server answering OPTIONS requests with the headers of a
+// testdata/cors-max-age fixture ("Name: value" lines) and returns the scanned response.
+func serveCORSPreflight(t *testing.T, fixture string) *http.Response {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "cors-max-age", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+				name, value, _ := strings.Cut(line, ":")
+				w.Header().Add(strings.TrimSpace(name), strings.TrimSpace(value))
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte("<html><body>API</body></html>"))
+	}))
+	t.Cleanup(server.Close)
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/api", nil)
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: request}
+}
+
+func TestCORSMaxAgeTest(t *testing.T) {
+	tests := []struct {
+		name           string
+		fixture        string
+		wantThreat     ThreatLevel
+		wantMaxAge     int
+		wantPermissive bool
+	}{
+		{name: "Long max-age with reflected origin", fixture: "long.headers", wantThreat: Low, wantMaxAge: 31536000, wantPermissive: true},
+		{name: "Long max-age with restricted origin", fixture: "long-restricted.headers", wantThreat: Info, wantMaxAge: 604800},
+		{name: "Absent max-age", fixture: "absent.headers", wantThreat: Info, wantMaxAge: -1, wantPermissive: true},
+		{name: "Invalid max-age", fixture: "invalid.headers", wantThreat: Info, wantMaxAge: -1, wantPermissive: true},
+		{name: "Sensible max-age", fixture: "sensible.headers", wantThreat: None, wantMaxAge: 7200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := serveCORSPreflight(t, tt.fixture)
+
+			result := NewCORSMaxAgeTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if maxAge := metadata["max_age"].(int); maxAge != tt.wantMaxAge {
+				t.Errorf("Expected max-age %d, got %d", tt.wantMaxAge, maxAge)
+			}
+			if permissive := metadata["permissive"].(bool); permissive != tt.wantPermissive {
+				t.Errorf("Expected permissive %v, got %v", tt.wantPermissive, permissive)
+			}
+			if !metadata["preflight_sent"].(bool) || metadata["status_code"].(int) != http.StatusNoContent {
+				t.Errorf("Expected the preflight to be answered, got %v", metadata)
+			}
+		})
+	}
+}
+
+func TestCORSMaxAgeTest_NoCORS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer server.Close()
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Access-Control-Max-Age": {"600"}}, Request: request}
+
+	result := NewCORSMaxAgeTest().Run(ResponseTestParams{Response: response})
+
+	if result.ThreatLevel != None {
+		t.Errorf("Expected None without CORS, got %v (%s)", result.ThreatLevel, result.Description)
+	}
+	if result.Metadata.(map[string]interface{})["cors_enabled"].(bool) {
+		t.Errorf("Expected CORS to be reported as disabled")
+	}
+}
+
+func TestCORSMaxAgeTest_Offline(t *testing.T) {
+	response := serveCORSPreflight(t, "long.headers")
+	response.Header.Set("Access-Control-Allow-Origin", "*")
+	response.Header.Set("Access-Control-Max-Age", "600")
+
+	result := NewCORSMaxAgeTest().Run(ResponseTestParams{Response: response, Offline: true})
+
+	metadata := result.Metadata.(map[string]interface{})
+	if metadata["preflight_sent"].(bool) {
+		t.Errorf("Expected no preflight for a response loaded from a file")
+	}
+	if metadata["max_age_source"] != "response" || metadata["max_age"].(int) != 600 {
+		t.Errorf("Expected max-age to be read from the saved response, got %v", metadata)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	helpers "Engine-AntiGinx/App/Helpers"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -52,13 +53,25 @@ import (
 //	// Result includes threat level and detailed CSP configuration analysis
 func NewCSPTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "csp",
-		Name:        "Content Security Policy Analysis",
-		Description: "Analyzes Content-Security-Policy header configuration to assess protection against XSS, injection attacks, and resource loading security",
-		Category:    "Headers",
+		Id:            "csp",
+		Name:          "Content Security Policy Analysis",
+		Description:   "Analyzes Content-Security-Policy header configuration to assess protection against XSS, injection attacks, and resource loading security",
+		Category:      "Headers",
+		CWE:           "CWE-693",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"Content-Security-Policy"},
+		Rubric: []RubricEntry{
+			{ThreatLevel: None, Condition: "Policy strength of at least 80/100 without critical issues (strict directives, no unsafe values)"},
+			{ThreatLevel: Info, Condition: "Policy strength of 60-79/100 without critical issues"},
+			{ThreatLevel: Low, Condition: "Policy strength of 40-59/100 without critical issues"},
+			{ThreatLevel: Medium, Condition: "Policy strength of 20-39/100 without critical issues"},
+			{ThreatLevel: High, Condition: "Policy strength below 20/100"},
+			{ThreatLevel: High, Condition: "Critical issue in the policy: 'unsafe-inline' in script-src, 'unsafe-eval', or a wildcard in a critical directive"},
+			{ThreatLevel: Critical, Condition: "No Content-Security-Policy header"},
+		},
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Check for CSP header
-			cspHeader := params.Response.Header.Get("Content-Security-Policy")
+			cspHeader := HeaderValue(params.Response.Header, "Content-Security-Policy")
 
 			if cspHeader == "" {
 				return TestResult{
@@ -67,6 +80,9 @@ func NewCSPTest() *ResponseTest {
 					ThreatLevel: Critical,
 					Metadata:    nil,
 					Description: "Missing Content-Security-Policy header - site vulnerable to XSS attacks, data injection, and other script-based vulnerabilities. Implement CSP to restrict resource loading and script execution.",
+					Remediation: "Send a Content-Security-Policy header, starting from: " + baselineCSP +
+						" and allow further sources only as needed",
+					References: cspReferences,
 				}
 			}
 
@@ -79,17 +95,33 @@ func NewCSPTest() *ResponseTest {
 			// Generate description based on findings
 			description := generateCSPDescription(metadata)
 
-			return TestResult{
+			result := TestResult{
 				Name:        "Content Security Policy Analysis",
 				Certainty:   100,
 				ThreatLevel: threatLevel,
 				Metadata:    metadata,
 				Description: description,
 			}
+			if threatLevel > None {
+				result.Remediation = generateCSPRemediation(metadata)
+				result.References = cspReferences
+			}
+			return result
 		},
 	}
 }
 
+// baselineCSP is a restrictive policy suggested as the starting point for sites without CSP.
+const baselineCSP = "default-src 'self'; script-src 'self'; object-src 'none'; base-uri 'self'; " +
+	"frame-ancestors 'self'; form-action 'self'"
+
+// cspReferences documents the Content-Security-Policy header and strict policies.
+var cspReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/CSP",
+	"https://cheatsheetseries.owasp.org/cheatsheets/Content_Security_Policy_Cheat_Sheet.html",
+	"https://www.w3.org/TR/CSP3/",
+}
+
 // CSPAnalysis represents the parsed and analyzed CSP configuration
 type CSPAnalysis struct {
 	HasCSP              bool                `json:"hasCSP"`
@@ -368,6 +400,27 @@ func generateCSPDescription(analysis CSPAnalysis) string {
 	return result
 }
 
+// generateCSPRemediation lists the changes fixing the analyzed policy: removing unsafe
+// source expressions and adding the missing recommended directives.
+func generateCSPRemediation(analysis CSPAnalysis) string {
+	steps := []string{}
+	if len(analysis.UnsafeDirectives) > 0 {
+		unsafe := append([]string{}, analysis.UnsafeDirectives...)
+		sort.Strings(unsafe)
+		steps = append(steps, "Remove the unsafe source expressions ("+strings.Join(unsafe, ", ")+
+			"); allow inline scripts and styles with nonces or hashes instead of 'unsafe-inline'")
+	}
+	if len(analysis.MissingDirectives) > 0 {
+		missing := append([]string{}, analysis.MissingDirectives...)
+		sort.Strings(missing)
+		steps = append(steps, "Add the "+strings.Join(missing, ", ")+" directive(s), e.g. "+baselineCSP)
+	}
+	if len(steps) == 0 {
+		steps = append(steps, "Restrict default-src and script-src to 'self' or nonce/hash sources and set object-src 'none'")
+	}
+	return strings.Join(steps, ". ")
+}
+
 // CSP-specific utility functions
 func containsNonce(values []string) bool {
 	nonceRegex := regexp.MustCompile(`'nonce-[A-Za-z0-9+/=]+'`)
@@ -388,3 +441,24 @@ func containsHash(values []string) bool {
 	}
 	return false
 }
+
+// BlocksInlineScripts reports whether the policy stops injected inline scripts: the effective
+// script source list (script-src, or default-src as its fallback) exists and does not allow
+// 'unsafe-inline', or allows it only next to a nonce or hash, which makes browsers ignore it.
+//
+// Returns:
+//   - bool: true if inline scripts are blocked
+func (a CSPAnalysis) BlocksInlineScripts() bool {
+	sources, exists := a.Directives["script-src"]
+	if !exists {
+		if sources, exists = a.Directives["default-src"]; !exists {
+			return false
+		}
+	}
+	for _, source := range sources {
+		if strings.EqualFold(source, "'unsafe-inline'") {
+			return containsNonce(sources) || containsHash(sources)
+		}
+	}
+	return true
+}
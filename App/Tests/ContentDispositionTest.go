@@ -0,0 +1,208 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the Content-Disposition test that detects downloadable or user-uploaded
+// content rendered inline by the browser instead of being downloaded.
+package Tests
+
+import (
+	helpers "Engine-AntiGinx/App/Helpers"
+	"mime"
+	"path"
+	"strings"
+)
+
+// contentDispositionReferences documents the Content-Disposition header and safe file serving.
+var contentDispositionReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Disposition",
+	"https://cheatsheetseries.owasp.org/cheatsheets/File_Upload_Cheat_Sheet.html",
+}
+
+// uploadPathSegments are URL path segments under which applications commonly serve files
+// uploaded by users or offered for download.
+var uploadPathSegments = []string{
+	"upload", "uploads", "file", "files", "attachment", "attachments", "download", "downloads",
+	"user-content", "usercontent", "user-files", "media",
+}
+
+// downloadExtensions are file extensions of documents and archives which are downloaded
+// rather than browsed.
+var downloadExtensions = []string{
+	".pdf", ".zip", ".rar", ".7z", ".gz", ".tar", ".doc", ".docx", ".xls", ".xlsx", ".ppt",
+	".pptx", ".odt", ".ods", ".csv", ".txt", ".rtf", ".exe", ".msi", ".dmg", ".apk", ".bin",
+}
+
+// downloadContentTypes are media types of content that is not meant to be rendered as a page.
+var downloadContentTypes = []string{
+	"application/octet-stream", "application/pdf", "application/zip", "application/x-zip-compressed",
+	"application/gzip", "application/x-tar", "application/x-7z-compressed", "application/vnd.rar",
+	"application/msword", "application/vnd.ms-excel", "text/csv", "application/x-msdownload",
+}
+
+// scriptCapableContentTypes are media types browsers render as documents able to run scripts.
+var scriptCapableContentTypes = []string{
+	"text/html", "application/xhtml+xml", "image/svg+xml", "text/xml", "application/xml",
+}
+
+// NewContentDispositionTest creates a new ResponseTest that checks how downloadable content
+// is served. Files uploaded by users (avatars, attachments, documents) are attacker
+// controlled; when such a file is served inline with an HTML, SVG or XML content type, the
+// browser renders it in the site's origin and any script it contains runs as stored XSS.
+// "Content-Disposition: attachment" makes the browser download the file instead.
+//
+// A response is treated as downloadable content when its URL path lies under an upload or
+// download directory (e.g., /uploads/, /files/, /download/), ends in a document or archive
+// extension, or its content type is a download type such as application/octet-stream.
+//
+// The test evaluates:
+//   - The request URL path and the Content-Type of the response
+//   - The Content-Disposition type (attachment or inline) and filename
+//
+// Threat level assessment:
+//   - None (0): Not downloadable content, or served with "Content-Disposition: attachment"
+//   - Low (2): Downloadable content of a non-scriptable type served inline
+//   - Medium (3): Downloadable content of an HTML, SVG or XML type served inline
+//
+// Returns:
+//   - *ResponseTest: Configured Content-Disposition test ready for execution
+//
+// Example usage:
+//
+//	dispositionTest := NewContentDispositionTest()
+//	result := dispositionTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["content_type"] and Metadata["disposition"] describe how the file is served
+func NewContentDispositionTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "content-disposition",
+		Name:          "Content-Disposition for Downloads",
+		Description:   "Checks that uploaded and downloadable files are served with Content-Disposition: attachment instead of being rendered inline",
+		Category:      "Headers",
+		CWE:           "CWE-79",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeContentDisposition(params)
+			threatLevel := evaluateContentDispositionThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "Content-Disposition for Downloads",
+				Certainty:   70,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateContentDispositionDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Serve uploaded and downloadable files with \"Content-Disposition: attachment; " +
+					"filename=...\" and \"X-Content-Type-Options: nosniff\", and preferably from a separate, " +
+					"cookieless domain; never serve user uploads as text/html or image/svg+xml inline"
+				result.References = contentDispositionReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeContentDisposition collects the content type, disposition and download indicators
+// of a response.
+//
+// Parameters:
+//   - params: Test parameters holding the response (and the request that produced it)
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "content_type" (string): Media type of the response, lower-cased
+//   - "disposition" (string): Raw Content-Disposition header value, empty if absent
+//   - "disposition_type" (string): "attachment", "inline" or empty when absent or invalid
+//   - "filename" (string): Filename parameter of the disposition, empty if absent
+//   - "downloadable" (bool): Response looks like an uploaded or downloadable file
+//   - "download_reason" (string): Why the response was considered downloadable
+//   - "script_capable" (bool): Content type is rendered as a scriptable document
+//
+// Example:
+//
+//	metadata := analyzeContentDisposition(ResponseTestParams{Response: uploadResponse})
+//	// metadata["download_reason"] == "path under /uploads/"
+func analyzeContentDisposition(params ResponseTestParams) map[string]interface{} {
+	response := params.Response
+	contentType, _, err := mime.ParseMediaType(HeaderValue(response.Header, "Content-Type"))
+	if err != nil {
+		contentType = ""
+	}
+
+	disposition := HeaderValue(response.Header, "Content-Disposition")
+	dispositionType, filename := "", ""
+	if disposition != "" {
+		if kind, dispositionParams, err := mime.ParseMediaType(disposition); err == nil {
+			dispositionType = kind
+			filename = dispositionParams["filename"]
+		}
+	}
+
+	urlPath := ""
+	if response.Request != nil && response.Request.URL != nil {
+		urlPath = strings.ToLower(response.Request.URL.Path)
+	}
+	reason := downloadReason(urlPath, contentType)
+
+	return map[string]interface{}{
+		"content_type":     contentType,
+		"disposition":      disposition,
+		"disposition_type": dispositionType,
+		"filename":         filename,
+		"downloadable":     reason != "",
+		"download_reason":  reason,
+		"script_capable":   helpers.StringInSlice(scriptCapableContentTypes, contentType),
+	}
+}
+
+// downloadReason explains why a response with the given lower-cased URL path and content
+// type is considered downloadable content, or returns an empty string if it is not.
+func downloadReason(urlPath, contentType string) string {
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+	for _, segment := range segments[:len(segments)-1] {
+		if helpers.StringInSlice(uploadPathSegments, segment) {
+			return "path under /" + segment + "/"
+		}
+	}
+	if extension := path.Ext(urlPath); helpers.StringInSlice(downloadExtensions, extension) {
+		return "file extension " + extension
+	}
+	if helpers.StringInSlice(downloadContentTypes, contentType) {
+		return "content type " + contentType
+	}
+	return ""
+}
+
+// evaluateContentDispositionThreatLevel maps the Content-Disposition analysis to a threat level.
+func evaluateContentDispositionThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch {
+	case !metadata["downloadable"].(bool) || metadata["disposition_type"] == "attachment":
+		return None
+	case metadata["script_capable"].(bool):
+		return Medium
+	default:
+		return Low
+	}
+}
+
+// generateContentDispositionDescription builds a human-readable summary of the analysis.
+func generateContentDispositionDescription(metadata map[string]interface{}) string {
+	if !metadata["downloadable"].(bool) {
+		return "Response is not an uploaded or downloadable file, Content-Disposition is not required"
+	}
+	contentType := metadata["content_type"].(string)
+	if contentType == "" {
+		contentType = "no content type"
+	}
+	served := "without Content-Disposition"
+	if disposition := metadata["disposition"].(string); disposition != "" {
+		served = "with Content-Disposition: " + disposition
+	}
+	file := "Downloadable content (" + metadata["download_reason"].(string) + ") is served as " + contentType + " " + served
+	switch evaluateContentDispositionThreatLevel(metadata) {
+	case None:
+		return file + ", so browsers download it instead of rendering it"
+	case Medium:
+		return file + ", so browsers render it inline in the site's origin; an uploaded file containing " +
+			"scripts runs as stored XSS"
+	default:
+		return file + ", so browsers may display it inline instead of downloading it"
+	}
+}
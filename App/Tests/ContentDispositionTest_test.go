@@ -0,0 +1,85 @@
+package Tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestContentDispositionTest(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		contentType     string
+		disposition     string
+		wantThreat      ThreatLevel
+		wantDownload    bool
+		wantDisposition string
+	}{
+		{name: "Regular page", path: "/about", contentType: "text/html; charset=utf-8", wantThreat: None},
+		{
+			name:         "Inline-served HTML download",
+			path:         "/uploads/2024/report.html",
+			contentType:  "text/html",
+			wantThreat:   Medium,
+			wantDownload: true,
+		},
+		{
+			name:            "Attached HTML download",
+			path:            "/uploads/2024/report.html",
+			contentType:     "text/html",
+			disposition:     `attachment; filename="report.html"`,
+			wantThreat:      None,
+			wantDownload:    true,
+			wantDisposition: "attachment",
+		},
+		{
+			name:            "Explicitly inline SVG upload",
+			path:            "/media/avatar.svg",
+			contentType:     "image/svg+xml",
+			disposition:     "inline",
+			wantThreat:      Medium,
+			wantDownload:    true,
+			wantDisposition: "inline",
+		},
+		{
+			name:         "PDF without disposition",
+			path:         "/docs/terms.pdf",
+			contentType:  "application/pdf",
+			wantThreat:   Low,
+			wantDownload: true,
+		},
+		{
+			name:         "Binary content type",
+			path:         "/export",
+			contentType:  "application/octet-stream",
+			wantThreat:   Low,
+			wantDownload: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{
+				Header:  http.Header{},
+				Request: &http.Request{URL: &url.URL{Scheme: "https", Host: "example.com", Path: tt.path}},
+			}
+			response.Header.Set("Content-Type", tt.contentType)
+			if tt.disposition != "" {
+				response.Header.Set("Content-Disposition", tt.disposition)
+			}
+
+			result := NewContentDispositionTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if metadata["downloadable"] != tt.wantDownload || metadata["disposition_type"] != tt.wantDisposition {
+				t.Errorf("Unexpected metadata %v", metadata)
+			}
+			if (result.Remediation != "") != (tt.wantThreat > None) {
+				t.Errorf("Expected remediation only for findings, got %q", result.Remediation)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the cookie scheme reuse test that detects session cookies set over
+// both HTTP and HTTPS along the redirect chain of the scan.
+package Tests
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// cookieSchemeReuseReferences documents session cookies leaking over plaintext HTTP.
+var cookieSchemeReuseReferences = []string{
+	"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/06-Session_Management_Testing/02-Testing_for_Cookies_Attributes",
+	"https://cheatsheetseries.owasp.org/cheatsheets/Session_Management_Cheat_Sheet.html#transport-layer-security",
+	"https://cwe.mitre.org/data/definitions/614.html",
+}
+
+// NewCookieSchemeReuseTest creates a new ResponseTest that follows the redirect chain of the
+// scan (Request.Response) and compares the cookies set on its HTTP and HTTPS hops. A
+// session cookie the server sets over plain HTTP without the Secure flag and again, under
+// the same name, over HTTPS is the same session token travelling in plaintext: a network
+// attacker reading the HTTP hop can hijack the HTTPS session. Unlike the generic missing
+// Secure warning of cookie-sec, the leak is observed on the wire.
+//
+// The chain is only observable when the scan starts over HTTP, so the target is fetched
+// over HTTP whenever this test is selected (see TargetFormatter).
+//
+// Threat level assessment:
+//   - None (0): No session cookie is reused across HTTP and HTTPS
+//   - Info (1): The scan started over HTTPS, the HTTP hop could not be observed
+//   - High (4): A session cookie set without Secure over HTTP is set again over HTTPS
+//
+// Returns:
+//   - *ResponseTest: Configured cookie scheme reuse test ready for execution
+//
+// Example usage:
+//
+//	reuseTest := NewCookieSchemeReuseTest()
+//	result := reuseTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["reused_cookies"] lists the session cookies sent over both schemes
+func NewCookieSchemeReuseTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "cookie-scheme-reuse",
+		Name:          "Session Cookie Reuse Across HTTP and HTTPS",
+		Description:   "Detects session cookies set without Secure over HTTP and again over HTTPS along the redirect chain, exposing the session token in plaintext",
+		Category:      "App-Configuration",
+		CWE:           "CWE-614",
+		OWASPCategory: "A02:2021-Cryptographic Failures",
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeCookieSchemeReuse(params)
+			threatLevel := evaluateCookieSchemeReuseThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "Session Cookie Reuse Across HTTP and HTTPS",
+				Certainty:   90,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateCookieSchemeReuseDescription(metadata),
+			}
+			if threatLevel > Info {
+				result.Remediation = "Do not issue session cookies over HTTP: redirect to HTTPS before any session is " +
+					"created, set the Secure flag on every session cookie and enable HSTS so browsers never send " +
+					"the cookie over plaintext again"
+				result.References = cookieSchemeReuseReferences
+			}
+			return result
+		},
+	}
+}
+
+// redirectChain returns the responses of the redirect chain ending with response, oldest
+// first. The chain is followed back through Request.Response.
+func redirectChain(response *http.Response) []*http.Response {
+	chain := []*http.Response{response}
+	for request := response.Request; request != nil && request.Response != nil; request = request.Response.Request {
+		chain = append([]*http.Response{request.Response}, chain...)
+	}
+	return chain
+}
+
+// analyzeCookieSchemeReuse collects the session cookies set on the HTTP and HTTPS hops of
+// the redirect chain.
+//
+// Parameters:
+//   - params: Test parameters holding the final response of the scan
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "started_over_http" (bool): Whether the first request of the chain used HTTP
+//   - "hops" ([]string): URLs of the chain, oldest first
+//   - "http_cookies" ([]string): Session cookies set without Secure over HTTP, sorted
+//   - "https_cookies" ([]string): Session cookies set over HTTPS, sorted
+//   - "reused_cookies" ([]string): Cookies in both lists, sorted
+//
+// Example:
+//
+//	metadata := analyzeCookieSchemeReuse(ResponseTestParams{Response: response})
+//	// metadata["reused_cookies"] == []string{"JSESSIONID"}
+func analyzeCookieSchemeReuse(params ResponseTestParams) map[string]interface{} {
+	chain := redirectChain(params.Response)
+	httpCookies := make(map[string]bool)
+	httpsCookies := make(map[string]bool)
+	hops := make([]string, 0, len(chain))
+	for _, hop := range chain {
+		if hop.Request == nil || hop.Request.URL == nil {
+			continue
+		}
+		hops = append(hops, hop.Request.URL.String())
+		for _, cookie := range responseCookies(hop) {
+			if !isSessionCookie(cookie) {
+				continue
+			}
+			switch strings.ToLower(hop.Request.URL.Scheme) {
+			case "http":
+				if !cookie.Secure {
+					httpCookies[cookie.Name] = true
+				}
+			case "https":
+				httpsCookies[cookie.Name] = true
+			}
+		}
+	}
+
+	reused := []string{}
+	for name := range httpCookies {
+		if httpsCookies[name] {
+			reused = append(reused, name)
+		}
+	}
+	sort.Strings(reused)
+
+	startedOverHTTP := false
+	if first := chain[0]; first.Request != nil && first.Request.URL != nil {
+		startedOverHTTP = strings.EqualFold(first.Request.URL.Scheme, "http")
+	}
+	return map[string]interface{}{
+		"started_over_http": startedOverHTTP,
+		"hops":              hops,
+		"http_cookies":      sortedNames(httpCookies),
+		"https_cookies":     sortedNames(httpsCookies),
+		"reused_cookies":    reused,
+	}
+}
+
+// sortedNames returns the keys of names, sorted.
+func sortedNames(names map[string]bool) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// evaluateCookieSchemeReuseThreatLevel maps the analysis to a threat level.
+func evaluateCookieSchemeReuseThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch {
+	case len(metadata["reused_cookies"].([]string)) > 0:
+		return High
+	case !metadata["started_over_http"].(bool):
+		return Info
+	default:
+		return None
+	}
+}
+
+// generateCookieSchemeReuseDescription builds a human-readable summary of the analysis.
+func generateCookieSchemeReuseDescription(metadata map[string]interface{}) string {
+	if reused := metadata["reused_cookies"].([]string); len(reused) > 0 {
+		return "Session cookies set without Secure over HTTP and again over HTTPS: " + strings.Join(reused, ", ") +
+			". The session token travels in plaintext and can be captured to hijack the HTTPS session"
+	}
+	if !metadata["started_over_http"].(bool) {
+		return "Scan started over HTTPS, session cookie reuse across HTTP and HTTPS not checked"
+	}
+	return "No session cookie is set over both HTTP and HTTPS"
+}
@@ -0,0 +1,145 @@
+package Tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// redirectingResponse builds the final response of a redirect chain from http://example.com/
+// to https://example.com/, with the given Set-Cookie headers on each hop.
+func redirectingResponse(t *testing.T, httpCookies, httpsCookies []string) *http.Response {
+	t.Helper()
+	first, err := url.Parse("http://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := url.Parse("https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	redirect := &http.Response{StatusCode: http.StatusMovedPermanently, Header: http.Header{}, Request: &http.Request{URL: first}}
+	for _, cookie := range httpCookies {
+		redirect.Header.Add("Set-Cookie", cookie)
+	}
+	final := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: &http.Request{URL: second, Response: redirect}}
+	for _, cookie := range httpsCookies {
+		final.Header.Add("Set-Cookie", cookie)
+	}
+	return final
+}
+
+func TestCookieSchemeReuseTest(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      func(t *testing.T) *http.Response
+		wantThreat    ThreatLevel
+		wantReused    []string
+		wantStartHTTP bool
+	}{
+		{
+			name: "Session cookie reused without Secure",
+			response: func(t *testing.T) *http.Response {
+				return redirectingResponse(t, []string{"JSESSIONID=abc123; Path=/"}, []string{"JSESSIONID=abc123; Path=/; Secure; HttpOnly"})
+			},
+			wantThreat:    High,
+			wantReused:    []string{"JSESSIONID"},
+			wantStartHTTP: true,
+		},
+		{
+			name: "Secure on the HTTP hop",
+			response: func(t *testing.T) *http.Response {
+				return redirectingResponse(t, []string{"JSESSIONID=abc123; Path=/; Secure"}, []string{"JSESSIONID=abc123; Path=/; Secure"})
+			},
+			wantThreat:    None,
+			wantReused:    []string{},
+			wantStartHTTP: true,
+		},
+		{
+			name: "Session cookie set over HTTPS only",
+			response: func(t *testing.T) *http.Response {
+				return redirectingResponse(t, nil, []string{"sessionid=xyz; Path=/; Secure"})
+			},
+			wantThreat:    None,
+			wantReused:    []string{},
+			wantStartHTTP: true,
+		},
+		{
+			name: "Different cookies per scheme",
+			response: func(t *testing.T) *http.Response {
+				return redirectingResponse(t, []string{"visitor=1; Path=/"}, []string{"sessionid=xyz; Path=/; Secure"})
+			},
+			wantThreat:    None,
+			wantReused:    []string{},
+			wantStartHTTP: true,
+		},
+		{
+			name: "Scan started over HTTPS",
+			response: func(t *testing.T) *http.Response {
+				target, _ := url.Parse("https://example.com/")
+				return &http.Response{Header: http.Header{"Set-Cookie": {"sessionid=xyz"}}, Request: &http.Request{URL: target}}
+			},
+			wantThreat: Info,
+			wantReused: []string{},
+		},
+		{
+			name: "No request details",
+			response: func(t *testing.T) *http.Response {
+				return &http.Response{Header: http.Header{}}
+			},
+			wantThreat: Info,
+			wantReused: []string{},
+		},
+	}
+
+	reuseTest := NewCookieSchemeReuseTest()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := reuseTest.Run(ResponseTestParams{Response: tt.response(t)})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if reused := metadata["reused_cookies"].([]string); !reflect.DeepEqual(reused, tt.wantReused) {
+				t.Errorf("Expected reused cookies %v, got %v", tt.wantReused, reused)
+			}
+			if metadata["started_over_http"] != tt.wantStartHTTP {
+				t.Errorf("Expected started_over_http %v, got %v", tt.wantStartHTTP, metadata["started_over_http"])
+			}
+			if (result.Remediation != "") != (tt.wantThreat == High) {
+				t.Errorf("Unexpected remediation %q for threat level %v", result.Remediation, result.ThreatLevel)
+			}
+		})
+	}
+}
+
+func TestCookieSchemeReuseTest_RedirectFlow(t *testing.T) {
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		http.SetCookie(writer, &http.Cookie{Name: "PHPSESSID", Value: "f00d", Path: "/", Secure: true, HttpOnly: true})
+	}))
+	defer secure.Close()
+	plain := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		http.SetCookie(writer, &http.Cookie{Name: "PHPSESSID", Value: "f00d", Path: "/"})
+		http.Redirect(writer, request, secure.URL, http.StatusMovedPermanently)
+	}))
+	defer plain.Close()
+
+	response, err := secure.Client().Get(plain.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	result := NewCookieSchemeReuseTest().Run(ResponseTestParams{Response: response})
+
+	if result.ThreatLevel != High {
+		t.Errorf("Expected threat level High, got %v (%s)", result.ThreatLevel, result.Description)
+	}
+	metadata := result.Metadata.(map[string]interface{})
+	if hops := metadata["hops"].([]string); len(hops) != 2 {
+		t.Errorf("Expected 2 hops, got %v", hops)
+	}
+}
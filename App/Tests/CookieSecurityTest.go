@@ -26,11 +26,12 @@ import (
 //   - Expiration times (Max-Age/Expires values)
 //   - Cookie predictability and session fixation risks
 //   - Cookie name patterns indicating sensitive data
+//   - Malformed or nameless Set-Cookie headers and cookies larger than 4KB ("cookie bombing")
 //
 // Threat level assessment:
 //   - None (0): All cookies properly secured with HttpOnly, Secure, SameSite, and reasonable expiration
 //   - Info (1): Minor issues like long expiration times but critical flags present
-//   - Low (2): Some cookies missing non-critical security attributes
+//   - Low (2): Some cookies missing non-critical security attributes, oversized or malformed cookies
 //   - Medium (3): Cookies missing important security flags (HttpOnly or Secure)
 //   - High (4): Multiple security issues or session cookies without protection
 //   - Critical (5): Session cookies completely unsecured or high fixation risk
@@ -41,6 +42,7 @@ import (
 //   - Missing SameSite: Vulnerable to CSRF attacks
 //   - Long expiration: Extended window for cookie theft/replay attacks
 //   - Predictable values: Session fixation and prediction attacks
+//   - Oversized cookies: Subsequent requests may exceed server header limits and be rejected
 //
 // Returns:
 //   - *ResponseTest: Configured cookie security test ready for execution
@@ -52,15 +54,16 @@ import (
 //	// Result includes threat level and detailed cookie security analysis
 func NewCookieSecurityTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "cookie-sec",
-		Name:        "Cookie Security Analysis",
-		Description: "Analyzes Set-Cookie headers for security attributes including HttpOnly, Secure, SameSite, expiration times, and session fixation risks",
-		Category:    "App-Configuration",
+		Id:           "cookie-sec",
+		Name:         "Cookie Security Analysis",
+		Description:  "Analyzes Set-Cookie headers for security attributes including HttpOnly, Secure, SameSite, expiration times, and session fixation risks",
+		Category:     "App-Configuration",
+		CacheHeaders: []string{"Set-Cookie"},
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Get all Set-Cookie headers
-			cookies := params.Response.Cookies()
+			cookies := responseCookies(params.Response)
 
-			if len(cookies) == 0 {
+			if len(cookies) == 0 && len(HeaderValues(params.Response.Header, "Set-Cookie")) == 0 {
 				return TestResult{
 					Name:        "Cookie Security Analysis",
 					Certainty:   100,
@@ -79,13 +82,18 @@ func NewCookieSecurityTest() *ResponseTest {
 			// Generate description
 			description := generateCookieDescription(analysis)
 
-			return TestResult{
+			result := TestResult{
 				Name:        "Cookie Security Analysis",
 				Certainty:   100,
 				ThreatLevel: threatLevel,
 				Metadata:    analysis,
 				Description: description,
+				Remediation: generateCookieRemediation(analysis),
 			}
+			if result.Remediation != "" {
+				result.References = cookieReferences
+			}
+			return result
 		},
 	}
 }
@@ -103,6 +111,8 @@ type CookieSecurityAnalysis struct {
 	SessionCookies       int                    `json:"sessionCookies"`
 	InsecureSession      bool                   `json:"insecureSession"`
 	FixationRisk         bool                   `json:"fixationRisk"`
+	OversizedCookies     int                    `json:"oversizedCookies"`
+	MalformedCookies     int                    `json:"malformedCookies"`
 	OverallSecurityScore int                    `json:"overallSecurityScore"` // 0-100
 }
 
@@ -118,8 +128,20 @@ type CookieSecurityDetail struct {
 	SecurityIssues   []string `json:"securityIssues"`
 	SecurityScore    int      `json:"securityScore"` // 0-100
 	PredictableValue bool     `json:"predictableValue"`
+	Size             int      `json:"size"` // Bytes of name=value sent back in the Cookie header
+}
+
+// cookieReferences documents the Set-Cookie attributes and secure session management.
+var cookieReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie",
+	"https://cheatsheetseries.owasp.org/cheatsheets/Session_Management_Cheat_Sheet.html",
+	"https://www.rfc-editor.org/rfc/rfc6265",
 }
 
+// maxCookieSize is the cookie size (name and value) browsers are required to support
+// (RFC 6265, section 6.1). Larger cookies risk overflowing request header limits.
+const maxCookieSize = 4096
+
 // analyzeCookieSecurity performs comprehensive analysis of all cookies
 func analyzeCookieSecurity(cookies []*http.Cookie, headers http.Header) CookieSecurityAnalysis {
 	analysis := CookieSecurityAnalysis{
@@ -130,7 +152,7 @@ func analyzeCookieSecurity(cookies []*http.Cookie, headers http.Header) CookieSe
 	}
 
 	// Get raw Set-Cookie headers for additional analysis
-	setCookieHeaders := headers.Values("Set-Cookie")
+	setCookieHeaders := HeaderValues(headers, "Set-Cookie")
 
 	for i, cookie := range cookies {
 		detail := analyzeSingleCookie(cookie, setCookieHeaders, i)
@@ -158,6 +180,16 @@ func analyzeCookieSecurity(cookies []*http.Cookie, headers http.Header) CookieSe
 		if detail.PredictableValue {
 			analysis.FixationRisk = true
 		}
+		if detail.Size > maxCookieSize {
+			analysis.OversizedCookies++
+		}
+	}
+
+	// Net/http silently drops Set-Cookie headers without a valid name=value pair
+	for _, header := range setCookieHeaders {
+		if _, err := http.ParseSetCookie(header); err != nil {
+			analysis.MalformedCookies++
+		}
 	}
 
 	// Aggregate security issues
@@ -179,6 +211,7 @@ func analyzeSingleCookie(cookie *http.Cookie, setCookieHeaders []string, index i
 		MaxAge:          cookie.MaxAge,
 		IsSessionCookie: isSessionCookie(cookie),
 		SecurityIssues:  []string{},
+		Size:            len(cookie.Name) + 1 + len(cookie.Value),
 	}
 
 	// Calculate expiration
@@ -255,6 +288,12 @@ func analyzeIndividualCookieSecurity(detail *CookieSecurityDetail, cookie *http.
 			"Cookie value appears predictable - potential session fixation risk")
 	}
 
+	// Check cookie size
+	if detail.Size > maxCookieSize {
+		detail.SecurityIssues = append(detail.SecurityIssues,
+			fmt.Sprintf("Cookie size of %d bytes exceeds 4KB - subsequent requests may overflow header limits (cookie bombing)", detail.Size))
+	}
+
 	// Check sensitive cookie names
 	sensitiveNames := []string{"session", "sessid", "auth", "token", "jwt", "access"}
 	nameLower := strings.ToLower(detail.Name)
@@ -303,6 +342,16 @@ func aggregateSecurityIssues(analysis *CookieSecurityAnalysis) {
 			fmt.Sprintf("%d cookie(s) with excessive expiration times", analysis.LongExpiration))
 	}
 
+	// Cookie bombing issues
+	if analysis.OversizedCookies > 0 {
+		analysis.SecurityIssues = append(analysis.SecurityIssues,
+			fmt.Sprintf("%d cookie(s) larger than 4KB - risk of request header overflow", analysis.OversizedCookies))
+	}
+	if analysis.MalformedCookies > 0 {
+		analysis.SecurityIssues = append(analysis.SecurityIssues,
+			fmt.Sprintf("%d malformed or nameless Set-Cookie header(s)", analysis.MalformedCookies))
+	}
+
 	// Session fixation risk
 	if analysis.FixationRisk {
 		analysis.CriticalIssues = append(analysis.CriticalIssues,
@@ -388,6 +437,11 @@ func calculateIndividualCookieScore(detail CookieSecurityDetail) int {
 		score -= 15
 	}
 
+	// Deduct for oversized cookies
+	if detail.Size > maxCookieSize {
+		score -= 10
+	}
+
 	// Extra penalty for insecure session cookies
 	if detail.IsSessionCookie && (!detail.HasHttpOnly || !detail.HasSecure) {
 		score -= 10
@@ -417,8 +471,9 @@ func evaluateCookieThreatLevel(analysis CookieSecurityAnalysis) ThreatLevel {
 		return Medium
 	}
 
-	// Low: Minor issues like SameSite or long expiration
-	if analysis.MissingSameSite > 0 || analysis.LongExpiration > 0 {
+	// Low: Minor issues like SameSite, long expiration or oversized and malformed cookies
+	if analysis.MissingSameSite > 0 || analysis.LongExpiration > 0 ||
+		analysis.OversizedCookies > 0 || analysis.MalformedCookies > 0 {
 		return Low
 	}
 
@@ -469,6 +524,40 @@ func generateCookieDescription(analysis CookieSecurityAnalysis) string {
 	return result
 }
 
+// generateCookieRemediation lists the attribute changes fixing every detected cookie issue,
+// ending with an example of a well configured session cookie.
+//
+// Returns:
+//   - string: Remediation text, empty when no issue was found
+func generateCookieRemediation(analysis CookieSecurityAnalysis) string {
+	steps := []string{}
+	if analysis.MissingHttpOnly > 0 {
+		steps = append(steps, fmt.Sprintf("Add HttpOnly to %d cookie(s) not read by JavaScript", analysis.MissingHttpOnly))
+	}
+	if analysis.MissingSecure > 0 {
+		steps = append(steps, fmt.Sprintf("Add Secure to %d cookie(s) so they are only sent over HTTPS", analysis.MissingSecure))
+	}
+	if analysis.MissingSameSite > 0 {
+		steps = append(steps, fmt.Sprintf("Set SameSite=Lax (or Strict) on %d cookie(s)", analysis.MissingSameSite))
+	}
+	if analysis.LongExpiration > 0 {
+		steps = append(steps, fmt.Sprintf("Shorten Max-Age/Expires of %d cookie(s)", analysis.LongExpiration))
+	}
+	if analysis.FixationRisk {
+		steps = append(steps, "Generate session identifiers with a cryptographically secure random generator and rotate them after login")
+	}
+	if analysis.OversizedCookies > 0 {
+		steps = append(steps, fmt.Sprintf("Keep each cookie below %d bytes, storing large state server-side", maxCookieSize))
+	}
+	if analysis.MalformedCookies > 0 {
+		steps = append(steps, "Fix malformed Set-Cookie headers so every cookie has a name=value pair")
+	}
+	if len(steps) == 0 {
+		return ""
+	}
+	return strings.Join(steps, ". ") + ". Example: Set-Cookie: session=<random>; Path=/; Secure; HttpOnly; SameSite=Lax"
+}
+
 // Utility functions
 
 // getSameSiteString converts SameSite enum to string
@@ -556,3 +645,18 @@ func formatDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%.0f second(s)", d.Seconds())
 }
+
+// ScriptReadableSessionCookies returns the names of session cookies set without HttpOnly,
+// which scripts injected through XSS can read and exfiltrate.
+//
+// Returns:
+//   - []string: Cookie names in the order they were set, empty if none
+func (a CookieSecurityAnalysis) ScriptReadableSessionCookies() []string {
+	names := []string{}
+	for _, detail := range a.CookieDetails {
+		if detail.IsSessionCookie && !detail.HasHttpOnly {
+			names = append(names, detail.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,54 @@
+package Tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCookieSecurityTest_CookieBombing(t *testing.T) {
+	const secureAttributes = "; Path=/; Max-Age=3600; Secure; HttpOnly; SameSite=Strict"
+	secureValue := "c2VjdXJlLXJhbmRvbS10b2tlbi12YWx1ZQ"
+	tests := []struct {
+		name          string
+		setCookies    []string
+		wantThreat    ThreatLevel
+		wantOversized int
+		wantMalformed int
+		wantSize      int
+	}{
+		{name: "Secure cookie", setCookies: []string{"pref=" + secureValue + secureAttributes},
+			wantThreat: None, wantSize: len("pref=" + secureValue)},
+		{name: "Oversized cookie value", setCookies: []string{"pref=" + strings.Repeat("a1B2", 1250) + secureAttributes},
+			wantThreat: Low, wantOversized: 1, wantSize: len("pref=") + 5000},
+		{name: "Nameless cookie", setCookies: []string{"pref=" + secureValue + secureAttributes, "=" + secureValue + secureAttributes},
+			wantThreat: Low, wantMalformed: 1, wantSize: len("pref=" + secureValue)},
+		{name: "Only malformed header", setCookies: []string{"no-equals-sign"}, wantThreat: Low, wantMalformed: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{"Set-Cookie": tt.setCookies}}
+
+			result := NewCookieSecurityTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			analysis, ok := result.Metadata.(CookieSecurityAnalysis)
+			if !ok {
+				t.Fatalf("Expected CookieSecurityAnalysis metadata, got %T", result.Metadata)
+			}
+			if analysis.OversizedCookies != tt.wantOversized {
+				t.Errorf("Expected %d oversized cookies, got %d", tt.wantOversized, analysis.OversizedCookies)
+			}
+			if analysis.MalformedCookies != tt.wantMalformed {
+				t.Errorf("Expected %d malformed cookies, got %d", tt.wantMalformed, analysis.MalformedCookies)
+			}
+			if tt.wantSize > 0 {
+				if len(analysis.CookieDetails) == 0 || analysis.CookieDetails[0].Size != tt.wantSize {
+					t.Errorf("Expected first cookie size %d, got %+v", tt.wantSize, analysis.CookieDetails)
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@
 package Tests
 
 import (
+	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -18,6 +20,8 @@ import (
 //   - Cross-Origin-Opener-Policy (COOP): Controls cross-origin window opener access
 //   - Header value validation and security implications
 //   - Combination effectiveness for comprehensive isolation
+//   - With COEP require-corp: whether a sample of same-origin subresources sends the
+//     Cross-Origin-Resource-Policy header the policy relies on
 //
 // Threat level assessment:
 //   - None (0): Excellent - All three headers properly configured with strict values
@@ -25,6 +29,8 @@ import (
 //   - Low (2): Acceptable - One header configured or less strict configuration
 //   - Medium (3): Weak - Headers present but with permissive values
 //   - High (4): Poor - No cross-origin security headers found
+//   - At least Low (2): COEP require-corp is declared but sampled subresources lack CORP,
+//     a broken-but-intended isolation setup (listed in the subresourcesWithoutCORP metadata)
 //
 // Security implications:
 //   - Missing COEP: Vulnerable to cross-origin resource embedding attacks
@@ -48,15 +54,17 @@ import (
 //	// Result includes threat level and detailed cross-origin security analysis
 func NewCrossOriginTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "cross-origin-x",
-		Name:        "Cross-Origin Security Headers Analysis",
-		Description: "Analyzes Cross-Origin-Embedder-Policy, Cross-Origin-Resource-Policy, and Cross-Origin-Opener-Policy headers for cross-origin attack protection",
-		Category:    "Headers",
+		Id:            "cross-origin-x",
+		Name:          "Cross-Origin Security Headers Analysis",
+		Description:   "Analyzes Cross-Origin-Embedder-Policy, Cross-Origin-Resource-Policy, and Cross-Origin-Opener-Policy headers for cross-origin attack protection",
+		Category:      "Headers",
+		CWE:           "CWE-693",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Check for Cross-Origin security headers
-			coepHeader := params.Response.Header.Get("Cross-Origin-Embedder-Policy")
-			corpHeader := params.Response.Header.Get("Cross-Origin-Resource-Policy")
-			coopHeader := params.Response.Header.Get("Cross-Origin-Opener-Policy")
+			coepHeader := HeaderValue(params.Response.Header, "Cross-Origin-Embedder-Policy")
+			corpHeader := HeaderValue(params.Response.Header, "Cross-Origin-Resource-Policy")
+			coopHeader := HeaderValue(params.Response.Header, "Cross-Origin-Opener-Policy")
 
 			// Analyze headers for comprehensive security assessment
 			metadata := analyzeCrossOriginHeaders(coepHeader, corpHeader, coopHeader)
@@ -64,6 +72,20 @@ func NewCrossOriginTest() *ResponseTest {
 			// Determine threat level based on cross-origin configuration
 			threatLevel := evaluateCrossOriginThreatLevel(metadata)
 
+			// COEP require-corp only works if the embedded resources opt in with CORP
+			checked, withoutCORP := []string{}, []string{}
+			if metadata["coepValue"] == "require-corp" {
+				checked, withoutCORP = checkSubresourcesCORP(params)
+			}
+			metadata["checkedSubresources"] = checked
+			metadata["subresourcesWithoutCORP"] = withoutCORP
+			if len(withoutCORP) > 0 {
+				metadata["isolationEffective"] = false
+				if threatLevel < Low {
+					threatLevel = Low
+				}
+			}
+
 			// Generate description based on findings
 			description := generateCrossOriginDescription(metadata)
 
@@ -201,6 +223,83 @@ func analyzeCrossOriginHeaders(coepHeader, corpHeader, coopHeader string) map[st
 	}
 }
 
+// maxCORPSubresources bounds how many subresources checkSubresourcesCORP requests.
+const maxCORPSubresources = 5
+
+// checkSubresourcesCORP requests a sample of the same-origin subresources embedded by the
+// page (images, scripts, media and stylesheets) and reports which of them answer without a
+// Cross-Origin-Resource-Policy header. Under COEP require-corp such resources are blocked
+// wherever they end up loaded cross-origin (another hostname, a cross-origin redirect), so
+// a page relying on the policy should serve all of its resources with CORP. Subresources
+// that cannot be fetched or answer with an error status are not counted. Nothing is
+// requested when the page was loaded from a file (params.Offline).
+//
+// Parameters:
+//   - params: Test parameters holding the page, scan client and scan context
+//
+// Returns:
+//   - []string: URLs of the checked subresources
+//   - []string: URLs of the checked subresources without CORP
+func checkSubresourcesCORP(params ResponseTestParams) ([]string, []string) {
+	checked, withoutCORP := []string{}, []string{}
+	if params.Offline || params.Response == nil || params.Response.Request == nil || params.Response.Request.URL == nil {
+		return checked, withoutCORP
+	}
+	body, err := params.responseBody()
+	if err != nil {
+		return checked, withoutCORP
+	}
+	base := params.Response.Request.URL
+	seen := make(map[string]bool)
+
+	for _, tag := range FindHTMLTags(body, "img", "script", "audio", "video", "source", "link") {
+		attr := "src"
+		if tag.Name == "link" {
+			rel, _ := tag.Attr("rel")
+			rel = strings.ToLower(rel)
+			if !strings.Contains(rel, "stylesheet") && !strings.Contains(rel, "icon") {
+				continue
+			}
+			attr = "href"
+		}
+		value, ok := tag.Attr(attr)
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		resource, err := base.Parse(strings.TrimSpace(value))
+		if err != nil || !sameOrigin(base, resource) {
+			continue
+		}
+		resource.Fragment = ""
+		resourceURL := resource.String()
+		if seen[resourceURL] {
+			continue
+		}
+		seen[resourceURL] = true
+		if len(checked) >= maxCORPSubresources {
+			break
+		}
+
+		request, err := http.NewRequestWithContext(params.scanContext(), http.MethodGet, resourceURL, nil)
+		if err != nil {
+			continue
+		}
+		response, err := params.httpClient().Do(request)
+		if err != nil {
+			continue
+		}
+		_ = response.Body.Close()
+		if response.StatusCode >= http.StatusBadRequest {
+			continue
+		}
+		checked = append(checked, resourceURL)
+		if HeaderValue(response.Header, "Cross-Origin-Resource-Policy") == "" {
+			withoutCORP = append(withoutCORP, resourceURL)
+		}
+	}
+	return checked, withoutCORP
+}
+
 // evaluateCrossOriginThreatLevel determines the security threat level based on cross-origin
 // headers configuration and their security implications.
 //
@@ -244,6 +343,7 @@ func generateCrossOriginDescription(metadata map[string]interface{}) string {
 	configuredHeaders := metadata["configuredHeaders"].([]string)
 	missingHeaders := metadata["missingHeaders"].([]string)
 	isolationEffective := metadata["isolationEffective"].(bool)
+	withoutCORP, _ := metadata["subresourcesWithoutCORP"].([]string)
 
 	description := ""
 
@@ -278,6 +378,13 @@ func generateCrossOriginDescription(metadata map[string]interface{}) string {
 		}
 	}
 
+	// Subresources that COEP require-corp would block once loaded cross-origin
+	if len(withoutCORP) > 0 {
+		description += "COEP require-corp is declared but " + strconv.Itoa(len(withoutCORP)) +
+			" embedded subresource(s) answer without Cross-Origin-Resource-Policy (" + strings.Join(withoutCORP, ", ") +
+			"); they are blocked whenever served from another origin, so the intended isolation is broken. "
+	}
+
 	// Recommendations based on protection level
 	switch protectionLevel {
 	case "None":
@@ -0,0 +1,102 @@
+package Tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// runCrossOrigin runs the cross-origin test on a fixture of testdata/cross-origin served with
+// strict isolation headers. The page's subresources are answered by a stub server sending
+// CORP for every path except /static/logo.png; the requested paths are recorded. offline
+// marks the response as loaded from a file.
+func runCrossOrigin(t *testing.T, fixture string, requested *[]string, offline bool) TestResult {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "cross-origin", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*requested = append(*requested, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path != "/static/logo.png" {
+			w.Header().Set("Cross-Origin-Resource-Policy", "same-origin")
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/dashboard", nil)
+	response := &http.Response{
+		Header: http.Header{
+			"Content-Type":                 []string{"text/html"},
+			"Cross-Origin-Embedder-Policy": []string{"require-corp"},
+			"Cross-Origin-Resource-Policy": []string{"same-origin"},
+			"Cross-Origin-Opener-Policy":   []string{"same-origin"},
+		},
+		Request: request,
+	}
+	return NewCrossOriginTest().Run(ResponseTestParams{Response: response, Body: body, HTTPClient: server.Client(), Offline: offline})
+}
+
+func TestCrossOriginTest_COEPSubresourceWithoutCORP(t *testing.T) {
+	var requested []string
+	result := runCrossOrigin(t, "coep-missing-corp.html", &requested, false)
+
+	sort.Strings(requested)
+	if want := []string{"/static/app.css", "/static/app.js", "/static/logo.png"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("Expected only same-origin subresources to be requested %v, got %v", want, requested)
+	}
+	metadata := result.Metadata.(map[string]interface{})
+	withoutCORP := metadata["subresourcesWithoutCORP"].([]string)
+	if len(withoutCORP) != 1 || !strings.HasSuffix(withoutCORP[0], "/static/logo.png") {
+		t.Errorf("Expected the logo to lack CORP, got %v", withoutCORP)
+	}
+	if result.ThreatLevel != Low {
+		t.Errorf("Expected Low for COEP with a subresource lacking CORP, got %v", result.ThreatLevel)
+	}
+	if metadata["isolationEffective"] != false {
+		t.Error("Expected isolation not to be effective")
+	}
+	if !strings.Contains(result.Description, "without Cross-Origin-Resource-Policy") {
+		t.Errorf("Expected the description to mention the subresource, got %q", result.Description)
+	}
+}
+
+func TestCrossOriginTest_COEPSubresourcesWithCORP(t *testing.T) {
+	var requested []string
+	result := runCrossOrigin(t, "coep-with-corp.html", &requested, false)
+
+	if len(requested) != 2 {
+		t.Errorf("Expected each subresource to be requested once, got %v", requested)
+	}
+	metadata := result.Metadata.(map[string]interface{})
+	if checked := metadata["checkedSubresources"].([]string); len(checked) != 2 {
+		t.Errorf("Expected 2 checked subresources, got %v", checked)
+	}
+	if withoutCORP := metadata["subresourcesWithoutCORP"].([]string); len(withoutCORP) != 0 {
+		t.Errorf("Expected every subresource to send CORP, got %v", withoutCORP)
+	}
+	if result.ThreatLevel != None {
+		t.Errorf("Expected None for strict isolation, got %v", result.ThreatLevel)
+	}
+}
+
+func TestCrossOriginTest_OfflineSkipsSubresources(t *testing.T) {
+	var requested []string
+	result := runCrossOrigin(t, "coep-missing-corp.html", &requested, true)
+
+	if len(requested) != 0 {
+		t.Errorf("Expected no subresource requests for a response loaded from a file, got %v", requested)
+	}
+	if checked := result.Metadata.(map[string]interface{})["checkedSubresources"].([]string); len(checked) != 0 {
+		t.Errorf("Expected no checked subresources, got %v", checked)
+	}
+}
@@ -0,0 +1,267 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the custom check test that applies user-defined regex rules, loaded
+// from a JSON file (--custom-rules), to response headers and the response body.
+package Tests
+
+import (
+	"Engine-AntiGinx/App/Errors"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// customBodyLocation is the rule location matching the response body; any other location
+// is a header name.
+const customBodyLocation = "body"
+
+// customMatchMaxLength caps the matched text kept in the metadata of a finding.
+const customMatchMaxLength = 100
+
+// CustomRule is a user-defined check as written in a custom rules file:
+//
+//	[
+//	  {"name": "debug-token", "location": "X-Debug-Token", "pattern": ".+", "severity": "medium"},
+//	  {"name": "stack-trace", "location": "body", "pattern": "at [\\w.]+\\(\\w+\\.java:\\d+\\)", "severity": "low"}
+//	]
+type CustomRule struct {
+	Name     string `json:"name"`     // Name reported with the finding
+	Location string `json:"location"` // "body" or the name of a response header (case-insensitive)
+	Pattern  string `json:"pattern"`  // Go regular expression (RE2 syntax)
+	Severity string `json:"severity"` // Threat level of a match ("info" to "critical")
+}
+
+// compiledCustomRule is a validated CustomRule ready for matching.
+type compiledCustomRule struct {
+	name     string
+	location string
+	pattern  *regexp.Regexp
+	severity ThreatLevel
+}
+
+// CustomRules is a set of validated custom rules applied by the custom test. A nil
+// CustomRules holds no rules.
+type CustomRules struct {
+	rules []compiledCustomRule
+}
+
+// LoadCustomRules reads and validates a custom rules file (--custom-rules).
+//
+// Parameters:
+//   - path: Path to the JSON rules file
+//
+// Returns:
+//   - *CustomRules: Validated rules
+//   - *Errors.Error: Code 401 if the file cannot be read, 402/403 if it is invalid
+//
+// Example:
+//
+//	rules, err := LoadCustomRules("rules.json")
+//	result := NewCustomCheckTest().Run(ResponseTestParams{Response: response, CustomRules: rules})
+func LoadCustomRules(path string) (*CustomRules, *Errors.Error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &Errors.Error{
+			Code:        401,
+			Message:     fmt.Sprintf("Custom rules error occurred. This could be due to:\n- custom rules file %s cannot be read: %v", path, err),
+			Source:      "Tests",
+			IsRetryable: false,
+		}
+	}
+	return ParseCustomRules(data)
+}
+
+// ParseCustomRules decodes and validates custom rules from JSON content. Every rule needs
+// a name, a location and a pattern that compiles; the severity must be a threat level
+// above none.
+//
+// Parameters:
+//   - data: JSON array of custom rules
+//
+// Returns:
+//   - *CustomRules: Validated rules
+//   - *Errors.Error: Code 402 on malformed JSON, 403 on an invalid rule
+func ParseCustomRules(data []byte) (*CustomRules, *Errors.Error) {
+	var rules []CustomRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, &Errors.Error{
+			Code:        402,
+			Message:     fmt.Sprintf("Custom rules error occurred. This could be due to:\n- invalid custom rules file format: %v", err),
+			Source:      "Tests",
+			IsRetryable: false,
+		}
+	}
+
+	compiled := make([]compiledCustomRule, 0, len(rules))
+	for i, rule := range rules {
+		problem := ""
+		pattern, err := regexp.Compile(rule.Pattern)
+		severity, severityErr := ParseThreatLevel(rule.Severity)
+		switch {
+		case strings.TrimSpace(rule.Name) == "":
+			problem = "has no name"
+		case strings.TrimSpace(rule.Location) == "":
+			problem = "has no location (\"body\" or a header name)"
+		case rule.Pattern == "":
+			problem = "has no pattern"
+		case err != nil:
+			problem = "has an invalid pattern: " + err.Error()
+		case severityErr != nil || severity == None:
+			problem = fmt.Sprintf("has an invalid severity %q (info, low, medium, high or critical)", rule.Severity)
+		}
+		if problem != "" {
+			return nil, &Errors.Error{
+				Code:        403,
+				Message:     fmt.Sprintf("Custom rules error occurred. This could be due to:\n- custom rule %d %s", i, problem),
+				Source:      "Tests",
+				IsRetryable: false,
+			}
+		}
+		compiled = append(compiled, compiledCustomRule{
+			name:     rule.Name,
+			location: strings.TrimSpace(rule.Location),
+			pattern:  pattern,
+			severity: severity,
+		})
+	}
+	return &CustomRules{rules: compiled}, nil
+}
+
+// Len returns the number of rules (0 for nil rules).
+func (r *CustomRules) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.rules)
+}
+
+// NewCustomCheckTest creates a new ResponseTest that applies the custom rules of the scan
+// (ResponseTestParams.CustomRules, loaded from --custom-rules) to the response. Every rule
+// matches a regular expression against one response header or the response body, so
+// target-specific patterns (debug headers, internal hostnames, stack traces) can be
+// codified without writing Go. Without rules the test reports nothing.
+//
+// Threat level assessment:
+//   - None (0): No rules configured, or no rule matched
+//   - Info (1) to Critical (5): Highest severity among the matching rules
+//
+// Returns:
+//   - *ResponseTest: Configured custom check test ready for execution
+//
+// Example usage:
+//
+//	rules, _ := LoadCustomRules("rules.json")
+//	result := NewCustomCheckTest().Run(ResponseTestParams{Response: httpResponse, CustomRules: rules})
+//	// Metadata["matches"] lists the rules that matched and the matched text
+func NewCustomCheckTest() *ResponseTest {
+	return &ResponseTest{
+		Id:          "custom",
+		Name:        "Custom Rules",
+		Description: "Applies user-defined regex rules from --custom-rules to response headers and the response body",
+		Category:    "Custom",
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeCustomRules(params)
+			threatLevel := evaluateCustomRulesThreatLevel(metadata)
+
+			return TestResult{
+				Name:        "Custom Rules",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateCustomRulesDescription(metadata),
+			}
+		},
+	}
+}
+
+// analyzeCustomRules applies every custom rule to the response. The body is read only
+// when a rule needs it.
+//
+// Parameters:
+//   - params: Test parameters holding the response and the custom rules
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "rule_count" (int): Number of configured rules
+//   - "matches" ([]map[string]string): Matching rules with "rule", "location", "severity" and "match"
+//
+// Example:
+//
+//	metadata := analyzeCustomRules(ResponseTestParams{Response: response, CustomRules: rules})
+//	// metadata["matches"] == []map[string]string{{"rule": "debug-token", "location": "X-Debug-Token", ...}}
+func analyzeCustomRules(params ResponseTestParams) map[string]interface{} {
+	matches := []map[string]string{}
+	var body *string
+	for _, rule := range rulesOf(params.CustomRules) {
+		var values []string
+		if strings.EqualFold(rule.location, customBodyLocation) {
+			if body == nil {
+				body = new(string)
+				if data, err := params.responseBody(); err == nil {
+					*body = string(data)
+				}
+			}
+			values = []string{*body}
+		} else {
+			values = HeaderValues(params.Response.Header, rule.location)
+		}
+
+		for _, value := range values {
+			match := rule.pattern.FindString(value)
+			if match == "" && !rule.pattern.MatchString(value) {
+				continue
+			}
+			if len(match) > customMatchMaxLength {
+				match = match[:customMatchMaxLength] + "..."
+			}
+			matches = append(matches, map[string]string{
+				"rule":     rule.name,
+				"location": rule.location,
+				"severity": rule.severity.String(),
+				"match":    match,
+			})
+			break
+		}
+	}
+
+	return map[string]interface{}{
+		"rule_count": params.CustomRules.Len(),
+		"matches":    matches,
+	}
+}
+
+// rulesOf returns the compiled rules of r (nil for nil rules).
+func rulesOf(r *CustomRules) []compiledCustomRule {
+	if r == nil {
+		return nil
+	}
+	return r.rules
+}
+
+// evaluateCustomRulesThreatLevel returns the highest severity among the matching rules.
+func evaluateCustomRulesThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	threatLevel := None
+	for _, match := range metadata["matches"].([]map[string]string) {
+		if severity, err := ParseThreatLevel(match["severity"]); err == nil {
+			threatLevel = max(threatLevel, severity)
+		}
+	}
+	return threatLevel
+}
+
+// generateCustomRulesDescription builds a human-readable summary of the custom rule matches.
+func generateCustomRulesDescription(metadata map[string]interface{}) string {
+	if metadata["rule_count"].(int) == 0 {
+		return "No custom rules configured (--custom-rules)"
+	}
+	matches := metadata["matches"].([]map[string]string)
+	if len(matches) == 0 {
+		return fmt.Sprintf("None of the %d custom rules matched", metadata["rule_count"].(int))
+	}
+	found := make([]string, 0, len(matches))
+	for _, match := range matches {
+		found = append(found, fmt.Sprintf("%s (%s in %s)", match["rule"], match["severity"], match["location"]))
+	}
+	return "Custom rules matched: " + strings.Join(found, ", ")
+}
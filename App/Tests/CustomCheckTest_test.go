@@ -0,0 +1,119 @@
+package Tests
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const customRulesJSON = `[
+	{"name": "debug-token", "location": "X-Debug-Token", "pattern": "^[0-9a-f]{8}$", "severity": "medium"},
+	{"name": "java-stack-trace", "location": "body", "pattern": "at [\\w.]+\\(\\w+\\.java:\\d+\\)", "severity": "low"}
+]`
+
+func TestCustomCheckTest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(customRulesJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rules, loadErr := LoadCustomRules(path)
+	if loadErr != nil {
+		t.Fatalf("LoadCustomRules failed: %v", loadErr)
+	}
+	if rules.Len() != 2 {
+		t.Fatalf("Expected 2 rules, got %d", rules.Len())
+	}
+
+	tests := []struct {
+		name        string
+		rules       *CustomRules
+		headers     map[string]string
+		body        string
+		wantThreat  ThreatLevel
+		wantMatches []string
+	}{
+		{name: "No rules", headers: map[string]string{"X-Debug-Token": "1a2b3c4d"}, wantThreat: None},
+		{name: "No match", rules: rules, headers: map[string]string{"X-Debug-Token": "disabled"}, body: "<html></html>", wantThreat: None},
+		{
+			name:        "Custom header",
+			rules:       rules,
+			headers:     map[string]string{"x-debug-token": "1a2b3c4d"},
+			body:        "<html></html>",
+			wantThreat:  Medium,
+			wantMatches: []string{"debug-token"},
+		},
+		{
+			name:        "Body",
+			rules:       rules,
+			body:        "Error\n\tat com.example.Shop(Cart.java:42)",
+			wantThreat:  Low,
+			wantMatches: []string{"java-stack-trace"},
+		},
+		{
+			name:        "Header and body",
+			rules:       rules,
+			headers:     map[string]string{"X-Debug-Token": "1a2b3c4d"},
+			body:        "at org.app.Main(Main.java:7)",
+			wantThreat:  Medium,
+			wantMatches: []string{"debug-token", "java-stack-trace"},
+		},
+	}
+
+	customTest := NewCustomCheckTest()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}, Body: io.NopCloser(strings.NewReader(tt.body))}
+			for name, value := range tt.headers {
+				resp.Header.Set(name, value)
+			}
+
+			result := customTest.Run(ResponseTestParams{Response: resp, CustomRules: tt.rules})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			matches := result.Metadata.(map[string]interface{})["matches"].([]map[string]string)
+			if len(matches) != len(tt.wantMatches) {
+				t.Fatalf("Expected matches %v, got %v", tt.wantMatches, matches)
+			}
+			for i, match := range matches {
+				if match["rule"] != tt.wantMatches[i] {
+					t.Errorf("Expected match %d to be %q, got %q", i, tt.wantMatches[i], match["rule"])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCustomRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantCode int
+	}{
+		{name: "Malformed JSON", data: `{"name":`, wantCode: 402},
+		{name: "Missing name", data: `[{"location": "body", "pattern": "x", "severity": "low"}]`, wantCode: 403},
+		{name: "Missing location", data: `[{"name": "r", "pattern": "x", "severity": "low"}]`, wantCode: 403},
+		{name: "Invalid pattern", data: `[{"name": "r", "location": "body", "pattern": "(", "severity": "low"}]`, wantCode: 403},
+		{name: "Unknown severity", data: `[{"name": "r", "location": "body", "pattern": "x", "severity": "severe"}]`, wantCode: 403},
+		{name: "Severity none", data: `[{"name": "r", "location": "body", "pattern": "x", "severity": "none"}]`, wantCode: 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseCustomRules([]byte(tt.data))
+			if err == nil {
+				t.Fatalf("Expected error code %d, got %d rules", tt.wantCode, rules.Len())
+			}
+			if err.Code != tt.wantCode {
+				t.Errorf("Expected error code %d, got %d", tt.wantCode, err.Code)
+			}
+		})
+	}
+
+	if _, err := LoadCustomRules(filepath.Join(t.TempDir(), "missing.json")); err == nil || err.Code != 401 {
+		t.Errorf("Expected error code 401 for a missing file, got %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+// Package Tests provides security testing functionality for Engine-AntiGinx.
+//
+// # EmailDNSTest Module
+//
+// This module inspects the SPF (RFC 7208) and DMARC (RFC 7489) TXT records of the target
+// domain. Together they tell receiving mail servers which hosts may send mail for the
+// domain and what to do with messages failing those checks; without them the domain is
+// easy to spoof in phishing campaigns.
+//
+// The "www." prefix is removed from the target host, as mail is normally configured on
+// the bare domain. DMARC is looked up at the "_dmarc" subdomain.
+//
+//	Result:
+//	  Lookup failed                          → Info (certainty 50)
+//	  IP address target                      → Info (not applicable)
+//	  Missing SPF or missing DMARC           → Low
+//	  Multiple SPF records (SPF permerror)   → Low
+//	  SPF allowing any sender ("+all"/"all") → Medium
+//	  SPF and DMARC present                  → None
+package Tests
+
+import (
+	"Engine-AntiGinx/App/DNS"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NewEmailDNSTest creates a test checking the SPF and DMARC records of the target domain.
+//
+// Metadata includes:
+//   - domain: Analyzed mail domain
+//   - spf_count: Number of SPF records found
+//   - dmarc_present: true when a DMARC record was found
+//   - spf_record: Raw SPF record (empty if none)
+//   - spf_mechanisms: SPF terms following "v=spf1"
+//   - spf_all: Qualified "all" mechanism (e.g., "-all", "~all"), empty if absent
+//   - dmarc_record: Raw DMARC record (empty if none)
+//   - dmarc_policy: DMARC "p" tag (none, quarantine, reject)
+//   - dmarc_tags: All DMARC tags
+//   - issues: Detected problems
+//
+// Example:
+//
+//	emailTest := NewEmailDNSTest()
+//	result := emailTest.Run(ResponseTestParams{Response: httpResponse})
+//	if result.ThreatLevel >= Low {
+//	    fmt.Println(result.Description)
+//	}
+func NewEmailDNSTest() *ResponseTest {
+	return newEmailDNSTest(DNS.NewClient())
+}
+
+// newEmailDNSTest creates the email DNS test with the given resolver, allowing lookups to be stubbed.
+func newEmailDNSTest(resolver DNS.Resolver) *ResponseTest {
+	return &ResponseTest{
+		Id:          "email-dns",
+		Name:        "SPF/DMARC Email Security Records",
+		Description: "Checks the SPF and DMARC DNS records protecting the domain against email spoofing",
+		Category:    "DNS",
+		RunTest: func(params ResponseTestParams) TestResult {
+			host := strings.ToLower(strings.TrimSuffix(params.Response.Request.URL.Hostname(), "."))
+			domain := strings.TrimPrefix(host, "www.")
+			if domain == "" || net.ParseIP(domain) != nil {
+				return TestResult{
+					Name:        "SPF/DMARC Email Security Records",
+					Certainty:   100,
+					ThreatLevel: Info,
+					Metadata:    map[string]any{"domain": domain},
+					Description: "Target is not a domain name, SPF and DMARC records are not applicable",
+				}
+			}
+
+			spfRecords, err := lookupPrefixedTXT(params.scanContext(), resolver, domain, "v=spf1")
+			var dmarcRecords []string
+			if err == nil {
+				dmarcRecords, err = lookupPrefixedTXT(params.scanContext(), resolver, "_dmarc."+domain, "v=DMARC1")
+			}
+			if err != nil {
+				return TestResult{
+					Name:        "SPF/DMARC Email Security Records",
+					Certainty:   50,
+					ThreatLevel: Info,
+					Metadata:    map[string]any{"domain": domain, "error": err.Error()},
+					Description: fmt.Sprintf("SPF/DMARC records could not be looked up: %v", err),
+				}
+			}
+
+			analysis := analyzeEmailRecords(domain, spfRecords, dmarcRecords)
+			threat := evaluateEmailDNSThreatLevel(analysis)
+			return TestResult{
+				Name:        "SPF/DMARC Email Security Records",
+				Certainty:   100,
+				ThreatLevel: threat,
+				Metadata:    analysis,
+				Description: generateEmailDNSDescription(analysis),
+			}
+		},
+	}
+}
+
+// lookupPrefixedTXT returns the TXT records of domain starting with the given version tag.
+func lookupPrefixedTXT(ctx context.Context, resolver DNS.Resolver, domain, prefix string) ([]string, error) {
+	records, err := resolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	matching := []string{}
+	for _, record := range records {
+		record = strings.TrimSpace(record)
+		lower := strings.ToLower(record)
+		lowerPrefix := strings.ToLower(prefix)
+		if lower == lowerPrefix || strings.HasPrefix(lower, lowerPrefix+" ") || strings.HasPrefix(lower, lowerPrefix+";") {
+			matching = append(matching, record)
+		}
+	}
+	return matching, nil
+}
+
+// analyzeEmailRecords parses the SPF and DMARC records and collects detected issues.
+func analyzeEmailRecords(domain string, spfRecords, dmarcRecords []string) map[string]any {
+	issues := []string{}
+	spfRecord := ""
+	spfMechanisms := []string{}
+	spfAll := ""
+
+	switch {
+	case len(spfRecords) == 0:
+		issues = append(issues, "no SPF record published")
+	case len(spfRecords) > 1:
+		issues = append(issues, "multiple SPF records published, receivers treat SPF as a permanent error")
+	}
+	if len(spfRecords) > 0 {
+		spfRecord = spfRecords[0]
+		spfMechanisms = strings.Fields(spfRecord)[1:]
+		for _, term := range spfMechanisms {
+			switch strings.ToLower(term) {
+			case "all", "+all":
+				spfAll = "+all"
+				issues = append(issues, "SPF \"+all\" authorizes any host to send mail for the domain")
+			case "-all", "~all", "?all":
+				spfAll = strings.ToLower(term)
+			}
+		}
+	}
+
+	dmarcRecord := ""
+	dmarcTags := map[string]string{}
+	if len(dmarcRecords) == 0 {
+		issues = append(issues, "no DMARC record published at _dmarc."+domain)
+	} else {
+		dmarcRecord = dmarcRecords[0]
+		for _, tag := range strings.Split(dmarcRecord, ";") {
+			key, value, ok := strings.Cut(tag, "=")
+			if !ok {
+				continue
+			}
+			dmarcTags[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+		}
+	}
+
+	return map[string]any{
+		"domain":         domain,
+		"spf_count":      len(spfRecords),
+		"dmarc_present":  len(dmarcRecords) > 0,
+		"spf_record":     spfRecord,
+		"spf_mechanisms": spfMechanisms,
+		"spf_all":        spfAll,
+		"dmarc_record":   dmarcRecord,
+		"dmarc_policy":   strings.ToLower(dmarcTags["p"]),
+		"dmarc_tags":     dmarcTags,
+		"issues":         issues,
+	}
+}
+
+// evaluateEmailDNSThreatLevel maps the SPF/DMARC analysis to a threat level.
+func evaluateEmailDNSThreatLevel(analysis map[string]any) ThreatLevel {
+	if analysis["spf_all"] == "+all" {
+		return Medium
+	}
+	if analysis["spf_count"] != 1 || !analysis["dmarc_present"].(bool) {
+		return Low
+	}
+	return None
+}
+
+// generateEmailDNSDescription builds a human-readable summary of the SPF/DMARC analysis.
+func generateEmailDNSDescription(analysis map[string]any) string {
+	issues := analysis["issues"].([]string)
+	if len(issues) == 0 {
+		return fmt.Sprintf("SPF (%s) and DMARC (policy %q) records are published for %s",
+			analysis["spf_all"], analysis["dmarc_policy"], analysis["domain"])
+	}
+	return fmt.Sprintf("Email security records of %s are incomplete: %s", analysis["domain"], strings.Join(issues, "; "))
+}
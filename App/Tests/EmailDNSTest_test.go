@@ -0,0 +1,122 @@
+package Tests
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEmailDNSTest(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		txt        map[string][]string
+		err        error
+		wantThreat ThreatLevel
+		wantPolicy string
+		wantAll    string
+	}{
+		{
+			name:   "SPF and DMARC present",
+			target: "https://www.example.com",
+			txt: map[string][]string{
+				"example.com":        {"google-site-verification=abc", "v=spf1 include:_spf.google.com -all"},
+				"_dmarc.example.com": {"v=DMARC1; p=reject; rua=mailto:dmarc@example.com"},
+			},
+			wantThreat: None,
+			wantPolicy: "reject",
+			wantAll:    "-all",
+		},
+		{
+			name:       "No records",
+			target:     "https://example.com",
+			wantThreat: Low,
+		},
+		{
+			name:   "Missing DMARC",
+			target: "https://example.com",
+			txt: map[string][]string{
+				"example.com": {"v=spf1 mx ~all"},
+			},
+			wantThreat: Low,
+			wantAll:    "~all",
+		},
+		{
+			name:   "Missing SPF",
+			target: "https://example.com",
+			txt: map[string][]string{
+				"_dmarc.example.com": {"v=DMARC1; p=none"},
+			},
+			wantThreat: Low,
+			wantPolicy: "none",
+		},
+		{
+			name:   "Multiple SPF records",
+			target: "https://example.com",
+			txt: map[string][]string{
+				"example.com":        {"v=spf1 mx -all", "v=spf1 a -all"},
+				"_dmarc.example.com": {"v=DMARC1; p=quarantine"},
+			},
+			wantThreat: Low,
+		},
+		{
+			name:   "Permissive SPF",
+			target: "https://example.com",
+			txt: map[string][]string{
+				"example.com":        {"v=spf1 ip4:192.0.2.0/24 +all"},
+				"_dmarc.example.com": {"v=DMARC1; p=reject"},
+			},
+			wantThreat: Medium,
+			wantAll:    "+all",
+		},
+		{
+			name:   "Unqualified all is permissive",
+			target: "https://example.com",
+			txt: map[string][]string{
+				"example.com": {"v=spf1 all"},
+			},
+			wantThreat: Medium,
+			wantAll:    "+all",
+		},
+		{
+			name:       "Lookup failure",
+			target:     "https://example.com",
+			err:        errors.New("timeout"),
+			wantThreat: Info,
+		},
+		{
+			name:       "IP address target",
+			target:     "http://192.0.2.1",
+			wantThreat: Info,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &stubResolver{txt: tt.txt, err: tt.err}
+			result := newEmailDNSTest(resolver).Run(ResponseTestParams{Response: responseFor(t, tt.target)})
+
+			if result.TestId != "email-dns" {
+				t.Errorf("Expected TestId email-dns, got %q", result.TestId)
+			}
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]any)
+			if tt.wantPolicy != "" && metadata["dmarc_policy"] != tt.wantPolicy {
+				t.Errorf("Expected DMARC policy %q, got %v", tt.wantPolicy, metadata["dmarc_policy"])
+			}
+			if tt.wantAll != "" && metadata["spf_all"] != tt.wantAll {
+				t.Errorf("Expected SPF all %q, got %v", tt.wantAll, metadata["spf_all"])
+			}
+		})
+	}
+}
+
+func TestEmailDNSTest_QueriesDMARCSubdomain(t *testing.T) {
+	resolver := &stubResolver{}
+	newEmailDNSTest(resolver).Run(ResponseTestParams{Response: responseFor(t, "https://www.example.com")})
+
+	want := []string{"example.com", "_dmarc.example.com"}
+	if len(resolver.queried) != len(want) || resolver.queried[0] != want[0] || resolver.queried[1] != want[1] {
+		t.Errorf("Expected lookups %v, got %v", want, resolver.queried)
+	}
+}
@@ -0,0 +1,170 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the HPKP test that detects the deprecated Public-Key-Pins header.
+package Tests
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hpkpReferences documents the deprecation of HPKP and its replacement.
+var hpkpReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Public-Key-Pins",
+	"https://www.rfc-editor.org/rfc/rfc7469",
+	"https://developer.mozilla.org/en-US/docs/Web/Security/Certificate_Transparency",
+}
+
+// NewHPKPTest creates a new ResponseTest that detects HTTP Public Key Pinning (HPKP).
+// The Public-Key-Pins header pins the site to a set of public key hashes for max-age
+// seconds. A lost, compromised or rotated key - or an attacker injecting the header -
+// could make the site unreachable for every visitor that cached the pins ("bricking"),
+// which is why browsers removed support. Certificate Transparency replaces it.
+//
+// The test evaluates:
+//   - Public-Key-Pins: Enforced pins (max-age, pin-sha256 count, includeSubDomains, report-uri)
+//   - Public-Key-Pins-Report-Only: Pins that are only reported, never enforced
+//
+// Threat level assessment:
+//   - None (0): Neither header is sent
+//   - Low (2): Only Public-Key-Pins-Report-Only is sent (deprecated but cannot lock users out)
+//   - Medium (3): Public-Key-Pins is sent
+//
+// Returns:
+//   - *ResponseTest: Configured HPKP test ready for execution
+//
+// Example usage:
+//
+//	hpkpTest := NewHPKPTest()
+//	result := hpkpTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["pin_count"] and Metadata["max_age"] describe the pins
+func NewHPKPTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "hpkp",
+		Name:          "HTTP Public Key Pinning (HPKP)",
+		Description:   "Detects the deprecated Public-Key-Pins header, which can make a site unreachable when pinned keys change",
+		Category:      "Headers",
+		CWE:           "CWE-16",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"Public-Key-Pins", "Public-Key-Pins-Report-Only"},
+		RequiresHTTPS: true,
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeHPKP(HeaderValue(params.Response.Header, "Public-Key-Pins"),
+				HeaderValue(params.Response.Header, "Public-Key-Pins-Report-Only"))
+			threatLevel := evaluateHPKPThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "HTTP Public Key Pinning (HPKP)",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateHPKPDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Remove the Public-Key-Pins and Public-Key-Pins-Report-Only headers; rely on " +
+					"Certificate Transparency and DNS CAA records to detect and restrict mis-issued certificates"
+				result.References = hpkpReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeHPKP parses the Public-Key-Pins headers into structured metadata. When both
+// headers are sent, the pins described are those of the enforced header.
+//
+// Parameters:
+//   - enforced: Value of Public-Key-Pins, empty if absent
+//   - reportOnly: Value of Public-Key-Pins-Report-Only, empty if absent
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "present" (bool): Public-Key-Pins was sent
+//   - "report_only_present" (bool): Public-Key-Pins-Report-Only was sent
+//   - "mode" (string): "enforced", "report-only" or "none"
+//   - "value" (string): Raw value of the analyzed header
+//   - "pin_count" (int): Number of pin-sha256 directives
+//   - "max_age" (int): max-age in seconds (0 when missing or invalid)
+//   - "include_subdomains" (bool): includeSubDomains directive present
+//   - "report_uri" (string): Value of the report-uri directive, empty if absent
+//
+// Example:
+//
+//	metadata := analyzeHPKP(`pin-sha256="d6qz..."; pin-sha256="E9CZ..."; max-age=5184000`, "")
+//	// metadata["pin_count"] == 2, metadata["max_age"] == 5184000
+func analyzeHPKP(enforced, reportOnly string) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"present":             enforced != "",
+		"report_only_present": reportOnly != "",
+		"mode":                "none",
+		"value":               "",
+		"pin_count":           0,
+		"max_age":             0,
+		"include_subdomains":  false,
+		"report_uri":          "",
+	}
+	value := enforced
+	switch {
+	case enforced != "":
+		metadata["mode"] = "enforced"
+	case reportOnly != "":
+		metadata["mode"] = "report-only"
+		value = reportOnly
+	default:
+		return metadata
+	}
+	metadata["value"] = value
+
+	pins := 0
+	for _, directive := range strings.Split(value, ";") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		arg = strings.Trim(strings.TrimSpace(arg), `"`)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "pin-sha256":
+			if arg != "" {
+				pins++
+			}
+		case "max-age":
+			if maxAge, err := strconv.Atoi(arg); err == nil && maxAge > 0 {
+				metadata["max_age"] = maxAge
+			}
+		case "includesubdomains":
+			metadata["include_subdomains"] = true
+		case "report-uri":
+			metadata["report_uri"] = arg
+		}
+	}
+	metadata["pin_count"] = pins
+	return metadata
+}
+
+// evaluateHPKPThreatLevel maps the HPKP analysis to a threat level.
+func evaluateHPKPThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch metadata["mode"].(string) {
+	case "enforced":
+		return Medium
+	case "report-only":
+		return Low
+	default:
+		return None
+	}
+}
+
+// generateHPKPDescription builds a human-readable summary of the HPKP analysis.
+func generateHPKPDescription(metadata map[string]interface{}) string {
+	mode := metadata["mode"].(string)
+	if mode == "none" {
+		return "No Public-Key-Pins header, the site does not use deprecated HTTP Public Key Pinning"
+	}
+	scope := ""
+	if metadata["include_subdomains"].(bool) {
+		scope = " including subdomains"
+	}
+	pins := fmt.Sprintf("%d pin(s) with max-age %d seconds%s", metadata["pin_count"].(int), metadata["max_age"].(int), scope)
+	if mode == "report-only" {
+		return "Public-Key-Pins-Report-Only declares " + pins + ". HPKP is deprecated and ignored by browsers; " +
+			"report-only pins cannot lock visitors out but should be removed"
+	}
+	return "Public-Key-Pins declares " + pins + ". HPKP is deprecated and dangerous: a lost or rotated key, or an " +
+		"injected header, can make the site unreachable for visitors that cached the pins"
+}
@@ -0,0 +1,75 @@
+package Tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHPKPTest(t *testing.T) {
+	const pins = `pin-sha256="d6qzRu9zOECb90Uez27xWltNsj0e1Md7GkYYkVoZWmM="; ` +
+		`pin-sha256="E9CZ9INDbd+2eRQozYqqbQ2yXLVKB9+xcprMF+44U1g="; `
+
+	tests := []struct {
+		name          string
+		enforced      string
+		reportOnly    string
+		wantThreat    ThreatLevel
+		wantMode      string
+		wantPins      int
+		wantMaxAge    int
+		wantSubdomain bool
+	}{
+		{name: "Absent", wantThreat: None, wantMode: "none"},
+		{
+			name:          "Enforced pins",
+			enforced:      pins + `max-age=5184000; includeSubDomains; report-uri="https://example.com/hpkp"`,
+			wantThreat:    Medium,
+			wantMode:      "enforced",
+			wantPins:      2,
+			wantMaxAge:    5184000,
+			wantSubdomain: true,
+		},
+		{
+			name:       "Report only",
+			reportOnly: pins + `max-age="600"`,
+			wantThreat: Low,
+			wantMode:   "report-only",
+			wantPins:   2,
+			wantMaxAge: 600,
+		},
+		{
+			name:       "Enforced and report only",
+			enforced:   `pin-sha256="d6qzRu9zOECb90Uez27xWltNsj0e1Md7GkYYkVoZWmM="; max-age=60`,
+			reportOnly: pins + "max-age=600",
+			wantThreat: Medium,
+			wantMode:   "enforced",
+			wantPins:   1,
+			wantMaxAge: 60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if tt.enforced != "" {
+				response.Header.Set("Public-Key-Pins", tt.enforced)
+			}
+			if tt.reportOnly != "" {
+				response.Header.Set("Public-Key-Pins-Report-Only", tt.reportOnly)
+			}
+
+			result := NewHPKPTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if metadata["mode"] != tt.wantMode || metadata["pin_count"] != tt.wantPins ||
+				metadata["max_age"] != tt.wantMaxAge || metadata["include_subdomains"] != tt.wantSubdomain {
+				t.Errorf("Unexpected metadata %v", metadata)
+			}
+			if (result.Remediation != "") != (tt.wantThreat > None) {
+				t.Errorf("Expected remediation only when HPKP is used, got %q", result.Remediation)
+			}
+		})
+	}
+}
@@ -4,10 +4,22 @@
 package Tests
 
 import (
+	"Engine-AntiGinx/App/Locale"
 	"strconv"
 	"strings"
 )
 
+// recommendedHSTSHeader is the Strict-Transport-Security value meeting the HSTS preload
+// list requirements, suggested by the remediation of every weak configuration.
+const recommendedHSTSHeader = "max-age=31536000; includeSubDomains; preload"
+
+// hstsReferences documents the HSTS header and the preload list.
+var hstsReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security",
+	"https://www.rfc-editor.org/rfc/rfc6797",
+	"https://hstspreload.org/",
+}
+
 // NewHSTSTest creates a new ResponseTest that analyzes HTTP Strict Transport Security (HSTS)
 // header configuration. HSTS is a security mechanism that forces browsers to interact with
 // websites exclusively over HTTPS, protecting against protocol downgrade attacks and cookie hijacking.
@@ -42,22 +54,38 @@ import (
 //	// Result includes threat level and detailed configuration analysis
 func NewHSTSTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "hsts",
-		Name:        "HSTS Header Analysis",
-		Description: "Checks for HTTP Strict Transport Security header presence and configuration",
-		Category:    "Encryption",
+		Id:            "hsts",
+		Name:          "HSTS Header Analysis",
+		Description:   "Checks for HTTP Strict Transport Security header presence and configuration",
+		Category:      "Encryption",
+		CWE:           "CWE-319",
+		OWASPCategory: "A02:2021-Cryptographic Failures",
+		CacheHeaders:  []string{"Strict-Transport-Security"},
+		RequiresHTTPS: true,
+		Rubric: []RubricEntry{
+			{ThreatLevel: None, Condition: "max-age of at least one year with includeSubDomains and preload"},
+			{ThreatLevel: Info, Condition: "max-age of at least one year with includeSubDomains, without preload"},
+			{ThreatLevel: Info, Condition: "Target served over plain HTTP without redirecting to HTTPS (test skipped)"},
+			{ThreatLevel: Low, Condition: "max-age of at least six months, without includeSubDomains or shorter than a year"},
+			{ThreatLevel: Medium, Condition: "max-age shorter than six months"},
+			{ThreatLevel: Medium, Condition: "No Strict-Transport-Security header"},
+			{ThreatLevel: High, Condition: "Strict-Transport-Security header without a valid max-age"},
+		},
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Check for HSTS header
-			hstsHeader := params.Response.Header.Get("Strict-Transport-Security")
+			hstsHeader := HeaderValue(params.Response.Header, "Strict-Transport-Security")
 
 			if hstsHeader == "" {
-				return TestResult{
+				result := TestResult{
 					Name:        "HSTS Header Analysis",
 					Certainty:   100,
 					ThreatLevel: Medium,
 					Metadata:    nil,
-					Description: "Missing HSTS header - site vulnerable to protocol downgrade attacks and man-in-the-middle attacks",
+					References:  hstsReferences,
 				}
+				result.SetDescription(Locale.NewText("hsts.missing.description"))
+				result.SetRemediation(Locale.NewText("hsts.missing.remediation", recommendedHSTSHeader))
+				return result
 			}
 
 			// Parse HSTS header for security analysis
@@ -66,16 +94,19 @@ func NewHSTSTest() *ResponseTest {
 			// Determine threat level based on HSTS configuration
 			threatLevel := evaluateHSTSThreatLevel(metadata)
 
-			// Generate description based on findings
-			description := generateHSTSDescription(metadata)
-
-			return TestResult{
+			result := TestResult{
 				Name:        "HSTS Header Analysis",
 				Certainty:   95,
 				ThreatLevel: threatLevel,
 				Metadata:    metadata,
-				Description: description,
 			}
+			// Generate description based on findings
+			result.SetDescription(generateHSTSDescription(metadata))
+			if remediation := generateHSTSRemediation(threatLevel); remediation != nil {
+				result.SetRemediation(remediation)
+				result.References = hstsReferences
+			}
+			return result
 		},
 	}
 }
@@ -308,7 +339,7 @@ func evaluateHSTSThreatLevel(metadata map[string]interface{}) ThreatLevel {
 //   - metadata: Parsed HSTS header metadata containing max_age, directives, and flags
 //
 // Returns:
-//   - string: Formatted description for the TestResult
+//   - *Locale.Text: Localizable description for the TestResult
 //
 // Example outputs:
 //
@@ -323,38 +354,38 @@ func evaluateHSTSThreatLevel(metadata map[string]interface{}) ThreatLevel {
 //
 //	// Missing max-age
 //	"HSTS header present but missing or invalid max-age directive"
-func generateHSTSDescription(metadata map[string]interface{}) string {
+func generateHSTSDescription(metadata map[string]interface{}) *Locale.Text {
 	maxAge := metadata["max_age"].(int)
 	includeSubdomains := metadata["include_subdomains"].(bool)
 	preload := metadata["preload"].(bool)
 	directives := metadata["directives"].([]string)
 
 	if maxAge == 0 {
-		return "HSTS header present but missing or invalid max-age directive"
+		return Locale.NewText("hsts.invalid_max_age")
 	}
 
 	ageDescription := formatMaxAge(maxAge)
 
-	description := "HSTS header configured with " + ageDescription
-
+	var includes any = ""
 	if len(directives) > 0 {
-		description += " and includes: " + strings.Join(directives, ", ")
+		includes = Locale.NewText("hsts.includes", strings.Join(directives, ", "))
 	}
 
 	oneYear := 60 * 60 * 24 * 365
 	sixMonths := 60 * 60 * 24 * 30 * 6
 
+	var verdict *Locale.Text
 	if includeSubdomains && preload && maxAge >= oneYear {
-		description += " - Excellent security configuration"
+		verdict = Locale.NewText("hsts.verdict.excellent")
 	} else if includeSubdomains && maxAge >= oneYear {
-		description += " - Good security configuration"
+		verdict = Locale.NewText("hsts.verdict.good")
 	} else if maxAge >= sixMonths {
-		description += " - Acceptable security configuration"
+		verdict = Locale.NewText("hsts.verdict.acceptable")
 	} else {
-		description += " - Weak security configuration, consider increasing max-age"
+		verdict = Locale.NewText("hsts.verdict.weak")
 	}
 
-	return description
+	return Locale.NewText("hsts.configured", ageDescription, includes, verdict)
 }
 
 // formatMaxAge converts a max-age value in seconds to a human-readable duration string.
@@ -369,57 +400,60 @@ func generateHSTSDescription(metadata map[string]interface{}) string {
 //   - < 1 hour: Express in seconds
 //
 // Singular/plural handling:
-//   - Uses singular form for value of 1 (e.g., "1 year", "1 day")
-//   - Uses plural form for all other values (e.g., "2 years", "30 days")
+//   - Uses the plural category of the rendering language (e.g., "1 year", "2 years";
+//     in Polish "1 rok", "2 lata", "5 lat")
 //
 // Parameters:
 //   - seconds: max-age value in seconds
 //
 // Returns:
-//   - string: Human-readable duration with appropriate unit
+//   - *Locale.Text: Localizable duration with appropriate unit
 //
 // Examples:
 //
-//	formatMaxAge(31536000)     // Returns: "1 year max-age"
-//	formatMaxAge(63072000)     // Returns: "2 years max-age"
-//	formatMaxAge(2592000)      // Returns: "1 month max-age"
-//	formatMaxAge(7776000)      // Returns: "3 months max-age"
-//	formatMaxAge(86400)        // Returns: "1 day max-age"
-//	formatMaxAge(259200)       // Returns: "3 days max-age"
-//	formatMaxAge(3600)         // Returns: "1 hour max-age"
-//	formatMaxAge(7200)         // Returns: "2 hours max-age"
-//	formatMaxAge(300)          // Returns: "300 seconds max-age"
-func formatMaxAge(seconds int) string {
+//	formatMaxAge(31536000)     // Renders in English: "1 year max-age"
+//	formatMaxAge(63072000)     // Renders in English: "2 years max-age"
+//	formatMaxAge(2592000)      // Renders in English: "1 month max-age"
+//	formatMaxAge(7776000)      // Renders in English: "3 months max-age"
+//	formatMaxAge(86400)        // Renders in English: "1 day max-age"
+//	formatMaxAge(259200)       // Renders in English: "3 days max-age"
+//	formatMaxAge(3600)         // Renders in English: "1 hour max-age"
+//	formatMaxAge(7200)         // Renders in English: "2 hours max-age"
+//	formatMaxAge(300)          // Renders in English: "300 seconds max-age"
+func formatMaxAge(seconds int) *Locale.Text {
 	oneYear := 60 * 60 * 24 * 365
 	oneMonth := 60 * 60 * 24 * 30
 	oneDay := 60 * 60 * 24
 	oneHour := 60 * 60
 
 	if seconds >= oneYear {
-		years := seconds / oneYear
-		if years == 1 {
-			return "1 year max-age"
-		}
-		return strconv.Itoa(years) + " years max-age"
+		return Locale.Plural("hsts.max_age.year", seconds/oneYear)
 	} else if seconds >= oneMonth {
-		months := seconds / oneMonth
-		if months == 1 {
-			return "1 month max-age"
-		}
-		return strconv.Itoa(months) + " months max-age"
+		return Locale.Plural("hsts.max_age.month", seconds/oneMonth)
 	} else if seconds >= oneDay {
-		days := seconds / oneDay
-		if days == 1 {
-			return "1 day max-age"
-		}
-		return strconv.Itoa(days) + " days max-age"
+		return Locale.Plural("hsts.max_age.day", seconds/oneDay)
 	} else if seconds >= oneHour {
-		hours := seconds / oneHour
-		if hours == 1 {
-			return "1 hour max-age"
-		}
-		return strconv.Itoa(hours) + " hours max-age"
+		return Locale.Plural("hsts.max_age.hour", seconds/oneHour)
 	} else {
-		return strconv.Itoa(seconds) + " seconds max-age"
+		return Locale.Plural("hsts.max_age.second", seconds)
+	}
+}
+
+// generateHSTSRemediation suggests the exact Strict-Transport-Security header fixing the
+// configuration rated with the given threat level.
+//
+// Parameters:
+//   - threatLevel: Threat level from evaluateHSTSThreatLevel
+//
+// Returns:
+//   - *Locale.Text: Localizable remediation, nil for an excellent (preload-ready) configuration
+func generateHSTSRemediation(threatLevel ThreatLevel) *Locale.Text {
+	switch threatLevel {
+	case None:
+		return nil
+	case Info:
+		return Locale.NewText("hsts.remediation.preload", recommendedHSTSHeader)
+	default:
+		return Locale.NewText("hsts.remediation.replace", recommendedHSTSHeader)
 	}
 }
@@ -0,0 +1,48 @@
+package Tests
+
+import (
+	"Engine-AntiGinx/App/Locale"
+	"net/http"
+	"testing"
+)
+
+func TestHSTSTest_Localize(t *testing.T) {
+	tests := []struct {
+		name            string
+		header          string
+		lang            Locale.Lang
+		wantDescription string
+		wantRemediation string
+	}{
+		{name: "Missing header in English", lang: Locale.English,
+			wantDescription: "Missing HSTS header - site vulnerable to protocol downgrade attacks and man-in-the-middle attacks",
+			wantRemediation: "Send the header on every HTTPS response: Strict-Transport-Security: " + recommendedHSTSHeader},
+		{name: "Missing header in Polish", lang: Locale.Polish,
+			wantDescription: "Brak nagłówka HSTS - witryna podatna na ataki obniżenia protokołu i ataki typu man-in-the-middle",
+			wantRemediation: "Wysyłaj nagłówek w każdej odpowiedzi HTTPS: Strict-Transport-Security: " + recommendedHSTSHeader},
+		{name: "Short max-age in Polish", header: "max-age=172800; includeSubDomains", lang: Locale.Polish,
+			wantDescription: "Nagłówek HSTS skonfigurowany z max-age 2 dni oraz zawiera: includeSubDomains - Słaba konfiguracja bezpieczeństwa, rozważ zwiększenie max-age",
+			wantRemediation: "Zastąp nagłówek wartością Strict-Transport-Security: " + recommendedHSTSHeader +
+				" (max-age co najmniej jeden rok, obejmujący wszystkie subdomeny)"},
+		{name: "Two years in Polish", header: "max-age=63072000; includeSubDomains; preload", lang: Locale.Polish,
+			wantDescription: "Nagłówek HSTS skonfigurowany z max-age 2 lata oraz zawiera: includeSubDomains, preload - Doskonała konfiguracja bezpieczeństwa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				response.Header.Set("Strict-Transport-Security", tt.header)
+			}
+
+			result := NewHSTSTest().Run(ResponseTestParams{Response: response})
+			result.Localize(tt.lang)
+
+			if result.Description != tt.wantDescription {
+				t.Errorf("Expected description %q, got %q", tt.wantDescription, result.Description)
+			}
+			if result.Remediation != tt.wantRemediation {
+				t.Errorf("Expected remediation %q, got %q", tt.wantRemediation, result.Remediation)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the HTTP methods test that detects Allow and Public headers
+// advertising WebDAV or non-standard methods.
+package Tests
+
+import (
+	helpers "Engine-AntiGinx/App/Helpers"
+	"strings"
+)
+
+// httpMethodsReferences documents the HTTP method headers and method hardening.
+var httpMethodsReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Allow",
+	"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/02-Configuration_and_Deployment_Management_Testing/06-Test_HTTP_Methods",
+}
+
+// standardMethods are the methods defined by the HTTP semantics specification.
+var standardMethods = []string{"GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH"}
+
+// webDAVMethods are the WebDAV extension methods, which let clients browse, create,
+// copy, move and lock resources on the server.
+var webDAVMethods = []string{
+	"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK", "SEARCH",
+	"REPORT", "MKCALENDAR", "VERSION-CONTROL", "CHECKIN", "CHECKOUT", "UNCHECKOUT",
+}
+
+// NewHTTPMethodsTest creates a new ResponseTest that analyzes the methods a server
+// advertises in the Allow header (RFC 9110) and the legacy Public header (RFC 2068).
+// Servers listing every method they understand disclose WebDAV support and internal
+// management verbs (e.g., DEBUG, PURGE, TRACK) that should never be reachable publicly:
+// WebDAV methods allow listing directories and uploading, moving or overwriting files.
+//
+// The test evaluates:
+//   - Allow and Public header values, split into individual methods
+//   - Extension methods: every advertised method beyond the standard HTTP methods
+//   - WebDAV methods among the extension methods
+//
+// Threat level assessment:
+//   - None (0): No Allow or Public header, or only standard methods advertised
+//   - Low (2): Non-standard management methods advertised
+//   - Medium (3): WebDAV methods (PROPFIND, MKCOL, COPY, MOVE, ...) advertised
+//
+// Returns:
+//   - *ResponseTest: Configured HTTP methods test ready for execution
+//
+// Example usage:
+//
+//	methodsTest := NewHTTPMethodsTest()
+//	result := methodsTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["extension_methods"] lists the disclosed non-standard methods
+func NewHTTPMethodsTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "http-methods",
+		Name:          "Advertised HTTP Methods",
+		Description:   "Checks the Allow and Public headers for WebDAV and non-standard methods disclosing internal functionality",
+		Category:      "Headers",
+		CWE:           "CWE-749",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"Allow", "Public"},
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeHTTPMethods(HeaderValues(params.Response.Header, "Allow"),
+				HeaderValues(params.Response.Header, "Public"))
+			threatLevel := evaluateHTTPMethodsThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "Advertised HTTP Methods",
+				Certainty:   90,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateHTTPMethodsDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Disable WebDAV and management methods on public servers (e.g., remove mod_dav " +
+					"or the WebDAV IIS module) and advertise only the methods the application serves in Allow; drop the " +
+					"legacy Public header"
+				result.References = httpMethodsReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeHTTPMethods splits the Allow and Public header values into methods and classifies
+// them. Methods are upper-cased and listed once, in the order they are first advertised.
+//
+// Parameters:
+//   - allow: Values of the Allow header
+//   - public: Values of the Public header
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "allow_present" (bool): Allow header was sent
+//   - "public_present" (bool): Public header was sent
+//   - "methods" ([]string): Every advertised method
+//   - "extension_methods" ([]string): Advertised methods beyond the standard HTTP methods
+//   - "webdav_methods" ([]string): Advertised WebDAV methods
+//
+// Example:
+//
+//	metadata := analyzeHTTPMethods([]string{"GET, HEAD, PROPFIND, MKCOL"}, nil)
+//	// metadata["webdav_methods"] == []string{"PROPFIND", "MKCOL"}
+func analyzeHTTPMethods(allow, public []string) map[string]interface{} {
+	methods := []string{}
+	for _, value := range append(append([]string{}, allow...), public...) {
+		for _, method := range strings.Split(value, ",") {
+			method = strings.ToUpper(strings.TrimSpace(method))
+			if method != "" && !helpers.StringInSlice(methods, method) {
+				methods = append(methods, method)
+			}
+		}
+	}
+
+	extension, webDAV := []string{}, []string{}
+	for _, method := range methods {
+		if helpers.StringInSlice(standardMethods, method) {
+			continue
+		}
+		extension = append(extension, method)
+		if helpers.StringInSlice(webDAVMethods, method) {
+			webDAV = append(webDAV, method)
+		}
+	}
+
+	return map[string]interface{}{
+		"allow_present":     len(allow) > 0,
+		"public_present":    len(public) > 0,
+		"methods":           methods,
+		"extension_methods": extension,
+		"webdav_methods":    webDAV,
+	}
+}
+
+// evaluateHTTPMethodsThreatLevel maps the HTTP methods analysis to a threat level.
+func evaluateHTTPMethodsThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch {
+	case len(metadata["webdav_methods"].([]string)) > 0:
+		return Medium
+	case len(metadata["extension_methods"].([]string)) > 0:
+		return Low
+	default:
+		return None
+	}
+}
+
+// generateHTTPMethodsDescription builds a human-readable summary of the HTTP methods analysis.
+func generateHTTPMethodsDescription(metadata map[string]interface{}) string {
+	if !metadata["allow_present"].(bool) && !metadata["public_present"].(bool) {
+		return "No Allow or Public header, the server does not advertise its HTTP methods"
+	}
+	advertised := "Server advertises " + strings.Join(metadata["methods"].([]string), ", ")
+	if webDAV := metadata["webdav_methods"].([]string); len(webDAV) > 0 {
+		return advertised + "; WebDAV methods (" + strings.Join(webDAV, ", ") + ") are exposed publicly and may " +
+			"allow listing, uploading or overwriting files"
+	}
+	if extension := metadata["extension_methods"].([]string); len(extension) > 0 {
+		return advertised + "; non-standard methods (" + strings.Join(extension, ", ") + ") disclose internal " +
+			"management functionality"
+	}
+	return advertised + ", only standard HTTP methods"
+}
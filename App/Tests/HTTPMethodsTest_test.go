@@ -0,0 +1,70 @@
+package Tests
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHTTPMethodsTest(t *testing.T) {
+	tests := []struct {
+		name          string
+		allow         string
+		public        string
+		wantThreat    ThreatLevel
+		wantExtension []string
+		wantWebDAV    []string
+	}{
+		{name: "Absent", wantThreat: None, wantExtension: []string{}, wantWebDAV: []string{}},
+		{name: "Standard methods", allow: "GET, HEAD, OPTIONS", wantThreat: None, wantExtension: []string{}, wantWebDAV: []string{}},
+		{
+			name:          "WebDAV methods",
+			allow:         "OPTIONS, GET, HEAD, POST, PROPFIND, MKCOL, COPY, MOVE, LOCK, UNLOCK",
+			wantThreat:    Medium,
+			wantExtension: []string{"PROPFIND", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK"},
+			wantWebDAV:    []string{"PROPFIND", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK"},
+		},
+		{
+			name:          "WebDAV in legacy Public header",
+			allow:         "GET, HEAD",
+			public:        "OPTIONS, TRACE, GET, HEAD, propfind, PROPPATCH",
+			wantThreat:    Medium,
+			wantExtension: []string{"PROPFIND", "PROPPATCH"},
+			wantWebDAV:    []string{"PROPFIND", "PROPPATCH"},
+		},
+		{
+			name:          "Management verbs",
+			allow:         "GET, POST, DEBUG, PURGE",
+			wantThreat:    Low,
+			wantExtension: []string{"DEBUG", "PURGE"},
+			wantWebDAV:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if tt.allow != "" {
+				response.Header.Set("Allow", tt.allow)
+			}
+			if tt.public != "" {
+				response.Header.Set("Public", tt.public)
+			}
+
+			result := NewHTTPMethodsTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if got := metadata["extension_methods"]; !reflect.DeepEqual(got, tt.wantExtension) {
+				t.Errorf("Expected extension methods %v, got %v", tt.wantExtension, got)
+			}
+			if got := metadata["webdav_methods"]; !reflect.DeepEqual(got, tt.wantWebDAV) {
+				t.Errorf("Expected WebDAV methods %v, got %v", tt.wantWebDAV, got)
+			}
+			if (result.Remediation != "") != (tt.wantThreat > None) {
+				t.Errorf("Unexpected remediation %q for threat level %v", result.Remediation, result.ThreatLevel)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the insecure deserialization test that detects serialized objects
+// stored in cookies.
+package Tests
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// insecureDeserializationReferences documents deserialization attacks and their prevention.
+var insecureDeserializationReferences = []string{
+	"https://owasp.org/www-community/vulnerabilities/Insecure_Deserialization",
+	"https://cheatsheetseries.owasp.org/cheatsheets/Deserialization_Cheat_Sheet.html",
+	"https://cwe.mitre.org/data/definitions/502.html",
+}
+
+// Serialization formats reported by the insecure deserialization test.
+const (
+	serializedJava      = "java"
+	serializedPHP       = "php"
+	serializedViewState = "dotnet-viewstate"
+	serializedPickle    = "python-pickle"
+)
+
+// javaSerializationMagic is the stream header of Java object serialization (STREAM_MAGIC
+// 0xACED, STREAM_VERSION 5); base64 encoded it starts with "rO0AB".
+var javaSerializationMagic = []byte{0xac, 0xed, 0x00, 0x05}
+
+// phpSerializedPattern matches a serialized PHP array or object, e.g. `a:1:{` or `O:4:"User"`.
+var phpSerializedPattern = regexp.MustCompile(`(?:^|[;{])(?:a:\d+:\{|O:\d+:"[A-Za-z_\\])`)
+
+// NewInsecureDeserializationTest creates a new ResponseTest that inspects cookie values,
+// including the session tokens and state kept in cookies, for serialized objects. A
+// serialized object in a cookie is deserialized by the server when the client sends it
+// back; since the client controls the value, a crafted object can trigger gadget chains
+// leading to remote code execution (Java, PHP, Python pickle) or, for an unprotected
+// .NET ViewState, tampered state.
+//
+// The test recognizes:
+//   - Java serialization: "rO0AB" (base64) or "aced0005" (hex) stream header
+//   - PHP serialize(): arrays and objects such as `a:1:{...}` or `O:4:"User":...`
+//   - .NET ViewState: cookies named __VIEWSTATE or values starting with "/wE"
+//   - Python pickle: base64 values decoding to a protocol 2+ pickle
+//
+// Threat level assessment:
+//   - None (0): No cookie holds a serialized object
+//   - Medium (3): At least one cookie holds a serialized object
+//
+// Returns:
+//   - *ResponseTest: Configured insecure deserialization test ready for execution
+//
+// Example usage:
+//
+//	deserTest := NewInsecureDeserializationTest()
+//	result := deserTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["formats"] maps every affected cookie to its serialization format
+func NewInsecureDeserializationTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "insecure-deser",
+		Name:          "Serialized Objects in Cookies",
+		Description:   "Detects Java, PHP, .NET ViewState and Python pickle serialized objects in cookies, which expose deserialization attack surface",
+		Category:      "App-Configuration",
+		CWE:           "CWE-502",
+		OWASPCategory: "A08:2021-Software and Data Integrity Failures",
+		CacheHeaders:  []string{"Set-Cookie"},
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeInsecureDeserialization(params)
+			threatLevel := evaluateInsecureDeserializationThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "Serialized Objects in Cookies",
+				Certainty:   75,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateInsecureDeserializationDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Do not store serialized objects in cookies; keep state on the server behind an " +
+					"opaque session ID or use a data-only format such as JSON protected by a signature (HMAC) that is " +
+					"verified before parsing, and enable ViewState MAC validation and encryption in ASP.NET"
+				result.References = insecureDeserializationReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeInsecureDeserialization detects the serialization format of every cookie set by
+// the response.
+//
+// Parameters:
+//   - params: Test parameters holding the response
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "cookie_count" (int): Number of cookies set by the response
+//   - "formats" (map[string]string): Serialization format per affected cookie
+//   - "affected_cookies" ([]string): Names of the affected cookies, sorted
+//
+// Example:
+//
+//	metadata := analyzeInsecureDeserialization(ResponseTestParams{Response: response})
+//	// metadata["formats"] == map[string]string{"prefs": "java"}
+func analyzeInsecureDeserialization(params ResponseTestParams) map[string]interface{} {
+	cookies := responseCookies(params.Response)
+	formats := make(map[string]string)
+	for _, cookie := range cookies {
+		if format := serializationFormat(cookie.Name, cookie.Value); format != "" {
+			formats[cookie.Name] = format
+		}
+	}
+	affected := make([]string, 0, len(formats))
+	for name := range formats {
+		affected = append(affected, name)
+	}
+	sort.Strings(affected)
+
+	return map[string]interface{}{
+		"cookie_count":     len(cookies),
+		"formats":          formats,
+		"affected_cookies": affected,
+	}
+}
+
+// serializationFormat returns the serialization format of a cookie value, or an empty
+// string if the value is not recognized as a serialized object. URL-encoded values are
+// decoded first.
+func serializationFormat(name, value string) string {
+	if decoded, err := url.PathUnescape(value); err == nil {
+		value = decoded
+	}
+	value = strings.Trim(value, `"`)
+	if value == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(strings.ToLower(value), hex.EncodeToString(javaSerializationMagic)) {
+		return serializedJava
+	}
+	if strings.Contains(strings.ToUpper(name), "__VIEWSTATE") || strings.HasPrefix(value, "/wE") {
+		return serializedViewState
+	}
+	if phpSerializedPattern.MatchString(value) {
+		return serializedPHP
+	}
+	if raw := decodeBase64Value(value); raw != nil {
+		switch {
+		case bytes.HasPrefix(raw, javaSerializationMagic):
+			return serializedJava
+		case isPickle(raw):
+			return serializedPickle
+		case phpSerializedPattern.Match(raw):
+			return serializedPHP
+		}
+	}
+	return ""
+}
+
+// decodeBase64Value decodes a standard or URL-safe base64 value, padded or not, returning
+// nil when the value is not base64.
+func decodeBase64Value(value string) []byte {
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if raw, err := encoding.DecodeString(value); err == nil {
+			return raw
+		}
+	}
+	return nil
+}
+
+// isPickle reports whether raw is a Python pickle of protocol 2 or newer: it starts with
+// the PROTO opcode (0x80) followed by the protocol version and ends with the STOP opcode.
+func isPickle(raw []byte) bool {
+	return len(raw) >= 4 && raw[0] == 0x80 && raw[1] >= 2 && raw[1] <= 5 && raw[len(raw)-1] == '.'
+}
+
+// evaluateInsecureDeserializationThreatLevel maps the analysis to a threat level.
+func evaluateInsecureDeserializationThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	if len(metadata["affected_cookies"].([]string)) > 0 {
+		return Medium
+	}
+	return None
+}
+
+// generateInsecureDeserializationDescription builds a human-readable summary of the analysis.
+func generateInsecureDeserializationDescription(metadata map[string]interface{}) string {
+	affected := metadata["affected_cookies"].([]string)
+	if len(affected) == 0 {
+		if metadata["cookie_count"].(int) == 0 {
+			return "No cookies set, no serialized objects exposed to the client"
+		}
+		return "No serialized objects found in cookies"
+	}
+	formats := metadata["formats"].(map[string]string)
+	found := make([]string, 0, len(affected))
+	for _, name := range affected {
+		found = append(found, name+" ("+formats[name]+")")
+	}
+	return "Cookies hold serialized objects: " + strings.Join(found, ", ") + ". The server deserializes " +
+		"client-controlled data, which can lead to remote code execution through crafted objects"
+}
@@ -0,0 +1,85 @@
+package Tests
+
+import (
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestInsecureDeserializationTest(t *testing.T) {
+	// A serialized java.lang.Integer, as sent by an application storing it in a cookie.
+	javaCookie := base64.StdEncoding.EncodeToString([]byte("\xac\xed\x00\x05sr\x00\x11java.lang.Integer\x12\xe2\xa0\xa4\xf7\x81\x878\x02\x00\x01I\x00\x05value"))
+	pickleCookie := base64.URLEncoding.EncodeToString([]byte("\x80\x04\x95\x10\x00\x00\x00\x00\x00\x00\x00}\x94\x8c\x04user\x94\x8c\x03bob\x94s."))
+
+	tests := []struct {
+		name        string
+		cookies     []string
+		wantThreat  ThreatLevel
+		wantFormats map[string]string
+	}{
+		{name: "No cookies", wantThreat: None, wantFormats: map[string]string{}},
+		{
+			name:        "Random session ID",
+			cookies:     []string{"session=3f9a7c1e5b2d4086a1c9e7f3b5d20c48; Path=/; Secure; HttpOnly"},
+			wantThreat:  None,
+			wantFormats: map[string]string{},
+		},
+		{
+			name:        "Random base64 token",
+			cookies:     []string{"token=q83vEjRWeJCrze8SNFZ4kA==; Path=/"},
+			wantThreat:  None,
+			wantFormats: map[string]string{},
+		},
+		{
+			name:        "Java serialized cookie",
+			cookies:     []string{"prefs=" + javaCookie + "; Path=/", "session=3f9a7c1e5b2d4086; Path=/"},
+			wantThreat:  Medium,
+			wantFormats: map[string]string{"prefs": "java"},
+		},
+		{
+			name:        "Java serialized hex",
+			cookies:     []string{"state=ACED0005737200116A6176612E6C616E672E496E7465676572"},
+			wantThreat:  Medium,
+			wantFormats: map[string]string{"state": "java"},
+		},
+		{
+			name:        "URL-encoded PHP array",
+			cookies:     []string{"cart=a%3A1%3A%7Bs%3A2%3A%22id%22%3Bi%3A7%3B%7D; Path=/"},
+			wantThreat:  Medium,
+			wantFormats: map[string]string{"cart": "php"},
+		},
+		{
+			name:        ".NET ViewState",
+			cookies:     []string{"vs=/wEPDwUKLTk2NjY5NzQ1MWRk; Path=/"},
+			wantThreat:  Medium,
+			wantFormats: map[string]string{"vs": "dotnet-viewstate"},
+		},
+		{
+			name:        "Python pickle",
+			cookies:     []string{"data=" + pickleCookie},
+			wantThreat:  Medium,
+			wantFormats: map[string]string{"data": "python-pickle"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			for _, cookie := range tt.cookies {
+				response.Header.Add("Set-Cookie", cookie)
+			}
+
+			result := NewInsecureDeserializationTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			if got := result.Metadata.(map[string]interface{})["formats"]; !reflect.DeepEqual(got, tt.wantFormats) {
+				t.Errorf("Expected formats %v, got %v", tt.wantFormats, got)
+			}
+			if (result.Remediation != "") != (tt.wantThreat > None) {
+				t.Errorf("Unexpected remediation %q for threat level %v", result.Remediation, result.ThreatLevel)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the JavaScript libraries test that fingerprints common front-end
+// libraries and their versions and looks up their known vulnerabilities.
+package Tests
+
+import (
+	"Engine-AntiGinx/App/CVE"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsLibsReferences documents the risk of shipping vulnerable front-end components.
+var jsLibsReferences = []string{
+	"https://owasp.org/Top10/A06_2021-Vulnerable_and_Outdated_Components/",
+	"https://cwe.mitre.org/data/definitions/1104.html",
+	"https://retirejs.github.io/retire.js/",
+}
+
+// jsLibraryFingerprint describes how a front-end library and its version are recognized.
+type jsLibraryFingerprint struct {
+	name    string           // Library name, also the technology name of the CVE lookup
+	files   []*regexp.Regexp // Matched against script and stylesheet URLs; group 1 is the version (optional)
+	banners []*regexp.Regexp // Matched against the body (comment banners, inline globals, markup); group 1 is the version
+}
+
+// jsLibraryFiles builds the URL patterns of a library whose file or CDN path is named token:
+// "jquery-1.12.4.min.js", "ajax/libs/jquery/1.12.4/", "react@16.13.1", "jquery.min.js?ver=1.12.4"
+// and, without a version, "jquery.min.js".
+func jsLibraryFiles(token string) []*regexp.Regexp {
+	const prefix = `(?i)(?:^|[^a-z0-9])(?:` // the token starts a path segment or file name
+	const suffixes = `(?:[.-](?:min|slim|bundle|umd|production|development))*\.js`
+	return []*regexp.Regexp{
+		regexp.MustCompile(prefix + token + `)(?:\.js)?[/@-]v?(\d+\.\d+(?:\.\d+)?)`),
+		regexp.MustCompile(prefix + token + `)` + suffixes + `\?(?:ver|v|version)=(\d+\.\d+(?:\.\d+)?)`),
+		regexp.MustCompile(prefix + token + `)` + suffixes + `(?:$|[?#])`),
+	}
+}
+
+// jsLibraryFingerprints are the libraries the test recognizes.
+var jsLibraryFingerprints = []jsLibraryFingerprint{
+	{
+		name:  "jQuery",
+		files: jsLibraryFiles(`jquery`),
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`jQuery(?: JavaScript Library)? v(\d+\.\d+\.\d+)`),
+			regexp.MustCompile(`\bjquery\s*:\s*["'](\d+\.\d+\.\d+)["']`),
+		},
+	},
+	{
+		name:  "AngularJS",
+		files: jsLibraryFiles(`angular(?:js)?`),
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`AngularJS v(\d+\.\d+\.\d+)`),
+		},
+	},
+	{
+		name:  "Angular",
+		files: jsLibraryFiles(`@angular/core`),
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`\bng-version=["'](\d+\.\d+\.\d+)`),
+		},
+	},
+	{
+		name:  "React",
+		files: jsLibraryFiles(`react(?:-dom)?`),
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`\bReact(?:DOM)? v(\d+\.\d+\.\d+)`),
+		},
+	},
+	{
+		name:  "Bootstrap",
+		files: jsLibraryFiles(`bootstrap`),
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`Bootstrap v(\d+\.\d+\.\d+)`),
+		},
+	},
+	{
+		name:  "Lodash",
+		files: jsLibraryFiles(`lodash`),
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\blodash(?:\.js)? v?(\d+\.\d+\.\d+)`),
+		},
+	},
+}
+
+// JSLibrary is a front-end library detected in the response.
+type JSLibrary struct {
+	Name     string      `json:"name"`            // Library name (e.g., "jQuery")
+	Version  string      `json:"version"`         // Detected version, empty when it is not disclosed
+	Source   string      `json:"source"`          // "script" or "stylesheet" URL, or "banner" in the body
+	Evidence string      `json:"evidence"`        // URL or body excerpt the library was recognized from
+	CVE      *CVESummary `json:"cve,omitempty"`   // Known vulnerabilities of the version, nil when not looked up
+	Error    string      `json:"error,omitempty"` // Why the CVE lookup failed
+}
+
+// JSLibrariesAnalysis holds the front-end libraries found in the response.
+type JSLibrariesAnalysis struct {
+	Libraries  []JSLibrary `json:"libraries"`  // Detected libraries, in detection order
+	Vulnerable []string    `json:"vulnerable"` // "Name Version" of the libraries with known CVEs
+	CVELookup  bool        `json:"cve_lookup"` // Whether known vulnerabilities were looked up
+	Error      string      `json:"error,omitempty"`
+}
+
+// NewJSLibrariesTest creates a new ResponseTest that fingerprints common front-end
+// libraries (jQuery, AngularJS, Angular, React, Bootstrap and Lodash) and looks up the
+// known vulnerabilities of their versions. Libraries are recognized from the URLs of the
+// page's scripts and stylesheets (file names, CDN paths and ?ver= parameters) and from the
+// body: comment banners of inlined libraries, inline version globals and Angular's
+// ng-version attribute. External scripts are not downloaded.
+//
+// Every library with a known version is assessed with the CVE client of the scan, over the
+// publication window (--cve-years) and minimum severity (--cve-min-severity) of the scan,
+// unless CVE lookups are disabled (--no-cve).
+//
+// Threat level assessment:
+//   - None (0): No common front-end library detected
+//   - Info (1): Libraries detected, without known CVEs for their versions (or without lookups)
+//   - Medium (3): A detected library version has known CVEs
+//   - High (4): A detected library version has a known high or critical severity CVE
+//
+// Returns:
+//   - *ResponseTest: Configured JavaScript libraries test ready for execution
+//
+// Example usage:
+//
+//	jsLibsTest := NewJSLibrariesTest()
+//	result := jsLibsTest.Run(ResponseTestParams{Response: httpResponse, CVEClient: client})
+//	// Metadata.(JSLibrariesAnalysis).Vulnerable lists e.g. "jQuery 1.12.4"
+func NewJSLibrariesTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "js-libs",
+		Name:          "Outdated JavaScript Libraries",
+		Description:   "Fingerprints front-end libraries (jQuery, Angular, React, Bootstrap, Lodash) and looks up known CVEs of their versions",
+		Category:      "App-Configuration",
+		CWE:           "CWE-1104",
+		OWASPCategory: "A06:2021-Vulnerable and Outdated Components",
+		RunTest: func(params ResponseTestParams) TestResult {
+			analysis := analyzeJSLibraries(params)
+			if !params.DisableCVE {
+				cveClient := params.CVEClient
+				if cveClient == nil {
+					cveClient = CVE.NewCVEClient()
+				}
+				assessJSLibraries(params.cveOptions(), &analysis, cveClient)
+			}
+			threatLevel := evaluateJSLibrariesThreatLevel(analysis)
+
+			result := TestResult{
+				Name:         "Outdated JavaScript Libraries",
+				Certainty:    80,
+				ThreatLevel:  threatLevel,
+				Metadata:     analysis,
+				Description:  generateJSLibrariesDescription(analysis),
+				Technologies: jsLibraryTechnologies(analysis),
+			}
+			if len(analysis.Vulnerable) > 0 {
+				result.Remediation = "Upgrade the vulnerable libraries to their latest release and audit front-end " +
+					"dependencies (e.g., npm audit, retire.js) so known-vulnerable versions are not shipped"
+				result.References = jsLibsReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeJSLibraries fingerprints the libraries referenced or inlined by the response body.
+func analyzeJSLibraries(params ResponseTestParams) JSLibrariesAnalysis {
+	analysis := JSLibrariesAnalysis{Libraries: []JSLibrary{}, Vulnerable: []string{}}
+	body, err := params.responseBody()
+	if err != nil {
+		analysis.Error = err.Error()
+		return analysis
+	}
+
+	for _, tag := range FindHTMLTags(body, "script", "link") {
+		source, attr := "script", "src"
+		if tag.Name == "link" {
+			source, attr = "stylesheet", "href"
+		}
+		link, ok := tag.Attr(attr)
+		if !ok || strings.TrimSpace(link) == "" {
+			continue
+		}
+		link = strings.TrimSpace(link)
+		for _, fingerprint := range jsLibraryFingerprints {
+			if version, ok := matchJSLibrary(fingerprint.files, link); ok {
+				analysis.add(JSLibrary{Name: fingerprint.name, Version: version, Source: source, Evidence: link})
+				break
+			}
+		}
+	}
+	for _, fingerprint := range jsLibraryFingerprints {
+		for _, banner := range fingerprint.banners {
+			for _, match := range banner.FindAllSubmatch(body, -1) {
+				analysis.add(JSLibrary{Name: fingerprint.name, Version: string(match[1]), Source: "banner", Evidence: string(match[0])})
+			}
+		}
+	}
+	return analysis
+}
+
+// matchJSLibrary returns the version captured by the first matching pattern.
+func matchJSLibrary(patterns []*regexp.Regexp, link string) (string, bool) {
+	for _, pattern := range patterns {
+		if match := pattern.FindStringSubmatch(link); match != nil {
+			if len(match) > 1 {
+				return match[1], true
+			}
+			return "", true
+		}
+	}
+	return "", false
+}
+
+// add records a detected library once per name and version. A version detected later
+// replaces an earlier detection of the same library without one.
+func (a *JSLibrariesAnalysis) add(library JSLibrary) {
+	for i, known := range a.Libraries {
+		if known.Name != library.Name {
+			continue
+		}
+		if known.Version == library.Version || library.Version == "" {
+			return
+		}
+		if known.Version == "" {
+			a.Libraries[i] = library
+			return
+		}
+	}
+	a.Libraries = append(a.Libraries, library)
+}
+
+// assessJSLibraries looks up the known vulnerabilities of every library with a version.
+func assessJSLibraries(cveOpts []CVE.AssessmentOption, analysis *JSLibrariesAnalysis, cveClient *CVE.CVEClient) {
+	analysis.CVELookup = true
+	for i := range analysis.Libraries {
+		library := &analysis.Libraries[i]
+		if library.Version == "" {
+			continue
+		}
+		assessment, err := cveClient.AssessTechnologyVulnerabilities(library.Name, library.Version, cveOpts...)
+		if err != nil {
+			library.Error = err.Error()
+			continue
+		}
+		library.CVE = newCVESummary(assessment)
+		if assessment.CVECount > 0 {
+			analysis.Vulnerable = append(analysis.Vulnerable, library.Name+" "+library.Version)
+		}
+	}
+}
+
+// evaluateJSLibrariesThreatLevel maps the JavaScript libraries analysis to a threat level.
+func evaluateJSLibrariesThreatLevel(analysis JSLibrariesAnalysis) ThreatLevel {
+	threatLevel := None
+	for _, library := range analysis.Libraries {
+		switch {
+		case library.CVE != nil && library.CVE.High > 0:
+			return High
+		case library.CVE != nil && library.CVE.Count > 0:
+			threatLevel = Medium
+		case threatLevel < Info:
+			threatLevel = Info
+		}
+	}
+	return threatLevel
+}
+
+// jsLibraryTechnologies lists the detected libraries for the technology inventory.
+func jsLibraryTechnologies(analysis JSLibrariesAnalysis) []Technology {
+	if len(analysis.Libraries) == 0 {
+		return nil
+	}
+	technologies := make([]Technology, 0, len(analysis.Libraries))
+	for _, library := range analysis.Libraries {
+		technologies = append(technologies, Technology{Name: library.Name, Version: library.Version, CVE: library.CVE})
+	}
+	return technologies
+}
+
+// generateJSLibrariesDescription builds a human-readable summary of the JavaScript
+// libraries analysis.
+func generateJSLibrariesDescription(analysis JSLibrariesAnalysis) string {
+	if analysis.Error != "" {
+		return "Could not read the response body: " + analysis.Error
+	}
+	if len(analysis.Libraries) == 0 {
+		return "No common front-end libraries (jQuery, Angular, React, Bootstrap, Lodash) detected"
+	}
+	if len(analysis.Vulnerable) > 0 {
+		details := make([]string, 0, len(analysis.Vulnerable))
+		for _, library := range analysis.Libraries {
+			if library.CVE != nil && library.CVE.Count > 0 {
+				details = append(details, library.Name+" "+library.Version+" ("+strconv.Itoa(library.CVE.Count)+
+					" CVE(s), max CVSS "+strconv.FormatFloat(library.CVE.MaxScore, 'f', 1, 64)+")")
+			}
+		}
+		return "Front-end libraries with known vulnerabilities: " + strings.Join(details, ", ")
+	}
+	names := make([]string, 0, len(analysis.Libraries))
+	for _, library := range analysis.Libraries {
+		names = append(names, strings.TrimSpace(library.Name+" "+library.Version))
+	}
+	description := "Detected front-end libraries: " + strings.Join(names, ", ")
+	if !analysis.CVELookup {
+		return description + " (CVE lookups disabled)"
+	}
+	return description + "; no known CVEs for the detected versions"
+}
@@ -0,0 +1,171 @@
+package Tests
+
+import (
+	"Engine-AntiGinx/App/CVE"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// jqueryCVEs is the NVD answer for jQuery 1.12.4 served by newJSLibsNVD.
+const jqueryCVEs = `{"resultsPerPage":2,"startIndex":0,"totalResults":2,"vulnerabilities":[
+	{"cve":{"id":"CVE-2019-11358","published":"2019-04-20T00:00:00.000","lastModified":"2019-04-20T00:00:00.000",
+		"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":6.1,"baseSeverity":"MEDIUM"}}]}}},
+	{"cve":{"id":"CVE-2020-11022","published":"2020-04-29T00:00:00.000","lastModified":"2020-04-29T00:00:00.000",
+		"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":6.9,"baseSeverity":"MEDIUM"}}]}}}
+]}`
+
+// newJSLibsNVD serves jqueryCVEs for the "jquery 1.12.4" keyword search and no CVEs for
+// any other search, recording the searched keywords.
+func newJSLibsNVD(t *testing.T, searches *[]string) *CVE.CVEClient {
+	t.Helper()
+	var mu sync.Mutex
+	nvd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyword := r.URL.Query().Get("keywordSearch")
+		mu.Lock()
+		*searches = append(*searches, keyword)
+		mu.Unlock()
+		if keyword == "jquery 1.12.4" {
+			_, _ = w.Write([]byte(jqueryCVEs))
+			return
+		}
+		_, _ = w.Write([]byte(`{"resultsPerPage":0,"startIndex":0,"totalResults":0,"vulnerabilities":[]}`))
+	}))
+	t.Cleanup(nvd.Close)
+	return CVE.NewCVEClient(CVE.WithBaseURL(nvd.URL), CVE.WithRequestInterval(0))
+}
+
+// runJSLibs runs the JavaScript libraries test on a fixture of testdata/js-libs.
+func runJSLibs(t *testing.T, fixture string, client *CVE.CVEClient, disableCVE bool) TestResult {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "js-libs", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	response := &http.Response{Header: http.Header{"Content-Type": []string{"text/html"}}}
+	return NewJSLibrariesTest().Run(ResponseTestParams{Response: response, Body: body, CVEClient: client, DisableCVE: disableCVE})
+}
+
+func TestJSLibrariesTest_OldJQueryBanner(t *testing.T) {
+	var searches []string
+	result := runJSLibs(t, "old-jquery.html", newJSLibsNVD(t, &searches), false)
+
+	analysis, ok := result.Metadata.(JSLibrariesAnalysis)
+	if !ok {
+		t.Fatalf("Expected JSLibrariesAnalysis metadata, got %T", result.Metadata)
+	}
+	var detected []string
+	for _, library := range analysis.Libraries {
+		detected = append(detected, library.Source+": "+library.Name+" "+library.Version)
+	}
+	wantDetected := []string{"stylesheet: Bootstrap 3.4.1", "script: Lodash 4.17.21", "banner: jQuery 1.12.4"}
+	if !reflect.DeepEqual(detected, wantDetected) {
+		t.Errorf("Expected libraries %v, got %v", wantDetected, detected)
+	}
+	wantSearches := []string{"bootstrap 3.4.1", "lodash 4.17.21", "jquery 1.12.4"}
+	if !reflect.DeepEqual(searches, wantSearches) {
+		t.Errorf("Expected CVE lookups %v, got %v", wantSearches, searches)
+	}
+
+	if result.ThreatLevel != Medium {
+		t.Errorf("Expected Medium for a jQuery version with medium CVEs, got %v", result.ThreatLevel)
+	}
+	if !reflect.DeepEqual(analysis.Vulnerable, []string{"jQuery 1.12.4"}) {
+		t.Errorf("Expected jQuery 1.12.4 to be vulnerable, got %v", analysis.Vulnerable)
+	}
+	jquery := analysis.Libraries[2]
+	if jquery.CVE == nil || jquery.CVE.Count != 2 || jquery.CVE.TopCVEs[0] != "CVE-2020-11022" {
+		t.Errorf("Unexpected jQuery CVE summary %+v", jquery.CVE)
+	}
+	if jquery.Evidence != "jQuery v1.12.4" {
+		t.Errorf("Expected the banner as evidence, got %q", jquery.Evidence)
+	}
+	if result.Remediation == "" || len(result.References) == 0 {
+		t.Errorf("Expected remediation and references for a vulnerable library")
+	}
+	if len(result.Technologies) != 3 || result.Technologies[2].Name != "jQuery" || result.Technologies[2].CVE == nil {
+		t.Errorf("Expected the libraries in the technology inventory, got %+v", result.Technologies)
+	}
+}
+
+func TestJSLibrariesTest_HighSeverityCVE(t *testing.T) {
+	nvd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resultsPerPage":1,"startIndex":0,"totalResults":1,"vulnerabilities":[
+			{"cve":{"id":"CVE-2021-23337","published":"2021-02-15T00:00:00.000","lastModified":"2021-02-15T00:00:00.000",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":7.2,"baseSeverity":"HIGH"}}]}}}
+		]}`))
+	}))
+	defer nvd.Close()
+	client := CVE.NewCVEClient(CVE.WithBaseURL(nvd.URL), CVE.WithRequestInterval(0))
+
+	result := runJSLibs(t, "old-jquery.html", client, false)
+	if result.ThreatLevel != High {
+		t.Errorf("Expected High for a library with a high severity CVE, got %v", result.ThreatLevel)
+	}
+}
+
+func TestJSLibrariesTest_NoCVELookup(t *testing.T) {
+	var searches []string
+	result := runJSLibs(t, "old-jquery.html", newJSLibsNVD(t, &searches), true)
+
+	if len(searches) != 0 {
+		t.Errorf("Expected no NVD requests with CVE lookups disabled, got %v", searches)
+	}
+	if result.ThreatLevel != Info {
+		t.Errorf("Expected Info for detected libraries without lookups, got %v", result.ThreatLevel)
+	}
+	if analysis := result.Metadata.(JSLibrariesAnalysis); analysis.CVELookup || len(analysis.Libraries) != 3 {
+		t.Errorf("Expected three libraries without CVE lookup, got %+v", analysis)
+	}
+}
+
+func TestJSLibrariesTest_NoLibraries(t *testing.T) {
+	var searches []string
+	result := runJSLibs(t, "no-libs.html", newJSLibsNVD(t, &searches), false)
+
+	if result.ThreatLevel != None {
+		t.Errorf("Expected None without front-end libraries, got %v", result.ThreatLevel)
+	}
+	if len(searches) != 0 {
+		t.Errorf("Expected no NVD requests without detected libraries, got %v", searches)
+	}
+}
+
+func TestJSLibrariesTest_Fingerprints(t *testing.T) {
+	tests := []struct {
+		link        string
+		wantName    string
+		wantVersion string
+	}{
+		{"https://code.jquery.com/jquery-3.5.1.min.js", "jQuery", "3.5.1"},
+		{"https://code.jquery.com/jquery-3.5.1.slim.min.js", "jQuery", "3.5.1"},
+		{"/wp-includes/js/jquery/jquery.min.js?ver=3.6.0", "jQuery", "3.6.0"},
+		{"/static/jquery.min.js", "jQuery", ""},
+		{"https://ajax.googleapis.com/ajax/libs/angularjs/1.5.8/angular.min.js", "AngularJS", "1.5.8"},
+		{"https://unpkg.com/@angular/core@12.2.0/bundles/core.umd.js", "Angular", "12.2.0"},
+		{"https://unpkg.com/react-dom@16.13.1/umd/react-dom.production.min.js", "React", "16.13.1"},
+		{"https://stackpath.bootstrapcdn.com/bootstrap/4.3.1/js/bootstrap.min.js", "Bootstrap", "4.3.1"},
+		{"/css/bootstrap-3.3.7.min.css", "Bootstrap", "3.3.7"},
+		{"https://cdn.jsdelivr.net/npm/lodash@4.17.15/lodash.min.js", "Lodash", "4.17.15"},
+		{"/static/js/jquery-ui-1.12.1.min.js", "", ""},
+		{"/static/js/preact.min.js", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			var name, version string
+			for _, fingerprint := range jsLibraryFingerprints {
+				if v, ok := matchJSLibrary(fingerprint.files, tt.link); ok {
+					name, version = fingerprint.name, v
+					break
+				}
+			}
+			if name != tt.wantName || version != tt.wantVersion {
+				t.Errorf("Expected %q %q, got %q %q", tt.wantName, tt.wantVersion, name, version)
+			}
+		})
+	}
+}
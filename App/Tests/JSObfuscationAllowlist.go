@@ -0,0 +1,110 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the allowlist of known-good library scripts excluded from the
+// JavaScript obfuscation analysis.
+package Tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// ScriptAllowlist recognizes well-known library scripts whose minified builds trip the
+// obfuscation heuristics (jQuery, Google Analytics, Google Tag Manager). Scripts are
+// recognized by the URL they are loaded from or by the SHA-256 of their content; the
+// js-obf test excludes recognized scripts from scoring and lists them as allowlisted.
+type ScriptAllowlist struct {
+	URLPrefixes map[string]string // URL prefix (scheme-less, lower-case) -> library name
+	Hashes      map[string]string // Hex SHA-256 of the script content -> library name
+}
+
+// defaultAllowlistedURLs maps the official CDN locations of popular libraries to their names.
+var defaultAllowlistedURLs = map[string]string{
+	"code.jquery.com/jquery-":                   "jQuery",
+	"ajax.googleapis.com/ajax/libs/jquery/":     "jQuery",
+	"cdnjs.cloudflare.com/ajax/libs/jquery/":    "jQuery",
+	"cdn.jsdelivr.net/npm/jquery@":              "jQuery",
+	"www.google-analytics.com/analytics.js":     "Google Analytics",
+	"www.google-analytics.com/ga.js":            "Google Analytics",
+	"ssl.google-analytics.com/ga.js":            "Google Analytics",
+	"www.googletagmanager.com/gtag/js":          "Google Tag (gtag.js)",
+	"www.googletagmanager.com/gtm.js":           "Google Tag Manager",
+	"ajax.googleapis.com/ajax/libs/jqueryui/":   "jQuery UI",
+	"cdn.jsdelivr.net/npm/bootstrap@":           "Bootstrap",
+	"stackpath.bootstrapcdn.com/bootstrap/":     "Bootstrap",
+	"cdnjs.cloudflare.com/ajax/libs/lodash.js/": "Lodash",
+}
+
+// DefaultScriptAllowlist returns the allowlist used by DefaultJSObfuscationConfig: the
+// official CDN locations of popular libraries. It holds no content hashes; add the hashes
+// of self-hosted library copies with AddHash.
+//
+// Returns:
+//   - *ScriptAllowlist: Allowlist of library CDN locations
+func DefaultScriptAllowlist() *ScriptAllowlist {
+	allowlist := &ScriptAllowlist{URLPrefixes: make(map[string]string), Hashes: make(map[string]string)}
+	for prefix, name := range defaultAllowlistedURLs {
+		allowlist.URLPrefixes[prefix] = name
+	}
+	return allowlist
+}
+
+// AddHash allowlists the script with the given content under the library name.
+//
+// Parameters:
+//   - content: Exact content of the library script
+//   - name: Library name reported for matching scripts
+func (a *ScriptAllowlist) AddHash(content, name string) {
+	if a.Hashes == nil {
+		a.Hashes = make(map[string]string)
+	}
+	a.Hashes[scriptHash(content)] = name
+}
+
+// MatchURL returns the library loaded from scriptURL, if its location is allowlisted.
+// The scheme is ignored so that http and https loads of a CDN match alike.
+//
+// Parameters:
+//   - scriptURL: Absolute URL of the script
+//
+// Returns:
+//   - string: Library name
+//   - bool: Whether the URL is allowlisted
+func (a *ScriptAllowlist) MatchURL(scriptURL string) (string, bool) {
+	if a == nil {
+		return "", false
+	}
+	location := strings.ToLower(scriptURL)
+	if i := strings.Index(location, "://"); i >= 0 {
+		location = location[i+3:]
+	}
+	for prefix, name := range a.URLPrefixes {
+		if strings.HasPrefix(location, strings.ToLower(prefix)) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// MatchContent returns the library whose content hash equals the hash of content.
+//
+// Parameters:
+//   - content: Script content, inline or fetched
+//
+// Returns:
+//   - string: Library name
+//   - bool: Whether the content is allowlisted
+func (a *ScriptAllowlist) MatchContent(content string) (string, bool) {
+	if a == nil || len(a.Hashes) == 0 {
+		return "", false
+	}
+	name, ok := a.Hashes[scriptHash(content)]
+	return name, ok
+}
+
+// scriptHash returns the hex SHA-256 of script content, ignoring surrounding whitespace
+// so that inline copies hash like the file they were pasted from.
+func scriptHash(content string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(content)))
+	return hex.EncodeToString(sum[:])
+}
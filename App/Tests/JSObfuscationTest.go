@@ -6,6 +6,8 @@ package Tests
 import (
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 )
@@ -39,6 +41,11 @@ import (
 //   - Often used in drive-by download attacks
 //   - May hide cryptocurrency miners or ad fraud scripts
 //
+// External scripts (<script src>) of the same origin as the scanned page are fetched with
+// the scan client and analyzed with the inline scripts, bounded in count and size by
+// JSObfuscationConfig. Cross-origin scripts are listed but not fetched by default.
+// Known-good library scripts (ScriptAllowlist) are left out of the analysis.
+//
 // Detection patterns:
 //   - Base64 encoded strings followed by decode/atob
 //   - Hex escape sequences (\x41\x42\x43)
@@ -57,6 +64,18 @@ import (
 //	result := jsObfTest.Run(ResponseTestParams{Response: httpResponse})
 //	// Result includes threat level and detailed obfuscation analysis
 func NewJSObfuscationTest() *ResponseTest {
+	return NewJSObfuscationTestWithConfig(DefaultJSObfuscationConfig())
+}
+
+// NewJSObfuscationTestWithConfig creates the JavaScript obfuscation test scoring scripts
+// with the given thresholds and weights instead of DefaultJSObfuscationConfig.
+//
+// Parameters:
+//   - config: Detection thresholds, score weights and minification-aware mode
+//
+// Returns:
+//   - *ResponseTest: Configured JavaScript obfuscation test ready for execution
+func NewJSObfuscationTestWithConfig(config JSObfuscationConfig) *ResponseTest {
 	return &ResponseTest{
 		Id:          "js-obf",
 		Name:        "JavaScript Obfuscation Detection",
@@ -64,7 +83,7 @@ func NewJSObfuscationTest() *ResponseTest {
 		Category:    "Phishing",
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Read response body
-			bodyBytes, err := io.ReadAll(params.Response.Body)
+			bodyBytes, err := params.responseBody()
 			if err != nil {
 				return TestResult{
 					Name:        "JavaScript Obfuscation Detection",
@@ -77,7 +96,7 @@ func NewJSObfuscationTest() *ResponseTest {
 			bodyStr := string(bodyBytes)
 
 			// Check if response contains JavaScript
-			contentType := params.Response.Header.Get("Content-Type")
+			contentType := HeaderValue(params.Response.Header, "Content-Type")
 			hasJavaScript := strings.Contains(contentType, "javascript") ||
 				strings.Contains(bodyStr, "<script") ||
 				strings.Contains(contentType, "html")
@@ -93,7 +112,8 @@ func NewJSObfuscationTest() *ResponseTest {
 			}
 
 			// Analyze JavaScript for obfuscation
-			analysis := analyzeJSObfuscation(bodyStr)
+			external := fetchExternalScripts(params, bodyStr, config)
+			analysis := analyzeJSObfuscation(bodyStr, external, config)
 
 			// Determine threat level
 			threatLevel := evaluateObfuscationThreat(analysis)
@@ -112,6 +132,81 @@ func NewJSObfuscationTest() *ResponseTest {
 	}
 }
 
+// JSObfuscationConfig holds the thresholds and score weights of the obfuscation scorer.
+// Counts are reported once they exceed their threshold; weights are the score points
+// added per occurrence (or once, for escape sequences).
+type JSObfuscationConfig struct {
+	HexEscapeThreshold       int // \x escapes tolerated before they are reported
+	UnicodeEscapeThreshold   int // \u escapes tolerated before they are reported
+	ConcatenationThreshold   int // String literal concatenations tolerated before they are reported
+	LongLineLength           int // Length above which a script line counts as long
+	LongLineThreshold        int // Long lines tolerated before they are reported
+	DynamicExecutionLimit    int // Dynamic execution calls above which an extra penalty applies
+	DynamicExecutionWeight   int
+	EncodedStringWeight      int
+	CharCodeWeight           int
+	EscapeSequenceWeight     int
+	Base64StringWeight       int
+	SuspiciousPatternWeight  int
+	MaliciousIndicatorWeight int
+	ObfuscationThreshold     int // Score above which the scripts count as obfuscated
+
+	// MinificationAware recognizes bundler output (webpack runtime signatures) and stops
+	// scoring the patterns bundlers emit legitimately: long lines, string concatenation,
+	// escape sequences, inlined Base64 data and Function() calls. Malicious indicators
+	// and encoded input to eval() are still scored.
+	MinificationAware bool
+
+	MaxExternalScripts      int   // External scripts fetched per page (0 disables fetching)
+	MaxExternalScriptBytes  int64 // Bytes read from each external script
+	FetchCrossOriginScripts bool  // Fetch scripts of other origins too (listed only by default)
+
+	// Allowlist recognizes known-good library scripts (see JSObfuscationAllowlist.go), which
+	// are not fetched or scored. Nil scores every script.
+	Allowlist *ScriptAllowlist
+}
+
+// DefaultJSObfuscationConfig returns the thresholds and weights the js-obf test uses by
+// default, with the minification-aware mode enabled.
+//
+// Returns:
+//   - JSObfuscationConfig: Default scorer configuration
+func DefaultJSObfuscationConfig() JSObfuscationConfig {
+	return JSObfuscationConfig{
+		HexEscapeThreshold:       10,
+		UnicodeEscapeThreshold:   10,
+		ConcatenationThreshold:   20,
+		LongLineLength:           500,
+		LongLineThreshold:        5,
+		DynamicExecutionLimit:    10,
+		DynamicExecutionWeight:   5,
+		EncodedStringWeight:      8,
+		CharCodeWeight:           6,
+		EscapeSequenceWeight:     15,
+		Base64StringWeight:       5,
+		SuspiciousPatternWeight:  10,
+		MaliciousIndicatorWeight: 25,
+		ObfuscationThreshold:     20,
+		MinificationAware:        true,
+		MaxExternalScripts:       5,
+		MaxExternalScriptBytes:   512 << 10,
+		Allowlist:                DefaultScriptAllowlist(),
+	}
+}
+
+// bundlerSignatures maps runtime identifiers emitted by JavaScript bundlers to the bundler name.
+var bundlerSignatures = []struct {
+	marker  string
+	bundler string
+}{
+	{"__webpack_require__", "webpack"},
+	{"webpackJsonp", "webpack"},
+	{"webpackChunk", "webpack"},
+	{"__webpack_modules__", "webpack"},
+	{"parcelRequire", "parcel"},
+	{"System.register(", "systemjs"},
+}
+
 // JSObfuscationAnalysis represents the comprehensive obfuscation analysis
 type JSObfuscationAnalysis struct {
 	HasObfuscation      bool     `json:"hasObfuscation"`
@@ -120,28 +215,147 @@ type JSObfuscationAnalysis struct {
 	SuspiciousPatterns  []string `json:"suspiciousPatterns"`
 	MaliciousIndicators []string `json:"maliciousIndicators"`
 	EncodingMethods     []string `json:"encodingMethods"`
-	DynamicExecution    int      `json:"dynamicExecution"` // Count of eval/Function calls
-	EncodedStrings      int      `json:"encodedStrings"`   // Count of encoded strings
-	CharCodeUsage       int      `json:"charCodeUsage"`    // String.fromCharCode usage
-	HexEscapes          int      `json:"hexEscapes"`       // \x escape sequences
-	UnicodeEscapes      int      `json:"unicodeEscapes"`   // \u escape sequences
-	Base64Strings       int      `json:"base64Strings"`    // Base64 encoded strings
-	ObfuscationLevel    string   `json:"obfuscationLevel"` // none, light, moderate, heavy, extreme
-	Certainty           int      `json:"certainty"`        // 0-100
+	DynamicExecution    int      `json:"dynamicExecution"`   // Count of eval/Function calls
+	EncodedStrings      int      `json:"encodedStrings"`     // Count of encoded strings
+	CharCodeUsage       int      `json:"charCodeUsage"`      // String.fromCharCode usage
+	HexEscapes          int      `json:"hexEscapes"`         // \x escape sequences
+	UnicodeEscapes      int      `json:"unicodeEscapes"`     // \u escape sequences
+	Base64Strings       int      `json:"base64Strings"`      // Base64 encoded strings
+	ObfuscationLevel    string   `json:"obfuscationLevel"`   // none, light, moderate, heavy, extreme
+	Bundler             string   `json:"bundler,omitempty"`  // Bundler recognized in minification-aware mode
+	Certainty           int      `json:"certainty"`          // 0-100
+	ExternalScripts     []string `json:"externalScripts"`    // External scripts fetched and analyzed
+	CrossOriginScripts  []string `json:"crossOriginScripts"` // Cross-origin scripts listed but not fetched
+	SkippedScripts      []string `json:"skippedScripts"`     // External scripts over the limit, failing to load or not fetched offline
+	AllowlistedScripts  []string `json:"allowlistedScripts"` // Known-good library scripts excluded from scoring
+}
+
+// externalScriptSet holds the external scripts of a page loaded for the analysis.
+type externalScriptSet struct {
+	contents    []string // Bodies of the fetched scripts
+	fetched     []string // URLs of the fetched scripts
+	crossOrigin []string // URLs of cross-origin scripts not fetched
+	skipped     []string // URLs over the count limit or failing to load
+	allowlisted []string // Allowlisted libraries, not fetched
+}
+
+// scriptSrcRegex matches the src attribute of script tags
+var scriptSrcRegex = regexp.MustCompile(`(?is)<script\b[^>]*?\bsrc\s*=\s*["']?([^"'\s>]+)`)
+
+// fetchExternalScripts loads the external scripts referenced by the page. Sources are
+// resolved against the URL of the scanned response; same-origin scripts (and cross-origin
+// ones with FetchCrossOriginScripts) are fetched with the scan client until
+// MaxExternalScripts is reached, reading at most MaxExternalScriptBytes of each. Scripts
+// loaded from allowlisted locations are not fetched, and none are when the page was loaded
+// from a file (params.Offline); those are listed as skipped.
+//
+// Parameters:
+//   - params: Test parameters holding the response, scan client and scan context
+//   - html: Body of the scanned page
+//   - config: Scorer configuration bounding the fetches
+//
+// Returns:
+//   - externalScriptSet: Fetched script bodies and the URLs of fetched, listed and skipped scripts
+func fetchExternalScripts(params ResponseTestParams, html string, config JSObfuscationConfig) externalScriptSet {
+	set := externalScriptSet{contents: []string{}, fetched: []string{}, crossOrigin: []string{}, skipped: []string{}, allowlisted: []string{}}
+	if params.Response == nil || params.Response.Request == nil || params.Response.Request.URL == nil {
+		return set
+	}
+	base := params.Response.Request.URL
+	seen := make(map[string]bool)
+
+	for _, match := range scriptSrcRegex.FindAllStringSubmatch(html, -1) {
+		src, err := base.Parse(strings.TrimSpace(match[1]))
+		if err != nil || (src.Scheme != "http" && src.Scheme != "https") {
+			continue
+		}
+		src.Fragment = ""
+		scriptURL := src.String()
+		if seen[scriptURL] {
+			continue
+		}
+		seen[scriptURL] = true
+
+		if name, ok := config.Allowlist.MatchURL(scriptURL); ok {
+			set.allowlisted = append(set.allowlisted, fmt.Sprintf("%s (%s)", name, scriptURL))
+			continue
+		}
+		if !sameOrigin(base, src) && !config.FetchCrossOriginScripts {
+			set.crossOrigin = append(set.crossOrigin, scriptURL)
+			continue
+		}
+		if params.Offline || len(set.fetched) >= config.MaxExternalScripts {
+			set.skipped = append(set.skipped, scriptURL)
+			continue
+		}
+		content, err := fetchScript(params, scriptURL, config.MaxExternalScriptBytes)
+		if err != nil {
+			set.skipped = append(set.skipped, scriptURL)
+			continue
+		}
+		set.fetched = append(set.fetched, scriptURL)
+		set.contents = append(set.contents, content)
+	}
+	return set
+}
+
+// fetchScript downloads one external script, reading at most maxBytes of its body
+func fetchScript(params ResponseTestParams, scriptURL string, maxBytes int64) (string, error) {
+	request, err := http.NewRequestWithContext(params.scanContext(), http.MethodGet, scriptURL, nil)
+	if err != nil {
+		return "", err
+	}
+	response, err := params.httpClient().Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d", response.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxBytes))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// sameOrigin reports whether two URLs share scheme, host and port
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
 }
 
 // analyzeJSObfuscation performs comprehensive JavaScript obfuscation analysis
-func analyzeJSObfuscation(content string) JSObfuscationAnalysis {
+func analyzeJSObfuscation(content string, external externalScriptSet, config JSObfuscationConfig) JSObfuscationAnalysis {
 	analysis := JSObfuscationAnalysis{
 		ObfuscationPatterns: []string{},
 		SuspiciousPatterns:  []string{},
 		MaliciousIndicators: []string{},
 		EncodingMethods:     []string{},
 		Certainty:           95,
+		ExternalScripts:     []string{},
+		CrossOriginScripts:  external.crossOrigin,
+		SkippedScripts:      external.skipped,
+		AllowlistedScripts:  append([]string{}, external.allowlisted...),
 	}
 
-	// Extract script content
-	scripts := extractScriptContent(content)
+	// Extract script content, inline and fetched, leaving out allowlisted libraries
+	scripts := []string{}
+	for _, script := range extractScriptContent(content) {
+		if name, ok := config.Allowlist.MatchContent(script); ok {
+			analysis.AllowlistedScripts = append(analysis.AllowlistedScripts, name+" (inline)")
+			continue
+		}
+		scripts = append(scripts, script)
+	}
+	for i, script := range external.contents {
+		if name, ok := config.Allowlist.MatchContent(script); ok {
+			analysis.AllowlistedScripts = append(analysis.AllowlistedScripts, fmt.Sprintf("%s (%s)", name, external.fetched[i]))
+			continue
+		}
+		analysis.ExternalScripts = append(analysis.ExternalScripts, external.fetched[i])
+		scripts = append(scripts, script)
+	}
 	if len(scripts) == 0 {
 		analysis.Certainty = 100
 		return analysis
@@ -149,23 +363,27 @@ func analyzeJSObfuscation(content string) JSObfuscationAnalysis {
 
 	scriptContent := strings.Join(scripts, "\n")
 
+	if config.MinificationAware {
+		analysis.Bundler = detectBundler(scriptContent)
+	}
+
 	// Detect various obfuscation patterns
 	detectDynamicExecution(&analysis, scriptContent)
 	detectEncodedStrings(&analysis, scriptContent)
 	detectCharCodeObfuscation(&analysis, scriptContent)
-	detectEscapeSequences(&analysis, scriptContent)
+	detectEscapeSequences(&analysis, scriptContent, config)
 	detectBase64Encoding(&analysis, scriptContent)
-	detectSuspiciousPatterns(&analysis, scriptContent)
+	detectSuspiciousPatterns(&analysis, scriptContent, config)
 	detectMaliciousIndicators(&analysis, scriptContent)
 
 	// Calculate obfuscation score
-	calculateObfuscationScore(&analysis)
+	calculateObfuscationScore(&analysis, scriptContent, config)
 
 	// Determine obfuscation level
 	determineObfuscationLevel(&analysis)
 
 	// Check if obfuscation detected
-	analysis.HasObfuscation = analysis.ObfuscationScore > 20
+	analysis.HasObfuscation = analysis.ObfuscationScore > config.ObfuscationThreshold
 
 	return analysis
 }
@@ -190,11 +408,25 @@ func extractScriptContent(html string) []string {
 	return scripts
 }
 
+// detectBundler returns the bundler whose runtime signature appears in the scripts, or an
+// empty string for hand-written or unrecognized code
+func detectBundler(content string) string {
+	for _, signature := range bundlerSignatures {
+		if strings.Contains(content, signature.marker) {
+			return signature.bundler
+		}
+	}
+	return ""
+}
+
+// functionCallRegex matches calls of the Function constructor
+var functionCallRegex = regexp.MustCompile(`\bFunction\s*\(`)
+
 // detectDynamicExecution detects eval, Function, and similar dynamic code execution
 func detectDynamicExecution(analysis *JSObfuscationAnalysis, content string) {
 	patterns := map[string]*regexp.Regexp{
 		"eval":        regexp.MustCompile(`\beval\s*\(`),
-		"Function":    regexp.MustCompile(`\bFunction\s*\(`),
+		"Function":    functionCallRegex,
 		"setTimeout":  regexp.MustCompile(`setTimeout\s*\(\s*["']`),
 		"setInterval": regexp.MustCompile(`setInterval\s*\(\s*["']`),
 	}
@@ -260,11 +492,11 @@ func detectCharCodeObfuscation(analysis *JSObfuscationAnalysis, content string)
 }
 
 // detectEscapeSequences detects hex and unicode escape sequences
-func detectEscapeSequences(analysis *JSObfuscationAnalysis, content string) {
+func detectEscapeSequences(analysis *JSObfuscationAnalysis, content string, config JSObfuscationConfig) {
 	// Hex escape sequences (\x41\x42...)
 	hexEscapeRegex := regexp.MustCompile(`\\x[0-9a-fA-F]{2}`)
 	hexMatches := hexEscapeRegex.FindAllString(content, -1)
-	if len(hexMatches) > 10 { // Threshold to avoid false positives
+	if len(hexMatches) > config.HexEscapeThreshold { // Threshold to avoid false positives
 		analysis.HexEscapes = len(hexMatches)
 		analysis.EncodingMethods = append(analysis.EncodingMethods, "Hex escape sequences")
 		analysis.ObfuscationPatterns = append(analysis.ObfuscationPatterns,
@@ -274,7 +506,7 @@ func detectEscapeSequences(analysis *JSObfuscationAnalysis, content string) {
 	// Unicode escape sequences (\u0041\u0042...)
 	unicodeEscapeRegex := regexp.MustCompile(`\\u[0-9a-fA-F]{4}`)
 	unicodeMatches := unicodeEscapeRegex.FindAllString(content, -1)
-	if len(unicodeMatches) > 10 { // Threshold to avoid false positives
+	if len(unicodeMatches) > config.UnicodeEscapeThreshold { // Threshold to avoid false positives
 		analysis.UnicodeEscapes = len(unicodeMatches)
 		analysis.EncodingMethods = append(analysis.EncodingMethods, "Unicode escape sequences")
 		analysis.ObfuscationPatterns = append(analysis.ObfuscationPatterns,
@@ -297,16 +529,16 @@ func detectBase64Encoding(analysis *JSObfuscationAnalysis, content string) {
 }
 
 // detectSuspiciousPatterns detects patterns commonly associated with obfuscation
-func detectSuspiciousPatterns(analysis *JSObfuscationAnalysis, content string) {
+func detectSuspiciousPatterns(analysis *JSObfuscationAnalysis, content string, config JSObfuscationConfig) {
 	// Self-modifying code
 	if matched, _ := regexp.MatchString(`document\.write\s*\(\s*(?:unescape|atob|String\.fromCharCode)`, content); matched {
 		analysis.SuspiciousPatterns = append(analysis.SuspiciousPatterns,
 			"Self-modifying code: document.write with decoded content")
 	}
 
-	// Excessive string concatenation
+	// Excessive string concatenation (bundlers join string literals when inlining modules)
 	concatRegex := regexp.MustCompile(`['"]\s*\+\s*['"]`)
-	if matches := concatRegex.FindAllString(content, -1); len(matches) > 20 {
+	if matches := concatRegex.FindAllString(content, -1); analysis.Bundler == "" && len(matches) > config.ConcatenationThreshold {
 		analysis.SuspiciousPatterns = append(analysis.SuspiciousPatterns,
 			fmt.Sprintf("Excessive string concatenation: %d instances", len(matches)))
 	}
@@ -318,17 +550,17 @@ func detectSuspiciousPatterns(analysis *JSObfuscationAnalysis, content string) {
 			"Bracket notation used to access dangerous functions")
 	}
 
-	// Extremely long lines (common in minified/obfuscated code)
+	// Extremely long lines (common in minified/obfuscated code, expected in bundles)
 	lines := strings.Split(content, "\n")
 	longLines := 0
 	for _, line := range lines {
-		if len(line) > 500 {
+		if len(line) > config.LongLineLength {
 			longLines++
 		}
 	}
-	if longLines > 5 {
+	if analysis.Bundler == "" && longLines > config.LongLineThreshold {
 		analysis.SuspiciousPatterns = append(analysis.SuspiciousPatterns,
-			fmt.Sprintf("Extremely long code lines: %d lines over 500 characters", longLines))
+			fmt.Sprintf("Extremely long code lines: %d lines over %d characters", longLines, config.LongLineLength))
 	}
 
 	// Hexadecimal or octal number arrays
@@ -374,43 +606,56 @@ func detectMaliciousIndicators(analysis *JSObfuscationAnalysis, content string)
 	}
 }
 
-// calculateObfuscationScore calculates overall obfuscation score (0-100)
-func calculateObfuscationScore(analysis *JSObfuscationAnalysis) {
+// calculateObfuscationScore calculates overall obfuscation score (0-100). For recognized
+// bundles, Function() calls, escape sequences and Base64 strings are not scored: bundler
+// runtimes resolve the global object with Function("return this") and inline escaped
+// string tables and data URIs.
+func calculateObfuscationScore(analysis *JSObfuscationAnalysis, content string, config JSObfuscationConfig) {
 	score := 0
+	bundled := analysis.Bundler != ""
 
 	// Dynamic execution (eval, Function, etc.)
-	score += analysis.DynamicExecution * 5
-	if analysis.DynamicExecution > 10 {
+	dynamicExecution := analysis.DynamicExecution
+	if bundled {
+		dynamicExecution -= len(functionCallRegex.FindAllString(content, -1))
+	}
+	score += dynamicExecution * config.DynamicExecutionWeight
+	if dynamicExecution > config.DynamicExecutionLimit {
 		score += 20 // Extra penalty for excessive use
 	}
 
 	// Encoded strings
-	score += analysis.EncodedStrings * 8
+	score += analysis.EncodedStrings * config.EncodedStringWeight
 
 	// Character code usage
-	score += analysis.CharCodeUsage * 6
+	score += analysis.CharCodeUsage * config.CharCodeWeight
 
-	// Escape sequences
-	if analysis.HexEscapes > 10 {
-		score += 15
-	}
-	if analysis.UnicodeEscapes > 10 {
-		score += 15
-	}
+	if !bundled {
+		// Escape sequences
+		if analysis.HexEscapes > 0 {
+			score += config.EscapeSequenceWeight
+		}
+		if analysis.UnicodeEscapes > 0 {
+			score += config.EscapeSequenceWeight
+		}
 
-	// Base64 strings
-	score += analysis.Base64Strings * 5
+		// Base64 strings
+		score += analysis.Base64Strings * config.Base64StringWeight
+	}
 
 	// Suspicious patterns
-	score += len(analysis.SuspiciousPatterns) * 10
+	score += len(analysis.SuspiciousPatterns) * config.SuspiciousPatternWeight
 
 	// Malicious indicators (heavy weight)
-	score += len(analysis.MaliciousIndicators) * 25
+	score += len(analysis.MaliciousIndicators) * config.MaliciousIndicatorWeight
 
 	// Cap at 100
 	if score > 100 {
 		score = 100
 	}
+	if score < 0 {
+		score = 0
+	}
 
 	analysis.ObfuscationScore = score
 }
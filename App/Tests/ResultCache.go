@@ -0,0 +1,98 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the result cache that lets pure header tests reuse prior results
+// for responses whose relevant headers are identical, e.g. pages behind one CDN config.
+package Tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+)
+
+// DefaultResultCacheSize is the number of results a ResultCache keeps when created with
+// a non-positive size.
+const DefaultResultCacheSize = 4096
+
+// ResultCache stores the results of pure header tests keyed by a fingerprint of the test
+// and the response headers it reads (ResponseTest.CacheHeaders). Tests that read the body,
+// the request or make external lookups declare no CacheHeaders and are never cached.
+//
+// The cache is safe for concurrent use. Once full, further results are no longer stored,
+// which bounds memory in long-running processes such as the task stream.
+type ResultCache struct {
+	mu      sync.Mutex
+	results map[string]TestResult
+	maxSize int
+	hits    int
+}
+
+// NewResultCache creates an empty result cache.
+//
+// Parameters:
+//   - maxSize: Maximum number of stored results (non-positive uses DefaultResultCacheSize)
+//
+// Returns:
+//   - *ResultCache: Cache ready to be passed to tests via ResponseTestParams.ResultCache
+//
+// Example:
+//
+//	cache := NewResultCache(0)
+//	result := NewHSTSTest().Run(ResponseTestParams{Response: resp, ResultCache: cache})
+func NewResultCache(maxSize int) *ResultCache {
+	if maxSize <= 0 {
+		maxSize = DefaultResultCacheSize
+	}
+	return &ResultCache{
+		results: make(map[string]TestResult),
+		maxSize: maxSize,
+	}
+}
+
+// Hits returns how many results were served from the cache.
+func (c *ResultCache) Hits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits
+}
+
+// lookup returns the cached result for the fingerprint, if any.
+func (c *ResultCache) lookup(key string) (TestResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.results[key]
+	if ok {
+		c.hits++
+	}
+	return result, ok
+}
+
+// store caches a result unless the cache is full.
+func (c *ResultCache) store(key string, result TestResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.results) < c.maxSize {
+		c.results[key] = result
+	}
+}
+
+// fingerprint hashes the test id together with the values of the headers the test reads,
+// in declaration order. An absent header hashes differently from an empty one.
+func fingerprint(testId string, names []string, header http.Header) string {
+	hash := sha256.New()
+	hash.Write([]byte(testId))
+	for _, name := range names {
+		hash.Write([]byte{0})
+		hash.Write([]byte(http.CanonicalHeaderKey(name)))
+		values := HeaderValues(header, name)
+		if values == nil {
+			hash.Write([]byte{1})
+			continue
+		}
+		for _, value := range values {
+			hash.Write([]byte{2})
+			hash.Write([]byte(value))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,65 @@
+package Tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestResultCache_ReusesPureHeaderResults(t *testing.T) {
+	runs := 0
+	test := &ResponseTest{
+		Id:           "counting",
+		CacheHeaders: []string{"Strict-Transport-Security"},
+		RunTest: func(params ResponseTestParams) TestResult {
+			runs++
+			return NewHSTSTest().RunTest(params)
+		},
+	}
+	cache := NewResultCache(0)
+
+	first := &http.Response{Header: http.Header{}}
+	first.Header.Set("Strict-Transport-Security", "max-age=300")
+	first.Header.Set("Date", "Mon, 12 Jan 2026 10:00:00 GMT")
+	second := &http.Response{Header: http.Header{}}
+	second.Header.Set("Strict-Transport-Security", "max-age=300")
+	second.Header.Set("Date", "Mon, 12 Jan 2026 10:00:05 GMT")
+
+	firstResult := test.Run(ResponseTestParams{Response: first, ResultCache: cache})
+	secondResult := test.Run(ResponseTestParams{Response: second, ResultCache: cache})
+
+	if runs != 1 || cache.Hits() != 1 {
+		t.Errorf("Expected the second response to reuse the cached result, got %d runs and %d hits", runs, cache.Hits())
+	}
+	if secondResult.TestId != "counting" || secondResult.ThreatLevel != firstResult.ThreatLevel || secondResult.Description != firstResult.Description {
+		t.Errorf("Expected cached result %+v, got %+v", firstResult, secondResult)
+	}
+
+	changed := &http.Response{Header: http.Header{}}
+	changed.Header.Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+	test.Run(ResponseTestParams{Response: changed, ResultCache: cache})
+	missing := &http.Response{Header: http.Header{}}
+	test.Run(ResponseTestParams{Response: missing, ResultCache: cache})
+	if runs != 3 {
+		t.Errorf("Expected responses with different header values to run the test, got %d runs", runs)
+	}
+}
+
+func TestResultCache_SkipsTestsWithoutCacheHeaders(t *testing.T) {
+	runs := 0
+	test := &ResponseTest{
+		Id: "body",
+		RunTest: func(params ResponseTestParams) TestResult {
+			runs++
+			return TestResult{}
+		},
+	}
+	cache := NewResultCache(0)
+	response := &http.Response{Header: http.Header{}}
+
+	test.Run(ResponseTestParams{Response: response, ResultCache: cache})
+	test.Run(ResponseTestParams{Response: response, ResultCache: cache})
+
+	if runs != 2 || cache.Hits() != 0 {
+		t.Errorf("Expected tests without CacheHeaders to run every time, got %d runs and %d hits", runs, cache.Hits())
+	}
+}
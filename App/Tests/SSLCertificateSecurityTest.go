@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 )
 
@@ -24,10 +25,12 @@ import (
 //   - Key length (2048 bits or higher recommended)
 //   - Certificate chain completeness
 //   - Hostname match
+//   - Certificate scope: all SANs, wildcard usage and unrelated domains sharing the certificate
 //
 // Threat level assessment:
 //   - None (0): Certificate is valid, strong, and not expiring soon
-//   - Info (1): Certificate is valid but expiring within 30 days
+//   - Info (1): Certificate is valid but expiring within 30 days, is a wildcard covering
+//     every subdomain of the apex domain, or is shared with unrelated domains
 //   - Medium (3): Weak signature algorithm or short key length
 //   - High (4): Certificate expired, not yet valid, or hostname mismatch
 //   - Critical (5): No certificate, self-signed, or invalid chain
@@ -36,10 +39,12 @@ import (
 //   - *ResponseTest: Configured SSL certificate security test ready for execution
 func NewSSLCertificateSecurityTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "ssl-cert",
-		Name:        "SSL Certificate Security Analysis",
-		Description: "Analyzes the SSL/TLS certificate of the target website for validity, expiration, and cryptographic strength.",
-		Category:    "Encryption",
+		Id:              "ssl-cert",
+		Name:            "SSL Certificate Security Analysis",
+		Description:     "Analyzes the SSL/TLS certificate of the target website for validity, expiration, and cryptographic strength.",
+		Category:        "Encryption",
+		RequiresHTTPS:   true,
+		RequiresNetwork: true,
 		RunTest: func(params ResponseTestParams) TestResult {
 			url := params.Response.Request.URL
 			if url.Scheme != "https" {
@@ -111,6 +116,10 @@ func NewSSLCertificateSecurityTest() *ResponseTest {
 				"DNSNames":           cert.DNSNames,
 				"IsCA":               cert.IsCA,
 			}
+			scope := analyzeCertificateScope(cert, host)
+			for key, value := range scope {
+				metadata[key] = value
+			}
 
 			// Populate public key metadata
 			switch pubKey := cert.PublicKey.(type) {
@@ -263,6 +272,16 @@ func NewSSLCertificateSecurityTest() *ResponseTest {
 				}
 			}
 
+			if notes := certificateScopeNotes(scope); len(notes) > 0 {
+				return TestResult{
+					Name:        "SSL Certificate Security Analysis",
+					Certainty:   100,
+					ThreatLevel: Info,
+					Metadata:    metadata,
+					Description: "SSL certificate is valid, strong, and not expiring soon, but " + strings.Join(notes, " and ") + ".",
+				}
+			}
+
 			return TestResult{
 				Name:        "SSL Certificate Security Analysis",
 				Certainty:   100,
@@ -273,3 +292,85 @@ func NewSSLCertificateSecurityTest() *ResponseTest {
 		},
 	}
 }
+
+// analyzeCertificateScope describes which names a certificate covers. Overly broad
+// certificates increase the blast radius of a leaked private key, as every covered
+// host can be impersonated with it.
+//
+// Domains are compared by their last two labels, which approximates the registrable
+// domain without a public suffix list.
+//
+// Parameters:
+//   - cert: Leaf certificate presented by the server
+//   - host: Hostname of the scanned target
+//
+// Returns a map merged into the test metadata:
+//   - SANs: Every subject alternative name (DNS names, IP addresses, emails, URIs)
+//   - Wildcard: true if any DNS name is a wildcard
+//   - WildcardNames: The wildcard DNS names
+//   - WildcardCoversApex: true if a wildcard covers every subdomain of the target's apex domain
+//   - UnrelatedDomains: Apex domains in the SANs other than the target's apex domain
+//     (empty for IP address targets)
+func analyzeCertificateScope(cert *x509.Certificate, host string) map[string]interface{} {
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses))
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	hostApex := apexDomain(strings.ToLower(host))
+	// Apex comparison is meaningless when the target is addressed by IP.
+	isIP := net.ParseIP(host) != nil
+	wildcards := []string{}
+	coversApex := false
+	unrelated := []string{}
+	seen := map[string]bool{}
+	for _, name := range cert.DNSNames {
+		name = strings.ToLower(strings.TrimSuffix(name, "."))
+		if base, ok := strings.CutPrefix(name, "*."); ok {
+			wildcards = append(wildcards, name)
+			if base == hostApex {
+				coversApex = true
+			}
+		}
+		apex := apexDomain(strings.TrimPrefix(name, "*."))
+		if !isIP && apex != hostApex && !seen[apex] {
+			seen[apex] = true
+			unrelated = append(unrelated, apex)
+		}
+	}
+
+	return map[string]interface{}{
+		"SANs":               sans,
+		"Wildcard":           len(wildcards) > 0,
+		"WildcardNames":      wildcards,
+		"WildcardCoversApex": coversApex,
+		"UnrelatedDomains":   unrelated,
+	}
+}
+
+// certificateScopeNotes returns human-readable remarks about a broad certificate scope,
+// or nil when the certificate is narrowly scoped.
+func certificateScopeNotes(scope map[string]interface{}) []string {
+	var notes []string
+	if scope["WildcardCoversApex"].(bool) {
+		notes = append(notes, "it is a wildcard certificate covering every subdomain of the apex domain")
+	}
+	if unrelated := scope["UnrelatedDomains"].([]string); len(unrelated) > 0 {
+		notes = append(notes, fmt.Sprintf("it is shared with %d unrelated domain(s): %s", len(unrelated), strings.Join(unrelated, ", ")))
+	}
+	return notes
+}
+
+// apexDomain returns the last two labels of a hostname (e.g., "example.com" for "a.b.example.com").
+func apexDomain(host string) string {
+	labels := strings.Split(host, ".")
+	if len(labels) <= 2 {
+		return host
+	}
+	return strings.Join(labels[len(labels)-2:], ".")
+}
@@ -0,0 +1,117 @@
+package Tests
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+// newMultiSANCertificate creates a self-signed certificate fixture covering the given DNS names.
+func newMultiSANCertificate(t *testing.T, dnsNames []string, ips []net.IP) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		DNSNames:     dnsNames,
+		IPAddresses:  ips,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+func TestSSLCertificateSecurityTest_ReportsSANs(t *testing.T) {
+	dnsNames := []string{"example.com", "*.example.com", "shop.example.net"}
+	cert := newMultiSANCertificate(t, dnsNames, []net.IP{net.ParseIP("192.0.2.10")})
+	response := responseFor(t, "https://www.example.com")
+	response.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	result := NewSSLCertificateSecurityTest().Run(ResponseTestParams{Response: response})
+
+	metadata, ok := result.Metadata.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected metadata map, got %T", result.Metadata)
+	}
+	sans := metadata["SANs"].([]string)
+	want := []string{"example.com", "*.example.com", "shop.example.net", "192.0.2.10"}
+	if len(sans) != len(want) {
+		t.Fatalf("Expected SANs %v, got %v", want, sans)
+	}
+	for i := range want {
+		if sans[i] != want[i] {
+			t.Errorf("Expected SANs %v, got %v", want, sans)
+		}
+	}
+	if metadata["Wildcard"] != true || metadata["WildcardCoversApex"] != true {
+		t.Errorf("Expected apex wildcard to be reported, got %v", metadata)
+	}
+	unrelated := metadata["UnrelatedDomains"].([]string)
+	if len(unrelated) != 1 || unrelated[0] != "example.net" {
+		t.Errorf("Expected example.net as unrelated domain, got %v", unrelated)
+	}
+}
+
+func TestAnalyzeCertificateScope(t *testing.T) {
+	tests := []struct {
+		name          string
+		dnsNames      []string
+		host          string
+		wantWildcard  bool
+		wantApex      bool
+		wantUnrelated int
+		wantNotes     int
+	}{
+		{name: "Single name", dnsNames: []string{"www.example.com"}, host: "www.example.com"},
+		{name: "Apex wildcard", dnsNames: []string{"*.example.com", "example.com"}, host: "www.example.com",
+			wantWildcard: true, wantApex: true, wantNotes: 1},
+		{name: "Nested wildcard", dnsNames: []string{"*.api.example.com"}, host: "v1.api.example.com",
+			wantWildcard: true},
+		{name: "Shared multi-domain", dnsNames: []string{"example.com", "other.org", "third.io", "www.other.org"}, host: "example.com",
+			wantUnrelated: 2, wantNotes: 1},
+		{name: "IP target", dnsNames: []string{"example.com"}, host: "192.0.2.10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := analyzeCertificateScope(newMultiSANCertificate(t, tt.dnsNames, nil), tt.host)
+
+			if scope["Wildcard"] != tt.wantWildcard {
+				t.Errorf("Expected Wildcard %t, got %v", tt.wantWildcard, scope["Wildcard"])
+			}
+			if scope["WildcardCoversApex"] != tt.wantApex {
+				t.Errorf("Expected WildcardCoversApex %t, got %v", tt.wantApex, scope["WildcardCoversApex"])
+			}
+			if got := len(scope["UnrelatedDomains"].([]string)); got != tt.wantUnrelated {
+				t.Errorf("Expected %d unrelated domains, got %v", tt.wantUnrelated, scope["UnrelatedDomains"])
+			}
+			if got := len(certificateScopeNotes(scope)); got != tt.wantNotes {
+				t.Errorf("Expected %d scope notes, got %d", tt.wantNotes, got)
+			}
+		})
+	}
+}
+
+func TestSSLCertificateSecurityTest_NotHTTPS(t *testing.T) {
+	result := NewSSLCertificateSecurityTest().Run(ResponseTestParams{Response: responseFor(t, "http://example.com")})
+	if result.ThreatLevel != Info {
+		t.Errorf("Expected Info for plain HTTP, got %v", result.ThreatLevel)
+	}
+}
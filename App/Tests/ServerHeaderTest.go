@@ -69,6 +69,8 @@ package Tests
 import (
 	"Engine-AntiGinx/App/CVE"
 	helpers "Engine-AntiGinx/App/Helpers"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -85,6 +87,11 @@ import (
 //   - total_exposures: Count of headers exposing information
 //   - header_details: Map of header names to their actual values
 //   - technology_stack: Map of detected technologies to their versions
+//   - cve_assessments: CVE assessments of the technologies, filled in by the threat level
+//     evaluation when CVE lookups are enabled
+//   - cve_errors: Technologies whose CVE lookup failed, with the error
+//   - ignored_headers: Headers present in the response but accepted by the organisation
+//     (--ignore-headers), not counted as exposures
 //
 // Example structure:
 //
@@ -107,6 +114,9 @@ type ServerHeaderAnalysis struct {
 	total_exposures  int
 	header_details   map[string]string
 	technology_stack map[string]string
+	cve_assessments  map[string]*CVE.VulnerabilityAssessment
+	cve_errors       map[string]string
+	ignored_headers  []string
 }
 
 // NewServerHeaderTest creates a new security test that analyzes HTTP response headers
@@ -147,6 +157,10 @@ type ServerHeaderAnalysis struct {
 //   - Technology stack fingerprinting
 //   - Reduced attacker reconnaissance time
 //
+// Headers the organisation exposes on purpose (e.g. a CDN's X-Served-By) can be listed in
+// ResponseTestParams.IgnoredHeaders (--ignore-headers); they are neither counted as exposures
+// nor used for technology detection.
+//
 // The test assigns higher threat levels when:
 //   - Multiple headers expose information (5+ = Medium)
 //   - Technologies have known high-severity CVEs (Critical)
@@ -194,47 +208,62 @@ type ServerHeaderAnalysis struct {
 //   - HSTSTest: Checks HTTP Strict Transport Security enforcement
 func NewServerHeaderTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "serv-h-a",
-		Name:        "Server Technology Disclosure Analysis",
-		Description: "Analyzes HTTP headers for information disclosure about server technology, frameworks, and hosting services",
-		Category:    "App-Configuration",
+		Id:            "serv-h-a",
+		Name:          "Server Technology Disclosure Analysis",
+		Description:   "Analyzes HTTP headers for information disclosure about server technology, frameworks, and hosting services",
+		Category:      "App-Configuration",
+		CWE:           "CWE-200",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Headers that commonly reveal server technology information
 			exposureHeaders := map[string]string{
-				"Server":              params.Response.Header.Get("Server"),
-				"X-Powered-By":        params.Response.Header.Get("X-Powered-By"),
-				"X-AspNet-Version":    params.Response.Header.Get("X-AspNet-Version"),
-				"X-AspNetMvc-Version": params.Response.Header.Get("X-AspNetMvc-Version"),
-				"X-Framework":         params.Response.Header.Get("X-Framework"),
-				"X-Generator":         params.Response.Header.Get("X-Generator"),
-				"X-Drupal-Cache":      params.Response.Header.Get("X-Drupal-Cache"),
-				"X-Mod-Pagespeed":     params.Response.Header.Get("X-Mod-Pagespeed"),
-				"X-Varnish":           params.Response.Header.Get("X-Varnish"),
-				"X-Served-By":         params.Response.Header.Get("X-Served-By"),
-				"X-Cache":             params.Response.Header.Get("X-Cache"),
-				"X-Runtime":           params.Response.Header.Get("X-Runtime"),
+				"Server":              HeaderValue(params.Response.Header, "Server"),
+				"X-Powered-By":        HeaderValue(params.Response.Header, "X-Powered-By"),
+				"X-AspNet-Version":    HeaderValue(params.Response.Header, "X-AspNet-Version"),
+				"X-AspNetMvc-Version": HeaderValue(params.Response.Header, "X-AspNetMvc-Version"),
+				"X-Framework":         HeaderValue(params.Response.Header, "X-Framework"),
+				"X-Generator":         HeaderValue(params.Response.Header, "X-Generator"),
+				"X-Drupal-Cache":      HeaderValue(params.Response.Header, "X-Drupal-Cache"),
+				"X-Mod-Pagespeed":     HeaderValue(params.Response.Header, "X-Mod-Pagespeed"),
+				"X-Varnish":           HeaderValue(params.Response.Header, "X-Varnish"),
+				"X-Served-By":         HeaderValue(params.Response.Header, "X-Served-By"),
+				"X-Cache":             HeaderValue(params.Response.Header, "X-Cache"),
+				"X-Runtime":           HeaderValue(params.Response.Header, "X-Runtime"),
 			}
 
 			// Analyze the collected headers
-			analysis := analyzeServerHeaders(exposureHeaders)
+			analysis := analyzeServerHeaders(exposureHeaders, params.IgnoredHeaders)
 
 			// Determine threat level based on exposure
-			threatLevel := evaluateServerExposureThreatLevel(analysis)
+			threatLevel := evaluateServerExposureThreatLevel(params.cveOptions(), analysis, params.CVEClient, !params.DisableCVE)
 
 			// Generate description
 			description := generateServerExposureDescription(analysis)
 
 			return TestResult{
-				Name:        "Server Technology Disclosure Analysis",
-				Certainty:   95,
-				ThreatLevel: threatLevel,
-				Metadata:    analysis,
-				Description: description,
+				Name:         "Server Technology Disclosure Analysis",
+				Certainty:    95,
+				ThreatLevel:  threatLevel,
+				Metadata:     analysis,
+				Description:  description,
+				Technologies: serverTechnologies(analysis),
 			}
 		},
 	}
 }
 
+// serverTechnologies lists the technologies of the analysis with the CVE summary of every
+// technology assessed during the threat level evaluation.
+func serverTechnologies(analysis *ServerHeaderAnalysis) []Technology {
+	technologies := technologiesFromStack(analysis.technology_stack)
+	for i := range technologies {
+		if assessment := analysis.cve_assessments[technologies[i].Name]; assessment != nil {
+			technologies[i].CVE = newCVESummary(assessment)
+		}
+	}
+	return technologies
+}
+
 // analyzeServerHeaders examines HTTP headers for technology disclosure patterns
 // and constructs a comprehensive analysis of exposed information.
 //
@@ -255,10 +284,12 @@ func NewServerHeaderTest() *ResponseTest {
 // Technology Detection:
 //
 // For each non-empty header, the function calls detectTechnologies() which uses
-// pattern matching to identify specific software, frameworks, and services.
+// pattern matching to identify specific software, frameworks, and services. Ignored
+// headers are only recorded in ignored_headers.
 //
 // Parameters:
 //   - headers: Map of header names to their values from HTTP response
+//   - ignored: Header names accepted by the organisation (case-insensitive, nil = none)
 //
 // Returns:
 //   - *ServerHeaderAnalysis: Comprehensive analysis structure with:
@@ -276,18 +307,19 @@ func NewServerHeaderTest() *ResponseTest {
 //	    "X-Cache": "",  // Empty, will be filtered
 //	}
 //
-//	analysis := analyzeServerHeaders(headers)
+//	analysis := analyzeServerHeaders(headers, nil)
 //	// analysis.exposed_headers = ["Server", "X-Powered-By"]
 //	// analysis.technologies = ["Nginx", "PHP"]
 //	// analysis.total_exposures = 2
 //	// analysis.technology_stack = {"Nginx": "1.18.0", "PHP": "7.4.3"}
-func analyzeServerHeaders(headers map[string]string) *ServerHeaderAnalysis {
+func analyzeServerHeaders(headers map[string]string, ignored []string) *ServerHeaderAnalysis {
 	analysis := &ServerHeaderAnalysis{
 		exposed_headers:  []string{},
 		technologies:     []string{},
 		total_exposures:  0,
 		header_details:   map[string]string{},
 		technology_stack: map[string]string{},
+		ignored_headers:  []string{},
 	}
 
 	var exposedHeaders []string
@@ -296,6 +328,10 @@ func analyzeServerHeaders(headers map[string]string) *ServerHeaderAnalysis {
 	var headerDetails = make(map[string]string)
 
 	for headerName, headerValue := range headers {
+		if headerValue != "" && slices.ContainsFunc(ignored, func(name string) bool { return strings.EqualFold(name, headerName) }) {
+			analysis.ignored_headers = append(analysis.ignored_headers, headerName)
+			continue
+		}
 		if headerValue != "" {
 			exposedHeaders = append(exposedHeaders, headerName)
 			headerDetails[headerName] = headerValue
@@ -320,6 +356,7 @@ func analyzeServerHeaders(headers map[string]string) *ServerHeaderAnalysis {
 	analysis.total_exposures = totalExposures
 	analysis.header_details = headerDetails
 	analysis.technology_stack = techStack
+	slices.Sort(analysis.ignored_headers)
 	return analysis
 }
 
@@ -627,15 +664,19 @@ func extractVersion(headerValue, technology string) string {
 //
 // CVE Integration:
 //
-// For each detected technology, the function:
-//  1. Creates CVE client instance
+// For each detected technology, unless lookupCVEs is false (--no-cve), the function:
+//  1. Uses the CVE client shared by the scan (or creates one when none is given)
 //  2. Queries NIST NVD API with technology name
 //  3. Receives vulnerability assessment with severity counts
 //  4. Maps CVE severity to our ThreatLevel enum
 //  5. Updates threat level if higher than current assessment
 //
 // Parameters:
+//   - cveOpts: Options of the NVD assessments (scan context and publication window, see
+//     ResponseTestParams.cveOptions)
 //   - analysis: ServerHeaderAnalysis containing exposure and technology data
+//   - cveClient: CVE client shared by the scan, nil to create a dedicated client
+//   - lookupCVEs: false skips Layer 2, so no NVD request is made
 //
 // Returns:
 //   - ThreatLevel: Final calculated threat level (None/Info/Low/Medium/High/Critical)
@@ -647,7 +688,7 @@ func extractVersion(headerValue, technology string) string {
 //	    total_exposures: 1,
 //	    technologies: []string{"Cloudflare"},
 //	}
-//	level := evaluateServerExposureThreatLevel(analysis)
+//	level := evaluateServerExposureThreatLevel(nil, analysis, nil, true)
 //	// Returns: Info
 //
 //	// Multiple exposures with vulnerable technology
@@ -655,7 +696,7 @@ func extractVersion(headerValue, technology string) string {
 //	    total_exposures: 5,
 //	    technologies: []string{"Apache", "PHP"},  // Has known CVEs
 //	}
-//	level := evaluateServerExposureThreatLevel(analysis)
+//	level := evaluateServerExposureThreatLevel(nil, analysis, nil, true)
 //	// Returns: Critical (due to CVE assessment)
 //
 //	// Debug environment exposed
@@ -663,7 +704,7 @@ func extractVersion(headerValue, technology string) string {
 //	    total_exposures: 2,
 //	    technologies: []string{"Express-debug"},
 //	}
-//	level := evaluateServerExposureThreatLevel(analysis)
+//	level := evaluateServerExposureThreatLevel(nil, analysis, nil, true)
 //	// Returns: Critical (heuristic match)
 //
 // Security Context:
@@ -675,7 +716,7 @@ func extractVersion(headerValue, technology string) string {
 //   - Low: Minor risk (few exposures, low-severity CVEs)
 //   - Info: Informational (minimal exposure, no vulnerabilities)
 //   - None: Secure configuration (no disclosure)
-func evaluateServerExposureThreatLevel(analysis *ServerHeaderAnalysis) ThreatLevel {
+func evaluateServerExposureThreatLevel(cveOpts []CVE.AssessmentOption, analysis *ServerHeaderAnalysis, cveClient *CVE.CVEClient, lookupCVEs bool) ThreatLevel {
 	totalExposures := analysis.total_exposures
 	technologies := analysis.technologies
 
@@ -691,12 +732,28 @@ func evaluateServerExposureThreatLevel(analysis *ServerHeaderAnalysis) ThreatLev
 
 	// Enhanced threat assessment with CVE vulnerability analysis
 	if len(technologies) > 0 {
-		cveClient := CVE.NewCVEClient()
+		if cveClient == nil && lookupCVEs {
+			cveClient = CVE.NewCVEClient()
+		}
 		highestThreatLevel := baseThreatLevel
 
 		for _, tech := range technologies {
+			if !lookupCVEs {
+				break
+			}
 			// Assess CVE vulnerabilities for detected technology
-			assessment, err := cveClient.AssessTechnologyVulnerabilities(tech, "")
+			assessment, err := cveClient.AssessTechnologyVulnerabilities(tech, "", cveOpts...)
+			if err == nil {
+				if analysis.cve_assessments == nil {
+					analysis.cve_assessments = make(map[string]*CVE.VulnerabilityAssessment)
+				}
+				analysis.cve_assessments[tech] = assessment
+			} else {
+				if analysis.cve_errors == nil {
+					analysis.cve_errors = make(map[string]string)
+				}
+				analysis.cve_errors[tech] = err.Error()
+			}
 			if err == nil && assessment.CVECount > 0 {
 				// Map CVE severity to our threat levels
 				cveLevel := mapCVEThreatLevel(*assessment)
@@ -951,5 +1008,15 @@ func generateServerExposureDescription(analysis *ServerHeaderAnalysis) string {
 		description += ". Detected technologies: " + strings.Join(technologies, ", ")
 	}
 
+	// CVE counts are incomplete when lookups failed or NVD entries could not be parsed
+	for _, tech := range technologies {
+		if err, failed := analysis.cve_errors[tech]; failed {
+			description += ". CVE lookup for " + tech + " failed (" + err + "), its vulnerabilities were not assessed"
+		} else if assessment := analysis.cve_assessments[tech]; assessment != nil && assessment.DroppedEntries > 0 {
+			description += ". " + strconv.Itoa(assessment.DroppedEntries) + " NVD entries for " + tech +
+				" could not be parsed, its CVE counts may be incomplete"
+		}
+	}
+
 	return description
 }
@@ -0,0 +1,184 @@
+package Tests
+
+import (
+	"Engine-AntiGinx/App/CVE"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestServerHeaderTest_SharedCVEClient(t *testing.T) {
+	var nvdCalls atomic.Int32
+	nvd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nvdCalls.Add(1)
+		_, _ = w.Write([]byte(`{"resultsPerPage":0,"startIndex":0,"totalResults":0,"vulnerabilities":[]}`))
+	}))
+	defer nvd.Close()
+	client := CVE.NewCVEClient(CVE.WithBaseURL(nvd.URL), CVE.WithRequestInterval(0))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response := &http.Response{Header: http.Header{"Server": []string{"nginx"}}}
+			result := NewServerHeaderTest().Run(ResponseTestParams{Response: response, CVEClient: client})
+			if result.TestId != "serv-h-a" {
+				t.Errorf("Expected serv-h-a result, got %q", result.TestId)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := nvdCalls.Load(); got != 1 {
+		t.Errorf("Expected both tests to share one NVD request, got %d", got)
+	}
+}
+
+func TestServerHeaderTest_DisableCVE(t *testing.T) {
+	var nvdCalls atomic.Int32
+	nvd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nvdCalls.Add(1)
+		_, _ = w.Write([]byte(`{"resultsPerPage":0,"startIndex":0,"totalResults":0,"vulnerabilities":[]}`))
+	}))
+	defer nvd.Close()
+	client := CVE.NewCVEClient(CVE.WithBaseURL(nvd.URL), CVE.WithRequestInterval(0))
+
+	response := &http.Response{Header: http.Header{"Server": []string{"nginx/1.18.0"}, "X-Powered-By": []string{"PHP/8.1"}}}
+	result := NewServerHeaderTest().Run(ResponseTestParams{Response: response, CVEClient: client, DisableCVE: true})
+
+	if got := nvdCalls.Load(); got != 0 {
+		t.Errorf("Expected no NVD requests with CVE lookups disabled, got %d", got)
+	}
+	if result.ThreatLevel != High {
+		t.Errorf("Expected heuristic threat level High for nginx, got %v", result.ThreatLevel)
+	}
+}
+
+func TestServerHeaderTest_CVEInventory(t *testing.T) {
+	nvd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resultsPerPage":2,"startIndex":0,"totalResults":2,"vulnerabilities":[
+			{"cve":{"id":"CVE-2019-20372","published":"2020-01-09T00:00:00.000","lastModified":"2020-01-09T00:00:00.000",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":5.3,"baseSeverity":"MEDIUM"}}]}}},
+			{"cve":{"id":"CVE-2021-23017","published":"2021-06-01T00:00:00.000","lastModified":"2021-06-01T00:00:00.000",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":9.8,"baseSeverity":"CRITICAL"}}]}}}
+		]}`))
+	}))
+	defer nvd.Close()
+	client := CVE.NewCVEClient(CVE.WithBaseURL(nvd.URL), CVE.WithRequestInterval(0))
+
+	response := &http.Response{Header: http.Header{"Server": []string{"nginx/1.18.0"}}}
+	result := NewServerHeaderTest().Run(ResponseTestParams{Response: response, CVEClient: client})
+
+	if len(result.Technologies) != 1 || result.Technologies[0].CVE == nil {
+		t.Fatalf("Expected nginx with a CVE summary, got %+v", result.Technologies)
+	}
+	summary := result.Technologies[0].CVE
+	if summary.Count != 2 || summary.High != 1 || summary.Medium != 1 || summary.MaxScore != 9.8 {
+		t.Errorf("Unexpected CVE summary %+v", summary)
+	}
+	if len(summary.TopCVEs) != 2 || summary.TopCVEs[0] != "CVE-2021-23017" {
+		t.Errorf("Expected the highest scored CVE first, got %v", summary.TopCVEs)
+	}
+}
+
+func TestServerHeaderTest_CVEYears(t *testing.T) {
+	recent := time.Now().UTC().AddDate(0, -6, 0).Format("2006-01-02T15:04:05.000")
+	nvd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resultsPerPage":2,"startIndex":0,"totalResults":2,"vulnerabilities":[
+			{"cve":{"id":"CVE-2009-3896","published":"2009-11-24T17:30:00.687",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":7.5,"baseSeverity":"HIGH"}}]}}},
+			{"cve":{"id":"CVE-RECENT-0001","published":"` + recent + `",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":5.3,"baseSeverity":"MEDIUM"}}]}}}
+		]}`))
+	}))
+	defer nvd.Close()
+	client := CVE.NewCVEClient(CVE.WithBaseURL(nvd.URL), CVE.WithRequestInterval(0))
+
+	response := &http.Response{Header: http.Header{"Server": []string{"nginx/1.18.0"}}}
+	result := NewServerHeaderTest().Run(ResponseTestParams{Response: response, CVEClient: client, CVEYears: 2})
+
+	if len(result.Technologies) != 1 || result.Technologies[0].CVE == nil {
+		t.Fatalf("Expected nginx with a CVE summary, got %+v", result.Technologies)
+	}
+	if summary := result.Technologies[0].CVE; summary.Count != 1 || summary.TopCVEs[0] != "CVE-RECENT-0001" {
+		t.Errorf("Expected only the CVE of the last 2 years, got %+v", summary)
+	}
+}
+
+func TestServerHeaderTest_CVEMinSeverity(t *testing.T) {
+	nvd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resultsPerPage":2,"startIndex":0,"totalResults":2,"vulnerabilities":[
+			{"cve":{"id":"CVE-2021-23017","published":"2021-06-01T13:15:07.647",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":7.7,"baseSeverity":"HIGH"}}]}}},
+			{"cve":{"id":"CVE-2021-3618","published":"2022-03-23T20:15:10.200",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":3.1,"baseSeverity":"LOW"}}]}}}
+		]}`))
+	}))
+	defer nvd.Close()
+	client := CVE.NewCVEClient(CVE.WithBaseURL(nvd.URL), CVE.WithRequestInterval(0))
+
+	response := &http.Response{Header: http.Header{"Server": []string{"nginx/1.18.0"}}}
+	result := NewServerHeaderTest().Run(ResponseTestParams{Response: response, CVEClient: client, CVEMinSeverity: "medium"})
+
+	if len(result.Technologies) != 1 || result.Technologies[0].CVE == nil {
+		t.Fatalf("Expected nginx with a CVE summary, got %+v", result.Technologies)
+	}
+	if summary := result.Technologies[0].CVE; summary.Count != 1 || summary.TopCVEs[0] != "CVE-2021-23017" {
+		t.Errorf("Expected only the CVE rated MEDIUM or above, got %+v", summary)
+	}
+}
+
+func TestServerHeaderTest_CVELookupFailureDescribed(t *testing.T) {
+	nvd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resultsPerPage":1,"startIndex":0,"totalResults":1,"vulnerabilities":[
+			{"cve":{"id":"CVE-2021-23017","published":"06/01/2021",
+				"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":7.7,"baseSeverity":"HIGH"}}]}}}
+		]}`))
+	}))
+	defer nvd.Close()
+	client := CVE.NewCVEClient(CVE.WithBaseURL(nvd.URL), CVE.WithRequestInterval(0))
+
+	response := &http.Response{Header: http.Header{"Server": []string{"nginx/1.18.0"}}}
+	result := NewServerHeaderTest().Run(ResponseTestParams{Response: response, CVEClient: client})
+
+	if !strings.Contains(result.Description, "CVE lookup for Nginx failed") {
+		t.Errorf("Expected the failed CVE lookup to be described, got %q", result.Description)
+	}
+}
+
+func TestServerHeaderTest_IgnoredHeaders(t *testing.T) {
+	response := &http.Response{Header: http.Header{
+		"X-Powered-By": []string{"PHP/8.1"},
+		"X-Served-By":  []string{"cache-fra19123-FRA"},
+		"X-Cache":      []string{"HIT"},
+	}}
+
+	result := NewServerHeaderTest().Run(ResponseTestParams{Response: response, DisableCVE: true})
+	if analysis := result.Metadata.(*ServerHeaderAnalysis); analysis.total_exposures != 3 {
+		t.Fatalf("Expected 3 exposures without an allowlist, got %d", analysis.total_exposures)
+	}
+	if result.ThreatLevel != Low {
+		t.Errorf("Expected Low for 3 exposures, got %v", result.ThreatLevel)
+	}
+
+	result = NewServerHeaderTest().Run(ResponseTestParams{
+		Response:       response,
+		DisableCVE:     true,
+		IgnoredHeaders: []string{"x-served-by", "X-Cache"},
+	})
+	analysis := result.Metadata.(*ServerHeaderAnalysis)
+	if analysis.total_exposures != 1 || len(analysis.exposed_headers) != 1 || analysis.exposed_headers[0] != "X-Powered-By" {
+		t.Errorf("Expected only X-Powered-By to be counted, got %v", analysis.exposed_headers)
+	}
+	if len(analysis.ignored_headers) != 2 || analysis.ignored_headers[0] != "X-Cache" || analysis.ignored_headers[1] != "X-Served-By" {
+		t.Errorf("Expected the allowlisted headers to be recorded as ignored, got %v", analysis.ignored_headers)
+	}
+	if result.ThreatLevel != Info {
+		t.Errorf("Expected Info for a single exposure, got %v", result.ThreatLevel)
+	}
+}
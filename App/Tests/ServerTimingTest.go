@@ -0,0 +1,254 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the Server-Timing test that detects backend internals leaked through
+// Server-Timing metric names and descriptions.
+package Tests
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// serverTimingReferences documents the Server-Timing header and its privacy considerations.
+var serverTimingReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Server-Timing",
+	"https://www.w3.org/TR/server-timing/#privacy-and-security",
+}
+
+// internalComponentKeywords are fragments of metric names and descriptions that name backend
+// components (databases, caches, queues, search engines, application runtimes) rather than
+// a generic phase of the request.
+var internalComponentKeywords = []string{
+	"mysql", "mariadb", "postgres", "pgsql", "oracle", "mssql", "sqlserver", "sqlite", "mongo",
+	"redis", "memcache", "cassandra", "dynamodb", "couchdb", "elastic", "solr", "opensearch",
+	"rabbitmq", "kafka", "sqs", "fpm", "gunicorn", "uwsgi", "unicorn", "puma",
+	"tomcat", "jvm", "lambda", "kubernetes", "k8s", "upstream", "backend",
+	"sql", "db", "database", "query",
+}
+
+// internalHostSuffixes mark host names that only resolve inside a private network.
+var internalHostSuffixes = []string{".internal", ".local", ".lan", ".corp", ".intranet", ".svc", ".cluster.local"}
+
+// ServerTimingMetric is a single entry of the Server-Timing header.
+type ServerTimingMetric struct {
+	Name        string  `json:"name"`                  // Metric name (e.g., "db")
+	Description string  `json:"description,omitempty"` // Value of the desc parameter
+	Duration    float64 `json:"duration,omitempty"`    // Value of the dur parameter in milliseconds
+	Internal    string  `json:"internal,omitempty"`    // Internal component the entry exposes, empty if none
+}
+
+// NewServerTimingTest creates a new ResponseTest that inspects the Server-Timing header.
+// Server-Timing is meant for performance debugging in the browser's developer tools, but it
+// is readable by every visitor and, with Timing-Allow-Origin, by other origins. Metric names
+// and descriptions often reveal backend internals such as database engines, cache servers,
+// queue names or internal host names, and durations can support timing attacks.
+//
+// The test evaluates:
+//   - The metric names, descriptions (desc) and durations (dur) of every entry
+//   - Internal component names: databases, caches, queues, search engines, runtimes
+//   - Internal host names and private IP addresses in names or descriptions
+//
+// Threat level assessment:
+//   - None (0): Header absent, or only generic metrics (e.g., "total", "edge")
+//   - Low (2): At least one entry exposes an internal component name
+//
+// Returns:
+//   - *ResponseTest: Configured Server-Timing test ready for execution
+//
+// Example usage:
+//
+//	serverTimingTest := NewServerTimingTest()
+//	result := serverTimingTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["metrics"] lists every exposed metric
+func NewServerTimingTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "server-timing",
+		Name:          "Server-Timing Information Leakage",
+		Description:   "Inspects Server-Timing metrics for leaked backend timing, database names and cache internals",
+		Category:      "Headers",
+		CWE:           "CWE-200",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"Server-Timing"},
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeServerTiming(HeaderValues(params.Response.Header, "Server-Timing"))
+			threatLevel := evaluateServerTimingThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "Server-Timing Information Leakage",
+				Certainty:   90,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateServerTimingDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Remove Server-Timing from production responses, or send only generic metric names " +
+					"(e.g., total;dur=120) without descriptions naming databases, caches or hosts, and do not send " +
+					"Timing-Allow-Origin for it"
+				result.References = serverTimingReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeServerTiming parses the Server-Timing header lines into structured metadata.
+//
+// Parameters:
+//   - values: Server-Timing header lines; each may hold several comma-separated entries
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "present" (bool): Header was sent
+//   - "metrics" ([]ServerTimingMetric): Every parsed entry
+//   - "exposed_internals" ([]string): Internal components exposed, one per leaking entry
+//
+// Example:
+//
+//	metadata := analyzeServerTiming([]string{`db;dur=53;desc="mysql primary", total;dur=120`})
+//	// metadata["exposed_internals"] == []string{"db"}
+func analyzeServerTiming(values []string) map[string]interface{} {
+	metrics := []ServerTimingMetric{}
+	internals := []string{}
+	for _, value := range values {
+		for _, entry := range splitOutsideQuotes(value, ',') {
+			metric, ok := parseServerTimingMetric(entry)
+			if !ok {
+				continue
+			}
+			metric.Internal = internalComponent(metric.Name, metric.Description)
+			if metric.Internal != "" {
+				internals = append(internals, metric.Internal)
+			}
+			metrics = append(metrics, metric)
+		}
+	}
+	return map[string]interface{}{
+		"present":           len(values) > 0,
+		"metrics":           metrics,
+		"exposed_internals": internals,
+	}
+}
+
+// parseServerTimingMetric parses one entry such as `cache;desc="Cache Read";dur=23.2`.
+// Unknown parameters are ignored; an entry without a name is rejected.
+func parseServerTimingMetric(entry string) (ServerTimingMetric, bool) {
+	parts := splitOutsideQuotes(entry, ';')
+	metric := ServerTimingMetric{Name: strings.TrimSpace(parts[0])}
+	if metric.Name == "" {
+		return metric, false
+	}
+	for _, part := range parts[1:] {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		value = unquoteServerTimingValue(strings.TrimSpace(value))
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "desc":
+			metric.Description = value
+		case "dur":
+			if duration, err := strconv.ParseFloat(value, 64); err == nil {
+				metric.Duration = duration
+			}
+		}
+	}
+	return metric, true
+}
+
+// internalComponent returns the first internal component named by a metric, checking
+// host names and private IP addresses before component keywords.
+func internalComponent(name, description string) string {
+	for _, field := range strings.FieldsFunc(name+" "+description, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '(' || r == ')' || r == '/' || r == '@'
+	}) {
+		host := strings.ToLower(strings.TrimRight(field, ".:"))
+		if hostOnly, _, err := net.SplitHostPort(host); err == nil {
+			host = hostOnly
+		}
+		if ip := net.ParseIP(host); ip != nil && (ip.IsPrivate() || ip.IsLoopback()) {
+			return host
+		}
+		for _, suffix := range internalHostSuffixes {
+			if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return host
+			}
+		}
+	}
+
+	for _, text := range []string{name, description} {
+		for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return r == ' ' || r == '_' || r == '-' || r == '.' || r == ':' || r == ',' || r == '/'
+		}) {
+			for _, keyword := range internalComponentKeywords {
+				if word == keyword || (len(keyword) > 3 && strings.Contains(word, keyword)) {
+					return keyword
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// splitOutsideQuotes splits s at every separator that is not inside a quoted string.
+func splitOutsideQuotes(s string, separator rune) []string {
+	var parts []string
+	var current strings.Builder
+	quoted, escaped := false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == separator && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	return append(parts, current.String())
+}
+
+// unquoteServerTimingValue removes the quotes and escapes of a quoted parameter value.
+func unquoteServerTimingValue(value string) string {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return value
+	}
+	var unquoted strings.Builder
+	escaped := false
+	for _, r := range value[1 : len(value)-1] {
+		if !escaped && r == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		unquoted.WriteRune(r)
+	}
+	return unquoted.String()
+}
+
+// evaluateServerTimingThreatLevel maps the Server-Timing analysis to a threat level.
+func evaluateServerTimingThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	if len(metadata["exposed_internals"].([]string)) > 0 {
+		return Low
+	}
+	return None
+}
+
+// generateServerTimingDescription builds a human-readable summary of the Server-Timing analysis.
+func generateServerTimingDescription(metadata map[string]interface{}) string {
+	if !metadata["present"].(bool) {
+		return "No Server-Timing header, no backend timing is exposed"
+	}
+	metrics := metadata["metrics"].([]ServerTimingMetric)
+	names := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		names = append(names, metric.Name)
+	}
+	internals := metadata["exposed_internals"].([]string)
+	if len(internals) > 0 {
+		return "Server-Timing exposes internal components (" + strings.Join(internals, ", ") + ") in metrics: " +
+			strings.Join(names, ", ") + ". Backend names and timings help attackers map the infrastructure"
+	}
+	return "Server-Timing exposes only generic metrics (" + strings.Join(names, ", ") + "), no internal component names"
+}
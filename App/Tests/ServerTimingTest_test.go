@@ -0,0 +1,88 @@
+package Tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestServerTimingTest(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		wantThreat    ThreatLevel
+		wantMetrics   int
+		wantInternals []string
+	}{
+		{name: "Absent", header: "", wantThreat: None},
+		{
+			name:        "Benign generic metrics",
+			header:      `total;dur=123.4, cdn-cache;desc=HIT, edge;dur=4;desc="Edge processing"`,
+			wantThreat:  None,
+			wantMetrics: 3,
+		},
+		{
+			name:          "Database and cache names",
+			header:        `db-primary;dur=53.2;desc="MySQL orders", cache;desc="redis read";dur=2, total;dur=80`,
+			wantThreat:    Low,
+			wantMetrics:   3,
+			wantInternals: []string{"db", "redis"},
+		},
+		{
+			name:          "Internal host in description",
+			header:        `app;dur=40;desc="served by web-3.prod.internal"`,
+			wantThreat:    Low,
+			wantMetrics:   1,
+			wantInternals: []string{"web-3.prod.internal"},
+		},
+		{
+			name:          "Private IP in description",
+			header:        `origin;desc="10.0.12.7:8080";dur=12`,
+			wantThreat:    Low,
+			wantMetrics:   1,
+			wantInternals: []string{"10.0.12.7"},
+		},
+		{
+			name:        "Quoted comma and semicolon",
+			header:      `render;desc="templates, partials; layout";dur=7`,
+			wantThreat:  None,
+			wantMetrics: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				response.Header.Set("Server-Timing", tt.header)
+			}
+
+			result := NewServerTimingTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if metrics := metadata["metrics"].([]ServerTimingMetric); len(metrics) != tt.wantMetrics {
+				t.Errorf("Expected %d metrics, got %+v", tt.wantMetrics, metrics)
+			}
+			internals := metadata["exposed_internals"].([]string)
+			if len(internals) != len(tt.wantInternals) {
+				t.Fatalf("Expected internals %v, got %v", tt.wantInternals, internals)
+			}
+			for i := range internals {
+				if internals[i] != tt.wantInternals[i] {
+					t.Errorf("Expected internals %v, got %v", tt.wantInternals, internals)
+				}
+			}
+			if (result.Remediation != "") != (tt.wantThreat > None) {
+				t.Errorf("Expected remediation only for leaking headers, got %q", result.Remediation)
+			}
+		})
+	}
+
+	t.Run("Metric parameters are parsed", func(t *testing.T) {
+		metric, ok := parseServerTimingMetric(`cache;desc="Cache \"L2\" Read";dur=23.2`)
+		if !ok || metric.Name != "cache" || metric.Description != `Cache "L2" Read` || metric.Duration != 23.2 {
+			t.Errorf("Unexpected metric %+v", metric)
+		}
+	})
+}
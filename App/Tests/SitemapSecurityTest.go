@@ -42,6 +42,7 @@
 package Tests
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -149,16 +150,17 @@ type SitemapAnalysis struct {
 //   - JSObfuscationTest: Detects potential security threats in JavaScript
 func NewSitemapSecurityTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "sitemap",
-		Name:        "Sitemap Security Analysis",
-		Description: "Analyzes sitemap.xml for dangerous paths that should not be exposed to search engines",
-		Category:    "App-Configuration",
+		Id:              "sitemap",
+		Name:            "Sitemap Security Analysis",
+		Description:     "Analyzes sitemap.xml for dangerous paths that should not be exposed to search engines",
+		Category:        "App-Configuration",
+		RequiresNetwork: true,
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Extract base URL from the response
 			baseUrl := params.Response.Request.URL.Scheme + "://" + params.Response.Request.URL.Host
 			
 			// Fetch sitemap.xml
-			analysis := analyzeSitemap(baseUrl)
+			analysis := analyzeSitemap(params.scanContext(), baseUrl)
 
 			// Determine threat level based on dangerous paths found
 			threatLevel := evaluateSitemapThreatLevel(analysis)
@@ -186,6 +188,7 @@ func NewSitemapSecurityTest() *ResponseTest {
 //  4. Classification of found dangerous paths
 //
 // Parameters:
+//   - ctx: Scan context, cancelling the request at the scan deadline
 //   - baseUrl: The base URL of the target website (e.g., "https://example.com")
 //
 // Returns:
@@ -196,7 +199,7 @@ func NewSitemapSecurityTest() *ResponseTest {
 //   - Network errors - marks sitemap as inaccessible
 //   - Empty sitemaps - returns safe analysis
 //   - Malformed XML - attempts best-effort parsing
-func analyzeSitemap(baseUrl string) SitemapAnalysis {
+func analyzeSitemap(ctx context.Context, baseUrl string) SitemapAnalysis {
 	sitemapUrl := baseUrl + "/sitemap.xml"
 	
 	analysis := SitemapAnalysis{
@@ -209,7 +212,11 @@ func analyzeSitemap(baseUrl string) SitemapAnalysis {
 
 	// Fetch sitemap.xml
 
-	resp, err := http.Get(sitemapUrl)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sitemapUrl, nil)
+	if err != nil {
+		return analysis
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return analysis
 	}
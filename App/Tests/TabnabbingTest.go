@@ -0,0 +1,215 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the tabnabbing test that looks for links opening a new browsing context
+// (target="_blank") without rel="noopener" or rel="noreferrer".
+package Tests
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// tabnabbingReferences documents reverse tabnabbing and the noopener link type.
+var tabnabbingReferences = []string{
+	"https://owasp.org/www-community/attacks/Reverse_Tabnabbing",
+	"https://cheatsheetseries.owasp.org/cheatsheets/HTML5_Security_Cheat_Sheet.html#tabnabbing",
+	"https://html.spec.whatwg.org/multipage/links.html#link-type-noopener",
+}
+
+const (
+	// tabnabbingManyLinks is the number of affected links from which the finding is Low.
+	tabnabbingManyLinks = 5
+	// tabnabbingMaxListed caps the hrefs listed in the metadata.
+	tabnabbingMaxListed = 50
+)
+
+// TabnabbingAnalysis holds the target="_blank" links found in the page.
+type TabnabbingAnalysis struct {
+	BlankLinks      int      `json:"blank_links"`       // Links opening a new browsing context
+	ProtectedLinks  int      `json:"protected_links"`   // Of which with rel="noopener" or rel="noreferrer"
+	SameOriginLinks int      `json:"same_origin_links"` // Unprotected links to the scanned origin or to no site (mailto:, #), not affected
+	AffectedLinks   int      `json:"affected_links"`    // Unprotected links to other origins
+	AffectedHrefs   []string `json:"affected_hrefs"`    // Distinct hrefs of the affected links (capped at tabnabbingMaxListed)
+	BaseTargetBlank bool     `json:"base_target_blank"` // <base target="_blank"> opens every link in a new browsing context
+	Error           string   `json:"error,omitempty"`
+}
+
+// NewTabnabbingTest creates a new ResponseTest that looks for reverse tabnabbing. A link
+// opening another site in a new browsing context (target="_blank", or every link below a
+// <base target="_blank">) without rel="noopener" or rel="noreferrer" hands the opened page a
+// window.opener reference, which it can use to navigate the scanned page to a phishing copy.
+//
+// Links to the scanned origin are counted separately and are not affected: the opened page
+// is served by the site itself. Current browsers imply noopener for target="_blank" links,
+// so the finding mostly concerns older browsers and embedded web views.
+//
+// Threat level assessment:
+//   - None (0): No link to another origin opens a new browsing context without noopener
+//   - Info (1): A few affected links, or the body could not be read
+//   - Low (2): tabnabbingManyLinks or more affected links
+//
+// Returns:
+//   - *ResponseTest: Configured tabnabbing test ready for execution
+//
+// Example usage:
+//
+//	tabnabbingTest := NewTabnabbingTest()
+//	result := tabnabbingTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata.(TabnabbingAnalysis).AffectedHrefs lists the affected links
+func NewTabnabbingTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "tabnabbing",
+		Name:          "Reverse Tabnabbing Detection",
+		Description:   "Looks for target=\"_blank\" links to other origins lacking rel=\"noopener\" or rel=\"noreferrer\"",
+		Category:      "App-Configuration",
+		CWE:           "CWE-1022",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		RunTest: func(params ResponseTestParams) TestResult {
+			analysis := analyzeTabnabbing(params)
+			threatLevel := evaluateTabnabbingThreatLevel(analysis)
+
+			result := TestResult{
+				Name:        "Reverse Tabnabbing Detection",
+				Certainty:   90,
+				ThreatLevel: threatLevel,
+				Metadata:    analysis,
+				Description: generateTabnabbingDescription(analysis),
+			}
+			if analysis.AffectedLinks > 0 {
+				result.Remediation = "Add rel=\"noopener noreferrer\" to links opening other sites with target=\"_blank\""
+				result.References = tabnabbingReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeTabnabbing collects the links of the response body opening a new browsing context.
+func analyzeTabnabbing(params ResponseTestParams) TabnabbingAnalysis {
+	analysis := TabnabbingAnalysis{AffectedHrefs: []string{}}
+	body, err := params.responseBody()
+	if err != nil {
+		analysis.Error = err.Error()
+		return analysis
+	}
+
+	var page *url.URL
+	if params.Response != nil && params.Response.Request != nil {
+		page = params.Response.Request.URL
+	}
+	base := page
+	for _, tag := range FindHTMLTags(body, "base") {
+		if target, ok := tag.Attr("target"); ok && strings.EqualFold(strings.TrimSpace(target), "_blank") {
+			analysis.BaseTargetBlank = true
+		}
+		if href, ok := tag.Attr("href"); ok && base != nil {
+			if resolved, err := base.Parse(strings.TrimSpace(href)); err == nil {
+				base = resolved
+			}
+		}
+		break // Only the first <base> element is used by browsers
+	}
+
+	listed := make(map[string]bool)
+	for _, link := range FindHTMLTags(body, "a", "area") {
+		href, ok := link.Attr("href")
+		if !ok || !opensBlankContext(link, analysis.BaseTargetBlank) {
+			continue
+		}
+		analysis.BlankLinks++
+		if hasNoopener(link) {
+			analysis.ProtectedLinks++
+			continue
+		}
+		href = strings.TrimSpace(href)
+		if !linksToOtherOrigin(page, base, href) {
+			analysis.SameOriginLinks++
+			continue
+		}
+		analysis.AffectedLinks++
+		if !listed[href] && len(analysis.AffectedHrefs) < tabnabbingMaxListed {
+			listed[href] = true
+			analysis.AffectedHrefs = append(analysis.AffectedHrefs, href)
+		}
+	}
+	return analysis
+}
+
+// opensBlankContext reports whether following the link opens a new browsing context: its
+// target is _blank, or it has no target and the page's <base> target is _blank.
+func opensBlankContext(link HTMLTag, baseTargetBlank bool) bool {
+	target, ok := link.Attr("target")
+	if !ok {
+		return baseTargetBlank
+	}
+	return strings.EqualFold(strings.TrimSpace(target), "_blank")
+}
+
+// hasNoopener reports whether the rel attribute of the link contains noopener or noreferrer
+// (which implies noopener).
+func hasNoopener(link HTMLTag) bool {
+	rel, _ := link.Attr("rel")
+	for _, token := range strings.Fields(strings.ToLower(rel)) {
+		if token == "noopener" || token == "noreferrer" {
+			return true
+		}
+	}
+	return false
+}
+
+// linksToOtherOrigin reports whether href, resolved against base, leads to another origin
+// than page. Non-navigational hrefs (fragments, javascript:, mailto: and the like) do not.
+// Without a page URL only absolute http(s) URLs are considered to lead elsewhere.
+func linksToOtherOrigin(page, base *url.URL, href string) bool {
+	if href == "" || strings.HasPrefix(href, "#") {
+		return false
+	}
+	var resolved *url.URL
+	var err error
+	if base != nil {
+		resolved, err = base.Parse(href)
+	} else {
+		resolved, err = url.Parse(href)
+	}
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(resolved.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return false
+	}
+	if page == nil {
+		return resolved.Host != ""
+	}
+	return !strings.EqualFold(scheme, page.Scheme) || !strings.EqualFold(resolved.Host, page.Host)
+}
+
+// evaluateTabnabbingThreatLevel maps the tabnabbing analysis to a threat level.
+func evaluateTabnabbingThreatLevel(analysis TabnabbingAnalysis) ThreatLevel {
+	switch {
+	case analysis.Error != "":
+		return Info
+	case analysis.AffectedLinks >= tabnabbingManyLinks:
+		return Low
+	case analysis.AffectedLinks > 0:
+		return Info
+	default:
+		return None
+	}
+}
+
+// generateTabnabbingDescription builds a human-readable summary of the tabnabbing analysis.
+func generateTabnabbingDescription(analysis TabnabbingAnalysis) string {
+	if analysis.Error != "" {
+		return "Could not read the response body: " + analysis.Error
+	}
+	if analysis.AffectedLinks > 0 {
+		return strconv.Itoa(analysis.AffectedLinks) + " link(s) open another site in a new browsing context without " +
+			"rel=\"noopener\" or rel=\"noreferrer\", exposing the page to reverse tabnabbing through window.opener"
+	}
+	if analysis.BlankLinks == 0 {
+		return "No links opening a new browsing context (target=\"_blank\") were found"
+	}
+	return strconv.Itoa(analysis.BlankLinks) + " link(s) open a new browsing context; links to other sites use " +
+		"rel=\"noopener\" or rel=\"noreferrer\""
+}
@@ -0,0 +1,92 @@
+package Tests
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// runTabnabbing runs the tabnabbing test on a fixture of testdata/tabnabbing served as
+// https://www.example.com/partners.
+func runTabnabbing(t *testing.T, fixture string) TestResult {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "tabnabbing", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	page, _ := url.Parse("https://www.example.com/partners")
+	response := &http.Response{Header: http.Header{}, Request: &http.Request{URL: page}}
+	return NewTabnabbingTest().Run(ResponseTestParams{Response: response, Body: body})
+}
+
+func TestTabnabbingTest(t *testing.T) {
+	tests := []struct {
+		name         string
+		fixture      string
+		wantThreat   ThreatLevel
+		wantAnalysis TabnabbingAnalysis
+	}{
+		{
+			name:       "Vulnerable links",
+			fixture:    "vulnerable.html",
+			wantThreat: Low,
+			wantAnalysis: TabnabbingAnalysis{
+				BlankLinks:      9,
+				ProtectedLinks:  1,
+				SameOriginLinks: 1,
+				AffectedLinks:   7,
+				AffectedHrefs: []string{
+					"https://partner-one.example/",
+					"https://partner-two.example/offer",
+					"https://partner-three.example/",
+					"//partner-four.example/",
+					"https://partner-five.example/?a=1&b=2",
+					"https://ads.example/",
+				},
+			},
+		},
+		{
+			name:       "Links with noopener",
+			fixture:    "safe.html",
+			wantThreat: None,
+			wantAnalysis: TabnabbingAnalysis{
+				BlankLinks:      6,
+				ProtectedLinks:  3,
+				SameOriginLinks: 3,
+				AffectedHrefs:   []string{},
+			},
+		},
+		{
+			name:       "Base target",
+			fixture:    "base-target.html",
+			wantThreat: Info,
+			wantAnalysis: TabnabbingAnalysis{
+				BlankLinks:      2,
+				ProtectedLinks:  1,
+				AffectedLinks:   1,
+				AffectedHrefs:   []string{"docs/intro"},
+				BaseTargetBlank: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runTabnabbing(t, tt.fixture)
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			analysis := result.Metadata.(TabnabbingAnalysis)
+			if !reflect.DeepEqual(analysis, tt.wantAnalysis) {
+				t.Errorf("Expected analysis %+v, got %+v", tt.wantAnalysis, analysis)
+			}
+			if (result.Remediation != "") != (analysis.AffectedLinks > 0) {
+				t.Errorf("Expected a remediation only for affected links, got %q", result.Remediation)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the technology type tests use to report the software they detected.
+package Tests
+
+import (
+	"Engine-AntiGinx/App/CVE"
+	"sort"
+)
+
+// cveSummaryTopLimit caps the number of CVE IDs listed in a CVESummary.
+const cveSummaryTopLimit = 5
+
+// Technology is a piece of software detected by a test, e.g. a web server read from the
+// Server header. Tests report them in TestResult.Technologies; the Runner merges the
+// detections of all tests of a target into a single technology stack summary.
+//
+// Fields:
+//   - Name: Technology name (e.g., "Nginx", "PHP"); matched case-insensitively when merging
+//   - Version: Detected version (e.g., "1.18.0"), empty when the version is not disclosed
+//   - Sources: IDs of the tests that detected it, filled in by the Runner's merged summary
+//   - CVE: Known vulnerabilities of the technology, nil when no CVE lookup was made
+type Technology struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Sources []string    `json:"sources,omitempty"`
+	CVE     *CVESummary `json:"cve,omitempty"`
+}
+
+// CVESummary is the part of a CVE.VulnerabilityAssessment kept in the technology inventory,
+// so reports can feed the known vulnerabilities of every detected technology into other
+// risk tooling without the full CVE entries.
+//
+// Fields:
+//   - Count: Total number of CVEs found
+//   - High, Medium, Low: CVE counts by severity (High includes CRITICAL)
+//   - MaxScore: Highest CVSS score among the CVEs
+//   - RiskLevel: Overall risk of the assessment (NONE to CRITICAL)
+//   - TopCVEs: IDs of the highest scored CVEs, at most cveSummaryTopLimit
+type CVESummary struct {
+	Count     int      `json:"count"`
+	High      int      `json:"high"`
+	Medium    int      `json:"medium"`
+	Low       int      `json:"low"`
+	MaxScore  float64  `json:"maxScore"`
+	RiskLevel string   `json:"riskLevel"`
+	TopCVEs   []string `json:"topCves,omitempty"`
+}
+
+// newCVESummary condenses a vulnerability assessment into a CVESummary. CVEs with the same
+// score keep the order of the assessment.
+//
+// Example:
+//
+//	summary := newCVESummary(assessment)
+//	// summary.TopCVEs == []string{"CVE-2021-23017", ...} (highest CVSS first)
+func newCVESummary(assessment *CVE.VulnerabilityAssessment) *CVESummary {
+	cves := append([]CVE.CVEResult(nil), assessment.CVEs...)
+	sort.SliceStable(cves, func(i, j int) bool { return cves[i].Score > cves[j].Score })
+	top := make([]string, 0, min(len(cves), cveSummaryTopLimit))
+	for _, cve := range cves[:min(len(cves), cveSummaryTopLimit)] {
+		top = append(top, cve.ID)
+	}
+	return &CVESummary{
+		Count:     assessment.CVECount,
+		High:      assessment.HighSeverity,
+		Medium:    assessment.MediumSeverity,
+		Low:       assessment.LowSeverity,
+		MaxScore:  assessment.MaxScore,
+		RiskLevel: assessment.RiskLevel,
+		TopCVEs:   top,
+	}
+}
+
+// technologiesFromStack converts a technology-to-version map into Technologies sorted by
+// name. The placeholder version "detected" stands for an undisclosed version.
+func technologiesFromStack(stack map[string]string) []Technology {
+	if len(stack) == 0 {
+		return nil
+	}
+	technologies := make([]Technology, 0, len(stack))
+	for name, version := range stack {
+		if version == "detected" {
+			version = ""
+		}
+		technologies = append(technologies, Technology{Name: name, Version: version})
+	}
+	sort.Slice(technologies, func(i, j int) bool { return technologies[i].Name < technologies[j].Name })
+	return technologies
+}
@@ -0,0 +1,358 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the transfer integrity test that checks the framing headers of the
+// response (Content-Length, Transfer-Encoding) against each other and the body received.
+package Tests
+
+import (
+	"Engine-AntiGinx/App/Lookup"
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// transferIntegrityReferences documents message framing and response smuggling.
+var transferIntegrityReferences = []string{
+	"https://www.rfc-editor.org/rfc/rfc9112#section-6.3",
+	"https://portswigger.net/web-security/request-smuggling",
+	"https://cwe.mitre.org/data/definitions/444.html",
+}
+
+// transferIntegrityTimeout bounds the raw request of the transfer integrity test.
+const transferIntegrityTimeout = 10 * time.Second
+
+// transferIntegrityMaxBody caps how much of the body is read to measure its length.
+const transferIntegrityMaxBody = 4 << 20
+
+// NewTransferIntegrityTest creates a new ResponseTest that checks how the response body
+// is framed. A response carrying both Content-Length and Transfer-Encoding: chunked, or
+// several differing Content-Length values, is read differently by proxies, caches and
+// clients; the disagreement is the response-side counterpart of request smuggling and
+// lets an attacker desynchronize a shared connection or poison a cache. A Content-Length
+// that does not match the body sent points at a broken proxy or truncated responses.
+//
+// Go's HTTP client drops conflicting framing headers and decodes gzip transparently, so
+// the test sends its own HTTP/1.1 GET to the scanned URL and reads the raw header values.
+// Chunked bodies, including ones with further transfer codings such as "gzip, chunked",
+// are de-chunked to measure them; other bodies are read until the connection closes. The
+// body length buffered by the engine is checked against the declared length as well. A
+// response loaded from a file (--from-file) is only checked for the buffered length, as
+// the target must not be contacted.
+//
+// Threat level assessment:
+//   - None (0): Framing headers are consistent with each other and with the body
+//   - Low (2): Content-Length does not match the body, or a transfer coding other than
+//     chunked (e.g. gzip) is used, which most clients and proxies do not support
+//   - Medium (3): Conflicting framing headers (Content-Length with chunked, or
+//     differing Content-Length values)
+//
+// Returns:
+//   - *ResponseTest: Configured transfer integrity test ready for execution
+//
+// Example usage:
+//
+//	integrityTest := NewTransferIntegrityTest()
+//	result := integrityTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["conflicting_framing"] is true for Content-Length with chunked encoding
+func NewTransferIntegrityTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "transfer-integrity",
+		Name:          "Response Framing Integrity",
+		Description:   "Detects conflicting Content-Length and Transfer-Encoding headers and Content-Length values not matching the body",
+		Category:      "Protocol",
+		CWE:           "CWE-444",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeTransferIntegrity(params)
+			threatLevel := evaluateTransferIntegrityThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "Response Framing Integrity",
+				Certainty:   90,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateTransferIntegrityDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Send exactly one framing header: either a Content-Length matching the body " +
+					"or Transfer-Encoding: chunked, never both. Fix or replace proxies that rewrite the body " +
+					"without updating Content-Length, and use Content-Encoding rather than Transfer-Encoding for compression"
+				result.References = transferIntegrityReferences
+			}
+			return result
+		},
+	}
+}
+
+// rawFraming holds the framing of a response read off the wire.
+type rawFraming struct {
+	statusCode       int
+	contentLengths   []string // Content-Length values as sent
+	transferEncoding []string // Transfer codings, lower-cased, in order
+	contentEncoding  string
+	bodyLength       int64 // Body length received (de-chunked for chunked bodies)
+}
+
+// analyzeTransferIntegrity sends a raw GET to the URL of the scanned response and checks
+// its framing headers, and checks the length of the body buffered by the engine.
+//
+// Parameters:
+//   - params: Test parameters holding the response, its buffered body and the scan context
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "url" (string): URL the raw request was sent to
+//   - "sent" (bool): A raw response was received
+//   - "status_code" (int): Status code of the raw response (0 if none)
+//   - "content_lengths" ([]string): Content-Length values of the raw response
+//   - "transfer_encoding" ([]string): Transfer codings of the raw response, lower-cased
+//   - "content_encoding" (string): Content-Encoding of the raw response
+//   - "declared_length" (int64): Declared Content-Length (-1 if absent, invalid or chunked)
+//   - "received_length" (int64): Body bytes received on the raw connection (-1 if unknown)
+//   - "buffered_length" (int): Body bytes buffered by the engine (-1 if not buffered)
+//   - "conflicting_framing" (bool): Content-Length sent together with chunked encoding
+//   - "multiple_content_lengths" (bool): Differing Content-Length values were sent
+//   - "length_mismatch" (bool): A body length differs from the declared length
+//   - "unsupported_codings" ([]string): Transfer codings other than chunked
+//   - "offline" (bool): The response was loaded from a file, no raw request was sent
+//   - "error" (string): Why the raw request failed, empty on success
+//
+// Example:
+//
+//	metadata := analyzeTransferIntegrity(ResponseTestParams{Response: httpResponse})
+//	// metadata["conflicting_framing"] == true for "Content-Length: 5" with chunked encoding
+func analyzeTransferIntegrity(params ResponseTestParams) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"url":                      "",
+		"sent":                     false,
+		"status_code":              0,
+		"content_lengths":          []string{},
+		"transfer_encoding":        []string{},
+		"content_encoding":         "",
+		"declared_length":          int64(-1),
+		"received_length":          int64(-1),
+		"buffered_length":          -1,
+		"conflicting_framing":      false,
+		"multiple_content_lengths": false,
+		"length_mismatch":          false,
+		"unsupported_codings":      []string{},
+		"offline":                  params.Offline,
+		"error":                    "",
+	}
+
+	// The engine's own response: Go keeps Content-Length unless the body was chunked or
+	// transparently decompressed
+	if params.Body != nil {
+		metadata["buffered_length"] = len(params.Body)
+		if response := params.Response; response != nil && response.ContentLength >= 0 &&
+			!response.Uncompressed && int64(len(params.Body)) != response.ContentLength {
+			metadata["length_mismatch"] = true
+		}
+	}
+
+	if params.Offline {
+		return metadata
+	}
+	if params.Response == nil || params.Response.Request == nil || params.Response.Request.URL == nil {
+		metadata["error"] = "request URL unknown"
+		return metadata
+	}
+	target := *params.Response.Request.URL
+	target.Fragment = ""
+	metadata["url"] = target.String()
+
+	framing, err := fetchRawFraming(params.scanContext(), &target)
+	if err != nil {
+		metadata["error"] = err.Error()
+		return metadata
+	}
+	metadata["sent"] = true
+	metadata["status_code"] = framing.statusCode
+	metadata["content_lengths"] = framing.contentLengths
+	metadata["transfer_encoding"] = framing.transferEncoding
+	metadata["content_encoding"] = framing.contentEncoding
+	metadata["received_length"] = framing.bodyLength
+
+	chunked := len(framing.transferEncoding) > 0 &&
+		framing.transferEncoding[len(framing.transferEncoding)-1] == "chunked"
+	unsupported := []string{}
+	for _, coding := range framing.transferEncoding {
+		if coding != "chunked" {
+			unsupported = append(unsupported, coding)
+		}
+	}
+	metadata["unsupported_codings"] = unsupported
+	metadata["conflicting_framing"] = len(framing.contentLengths) > 0 && len(framing.transferEncoding) > 0
+
+	declared := int64(-1)
+	for i, value := range framing.contentLengths {
+		if i > 0 && value != framing.contentLengths[0] {
+			metadata["multiple_content_lengths"] = true
+		}
+	}
+	if !chunked && !metadata["multiple_content_lengths"].(bool) && len(framing.contentLengths) > 0 {
+		if n, err := strconv.ParseInt(framing.contentLengths[0], 10, 64); err == nil && n >= 0 {
+			declared = n
+		}
+	}
+	metadata["declared_length"] = declared
+	if declared >= 0 && framing.bodyLength >= 0 && framing.bodyLength <= transferIntegrityMaxBody &&
+		framing.bodyLength != declared {
+		metadata["length_mismatch"] = true
+	}
+	return metadata
+}
+
+// fetchRawFraming sends an HTTP/1.1 GET asking the server to close the connection after
+// the response, and reads the status, the framing headers and the length of the body. The
+// connection holds a slot of the shared lookup limiter until it is closed.
+func fetchRawFraming(ctx context.Context, target *url.URL) (rawFraming, error) {
+	framing := rawFraming{bodyLength: -1}
+	release, err := Lookup.Shared().Acquire(ctx)
+	if err != nil {
+		return framing, err
+	}
+	defer release()
+
+	host := target.Host
+	if target.Port() == "" {
+		port := "80"
+		if strings.EqualFold(target.Scheme, "https") {
+			port = "443"
+		}
+		host = net.JoinHostPort(target.Hostname(), port)
+	}
+	dialer := &net.Dialer{Timeout: transferIntegrityTimeout}
+	var conn net.Conn
+	if strings.EqualFold(target.Scheme, "https") {
+		// The certificate is analysed by ssl-cert; only the framing matters here
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         target.Hostname(),
+			NextProtos:         []string{"http/1.1"},
+		}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", host)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	}
+	if err != nil {
+		return framing, err
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(transferIntegrityTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetDeadline(deadline)
+
+	request := "GET " + target.RequestURI() + " HTTP/1.1\r\n" +
+		"Host: " + target.Host + "\r\n" +
+		"User-Agent: AntiGinx-TestClient/1.0\r\n" +
+		"Accept: */*\r\n" +
+		"Accept-Encoding: gzip\r\n" +
+		"Connection: close\r\n\r\n"
+	if _, err := io.WriteString(conn, request); err != nil {
+		return framing, err
+	}
+
+	reader := bufio.NewReader(conn)
+	protocol := textproto.NewReader(reader)
+	statusLine, err := protocol.ReadLine()
+	if err != nil {
+		return framing, err
+	}
+	fields := strings.Fields(statusLine)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "HTTP/") {
+		return framing, fmt.Errorf("malformed status line %q", statusLine)
+	}
+	if framing.statusCode, err = strconv.Atoi(fields[1]); err != nil {
+		return framing, fmt.Errorf("malformed status line %q", statusLine)
+	}
+	header, err := protocol.ReadMIMEHeader()
+	if err != nil && len(header) == 0 {
+		return framing, err
+	}
+
+	framing.contentLengths = []string{}
+	for _, value := range header.Values("Content-Length") {
+		framing.contentLengths = append(framing.contentLengths, strings.TrimSpace(value))
+	}
+	framing.transferEncoding = []string{}
+	for _, value := range header.Values("Transfer-Encoding") {
+		for _, coding := range strings.Split(value, ",") {
+			if coding = strings.ToLower(strings.TrimSpace(coding)); coding != "" {
+				framing.transferEncoding = append(framing.transferEncoding, coding)
+			}
+		}
+	}
+	framing.contentEncoding = strings.ToLower(strings.TrimSpace(header.Get("Content-Encoding")))
+
+	// 1xx, 204 and 304 responses have no body whatever the headers say
+	if framing.statusCode < 200 || framing.statusCode == http.StatusNoContent || framing.statusCode == http.StatusNotModified {
+		framing.bodyLength = 0
+		return framing, nil
+	}
+	var body io.Reader = reader
+	if n := len(framing.transferEncoding); n > 0 && framing.transferEncoding[n-1] == "chunked" {
+		body = httputil.NewChunkedReader(reader)
+	}
+	// A body cut short by the deadline still tells how much of it was sent
+	framing.bodyLength, _ = io.Copy(io.Discard, io.LimitReader(body, transferIntegrityMaxBody+1))
+	return framing, nil
+}
+
+// evaluateTransferIntegrityThreatLevel maps the framing analysis to a threat level.
+func evaluateTransferIntegrityThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch {
+	case metadata["conflicting_framing"].(bool), metadata["multiple_content_lengths"].(bool):
+		return Medium
+	case metadata["length_mismatch"].(bool), len(metadata["unsupported_codings"].([]string)) > 0:
+		return Low
+	default:
+		return None
+	}
+}
+
+// generateTransferIntegrityDescription builds a human-readable summary of the framing analysis.
+func generateTransferIntegrityDescription(metadata map[string]interface{}) string {
+	findings := []string{}
+	if metadata["conflicting_framing"].(bool) {
+		findings = append(findings, fmt.Sprintf("Content-Length (%s) is sent together with Transfer-Encoding (%s); "+
+			"proxies and clients disagree on where the body ends, a response smuggling vector",
+			strings.Join(metadata["content_lengths"].([]string), ", "), strings.Join(metadata["transfer_encoding"].([]string), ", ")))
+	}
+	if metadata["multiple_content_lengths"].(bool) {
+		findings = append(findings, fmt.Sprintf("Differing Content-Length values are sent (%s)",
+			strings.Join(metadata["content_lengths"].([]string), ", ")))
+	}
+	if metadata["length_mismatch"].(bool) {
+		finding := "Content-Length of the scanned response does not match the body buffered by the engine"
+		if declared, received := metadata["declared_length"].(int64), metadata["received_length"].(int64); declared >= 0 && received != declared {
+			finding = fmt.Sprintf("Content-Length declares %d bytes but %d were received", declared, received)
+		}
+		findings = append(findings, finding)
+	}
+	if codings := metadata["unsupported_codings"].([]string); len(codings) > 0 {
+		findings = append(findings, "Transfer coding "+strings.Join(codings, ", ")+
+			" is used, which most clients and proxies do not support")
+	}
+	if len(findings) > 0 {
+		return strings.Join(findings, ". ")
+	}
+	if metadata["offline"].(bool) {
+		return "Response loaded from a file: the body matches its Content-Length, the framing headers sent by the target were not checked"
+	}
+	if !metadata["sent"].(bool) {
+		return "Raw request could not be sent (" + metadata["error"].(string) + "), response framing was not checked"
+	}
+	return "Response framing is consistent: one framing header matching the body"
+}
@@ -0,0 +1,142 @@
+package Tests
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// serveRawFixture answers every connection with the raw HTTP response of a fixture in
+// testdata/transfer-integrity (LF line endings, sent as CRLF) and returns the server URL.
+func serveRawFixture(t *testing.T, name string) *url.URL {
+	t.Helper()
+	fixture, err := os.ReadFile(filepath.Join("testdata", "transfer-integrity", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	raw := bytes.ReplaceAll(fixture, []byte("\n"), []byte("\r\n"))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = textproto.NewReader(bufio.NewReader(conn)).ReadMIMEHeader()
+			_, _ = conn.Write(raw)
+			_ = conn.Close()
+		}
+	}()
+	target, _ := url.Parse("http://" + listener.Addr().String() + "/")
+	return target
+}
+
+func TestTransferIntegrityTest_Fixtures(t *testing.T) {
+	tests := []struct {
+		fixture     string
+		wantThreat  ThreatLevel
+		wantFlag    string
+		wantDeclare int64
+	}{
+		{fixture: "clean.txt", wantThreat: None, wantDeclare: 5},
+		{fixture: "length-mismatch.txt", wantThreat: Low, wantFlag: "length_mismatch", wantDeclare: 20},
+		{fixture: "conflicting-framing.txt", wantThreat: Medium, wantFlag: "conflicting_framing", wantDeclare: -1},
+		{fixture: "multiple-content-lengths.txt", wantThreat: Medium, wantFlag: "multiple_content_lengths", wantDeclare: -1},
+		{fixture: "gzip-chunked.txt", wantThreat: Low, wantDeclare: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}, Request: &http.Request{URL: serveRawFixture(t, tt.fixture)}}
+
+			result := NewTransferIntegrityTest().Run(ResponseTestParams{Response: response})
+
+			metadata := result.Metadata.(map[string]interface{})
+			if !metadata["sent"].(bool) {
+				t.Fatalf("Expected the raw request to be sent, got error %q", metadata["error"])
+			}
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			if tt.wantFlag != "" && !metadata[tt.wantFlag].(bool) {
+				t.Errorf("Expected %s to be reported, got %v", tt.wantFlag, metadata)
+			}
+			if declared := metadata["declared_length"].(int64); declared != tt.wantDeclare {
+				t.Errorf("Expected declared length %d, got %d", tt.wantDeclare, declared)
+			}
+			if received := metadata["received_length"].(int64); received != 5 {
+				t.Errorf("Expected 5 body bytes received, got %d", received)
+			}
+			if result.CWE != "CWE-444" {
+				t.Errorf("Expected CWE-444, got %q", result.CWE)
+			}
+		})
+	}
+}
+
+func TestTransferIntegrityTest_GzipTransferCoding(t *testing.T) {
+	response := &http.Response{Header: http.Header{}, Request: &http.Request{URL: serveRawFixture(t, "gzip-chunked.txt")}}
+
+	metadata := NewTransferIntegrityTest().Run(ResponseTestParams{Response: response}).Metadata.(map[string]interface{})
+
+	if codings := metadata["unsupported_codings"].([]string); len(codings) != 1 || codings[0] != "gzip" {
+		t.Errorf("Expected gzip to be reported as unsupported transfer coding, got %v", codings)
+	}
+}
+
+func TestTransferIntegrityTest_BufferedLengthMismatch(t *testing.T) {
+	response := &http.Response{Header: http.Header{}, ContentLength: 20}
+
+	result := NewTransferIntegrityTest().Run(ResponseTestParams{Response: response, Body: []byte("hello")})
+
+	metadata := result.Metadata.(map[string]interface{})
+	if !metadata["length_mismatch"].(bool) || metadata["buffered_length"].(int) != 5 {
+		t.Errorf("Expected the buffered body to mismatch Content-Length, got %v", metadata)
+	}
+	if result.ThreatLevel != Low {
+		t.Errorf("Expected Low, got %v (%s)", result.ThreatLevel, result.Description)
+	}
+}
+
+func TestTransferIntegrityTest_OfflineSendsNoRequest(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	connected := make(chan struct{}, 1)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			connected <- struct{}{}
+			_ = conn.Close()
+		}
+	}()
+	target, _ := url.Parse("http://" + listener.Addr().String() + "/")
+	response := &http.Response{Header: http.Header{}, ContentLength: 20, Request: &http.Request{URL: target}}
+
+	result := NewTransferIntegrityTest().Run(ResponseTestParams{Response: response, Body: []byte("hello"), Offline: true})
+
+	select {
+	case <-connected:
+		t.Error("Expected no connection to the target of an offline scan")
+	case <-time.After(50 * time.Millisecond):
+	}
+	metadata := result.Metadata.(map[string]interface{})
+	if metadata["sent"].(bool) || !metadata["length_mismatch"].(bool) {
+		t.Errorf("Expected only the buffered body to be checked, got %v", metadata)
+	}
+	if result.ThreatLevel != Low {
+		t.Errorf("Expected Low, got %v (%s)", result.ThreatLevel, result.Description)
+	}
+}
@@ -0,0 +1,200 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the transport security meta-test that rolls the HTTPS, HTTP to HTTPS
+// redirect, HSTS and TLS version checks up into a single transport-layer grade.
+package Tests
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// transportPenalties is the score deducted from 100 for a sub-result of each threat level.
+var transportPenalties = map[ThreatLevel]int{
+	None:     0,
+	Info:     5,
+	Low:      15,
+	Medium:   30,
+	High:     50,
+	Critical: 100,
+}
+
+// NewTransportSecurityTest creates a new ResponseTest that grades the transport layer of the
+// target as a whole. It reuses the individual HTTPS and HSTS tests and adds the HTTP to HTTPS
+// redirect and negotiated TLS version checks, so users get a single transport posture score.
+//
+// The test evaluates:
+//   - https: The final response was served over HTTPS (NewHTTPSTest)
+//   - redirect: A scan started over HTTP was redirected to HTTPS
+//   - hsts: Strict-Transport-Security configuration (NewHSTSTest)
+//   - tls_version: TLS version negotiated for the final response
+//
+// Grading:
+//   - The threat level is the most severe sub-result
+//   - The grade follows the report grades: A (None/Info), B (Low), C (Medium), D (High), F (Critical)
+//   - The score starts at 100 and loses 5/15/30/50/100 points per Info/Low/Medium/High/Critical sub-result
+//
+// Returns:
+//   - *ResponseTest: Configured transport security test ready for execution
+//
+// Example usage:
+//
+//	transportTest := NewTransportSecurityTest()
+//	result := transportTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata holds the grade, the score and every sub-result
+func NewTransportSecurityTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "transport",
+		Name:          "Transport Security Summary",
+		Description:   "Rolls up HTTPS usage, HTTP to HTTPS redirect, HSTS and TLS version into one transport-layer grade",
+		Category:      "Encryption",
+		CWE:           "CWE-319",
+		OWASPCategory: "A02:2021-Cryptographic Failures",
+		RunTest: func(params ResponseTestParams) TestResult {
+			subResults := map[string]TestResult{
+				"https":       NewHTTPSTest().Run(params),
+				"redirect":    evaluateHTTPSRedirect(params.Response),
+				"hsts":        NewHSTSTest().Run(params),
+				"tls_version": evaluateTLSVersion(params.Response),
+			}
+			metadata := summarizeTransport(subResults)
+			threatLevel := metadata["threat_level"].(ThreatLevel)
+
+			result := TestResult{
+				Name:        "Transport Security Summary",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateTransportDescription(metadata, subResults),
+			}
+			if hsts := subResults["hsts"]; hsts.Remediation != "" {
+				result.Remediation = hsts.Remediation
+				result.References = hsts.References
+			}
+			return result
+		},
+	}
+}
+
+// transportCheckOrder fixes the order in which sub-results are described.
+var transportCheckOrder = []string{"https", "redirect", "hsts", "tls_version"}
+
+// summarizeTransport derives the composite threat level, grade and score from the sub-results.
+//
+// Parameters:
+//   - subResults: Sub-results keyed by check name ("https", "redirect", "hsts", "tls_version")
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "grade" (string): Transport grade from A to F
+//   - "score" (int): Transport score from 0 to 100
+//   - "threat_level" (ThreatLevel): Most severe sub-result
+//   - "checks" (map[string]TestResult): Every sub-result
+func summarizeTransport(subResults map[string]TestResult) map[string]interface{} {
+	worst := None
+	score := 100
+	for _, result := range subResults {
+		if result.ThreatLevel > worst {
+			worst = result.ThreatLevel
+		}
+		score -= transportPenalties[result.ThreatLevel]
+	}
+	if score < 0 {
+		score = 0
+	}
+	return map[string]interface{}{
+		"grade":        transportGrade(worst),
+		"score":        score,
+		"threat_level": worst,
+		"checks":       subResults,
+	}
+}
+
+// transportGrade maps the most severe sub-result to the letter grades used by reports.
+func transportGrade(worst ThreatLevel) string {
+	switch {
+	case worst >= Critical:
+		return "F"
+	case worst == High:
+		return "D"
+	case worst == Medium:
+		return "C"
+	case worst == Low:
+		return "B"
+	default:
+		return "A"
+	}
+}
+
+// evaluateHTTPSRedirect checks whether a scan started over HTTP ended on HTTPS. The redirect
+// chain is followed back through Request.Response to the first request of the scan.
+//
+// Returns:
+//   - TestResult: None when redirected to HTTPS, High when HTTP is served without redirect,
+//     Info when the scan started over HTTPS and the redirect could not be observed
+func evaluateHTTPSRedirect(response *http.Response) TestResult {
+	first := response.Request
+	for first != nil && first.Response != nil && first.Response.Request != nil {
+		first = first.Response.Request
+	}
+	result := TestResult{TestId: "redirect", Name: "HTTP to HTTPS Redirect", Certainty: 100}
+	switch {
+	case first == nil || response.Request == nil:
+		result.ThreatLevel = Info
+		result.Description = "Request details unavailable, redirect not checked"
+	case first.URL.Scheme == "https":
+		result.ThreatLevel = Info
+		result.Description = "Scan started over HTTPS, HTTP to HTTPS redirect not checked"
+	case response.Request.URL.Scheme == "https":
+		result.ThreatLevel = None
+		result.Description = "HTTP requests are redirected to HTTPS"
+	default:
+		result.ThreatLevel = High
+		result.Description = "HTTP requests are served without redirecting to HTTPS"
+	}
+	return result
+}
+
+// evaluateTLSVersion rates the TLS version negotiated for the response.
+//
+// Returns:
+//   - TestResult: None for TLS 1.3, Info for TLS 1.2 or when no TLS state is available,
+//     High for TLS 1.1 and older
+func evaluateTLSVersion(response *http.Response) TestResult {
+	result := TestResult{TestId: "tls_version", Name: "TLS Version", Certainty: 100}
+	if response.TLS == nil {
+		result.ThreatLevel = Info
+		result.Description = "No TLS connection state, TLS version not checked"
+		return result
+	}
+	version := tls.VersionName(response.TLS.Version)
+	result.Metadata = map[string]interface{}{"version": version}
+	switch {
+	case response.TLS.Version >= tls.VersionTLS13:
+		result.ThreatLevel = None
+		result.Description = version + " negotiated"
+	case response.TLS.Version == tls.VersionTLS12:
+		result.ThreatLevel = Info
+		result.Description = version + " negotiated, TLS 1.3 is recommended"
+	default:
+		result.ThreatLevel = High
+		result.Description = version + " negotiated, versions older than TLS 1.2 are deprecated"
+	}
+	return result
+}
+
+// generateTransportDescription summarises the grade and the sub-results needing attention.
+func generateTransportDescription(metadata map[string]interface{}, subResults map[string]TestResult) string {
+	gaps := []string{}
+	for _, name := range transportCheckOrder {
+		if result := subResults[name]; result.ThreatLevel > Info {
+			gaps = append(gaps, result.Description)
+		}
+	}
+	summary := fmt.Sprintf("Transport security grade %s (score %d/100)", metadata["grade"], metadata["score"])
+	if len(gaps) == 0 {
+		return summary + ": HTTPS, redirect, HSTS and TLS version checks passed"
+	}
+	return summary + ": " + strings.Join(gaps, "; ")
+}
@@ -0,0 +1,75 @@
+package Tests
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransportSecurityTest_GoodTLSMissingHSTS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := server.Client()
+	client.Transport.(*http.Transport).TLSClientConfig.MinVersion = tls.VersionTLS13
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	result := NewTransportSecurityTest().Run(ResponseTestParams{Response: response})
+
+	checks := result.Metadata.(map[string]interface{})["checks"].(map[string]TestResult)
+	if checks["https"].ThreatLevel != None {
+		t.Errorf("Expected https sub-result None, got %v", checks["https"].ThreatLevel)
+	}
+	if checks["tls_version"].ThreatLevel != None {
+		t.Errorf("Expected tls_version sub-result None, got %v (%s)", checks["tls_version"].ThreatLevel, checks["tls_version"].Description)
+	}
+	if checks["hsts"].ThreatLevel == None {
+		t.Errorf("Expected hsts sub-result to report the missing header")
+	}
+	if result.ThreatLevel != checks["hsts"].ThreatLevel {
+		t.Errorf("Expected composite threat %v, got %v", checks["hsts"].ThreatLevel, result.ThreatLevel)
+	}
+	if grade := result.Metadata.(map[string]interface{})["grade"]; grade == "A" {
+		t.Errorf("Expected composite grade to reflect the missing HSTS header, got %v", grade)
+	}
+	if result.Remediation == "" {
+		t.Error("Expected HSTS remediation on the composite result")
+	}
+}
+
+func TestTransportSecurityTest_Redirect(t *testing.T) {
+	tests := []struct {
+		name       string
+		first      string
+		final      string
+		wantThreat ThreatLevel
+	}{
+		{name: "Redirected to HTTPS", first: "http://example.com", final: "https://example.com", wantThreat: None},
+		{name: "Served over HTTP", first: "http://example.com", final: "http://example.com", wantThreat: High},
+		{name: "Started over HTTPS", first: "https://example.com", final: "https://example.com", wantThreat: Info},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firstRequest, _ := http.NewRequest(http.MethodGet, tt.first, nil)
+			finalRequest, _ := http.NewRequest(http.MethodGet, tt.final, nil)
+			if tt.first != tt.final {
+				finalRequest.Response = &http.Response{Request: firstRequest}
+			} else {
+				finalRequest = firstRequest
+			}
+
+			result := evaluateHTTPSRedirect(&http.Response{Request: finalRequest})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+		})
+	}
+}
@@ -10,10 +10,16 @@
 package Tests
 
 import (
+	"Engine-AntiGinx/App/CVE"
 	"Engine-AntiGinx/App/Errors"
+	"Engine-AntiGinx/App/Locale"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // ThreatLevel represents the security threat classification for test results.
@@ -57,17 +63,79 @@ const (
 //   - Detailed forensic analysis
 //
 // Fields provide multiple levels of detail:
+//   - TestId: Registry ID of the test that produced the result (set by Run)
 //   - Name: Test identifier for categorization
 //   - Certainty: Confidence percentage (0-100) in the finding
 //   - ThreatLevel: Security classification (None to Critical)
 //   - Metadata: Test-specific data (headers, configurations, CVEs, etc.)
 //   - Description: Human-readable explanation of findings
+//   - Remediation: Actionable fix for the finding, separate from Description so reporters
+//     can render it on its own (empty when nothing needs fixing or the test provides none)
+//   - References: Documentation URLs backing the remediation
+//   - CWE, OWASPCategory: Standard classification of the finding for compliance reporting
+//   - Suppressed: Set by the Runner when the finding is listed in a suppressions file
+//   - Skipped: Set when the test was not run because it does not apply to the target
+//   - Duration: Time the test took, recorded by the strategies around Run
+//   - DescriptionText, RemediationText: Message ids behind Description and Remediation, used
+//     by the Runner to render them in the language selected with --lang (see Localize)
+//   - Technologies: Software detected by the test, merged by the Runner into the target's
+//     technology stack summary
 type TestResult struct {
-	Name        string      `json:"Name"`        // Test name for identification
-	Certainty   int         `json:"Certainty"`   // Confidence percentage (0-100)
-	ThreatLevel ThreatLevel `json:"ThreatLevel"` // Security threat classification
-	Metadata    any         `json:"Metadata"`    // Test-specific detailed data
-	Description string      `json:"Description"` // Human-readable findings explanation
+	TestId          string        `json:"TestId"`                  // Registry ID of the producing test (e.g., "hsts")
+	Name            string        `json:"Name"`                    // Test name for identification
+	Certainty       int           `json:"Certainty"`               // Confidence percentage (0-100)
+	ThreatLevel     ThreatLevel   `json:"ThreatLevel"`             // Security threat classification
+	Metadata        any           `json:"Metadata"`                // Test-specific detailed data
+	Description     string        `json:"Description"`             // Human-readable findings explanation
+	Remediation     string        `json:"Remediation,omitempty"`   // Actionable fix (e.g., the exact header value to send)
+	References      []string      `json:"References,omitempty"`    // Documentation URLs backing the remediation
+	CWE             string        `json:"CWE,omitempty"`           // CWE weakness identifier (e.g., "CWE-1021")
+	OWASPCategory   string        `json:"OWASPCategory,omitempty"` // OWASP Top 10 category (e.g., "A05:2021-Security Misconfiguration")
+	Suppressed      bool          `json:"Suppressed,omitempty"`    // Finding accepted via a suppressions file
+	Skipped         bool          `json:"Skipped,omitempty"`       // Test not run because it does not apply to the target (see ResponseTest.Applicable)
+	Duration        time.Duration `json:"Duration,omitempty"`      // Time spent running the test, recorded by the strategies (nanoseconds in JSON)
+	DescriptionText *Locale.Text  `json:"-"`                       // Localizable form of Description (nil = not localized)
+	RemediationText *Locale.Text  `json:"-"`                       // Localizable form of Remediation (nil = not localized)
+	Technologies    []Technology  `json:"Technologies,omitempty"`  // Software detected by the test (e.g., Nginx 1.18.0)
+}
+
+// SetDescription stores a localizable description, rendering Description in English.
+//
+// Parameters:
+//   - text: Localizable description (see Locale.NewText)
+func (r *TestResult) SetDescription(text *Locale.Text) {
+	r.DescriptionText = text
+	r.Description = text.Render(Locale.English)
+}
+
+// SetRemediation stores a localizable remediation, rendering Remediation in English.
+//
+// Parameters:
+//   - text: Localizable remediation (see Locale.NewText)
+func (r *TestResult) SetRemediation(text *Locale.Text) {
+	r.RemediationText = text
+	r.Remediation = text.Render(Locale.English)
+}
+
+// Localize renders the localizable description and remediation in the given language.
+// Fields set as plain strings by tests without message ids are left unchanged.
+//
+// Parameters:
+//   - lang: Target language (empty keeps the English text)
+//
+// Example:
+//
+//	result.Localize(Locale.Polish)
+func (r *TestResult) Localize(lang Locale.Lang) {
+	if lang == "" {
+		return
+	}
+	if r.DescriptionText != nil {
+		r.Description = r.DescriptionText.Render(lang)
+	}
+	if r.RemediationText != nil {
+		r.Remediation = r.RemediationText.Render(lang)
+	}
 }
 
 // ResponseTestParams encapsulates the parameters passed to a ResponseTest for execution.
@@ -77,15 +145,83 @@ type TestResult struct {
 // The structure enables:
 //   - Clean test interface with extensibility
 //   - Sharing of HTTP response across multiple tests
-//   - Future addition of context or configuration parameters
+//   - Cancellation of secondary requests through the scan context
 //
 // The Response object contains:
 //   - HTTP headers (security headers, server information, etc.)
 //   - Status code
 //   - Request details (URL, method, original request)
 //   - Body content (if read by test)
+//
+// Body holds the response body already read by the engine (see strategy.NewTestParams).
+// The response stream can only be consumed once, so content tests use responseBody instead
+// of reading params.Response.Body themselves.
 type ResponseTestParams struct {
-	Response *http.Response // HTTP response to analyze for security issues
+	Response       *http.Response  // HTTP response to analyze for security issues
+	CVEClient      *CVE.CVEClient  // CVE client shared by the scan (nil creates a dedicated client)
+	DisableCVE     bool            // Skip external CVE lookups (--no-cve)
+	CVEYears       int             // Publication window of CVE lookups in years (--cve-years, 0 = every CVE)
+	CVEMinSeverity string          // Lowest CVSS v3 severity counted by CVE lookups (--cve-min-severity, empty = all)
+	Context        context.Context // Scan context, cancelled at the scan deadline (nil = never cancelled)
+	ResultCache    *ResultCache    // Cache of pure header test results (nil disables caching)
+	CustomRules    *CustomRules    // User-defined rules of the custom test (--custom-rules, nil = none)
+	IgnoredHeaders []string        // Disclosure headers serv-h-a does not count (--ignore-headers, nil = none)
+	Body           []byte          // Response body read once by the engine (nil = not buffered, read Response.Body)
+	HTTPClient     *http.Client    // Client of the scan for secondary requests (nil uses a dedicated client)
+	Offline        bool            // Response loaded from a saved file (--from-file); the target must not be contacted
+}
+
+// responseBody returns the body of the response under test. The body buffered by the engine
+// is preferred; without it (tests run directly) the response stream is read instead.
+//
+// Returns:
+//   - []byte: Body content (empty if the response has no body)
+//   - error: Error reading the response stream
+func (p ResponseTestParams) responseBody() ([]byte, error) {
+	if p.Body != nil {
+		return p.Body, nil
+	}
+	if p.Response == nil || p.Response.Body == nil {
+		return []byte{}, nil
+	}
+	return io.ReadAll(p.Response.Body)
+}
+
+// scanContext returns the context secondary requests of a test should be bound to, so
+// they are abandoned once the scan deadline (--deadline) passes.
+func (p ResponseTestParams) scanContext() context.Context {
+	if p.Context == nil {
+		return context.Background()
+	}
+	return p.Context
+}
+
+// cveOptions returns the options of the CVE assessments of a test: bound to the scan
+// context and restricted to the publication window (CVEYears) and minimum severity
+// (CVEMinSeverity) of the scan.
+func (p ResponseTestParams) cveOptions() []CVE.AssessmentOption {
+	opts := []CVE.AssessmentOption{CVE.WithContext(p.scanContext())}
+	if p.CVEYears > 0 {
+		opts = append(opts, CVE.WithRecentYears(p.CVEYears))
+	}
+	if p.CVEMinSeverity != "" {
+		opts = append(opts, CVE.WithMinimumSeverity(p.CVEMinSeverity))
+	}
+	return opts
+}
+
+// secondaryRequestTimeout bounds the secondary requests of the dedicated client used when
+// no HTTPClient is shared by the scan.
+const secondaryRequestTimeout = 10 * time.Second
+
+// httpClient returns the client secondary requests of a test should be sent with: the
+// client shared by the scan (same transport, TLS and connection pool settings as the
+// target request), or a dedicated client when tests run directly.
+func (p ResponseTestParams) httpClient() *http.Client {
+	if p.HTTPClient == nil {
+		return &http.Client{Timeout: secondaryRequestTimeout}
+	}
+	return p.HTTPClient
 }
 
 // ResponseTest defines a security test that analyzes an HTTP response for vulnerabilities,
@@ -104,17 +240,53 @@ type ResponseTestParams struct {
 //   - Include detailed Metadata for findings
 //   - Provide actionable Description for remediation
 //
+// Contract for tests registered from outside the engine (Registry.Register):
+//   - Id is non-empty, unique across the registry and is the value users pass to --tests
+//   - RunTest is non-nil, safe for concurrent calls and must not close or replace
+//     params.Response; the body may already have been read, use params.Body (see
+//     responseBody) rather than the response stream
+//   - RunTest returns a TestResult with Name, ThreatLevel and Description set; Run fills in
+//     TestId, CWE and OWASPCategory
+//   - Secondary requests are sent with params.HTTPClient (see httpClient) and bound to
+//     params.Context (see scanContext) so they stop at the scan deadline, and external
+//     lookups honour params.DisableCVE and the CVE options of the scan (see cveOptions)
+//   - CacheHeaders stays nil unless the result depends on nothing but those headers
+//
 // Fields:
 //   - Id: Unique identifier for test registration and selection (e.g., "https", "hsts")
 //   - Name: Human-readable test name for display
 //   - Description: Detailed explanation of what the test checks
+//   - CWE, OWASPCategory: Standard classification copied into every result by Run
+//   - CacheHeaders: Response headers a pure header test reads exclusively; set only when the
+//     result depends on nothing else, so Run may reuse it from a ResultCache
+//   - RequiresHTTPS: The test analyses HTTPS-only behaviour (TLS, HSTS, key pinning); the
+//     strategies skip it with an informational note when the target is served over plain
+//     HTTP without redirecting to HTTPS (see Applicable)
+//   - RequiresNetwork: The test works by sending requests to the target (TRACE, probes,
+//     well-known paths); the strategies skip it when a saved response is analysed offline
+//     (--from-file). Tests only partly relying on requests check params.Offline instead
+//   - Rubric: Conditions under which the test reports each threat level, listed by the
+//     "list" command for backends presenting the tests (nil = not documented yet)
 //   - RunTest: Function that executes the test logic
 type ResponseTest struct {
-	Id          string                                     // Unique test identifier (e.g., "https", "hsts", "csp")
-	Name        string                                     // Human-readable test name
-	Description string                                     // Detailed test description
-	Category	string                                     // Test category for organizational purposes (e.g., "Headers", "TLS", "CSP")
-	RunTest     func(params ResponseTestParams) TestResult // Test execution function
+	Id              string                                     // Unique test identifier (e.g., "https", "hsts", "csp")
+	Name            string                                     // Human-readable test name
+	Description     string                                     // Detailed test description
+	Category        string                                     // Test category for organizational purposes (e.g., "Headers", "TLS", "CSP")
+	CWE             string                                     // CWE weakness reported by the test's findings (e.g., "CWE-1021")
+	OWASPCategory   string                                     // OWASP Top 10 category of the findings (e.g., "A05:2021-Security Misconfiguration")
+	CacheHeaders    []string                                   // Headers a pure header test depends on (nil = never cached)
+	RequiresHTTPS   bool                                       // Test only applies to responses served over HTTPS
+	RequiresNetwork bool                                       // Test sends requests to the target, not run offline
+	Rubric          []RubricEntry                              // Conditions for each threat level the test reports
+	RunTest         func(params ResponseTestParams) TestResult // Test execution function
+}
+
+// RubricEntry describes when a test reports a threat level. A test lists several entries
+// for the same level when different conditions lead to it.
+type RubricEntry struct {
+	ThreatLevel ThreatLevel `json:"ThreatLevel"` // Reported threat level
+	Condition   string      `json:"Condition"`   // Human-readable condition leading to it
 }
 
 // GetId returns the unique identifier of the test used for registration and lookup.
@@ -138,7 +310,6 @@ func (brt *ResponseTest) GetName() string { return brt.Name }
 //   - string: The test's detailed description
 func (brt *ResponseTest) GetDescription() string { return brt.Description }
 
-
 // GetCategory returns the category of the test for organizational purposes.
 // This method provides read-only access to the test's category.
 //
@@ -146,11 +317,67 @@ func (brt *ResponseTest) GetDescription() string { return brt.Description }
 //   - string: The test's category (e.g., "Headers", "TLS", "CSP")
 func (brt *ResponseTest) GetCategory() string { return brt.Category }
 
+// Applicable reports whether the test applies to the response of the target. A test
+// declaring RequiresNetwork does not apply to a saved response analysed offline, as its
+// requests would reach the live target. A test declaring RequiresHTTPS does not apply to
+// a response served over plain HTTP (the final response after redirects, so a target
+// redirecting to HTTPS is tested); its verdict would describe the missing transport
+// security the https test already reports.
+//
+// Parameters:
+//   - params: Parameters of the test; its response may be nil or lack a request (= applicable)
+//
+// Returns:
+//   - bool: true if the test should run
+//   - string: Why the test does not apply, empty when it does
+func (rt *ResponseTest) Applicable(params ResponseTestParams) (bool, string) {
+	if rt.RequiresNetwork && params.Offline {
+		return false, "The response was loaded from a file (--from-file), " +
+			"so this test, which sends requests to the target, was skipped"
+	}
+	response := params.Response
+	if !rt.RequiresHTTPS || response == nil || response.TLS != nil {
+		return true, ""
+	}
+	if response.Request == nil || response.Request.URL == nil || response.Request.URL.Scheme == "https" {
+		return true, ""
+	}
+	return false, "The target is served over plain HTTP and does not redirect to HTTPS, " +
+		"so this HTTPS-only test was skipped; see the https test for the transport security finding"
+}
+
+// SkippedResult builds the informational note published instead of running a test that
+// does not apply to the target.
+//
+// Parameters:
+//   - reason: Why the test does not apply (see Applicable)
+//
+// Returns:
+//   - TestResult: Info result marked Skipped, stamped like a result of Run
+func (rt *ResponseTest) SkippedResult(reason string) TestResult {
+	return TestResult{
+		TestId:        rt.Id,
+		Name:          rt.Name,
+		Certainty:     100,
+		ThreatLevel:   Info,
+		Metadata:      map[string]any{"skipped": true, "reason": reason},
+		Description:   reason,
+		CWE:           rt.CWE,
+		OWASPCategory: rt.OWASPCategory,
+		Skipped:       true,
+	}
+}
+
 // Run executes the test logic against the provided HTTP response parameters and returns
 // the security analysis results. This is the main entry point for test execution.
 //
 // The method validates that RunTest is implemented before execution and panics if not,
-// ensuring tests are properly configured before use.
+// ensuring tests are properly configured before use. The returned result is stamped
+// with the test's Id so reporters can identify which test produced it, and with the
+// test's CWE and OWASPCategory unless RunTest classified the finding itself.
+//
+// Tests declaring CacheHeaders reuse the result stored in params.ResultCache for a prior
+// response with identical values of those headers instead of running again.
 //
 // Parameters:
 //   - params: ResponseTestParams containing the HTTP response to analyze
@@ -171,7 +398,25 @@ func (rt *ResponseTest) Run(params ResponseTestParams) TestResult {
 	if rt.RunTest == nil {
 		panic("Run method not implemented")
 	}
-	return rt.RunTest(params)
+	var cacheKey string
+	if params.ResultCache != nil && rt.CacheHeaders != nil && params.Response != nil {
+		cacheKey = fingerprint(rt.Id, rt.CacheHeaders, params.Response.Header)
+		if result, ok := params.ResultCache.lookup(cacheKey); ok {
+			return result
+		}
+	}
+	result := rt.RunTest(params)
+	result.TestId = rt.Id
+	if result.CWE == "" {
+		result.CWE = rt.CWE
+	}
+	if result.OWASPCategory == "" {
+		result.OWASPCategory = rt.OWASPCategory
+	}
+	if cacheKey != "" {
+		params.ResultCache.store(cacheKey, result)
+	}
+	return result
 }
 
 // String converts a ThreatLevel value to its human-readable string representation.
@@ -186,12 +431,13 @@ func (rt *ResponseTest) Run(params ResponseTestParams) TestResult {
 //   - High (4) → "High"
 //   - Critical (5) → "Critical"
 //
+// Values outside the known range are rendered as "Unknown(<value>)" instead of
+// panicking, since String is called during JSON marshaling and a corrupted or
+// future value must not crash the reporter mid-scan.
+//
 // Returns:
 //   - string: Human-readable threat level name
 //
-// Panics:
-//   - Errors.Error: If the ThreatLevel value is invalid/unknown
-//
 // Example:
 //
 //	level := High
@@ -212,9 +458,7 @@ func (t ThreatLevel) String() string {
 	case Critical:
 		return "Critical"
 	default:
-		panic(Errors.Error{
-			Message: fmt.Sprintf("Unknown Threat Level %d", t),
-		})
+		return fmt.Sprintf("Unknown(%d)", int(t))
 	}
 }
 
@@ -246,3 +490,84 @@ func (t ThreatLevel) String() string {
 func (t ThreatLevel) MarshalJSON() ([]byte, error) {
 	return json.Marshal(t.String())
 }
+
+// UnmarshalJSON implements custom JSON unmarshaling for ThreatLevel, accepting the
+// string representation produced by MarshalJSON ("None", "Info", ..., "Critical").
+// Numeric values are also accepted for compatibility with payloads produced before
+// string serialization was introduced.
+//
+// Parameters:
+//   - data: JSON-encoded threat level (string or number)
+//
+// Returns:
+//   - error: Error if the value is not a known threat level
+//
+// Example:
+//
+//	var level ThreatLevel
+//	_ = json.Unmarshal([]byte(`"High"`), &level)
+//	// level == High
+func (t *ThreatLevel) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var value int
+		if numErr := json.Unmarshal(data, &value); numErr != nil {
+			return err
+		}
+		name = fmt.Sprint(value)
+	}
+	level, err := ParseThreatLevel(name)
+	if err != nil {
+		return err
+	}
+	*t = level
+	return nil
+}
+
+// ParseThreatLevel converts a threat level name back to the ThreatLevel enumeration.
+// Matching is case-insensitive and ignores surrounding whitespace, so values coming
+// from configuration files and command-line flags can be used directly. The numeric
+// form ("0" to "5") is accepted as well.
+//
+// Parameters:
+//   - s: Threat level name (e.g., "high", "Critical") or numeric value
+//
+// Returns:
+//   - ThreatLevel: Parsed threat level
+//   - error: *Errors.Error with code 400 if the value is not a known threat level
+//
+// Example:
+//
+//	level, err := ParseThreatLevel("medium")
+//	// level == Medium, err == nil
+func ParseThreatLevel(s string) (ThreatLevel, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	for level := None; level <= Critical; level++ {
+		if normalized == strings.ToLower(level.String()) || normalized == fmt.Sprint(int(level)) {
+			return level, nil
+		}
+	}
+	return None, &Errors.Error{
+		Code:        400,
+		Message:     fmt.Sprintf("Unknown Threat Level %q", s),
+		Source:      "Tests",
+		IsRetryable: false,
+	}
+}
+
+// AtLeast reports whether the threat level is equal to or more severe than other.
+// It is the comparison used by severity threshold filtering.
+//
+// Parameters:
+//   - other: Threshold to compare against
+//
+// Returns:
+//   - bool: true if t >= other
+//
+// Example:
+//
+//	High.AtLeast(Medium)   // true
+//	Low.AtLeast(Medium)    // false
+func (t ThreatLevel) AtLeast(other ThreatLevel) bool {
+	return t >= other
+}
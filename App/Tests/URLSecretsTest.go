@@ -0,0 +1,229 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the URL secrets test that looks for secrets (tokens, API keys,
+// passwords, session IDs) carried in the query strings of redirects and page links.
+package Tests
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// urlSecretsReferences documents the exposure of sensitive data in URLs.
+var urlSecretsReferences = []string{
+	"https://owasp.org/www-community/vulnerabilities/Information_exposure_through_query_strings_in_url",
+	"https://cwe.mitre.org/data/definitions/598.html",
+	"https://cheatsheetseries.owasp.org/cheatsheets/Session_Management_Cheat_Sheet.html#session-id-exposure",
+}
+
+// urlSecretParams are query parameter names (lower-cased, "-" folded to "_") that carry
+// secrets. Signatures of pre-signed URLs are left out: they are meant to be shared.
+var urlSecretParams = map[string]bool{
+	"token": true, "access_token": true, "id_token": true, "refresh_token": true, "auth_token": true,
+	"auth": true, "authorization": true, "bearer": true, "jwt": true,
+	"api_key": true, "apikey": true, "app_key": true, "private_key": true,
+	"secret": true, "client_secret": true,
+	"password": true, "passwd": true, "pwd": true, "pass": true,
+	"session": true, "sessionid": true, "session_id": true, "sid": true,
+	"jsessionid": true, "phpsessid": true, "aspsessionid": true,
+}
+
+// urlSecretMinLength is the shortest value considered a secret, skipping flags and
+// placeholders such as "?token=1".
+const urlSecretMinLength = 4
+
+// urlSecretsLinkAttrs are the URL attributes of the tags whose links are checked.
+var urlSecretsLinkAttrs = map[string]string{
+	"a":      "href",
+	"area":   "href",
+	"link":   "href",
+	"form":   "action",
+	"iframe": "src",
+	"script": "src",
+	"img":    "src",
+}
+
+// URLSecretFinding is a URL carrying secrets in its query string.
+type URLSecretFinding struct {
+	Source     string   `json:"source"`     // "redirect" (redirect chain) or "link" (response body)
+	URL        string   `json:"url"`        // URL without query string and fragment
+	Parameters []string `json:"parameters"` // Names of the parameters carrying secrets
+}
+
+// URLSecretsAnalysis holds the URLs found carrying secrets.
+type URLSecretsAnalysis struct {
+	URLsChecked int                `json:"urls_checked"` // Redirect targets and body links checked
+	Findings    []URLSecretFinding `json:"findings"`     // URLs carrying secrets, in document order
+	Parameters  []string           `json:"parameters"`   // Distinct names of the secret parameters, sorted
+	Error       string             `json:"error,omitempty"`
+}
+
+// NewURLSecretsTest creates a new ResponseTest that looks for secrets in URL query strings.
+// It checks the URLs the scanned URL redirected through (and the final URL) and the links of
+// the response body (a, area, link, form, iframe, script and img tags) for parameters such
+// as token, api_key, password or session IDs. Query strings end up in server and proxy logs,
+// browser history and the Referer header sent to other sites, leaking the secret.
+//
+// Only the names of the parameters are recorded, never their values. The scanned URL itself
+// is not checked, as it is chosen by the user.
+//
+// Threat level assessment:
+//   - None (0): No URL carries a secret in its query string
+//   - Info (1): The response body could not be read and no redirect carried a secret
+//   - Medium (3): A redirect or a link carries a secret in its query string
+//
+// Returns:
+//   - *ResponseTest: Configured URL secrets test ready for execution
+//
+// Example usage:
+//
+//	urlSecretsTest := NewURLSecretsTest()
+//	result := urlSecretsTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata.(URLSecretsAnalysis).Parameters lists the secret parameter names
+func NewURLSecretsTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "url-secrets",
+		Name:          "Sensitive Data in URL Query",
+		Description:   "Looks for tokens, API keys, passwords and session IDs in the query strings of redirects and page links",
+		Category:      "App-Configuration",
+		CWE:           "CWE-598",
+		OWASPCategory: "A04:2021-Insecure Design",
+		RunTest: func(params ResponseTestParams) TestResult {
+			analysis := analyzeURLSecrets(params)
+			threatLevel := evaluateURLSecretsThreatLevel(analysis)
+
+			result := TestResult{
+				Name:        "Sensitive Data in URL Query",
+				Certainty:   80,
+				ThreatLevel: threatLevel,
+				Metadata:    analysis,
+				Description: generateURLSecretsDescription(analysis),
+			}
+			if len(analysis.Findings) > 0 {
+				result.Remediation = "Send secrets in headers (Authorization) or POST bodies instead of query strings, " +
+					"and keep session IDs in cookies"
+				result.References = urlSecretsReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeURLSecrets checks the redirect chain and the body links of the response.
+func analyzeURLSecrets(params ResponseTestParams) URLSecretsAnalysis {
+	analysis := URLSecretsAnalysis{Findings: []URLSecretFinding{}, Parameters: []string{}}
+	if params.Response == nil {
+		analysis.Error = "no response"
+		return analysis
+	}
+
+	var page *url.URL
+	if params.Response.Request != nil {
+		page = params.Response.Request.URL
+	}
+	// The first hop of the chain is the scanned URL, chosen by the user.
+	for _, hop := range redirectChain(params.Response)[1:] {
+		if hop.Request != nil {
+			analysis.check("redirect", hop.Request.URL)
+		}
+	}
+
+	body, err := params.responseBody()
+	if err != nil {
+		analysis.Error = err.Error()
+	} else {
+		for _, tag := range FindHTMLTags(body, "a", "area", "link", "form", "iframe", "script", "img") {
+			raw, ok := tag.Attr(urlSecretsLinkAttrs[tag.Name])
+			if !ok {
+				continue
+			}
+			link, err := url.Parse(strings.TrimSpace(raw))
+			if err != nil {
+				continue
+			}
+			if page != nil {
+				link = page.ResolveReference(link)
+			}
+			analysis.check("link", link)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, finding := range analysis.Findings {
+		for _, name := range finding.Parameters {
+			if !seen[name] {
+				seen[name] = true
+				analysis.Parameters = append(analysis.Parameters, name)
+			}
+		}
+	}
+	sort.Strings(analysis.Parameters)
+	return analysis
+}
+
+// check records the URL when its query string (or a ;jsessionid= path parameter)
+// carries secrets.
+func (a *URLSecretsAnalysis) check(source string, target *url.URL) {
+	if target == nil || (target.Scheme != "" && target.Scheme != "http" && target.Scheme != "https") {
+		return
+	}
+	a.URLsChecked++
+
+	var names []string
+	seen := make(map[string]bool)
+	for name, values := range target.Query() {
+		normalized := strings.ReplaceAll(strings.ToLower(name), "-", "_")
+		if !urlSecretParams[normalized] || seen[name] {
+			continue
+		}
+		for _, value := range values {
+			if len(value) >= urlSecretMinLength {
+				seen[name] = true
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	if index := strings.Index(strings.ToLower(target.Path), ";jsessionid="); index >= 0 &&
+		len(target.Path)-index-len(";jsessionid=") >= urlSecretMinLength {
+		names = append(names, "jsessionid")
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	location := *target
+	location.RawQuery = ""
+	location.Fragment = ""
+	if index := strings.Index(strings.ToLower(location.Path), ";jsessionid="); index >= 0 {
+		location.Path = location.Path[:index]
+		location.RawPath = ""
+	}
+	a.Findings = append(a.Findings, URLSecretFinding{Source: source, URL: location.String(), Parameters: names})
+}
+
+// evaluateURLSecretsThreatLevel maps the URL secrets analysis to a threat level.
+func evaluateURLSecretsThreatLevel(analysis URLSecretsAnalysis) ThreatLevel {
+	switch {
+	case len(analysis.Findings) > 0:
+		return Medium
+	case analysis.Error != "":
+		return Info
+	default:
+		return None
+	}
+}
+
+// generateURLSecretsDescription builds a human-readable summary of the URL secrets analysis.
+func generateURLSecretsDescription(analysis URLSecretsAnalysis) string {
+	if len(analysis.Findings) > 0 {
+		return "Secrets are sent in URL query strings (" + strings.Join(analysis.Parameters, ", ") + ") in " +
+			strconv.Itoa(len(analysis.Findings)) + " URL(s)" + "; they leak through server logs, browser history and the Referer header"
+	}
+	if analysis.Error != "" {
+		return "Could not read the response body (" + analysis.Error + "), only the redirect chain was checked"
+	}
+	return "No secrets found in the query strings of " + strconv.Itoa(analysis.URLsChecked) + " URL(s)"
+}
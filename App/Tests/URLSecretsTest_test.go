@@ -0,0 +1,89 @@
+package Tests
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// readURLSecretsFixture reads a fixture of testdata/url-secrets.
+func readURLSecretsFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "url-secrets", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	return body
+}
+
+func TestURLSecretsTest_Links(t *testing.T) {
+	tests := []struct {
+		name           string
+		fixture        string
+		wantThreat     ThreatLevel
+		wantParameters []string
+		wantURLs       []string
+	}{
+		{
+			name:           "Token in links",
+			fixture:        "token-link.html",
+			wantThreat:     Medium,
+			wantParameters: []string{"API-Key", "jsessionid", "password", "token"},
+			wantURLs: []string{
+				"https://maps.example.com/api.js",
+				"https://www.example.com/account/reset",
+				"https://partner.example/landing",
+				"https://www.example.com/login",
+			},
+		},
+		{name: "Clean links", fixture: "clean.html", wantThreat: None, wantParameters: []string{}, wantURLs: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, _ := url.Parse("https://www.example.com/home")
+			response := &http.Response{Header: http.Header{}, Request: &http.Request{URL: page}}
+
+			result := NewURLSecretsTest().Run(ResponseTestParams{Response: response, Body: readURLSecretsFixture(t, tt.fixture)})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			analysis := result.Metadata.(URLSecretsAnalysis)
+			if !reflect.DeepEqual(analysis.Parameters, tt.wantParameters) {
+				t.Errorf("Expected parameters %v, got %v", tt.wantParameters, analysis.Parameters)
+			}
+			urls := []string{}
+			for _, finding := range analysis.Findings {
+				if finding.Source != "link" {
+					t.Errorf("Expected link findings, got %+v", finding)
+				}
+				urls = append(urls, finding.URL)
+			}
+			if !reflect.DeepEqual(urls, tt.wantURLs) {
+				t.Errorf("Expected URLs %v, got %v", tt.wantURLs, urls)
+			}
+		})
+	}
+}
+
+func TestURLSecretsTest_Redirect(t *testing.T) {
+	scanned, _ := url.Parse("https://www.example.com/login?token=user-chosen-token")
+	final, _ := url.Parse("https://www.example.com/dashboard?access_token=eyJhbGciOiJIUzI1NiJ9")
+	original := &http.Request{URL: scanned}
+	redirected := &http.Request{URL: final, Response: &http.Response{StatusCode: http.StatusFound, Request: original}}
+	response := &http.Response{Header: http.Header{}, Request: redirected}
+
+	result := NewURLSecretsTest().Run(ResponseTestParams{Response: response, Body: []byte("<html></html>")})
+
+	if result.ThreatLevel != Medium {
+		t.Errorf("Expected Medium, got %v (%s)", result.ThreatLevel, result.Description)
+	}
+	analysis := result.Metadata.(URLSecretsAnalysis)
+	want := []URLSecretFinding{{Source: "redirect", URL: "https://www.example.com/dashboard", Parameters: []string{"access_token"}}}
+	if !reflect.DeepEqual(analysis.Findings, want) {
+		t.Errorf("Expected only the redirect target to be reported, got %+v", analysis.Findings)
+	}
+}
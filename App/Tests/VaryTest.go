@@ -0,0 +1,192 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the Vary header test that checks whether content negotiated on the
+// request encoding or cookies is correctly keyed for caches.
+package Tests
+
+import (
+	"net/http"
+	"strings"
+)
+
+// varyReferences documents how caches use the Vary header.
+var varyReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Vary",
+	"https://www.rfc-editor.org/rfc/rfc9110#name-vary",
+}
+
+// NewVaryTest creates a new ResponseTest that checks the Vary header against the content
+// negotiation a response depends on. Caches key stored responses on the URL and the request
+// headers named in Vary; a response that depends on other request headers may be served to
+// clients it was not produced for, while "Vary: *" prevents caching altogether.
+//
+// The test evaluates:
+//   - Compressed responses (Content-Encoding) that omit "Vary: Accept-Encoding"
+//   - Cookie-dependent responses (Cookie sent, or Set-Cookie received) storable by shared
+//     caches that omit "Vary: Cookie"
+//   - "Vary: *", which disables caching entirely (a performance anti-pattern)
+//
+// Threat level assessment:
+//   - None (0): Vary covers the negotiation the response depends on
+//   - Info (1): "Vary: *", or a compressed response without "Vary: Accept-Encoding"
+//   - Low (2): Cookie-dependent response cacheable by intermediaries without "Vary: Cookie"
+//
+// Returns:
+//   - *ResponseTest: Configured Vary test ready for execution
+//
+// Example usage:
+//
+//	varyTest := NewVaryTest()
+//	result := varyTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata lists the Vary tokens and the detected negotiation
+func NewVaryTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "vary",
+		Name:          "Vary Header Analysis",
+		Description:   "Checks that responses negotiated on Accept-Encoding or cookies send a matching Vary header and do not send Vary: *",
+		Category:      "Headers",
+		CWE:           "CWE-524",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeVary(params.Response)
+			threatLevel := evaluateVaryThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "Vary Header Analysis",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateVaryDescription(metadata),
+			}
+			if remediation := generateVaryRemediation(metadata); remediation != "" {
+				result.Remediation = remediation
+				result.References = varyReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeVary collects the Vary tokens of a response and the negotiation it depends on.
+//
+// Parameters:
+//   - response: HTTP response to analyze (and the request that produced it)
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "vary_tokens" ([]string): Header names listed in Vary, canonicalized ("*" kept as is)
+//   - "wildcard" (bool): Vary contains "*"
+//   - "compressed" (bool): Response body was content-encoded
+//   - "cookie_sources" ([]string): Headers making the response cookie-dependent ("Cookie", "Set-Cookie")
+//   - "shared_cacheable" (bool): Response may be stored by intermediaries (no "no-store" or "private")
+//   - "missing_accept_encoding" (bool): Compressed response without "Vary: Accept-Encoding"
+//   - "missing_cookie" (bool): Shared-cacheable cookie-dependent response without "Vary: Cookie"
+//
+// Example:
+//
+//	metadata := analyzeVary(gzipResponse)
+//	// metadata["vary_tokens"] == []string{"Accept-Encoding"}
+func analyzeVary(response *http.Response) map[string]interface{} {
+	tokens := []string{}
+	varies := map[string]bool{}
+	for _, value := range HeaderValues(response.Header, "Vary") {
+		for _, token := range strings.Split(value, ",") {
+			token = strings.TrimSpace(token)
+			if token == "" {
+				continue
+			}
+			if token != "*" {
+				token = http.CanonicalHeaderKey(token)
+			}
+			if !varies[token] {
+				varies[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	// The transport removes Content-Encoding when it decompresses transparently
+	compressed := response.Uncompressed || HeaderValue(response.Header, "Content-Encoding") != ""
+
+	cookieSources := []string{}
+	if response.Request != nil && response.Request.Header.Get("Cookie") != "" {
+		cookieSources = append(cookieSources, "Cookie")
+	}
+	if len(HeaderValues(response.Header, "Set-Cookie")) > 0 {
+		cookieSources = append(cookieSources, "Set-Cookie")
+	}
+
+	directives := parseCacheControl(strings.Join(HeaderValues(response.Header, "Cache-Control"), ", "))
+	_, noStore := directives["no-store"]
+	_, private := directives["private"]
+	sharedCacheable := !noStore && !private
+
+	wildcard := varies["*"]
+	return map[string]interface{}{
+		"vary_tokens":             tokens,
+		"wildcard":                wildcard,
+		"compressed":              compressed,
+		"cookie_sources":          cookieSources,
+		"shared_cacheable":        sharedCacheable,
+		"missing_accept_encoding": compressed && !wildcard && !varies["Accept-Encoding"],
+		"missing_cookie":          len(cookieSources) > 0 && sharedCacheable && !wildcard && !varies["Cookie"],
+	}
+}
+
+// evaluateVaryThreatLevel maps the Vary analysis to a threat level.
+func evaluateVaryThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch {
+	case metadata["missing_cookie"].(bool):
+		return Low
+	case metadata["wildcard"].(bool), metadata["missing_accept_encoding"].(bool):
+		return Info
+	default:
+		return None
+	}
+}
+
+// generateVaryDescription builds a human-readable summary of the Vary analysis.
+func generateVaryDescription(metadata map[string]interface{}) string {
+	issues := []string{}
+	if metadata["missing_cookie"].(bool) {
+		issues = append(issues, "cookie-dependent response may be stored by shared caches without Vary: Cookie, "+
+			"so personalised content can be served to other users")
+	}
+	if metadata["missing_accept_encoding"].(bool) {
+		issues = append(issues, "compressed response without Vary: Accept-Encoding may be served to clients that cannot decode it")
+	}
+	if metadata["wildcard"].(bool) {
+		issues = append(issues, "Vary: * prevents caches from reusing the response")
+	}
+	if len(issues) > 0 {
+		return "Vary header issues: " + strings.Join(issues, "; ")
+	}
+	tokens := metadata["vary_tokens"].([]string)
+	if len(tokens) == 0 {
+		return "Response does not depend on negotiated request headers, no Vary header needed"
+	}
+	return "Vary header covers the content negotiation of the response (" + strings.Join(tokens, ", ") + ")"
+}
+
+// generateVaryRemediation builds the Vary value to send, or an empty string when no change is needed.
+func generateVaryRemediation(metadata map[string]interface{}) string {
+	if metadata["wildcard"].(bool) {
+		return "Replace Vary: * with the request headers the response actually depends on (e.g., Vary: Accept-Encoding), " +
+			"or use Cache-Control: no-store if the response must never be cached"
+	}
+	missing := []string{}
+	if metadata["missing_accept_encoding"].(bool) {
+		missing = append(missing, "Accept-Encoding")
+	}
+	if metadata["missing_cookie"].(bool) {
+		missing = append(missing, "Cookie")
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	tokens := append(append([]string{}, metadata["vary_tokens"].([]string)...), missing...)
+	remediation := "Send Vary: " + strings.Join(tokens, ", ")
+	if metadata["missing_cookie"].(bool) {
+		remediation += ", or mark personalised responses Cache-Control: private"
+	}
+	return remediation
+}
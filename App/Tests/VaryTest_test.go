@@ -0,0 +1,62 @@
+package Tests
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestVaryTest(t *testing.T) {
+	tests := []struct {
+		name            string
+		headers         map[string]string
+		requestCookie   bool
+		uncompressed    bool
+		wantThreat      ThreatLevel
+		wantTokens      []string
+		wantRemediation bool
+	}{
+		{name: "No negotiation", headers: map[string]string{"Cache-Control": "max-age=600"}, wantThreat: None, wantTokens: []string{}},
+		{name: "Compressed with Vary", headers: map[string]string{"Content-Encoding": "gzip", "Vary": "accept-encoding"},
+			wantThreat: None, wantTokens: []string{"Accept-Encoding"}},
+		{name: "Compressed without Vary", headers: map[string]string{"Content-Encoding": "br"},
+			wantThreat: Info, wantTokens: []string{}, wantRemediation: true},
+		{name: "Transparently decompressed without Vary", uncompressed: true,
+			wantThreat: Info, wantTokens: []string{}, wantRemediation: true},
+		{name: "Wildcard", headers: map[string]string{"Vary": "*"},
+			wantThreat: Info, wantTokens: []string{"*"}, wantRemediation: true},
+		{name: "Set-Cookie without Vary", headers: map[string]string{"Set-Cookie": "session=abc", "Vary": "Accept-Encoding"},
+			wantThreat: Low, wantTokens: []string{"Accept-Encoding"}, wantRemediation: true},
+		{name: "Request cookie without Vary", requestCookie: true, headers: map[string]string{"Cache-Control": "public, max-age=60"},
+			wantThreat: Low, wantTokens: []string{}, wantRemediation: true},
+		{name: "Cookie-dependent with Vary", headers: map[string]string{"Set-Cookie": "session=abc", "Vary": "Accept-Encoding, Cookie"},
+			wantThreat: None, wantTokens: []string{"Accept-Encoding", "Cookie"}},
+		{name: "Cookie-dependent private response", requestCookie: true, headers: map[string]string{"Cache-Control": "private"},
+			wantThreat: None, wantTokens: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+			if tt.requestCookie {
+				request.Header.Set("Cookie", "session=abc")
+			}
+			response := &http.Response{Header: http.Header{}, Request: request, Uncompressed: tt.uncompressed}
+			for name, value := range tt.headers {
+				response.Header.Set(name, value)
+			}
+
+			result := NewVaryTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			tokens := result.Metadata.(map[string]interface{})["vary_tokens"]
+			if !reflect.DeepEqual(tokens, tt.wantTokens) {
+				t.Errorf("Expected Vary tokens %v, got %v", tt.wantTokens, tokens)
+			}
+			if (result.Remediation != "") != tt.wantRemediation {
+				t.Errorf("Expected remediation %v, got %q", tt.wantRemediation, result.Remediation)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the .well-known test that probes common well-known URIs (RFC 8615)
+// for an exposed OpenID Connect discovery document, an advertised change-password URL and
+// Android asset links.
+package Tests
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// wellKnownReferences documents the probed well-known URIs.
+var wellKnownReferences = []string{
+	"https://w3c.github.io/webappsec-change-password-url/",
+	"https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfig",
+	"https://developers.google.com/digital-asset-links/v1/getting-started",
+}
+
+// Well-known paths probed by the test.
+const (
+	wellKnownChangePassword = "/.well-known/change-password"
+	wellKnownOpenID         = "/.well-known/openid-configuration"
+	wellKnownAssetLinks     = "/.well-known/assetlinks.json"
+)
+
+// wellKnownMaxBody caps how much of a well-known document is read.
+const wellKnownMaxBody = 256 << 10
+
+// openIDEndpointFields are the discovery document fields holding endpoint URLs.
+var openIDEndpointFields = []string{
+	"authorization_endpoint", "token_endpoint", "userinfo_endpoint", "jwks_uri",
+	"registration_endpoint", "end_session_endpoint", "introspection_endpoint", "revocation_endpoint",
+}
+
+// WellKnownEndpoint is the outcome of probing one well-known path.
+type WellKnownEndpoint struct {
+	Path       string `json:"path"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Location   string `json:"location,omitempty"` // Redirect target of a 3xx answer
+	Responded  bool   `json:"responded"`          // Answered with content (2xx) or a redirect (3xx)
+	Error      string `json:"error,omitempty"`    // Request error, the path is then not responded
+}
+
+// WellKnownAnalysis holds the results of the .well-known probes.
+type WellKnownAnalysis struct {
+	Endpoints                []WellKnownEndpoint `json:"endpoints"`
+	Responded                []string            `json:"responded"`                     // Probed paths that responded
+	CatchAll                 bool                `json:"catch_all"`                     // A made-up well-known path responded too
+	ChangePasswordAdvertised bool                `json:"change_password_advertised"`    // change-password responds and the site is no catch-all
+	OpenIDExposed            bool                `json:"openid_exposed"`                // A discovery document with an issuer is served
+	OpenIDIssuer             string              `json:"openid_issuer,omitempty"`       // Issuer of the discovery document
+	OpenIDEndpoints          map[string]string   `json:"openid_endpoints,omitempty"`    // Endpoint URLs of the discovery document
+	OpenIDIssues             []string            `json:"openid_issues"`                 // Details the discovery document leaks
+	AssetLinksPackages       []string            `json:"assetlinks_packages,omitempty"` // Android packages of assetlinks.json
+	Error                    string              `json:"error,omitempty"`
+}
+
+// NewWellKnownTest creates a new ResponseTest probing common .well-known endpoints of the
+// target's origin:
+//   - /.well-known/change-password: Lets password managers send users straight to the
+//     password change page; advertising it is good practice
+//   - /.well-known/openid-configuration: OpenID Connect discovery document. Serving it is
+//     expected for identity providers, but it can leak internal hostnames, plain HTTP
+//     endpoints or an open dynamic client registration endpoint
+//   - /.well-known/assetlinks.json: Android app links, listing the associated app packages
+//
+// A made-up well-known path is requested as well: when it also responds, the site answers
+// every path (e.g., a single page application) and change-password is not trusted.
+// Redirects are not followed, as change-password is expected to redirect.
+//
+// Threat level assessment:
+//   - None (0): change-password is advertised and no discovery document is exposed
+//   - Info (1): change-password is not advertised, or a discovery document is exposed
+//   - Low (2): The discovery document leaks internal hosts, plain HTTP endpoints or an
+//     open registration endpoint
+//
+// Returns:
+//   - *ResponseTest: Configured .well-known test ready for execution
+//
+// Example usage:
+//
+//	wellKnownTest := NewWellKnownTest()
+//	result := wellKnownTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata.(WellKnownAnalysis).Responded lists the endpoints that responded
+func NewWellKnownTest() *ResponseTest {
+	return &ResponseTest{
+		Id:              "well-known",
+		Name:            ".well-known Endpoint Analysis",
+		Description:     "Probes .well-known endpoints for an exposed OpenID discovery document, a change-password URL and Android asset links",
+		Category:        "App-Configuration",
+		CWE:             "CWE-200",
+		OWASPCategory:   "A05:2021-Security Misconfiguration",
+		RequiresNetwork: true,
+		RunTest: func(params ResponseTestParams) TestResult {
+			analysis := analyzeWellKnown(params)
+			threatLevel := evaluateWellKnownThreatLevel(analysis)
+
+			result := TestResult{
+				Name:        ".well-known Endpoint Analysis",
+				Certainty:   85,
+				ThreatLevel: threatLevel,
+				Metadata:    analysis,
+				Description: generateWellKnownDescription(analysis),
+			}
+			if threatLevel > None {
+				var steps []string
+				if !analysis.ChangePasswordAdvertised {
+					steps = append(steps, "redirect /.well-known/change-password to the password change page")
+				}
+				if len(analysis.OpenIDIssues) > 0 {
+					steps = append(steps, "publish only public HTTPS endpoints in the OpenID discovery document "+
+						"and protect dynamic client registration")
+				}
+				if len(steps) > 0 {
+					remediation := strings.Join(steps, "; ")
+					result.Remediation = strings.ToUpper(remediation[:1]) + remediation[1:]
+					result.References = wellKnownReferences
+				}
+			}
+			return result
+		},
+	}
+}
+
+// analyzeWellKnown probes the well-known endpoints of the origin of the scanned URL.
+func analyzeWellKnown(params ResponseTestParams) WellKnownAnalysis {
+	analysis := WellKnownAnalysis{Endpoints: []WellKnownEndpoint{}, Responded: []string{}, OpenIDIssues: []string{}}
+	if params.Response == nil || params.Response.Request == nil || params.Response.Request.URL == nil {
+		analysis.Error = "request URL unknown"
+		return analysis
+	}
+	origin := url.URL{Scheme: params.Response.Request.URL.Scheme, Host: params.Response.Request.URL.Host}
+
+	// Redirects are recorded rather than followed
+	client := *params.httpClient()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	changePassword, _ := probeWellKnown(params, &client, origin, wellKnownChangePassword)
+	openID, openIDBody := probeWellKnown(params, &client, origin, wellKnownOpenID)
+	assetLinks, assetLinksBody := probeWellKnown(params, &client, origin, wellKnownAssetLinks)
+	for _, endpoint := range []WellKnownEndpoint{changePassword, openID, assetLinks} {
+		analysis.Endpoints = append(analysis.Endpoints, endpoint)
+		if endpoint.Responded {
+			analysis.Responded = append(analysis.Responded, endpoint.Path)
+		}
+	}
+
+	marker := make([]byte, 8)
+	_, _ = rand.Read(marker)
+	unknown, _ := probeWellKnown(params, &client, origin, "/.well-known/antiginx-"+hex.EncodeToString(marker))
+	analysis.CatchAll = unknown.Responded
+	analysis.ChangePasswordAdvertised = changePassword.Responded && !analysis.CatchAll
+
+	if openID.StatusCode == http.StatusOK {
+		analyzeOpenIDConfiguration(&analysis, openIDBody, origin)
+	}
+	if assetLinks.StatusCode == http.StatusOK {
+		analysis.AssetLinksPackages = assetLinksPackages(assetLinksBody)
+	}
+	return analysis
+}
+
+// probeWellKnown requests path on origin and returns the outcome and the body of a 200 answer.
+func probeWellKnown(params ResponseTestParams, client *http.Client, origin url.URL, path string) (WellKnownEndpoint, []byte) {
+	endpoint := WellKnownEndpoint{Path: path}
+	origin.Path = path
+	request, err := http.NewRequestWithContext(params.scanContext(), http.MethodGet, origin.String(), nil)
+	if err != nil {
+		endpoint.Error = err.Error()
+		return endpoint, nil
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		endpoint.Error = err.Error()
+		return endpoint, nil
+	}
+	defer response.Body.Close()
+
+	endpoint.StatusCode = response.StatusCode
+	endpoint.Location = response.Header.Get("Location")
+	endpoint.Responded = response.StatusCode >= 200 && response.StatusCode < 400
+	if response.StatusCode != http.StatusOK {
+		return endpoint, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(response.Body, wellKnownMaxBody))
+	return endpoint, body
+}
+
+// analyzeOpenIDConfiguration records the issuer and endpoints of a discovery document and
+// the details it leaks. Bodies without an issuer (e.g., an HTML catch-all page) are ignored.
+func analyzeOpenIDConfiguration(analysis *WellKnownAnalysis, body []byte, origin url.URL) {
+	var document map[string]any
+	if err := json.Unmarshal(body, &document); err != nil {
+		return
+	}
+	issuer, _ := document["issuer"].(string)
+	if issuer == "" {
+		return
+	}
+	analysis.OpenIDExposed = true
+	analysis.OpenIDIssuer = issuer
+	analysis.OpenIDEndpoints = map[string]string{}
+
+	fields := append([]string{"issuer"}, openIDEndpointFields...)
+	for _, field := range fields {
+		value, _ := document[field].(string)
+		if value == "" {
+			continue
+		}
+		if field != "issuer" {
+			analysis.OpenIDEndpoints[field] = value
+		}
+		endpoint, err := url.Parse(value)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(endpoint.Scheme, "http") && !isInternalHost(endpoint.Hostname()) {
+			analysis.OpenIDIssues = append(analysis.OpenIDIssues, field+" uses plain HTTP ("+value+")")
+		}
+		if isInternalHost(endpoint.Hostname()) && !strings.EqualFold(endpoint.Hostname(), origin.Hostname()) {
+			analysis.OpenIDIssues = append(analysis.OpenIDIssues, field+" points at the internal host "+endpoint.Hostname())
+		}
+	}
+	if registration := analysis.OpenIDEndpoints["registration_endpoint"]; registration != "" {
+		analysis.OpenIDIssues = append(analysis.OpenIDIssues,
+			"dynamic client registration is advertised ("+registration+"), make sure it requires an initial access token")
+	}
+}
+
+// isInternalHost reports whether host is a loopback, private or link-local address or a
+// name reserved for internal use (localhost, *.local, *.internal, *.lan, *.corp, single labels).
+func isInternalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified()
+	}
+	if host == "localhost" || !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range []string{".localhost", ".local", ".internal", ".lan", ".corp", ".intranet"} {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// assetLinksPackages returns the sorted Android package names of an assetlinks.json document.
+func assetLinksPackages(body []byte) []string {
+	var statements []struct {
+		Target struct {
+			Namespace   string `json:"namespace"`
+			PackageName string `json:"package_name"`
+		} `json:"target"`
+	}
+	if err := json.Unmarshal(body, &statements); err != nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var packages []string
+	for _, statement := range statements {
+		name := statement.Target.PackageName
+		if statement.Target.Namespace == "android_app" && name != "" && !seen[name] {
+			seen[name] = true
+			packages = append(packages, name)
+		}
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+// evaluateWellKnownThreatLevel maps the .well-known analysis to a threat level.
+func evaluateWellKnownThreatLevel(analysis WellKnownAnalysis) ThreatLevel {
+	switch {
+	case analysis.Error != "":
+		return Info
+	case len(analysis.OpenIDIssues) > 0:
+		return Low
+	case analysis.OpenIDExposed || !analysis.ChangePasswordAdvertised:
+		return Info
+	default:
+		return None
+	}
+}
+
+// generateWellKnownDescription builds a human-readable summary of the .well-known analysis.
+func generateWellKnownDescription(analysis WellKnownAnalysis) string {
+	if analysis.Error != "" {
+		return "Could not probe the .well-known endpoints: " + analysis.Error
+	}
+	var parts []string
+	switch {
+	case analysis.ChangePasswordAdvertised:
+		parts = append(parts, "/.well-known/change-password is advertised, password managers can link to the password change page")
+	case analysis.CatchAll:
+		parts = append(parts, "The site answers every .well-known path, so /.well-known/change-password cannot be trusted")
+	default:
+		parts = append(parts, "/.well-known/change-password is not advertised")
+	}
+	if analysis.OpenIDExposed {
+		discovery := "An OpenID Connect discovery document is exposed (issuer " + analysis.OpenIDIssuer + ")"
+		if len(analysis.OpenIDIssues) > 0 {
+			discovery += " and leaks details: " + strings.Join(analysis.OpenIDIssues, "; ")
+		}
+		parts = append(parts, discovery)
+	}
+	if len(analysis.AssetLinksPackages) > 0 {
+		parts = append(parts, "assetlinks.json associates the Android apps "+strings.Join(analysis.AssetLinksPackages, ", "))
+	}
+	return strings.Join(parts, ". ")
+}
@@ -0,0 +1,144 @@
+package Tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// serveWellKnown starts a server answering the given paths with fixtures from
+// testdata/well-known (a fixture name) or a redirect (a "redirect:" prefixed target), and
+// every other path with 404 unless catchAll is set.
+func serveWellKnown(t *testing.T, routes map[string]string, catchAll bool) *http.Response {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, ok := routes[r.URL.Path]
+		switch {
+		case ok && len(route) > len("redirect:") && route[:len("redirect:")] == "redirect:":
+			http.Redirect(w, r, route[len("redirect:"):], http.StatusFound)
+		case ok:
+			fixture, err := os.ReadFile(filepath.Join("testdata", "well-known", route))
+			if err != nil {
+				t.Errorf("Failed to read fixture: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(fixture)
+		case catchAll:
+			_, _ = w.Write([]byte("<html><body>App shell</body></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL + "/")
+	return &http.Response{Header: http.Header{}, Request: &http.Request{URL: target}}
+}
+
+func TestWellKnownTest(t *testing.T) {
+	tests := []struct {
+		name               string
+		routes             map[string]string
+		catchAll           bool
+		wantThreat         ThreatLevel
+		wantResponded      []string
+		wantChangePassword bool
+		wantOpenID         bool
+		wantIssues         int
+	}{
+		{
+			name:          "Nothing exposed",
+			routes:        map[string]string{},
+			wantThreat:    Info,
+			wantResponded: []string{},
+		},
+		{
+			name:               "Change password advertised",
+			routes:             map[string]string{wellKnownChangePassword: "redirect:/account/password"},
+			wantThreat:         None,
+			wantResponded:      []string{wellKnownChangePassword},
+			wantChangePassword: true,
+		},
+		{
+			name: "Discovery document present",
+			routes: map[string]string{
+				wellKnownChangePassword: "redirect:/account/password",
+				wellKnownOpenID:         "openid-configuration.json",
+				wellKnownAssetLinks:     "assetlinks.json",
+			},
+			wantThreat:         Info,
+			wantResponded:      []string{wellKnownChangePassword, wellKnownOpenID, wellKnownAssetLinks},
+			wantChangePassword: true,
+			wantOpenID:         true,
+		},
+		{
+			name:          "Leaky discovery document",
+			routes:        map[string]string{wellKnownOpenID: "openid-configuration-leaky.json"},
+			wantThreat:    Low,
+			wantResponded: []string{wellKnownOpenID},
+			wantOpenID:    true,
+			wantIssues:    3,
+		},
+		{
+			name:          "Catch-all site",
+			routes:        map[string]string{},
+			catchAll:      true,
+			wantThreat:    Info,
+			wantResponded: []string{wellKnownChangePassword, wellKnownOpenID, wellKnownAssetLinks},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := serveWellKnown(t, tt.routes, tt.catchAll)
+
+			result := NewWellKnownTest().Run(ResponseTestParams{Response: response})
+
+			analysis := result.Metadata.(WellKnownAnalysis)
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			if !slices.Equal(analysis.Responded, tt.wantResponded) {
+				t.Errorf("Expected responded endpoints %v, got %v", tt.wantResponded, analysis.Responded)
+			}
+			if analysis.ChangePasswordAdvertised != tt.wantChangePassword {
+				t.Errorf("Expected change-password advertised %t, got %t", tt.wantChangePassword, analysis.ChangePasswordAdvertised)
+			}
+			if analysis.OpenIDExposed != tt.wantOpenID {
+				t.Errorf("Expected OpenID discovery exposed %t, got %t", tt.wantOpenID, analysis.OpenIDExposed)
+			}
+			if len(analysis.OpenIDIssues) != tt.wantIssues {
+				t.Errorf("Expected %d discovery document issues, got %v", tt.wantIssues, analysis.OpenIDIssues)
+			}
+		})
+	}
+}
+
+func TestWellKnownTest_DiscoveryDetails(t *testing.T) {
+	response := serveWellKnown(t, map[string]string{
+		wellKnownOpenID:     "openid-configuration.json",
+		wellKnownAssetLinks: "assetlinks.json",
+	}, false)
+
+	analysis := NewWellKnownTest().Run(ResponseTestParams{Response: response}).Metadata.(WellKnownAnalysis)
+
+	if analysis.OpenIDIssuer != "https://login.example.com" {
+		t.Errorf("Expected the issuer to be recorded, got %q", analysis.OpenIDIssuer)
+	}
+	if analysis.OpenIDEndpoints["token_endpoint"] != "https://login.example.com/oauth/token" {
+		t.Errorf("Expected the token endpoint to be recorded, got %v", analysis.OpenIDEndpoints)
+	}
+	if !slices.Equal(analysis.AssetLinksPackages, []string{"com.example.app"}) {
+		t.Errorf("Expected the Android package of assetlinks.json, got %v", analysis.AssetLinksPackages)
+	}
+}
+
+func TestWellKnownTest_UnknownURL(t *testing.T) {
+	result := NewWellKnownTest().Run(ResponseTestParams{Response: &http.Response{Header: http.Header{}}})
+
+	if analysis := result.Metadata.(WellKnownAnalysis); analysis.Error == "" || result.ThreatLevel != Info {
+		t.Errorf("Expected an Info result without a request URL, got %v (%+v)", result.ThreatLevel, analysis)
+	}
+}
@@ -30,13 +30,16 @@ import (
 //   - *ResponseTest: Configured X-Content-Type-Options test ready for execution
 func NewXContentTypeOptionsTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "x-content-type-options",
-		Name:        "X-Content-Type-Options Header Analysis",
-		Description: "Checks for X-Content-Type-Options header to prevent MIME type sniffing attacks",
-		Category:    "Headers",
+		Id:            "x-content-type-options",
+		Name:          "X-Content-Type-Options Header Analysis",
+		Description:   "Checks for X-Content-Type-Options header to prevent MIME type sniffing attacks",
+		Category:      "Headers",
+		CWE:           "CWE-693",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"X-Content-Type-Options"},
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Check for X-Content-Type-Options header
-			xContentTypeHeader := params.Response.Header.Get("X-Content-Type-Options")
+			xContentTypeHeader := HeaderValue(params.Response.Header, "X-Content-Type-Options")
 
 			if xContentTypeHeader == "" {
 				return TestResult{
@@ -4,9 +4,30 @@
 package Tests
 
 import (
+	"fmt"
 	"strings"
 )
 
+// frameAncestorsMaxHosts is the number of domains a CSP frame-ancestors list may allow
+// before the protection is graded weak: every listed site can frame the page, so each
+// additional third party widens the set of pages a clickjacking attack can start from.
+const frameAncestorsMaxHosts = 3
+
+// XFrameAnalysis is the metadata of the X-Frame-Options test: the frame protection headers
+// received and the protection they provide.
+type XFrameAnalysis struct {
+	XFrameValue         string   `json:"xFrameValue"`         // X-Frame-Options value as received (empty if absent)
+	CSPFrameAncestors   string   `json:"cspFrameAncestors"`   // CSP frame-ancestors value (empty if absent)
+	ProtectionLevel     string   `json:"protectionLevel"`     // excellent, good, limited, weak or vulnerable
+	EmbeddingCapability string   `json:"embeddingCapability"` // blocked, same-origin, limited or allowed
+	HasXFrame           bool     `json:"hasXFrame"`           // Whether X-Frame-Options is present
+	HasCSP              bool     `json:"hasCsp"`              // Whether CSP frame-ancestors is present
+	AllowsSelf          bool     `json:"allowsSelf"`          // Whether frame-ancestors allows 'self'
+	AllowedHosts        []string `json:"allowedHosts"`        // Specific domains allowed by frame-ancestors
+	BroadSources        []string `json:"broadSources"`        // Scheme or host wildcards allowed by frame-ancestors
+	Conflict            string   `json:"conflict,omitempty"`  // How X-Frame-Options and frame-ancestors disagree (empty if they agree)
+}
+
 // NewXFrameTest creates a new ResponseTest that analyzes X-Frame-Options header and CSP frame
 // directives to assess clickjacking protection. Clickjacking attacks embed target pages in
 // iframes to trick users into performing unintended actions on the embedded content.
@@ -20,10 +41,15 @@ import (
 // Threat level assessment:
 //   - None (0): Excellent - CSP frame-ancestors 'none' or X-Frame-Options DENY
 //   - Info (1): Good - CSP frame-ancestors 'self' or X-Frame-Options SAMEORIGIN
-//   - Low (2): Limited - X-Frame-Options ALLOW-FROM (deprecated and limited browser support)
-//   - Medium (3): Weak - Only CSP frame-ancestors with specific domains (partial protection)
+//   - Low (2): Limited - X-Frame-Options ALLOW-FROM (deprecated and limited browser support),
+//     or CSP frame-ancestors allowing up to frameAncestorsMaxHosts specific domains
+//   - Medium (3): Weak - CSP frame-ancestors allowing more domains, or any source of a scheme
+//     (https:, https://*, *), even next to 'self'
+
 //   - High (4): Vulnerable - Missing both X-Frame-Options and CSP frame-ancestors
 //   - High (4): Invalid - Present but with invalid/malformed directives
+//   - At least Low (2): X-Frame-Options and CSP frame-ancestors allow different embedders, so
+//     protection differs between browsers
 //
 // Security implications:
 //   - Missing protection: Vulnerable to clickjacking attacks, UI redressing, and iframe abuse
@@ -42,17 +68,20 @@ import (
 //
 //	xframeTest := NewXFrameTest()
 //	result := xframeTest.Run(ResponseTestParams{Response: httpResponse})
-//	// Result includes threat level and detailed iframe embedding analysis
+//	// result.Metadata is an XFrameAnalysis with the headers and embedding capability
 func NewXFrameTest() *ResponseTest {
 	return &ResponseTest{
-		Id:          "xframe",
-		Name:        "X-Frame-Options & CSP Frame Protection Analysis",
-		Description: "Analyzes X-Frame-Options header and CSP frame-ancestors directive to assess clickjacking protection and iframe embedding policies",
-		Category:    "Headers",
+		Id:            "xframe",
+		Name:          "X-Frame-Options & CSP Frame Protection Analysis",
+		Description:   "Analyzes X-Frame-Options header and CSP frame-ancestors directive to assess clickjacking protection and iframe embedding policies",
+		Category:      "Headers",
+		CWE:           "CWE-1021",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"X-Frame-Options", "Content-Security-Policy"},
 		RunTest: func(params ResponseTestParams) TestResult {
 			// Check for both X-Frame-Options and CSP frame-ancestors
-			xframeHeader := params.Response.Header.Get("X-Frame-Options")
-			cspHeader := params.Response.Header.Get("Content-Security-Policy")
+			xframeHeader := HeaderValue(params.Response.Header, "X-Frame-Options")
+			cspHeader := HeaderValue(params.Response.Header, "Content-Security-Policy")
 
 			// Analyze frame protection
 			hasXFrame := xframeHeader != ""
@@ -107,12 +136,37 @@ func NewXFrameTest() *ResponseTest {
 			// Generate description
 			canBeEmbedded := assessEmbeddingCapability(xframeDirective, cspFrameValue, xframeValid)
 			description := generateDescription(protectionLevel, hasXFrame, hasCSPFrameAncestors, canBeEmbedded)
+			ancestors := parseFrameAncestors(cspFrameValue)
+			if len(ancestors.broad) > 0 {
+				description += ". frame-ancestors allows every site matching " + strings.Join(ancestors.broad, ", ")
+			} else if len(ancestors.hosts) > frameAncestorsMaxHosts {
+				description += fmt.Sprintf(". frame-ancestors allows %d domains to frame the page", len(ancestors.hosts))
+			}
+
+			// Browsers supporting CSP Level 2 ignore X-Frame-Options when frame-ancestors is
+			// present, older ones only apply X-Frame-Options; a disagreement splits protection.
+			conflict := detectFrameProtectionConflict(xframeDirective, cspFrameValue, xframeValid)
+			if conflict != "" {
+				threatLevel = max(threatLevel, Low)
+				description += ". " + conflict
+			}
 
 			return TestResult{
 				Name:        "X-Frame-Options & CSP Frame Protection Analysis",
 				Certainty:   100,
 				ThreatLevel: threatLevel,
-				Metadata:    nil,
+				Metadata: XFrameAnalysis{
+					XFrameValue:         strings.TrimSpace(xframeHeader),
+					CSPFrameAncestors:   cspFrameValue,
+					ProtectionLevel:     protectionLevel,
+					EmbeddingCapability: canBeEmbedded,
+					HasXFrame:           hasXFrame,
+					HasCSP:              hasCSPFrameAncestors,
+					AllowsSelf:          ancestors.self,
+					AllowedHosts:        ancestors.hosts,
+					BroadSources:        ancestors.broad,
+					Conflict:            conflict,
+				},
 				Description: description,
 			}
 		},
@@ -155,6 +209,7 @@ func determineProtectionLevel(xframeDirective, cspFrameValue string, xframeValid
 	// CSP frame-ancestors takes precedence over X-Frame-Options in modern browsers
 	if cspFrameValue != "" {
 		cspLower := strings.ToLower(strings.TrimSpace(cspFrameValue))
+		ancestors := parseFrameAncestors(cspLower)
 		switch {
 		case cspLower == "'none'":
 			return "excellent"
@@ -163,6 +218,12 @@ func determineProtectionLevel(xframeDirective, cspFrameValue string, xframeValid
 		case hasOnlyBroadSources(cspLower):
 			// Values like "https:" or "http:" alone allow all sources with that scheme - weak protection
 			return "weak"
+		case len(ancestors.broad) > 0:
+			// A scheme or host wildcard next to 'self' or domains still allows every such site
+			return "weak"
+		case len(ancestors.hosts) > frameAncestorsMaxHosts:
+			// Many third-party domains - each of them can frame the page
+			return "weak"
 		case strings.Contains(cspLower, "'self'") || hasSpecificDomains(cspLower):
 			// 'self' with specific domains, or specific domains only - limited protection
 			return "limited"
@@ -214,6 +275,9 @@ func assessEmbeddingCapability(xframeDirective, cspFrameValue string, xframeVali
 		//	return "limited"
 
 		// After lint
+		if len(parseFrameAncestors(cspLower).broad) > 0 {
+			return "allowed"
+		}
 		switch cspLower {
 		case "'none'":
 			return "blocked"
@@ -243,6 +307,37 @@ func assessEmbeddingCapability(xframeDirective, cspFrameValue string, xframeVali
 	return "allowed"
 }
 
+// detectFrameProtectionConflict checks whether a valid X-Frame-Options header and CSP
+// frame-ancestors allow different embedders. Modern browsers apply frame-ancestors and
+// ignore X-Frame-Options, older ones only apply X-Frame-Options, so the page is protected
+// differently depending on the browser.
+//
+// Parameters:
+//   - xframeDirective: X-Frame-Options directive value
+//   - cspFrameValue: CSP frame-ancestors directive value
+//   - xframeValid: Whether X-Frame-Options syntax is valid
+//
+// Returns:
+//   - string: Description of the conflict, empty if the headers agree or one is missing
+//
+// Example:
+//
+//	detectFrameProtectionConflict("DENY", "https://partner.example", true)
+//	// "X-Frame-Options DENY (blocked) conflicts with frame-ancestors https://partner.example (limited) ..."
+func detectFrameProtectionConflict(xframeDirective, cspFrameValue string, xframeValid bool) string {
+	if !xframeValid || cspFrameValue == "" {
+		return ""
+	}
+	xframeEmbedding := assessEmbeddingCapability(xframeDirective, "", xframeValid)
+	cspEmbedding := assessEmbeddingCapability("", cspFrameValue, false)
+	if xframeEmbedding == cspEmbedding {
+		return ""
+	}
+	return fmt.Sprintf("X-Frame-Options %s (%s) conflicts with frame-ancestors %s (%s): browsers supporting CSP "+
+		"apply frame-ancestors, older browsers X-Frame-Options, so protection is inconsistent across browsers",
+		xframeDirective, xframeEmbedding, cspFrameValue, cspEmbedding)
+}
+
 // generateDescription creates a description based on protection analysis
 func generateDescription(protectionLevel string, hasXFrame, hasCSP bool, canBeEmbedded string) string {
 	var description strings.Builder
@@ -346,3 +441,58 @@ func hasSpecificDomains(cspLower string) bool {
 	}
 	return false
 }
+
+// frameAncestors holds the sources of a CSP frame-ancestors directive by kind.
+//
+// Fields:
+//   - sources: Every source as written, in order
+//   - self: Whether 'self' is allowed
+//   - hosts: Specific domains (including subdomain wildcards such as *.example.com)
+//   - broad: Sources allowing every site of a scheme or every site at all (https:, https://*, *)
+type frameAncestors struct {
+	sources []string
+	self    bool
+	hosts   []string
+	broad   []string
+}
+
+// parseFrameAncestors sorts the sources of a frame-ancestors value by kind.
+//
+// Parameters:
+//   - value: frame-ancestors directive value (e.g., "'self' https://a.example *.b.example")
+//
+// Returns:
+//   - frameAncestors: Sources grouped into 'self', specific hosts and broad sources
+//
+// Example:
+//
+//	ancestors := parseFrameAncestors("'self' https:")
+//	// ancestors.self == true, ancestors.broad == []string{"https:"}
+func parseFrameAncestors(value string) frameAncestors {
+	ancestors := frameAncestors{sources: []string{}, hosts: []string{}, broad: []string{}}
+	for _, source := range strings.Fields(value) {
+		ancestors.sources = append(ancestors.sources, source)
+		lower := strings.ToLower(source)
+		switch {
+		case lower == "'self'":
+			ancestors.self = true
+		case cspKeywords[lower]:
+		case cspBroadSources[lower] || isSchemeWildcard(lower):
+			ancestors.broad = append(ancestors.broad, source)
+		default:
+			ancestors.hosts = append(ancestors.hosts, source)
+		}
+	}
+	return ancestors
+}
+
+// isSchemeWildcard reports whether a source allows any host, either as a bare scheme
+// (e.g., "wss:") or with a wildcard host (e.g., "https://*", "*://*").
+func isSchemeWildcard(source string) bool {
+	if _, rest, found := strings.Cut(source, "://"); found {
+		host, _, _ := strings.Cut(rest, "/")
+		host, _, _ = strings.Cut(host, ":")
+		return host == "*"
+	}
+	return strings.HasSuffix(source, ":") && !strings.Contains(source, ".")
+}
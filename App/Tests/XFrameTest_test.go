@@ -0,0 +1,147 @@
+package Tests
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestXFrameTest_Classification(t *testing.T) {
+	for _, header := range []string{"", "DENY"} {
+		t.Run("X-Frame-Options "+header, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if header != "" {
+				response.Header.Set("X-Frame-Options", header)
+			}
+
+			result := NewXFrameTest().Run(ResponseTestParams{Response: response})
+
+			if result.CWE != "CWE-1021" {
+				t.Errorf("Expected CWE-1021, got %q", result.CWE)
+			}
+			if result.OWASPCategory != "A05:2021-Security Misconfiguration" {
+				t.Errorf("Expected A05:2021-Security Misconfiguration, got %q", result.OWASPCategory)
+			}
+		})
+	}
+}
+
+func TestXFrameTest_FrameAncestors(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		wantThreat ThreatLevel
+		wantHosts  []string
+		wantBroad  []string
+	}{
+		{name: "None", policy: "frame-ancestors 'none'", wantThreat: None, wantHosts: []string{}, wantBroad: []string{}},
+		{name: "Self", policy: "frame-ancestors 'self'", wantThreat: Info, wantHosts: []string{}, wantBroad: []string{}},
+		{
+			name:       "Single domain",
+			policy:     "default-src 'self'; frame-ancestors 'self' https://partner.example",
+			wantThreat: Low,
+			wantHosts:  []string{"https://partner.example"},
+			wantBroad:  []string{},
+		},
+		{
+			name:       "Many domains",
+			policy:     "frame-ancestors https://a.example https://b.example *.c.example https://d.example",
+			wantThreat: Medium,
+			wantHosts:  []string{"https://a.example", "https://b.example", "*.c.example", "https://d.example"},
+			wantBroad:  []string{},
+		},
+		{
+			name:       "Self with scheme wildcard",
+			policy:     "frame-ancestors 'self' https:",
+			wantThreat: Medium,
+			wantHosts:  []string{},
+			wantBroad:  []string{"https:"},
+		},
+		{
+			name:       "Domain with host wildcard",
+			policy:     "frame-ancestors https://partner.example https://*",
+			wantThreat: Medium,
+			wantHosts:  []string{"https://partner.example"},
+			wantBroad:  []string{"https://*"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			response.Header.Set("Content-Security-Policy", tt.policy)
+
+			result := NewXFrameTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			analysis := result.Metadata.(XFrameAnalysis)
+			if !reflect.DeepEqual(analysis.AllowedHosts, tt.wantHosts) {
+				t.Errorf("Expected allowed hosts %v, got %v", tt.wantHosts, analysis.AllowedHosts)
+			}
+			if !reflect.DeepEqual(analysis.BroadSources, tt.wantBroad) {
+				t.Errorf("Expected broad sources %v, got %v", tt.wantBroad, analysis.BroadSources)
+			}
+		})
+	}
+}
+
+func TestXFrameTest_Metadata(t *testing.T) {
+	response := &http.Response{Header: http.Header{}}
+	response.Header.Set("X-Frame-Options", "SAMEORIGIN")
+
+	result := NewXFrameTest().Run(ResponseTestParams{Response: response})
+
+	analysis, ok := result.Metadata.(XFrameAnalysis)
+	if !ok {
+		t.Fatalf("Expected XFrameAnalysis metadata, got %T", result.Metadata)
+	}
+	want := XFrameAnalysis{
+		XFrameValue:         "SAMEORIGIN",
+		ProtectionLevel:     "good",
+		EmbeddingCapability: "same-origin",
+		HasXFrame:           true,
+		AllowedHosts:        []string{},
+		BroadSources:        []string{},
+	}
+	if !reflect.DeepEqual(analysis, want) {
+		t.Errorf("Expected metadata %+v, got %+v", want, analysis)
+	}
+}
+
+func TestXFrameTest_Conflict(t *testing.T) {
+	tests := []struct {
+		name         string
+		xframe       string
+		policy       string
+		wantThreat   ThreatLevel
+		wantConflict bool
+	}{
+		{name: "DENY with permissive frame-ancestors", xframe: "DENY", policy: "frame-ancestors https://partner.example", wantThreat: Low, wantConflict: true},
+		{name: "DENY with frame-ancestors self", xframe: "DENY", policy: "frame-ancestors 'self'", wantThreat: Low, wantConflict: true},
+		{name: "DENY with frame-ancestors none", xframe: "DENY", policy: "frame-ancestors 'none'", wantThreat: None},
+		{name: "SAMEORIGIN with frame-ancestors self", xframe: "SAMEORIGIN", policy: "frame-ancestors 'self'", wantThreat: Info},
+		{name: "SAMEORIGIN with wildcard frame-ancestors", xframe: "SAMEORIGIN", policy: "frame-ancestors https:", wantThreat: Medium, wantConflict: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			response.Header.Set("X-Frame-Options", tt.xframe)
+			response.Header.Set("Content-Security-Policy", tt.policy)
+
+			result := NewXFrameTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			conflict := result.Metadata.(XFrameAnalysis).Conflict
+			if (conflict != "") != tt.wantConflict {
+				t.Errorf("Expected conflict %v, got %q", tt.wantConflict, conflict)
+			}
+			if tt.wantConflict && !strings.Contains(result.Description, "inconsistent across browsers") {
+				t.Errorf("Expected the conflict in the description, got %q", result.Description)
+			}
+		})
+	}
+}
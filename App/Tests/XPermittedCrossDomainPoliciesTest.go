@@ -0,0 +1,150 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the X-Permitted-Cross-Domain-Policies test that checks whether legacy
+// clients (Adobe Flash Player, Adobe Acrobat) may load cross-domain policy files from the site.
+package Tests
+
+import (
+	"strings"
+)
+
+// xpcdpReferences documents the X-Permitted-Cross-Domain-Policies header.
+var xpcdpReferences = []string{
+	"https://owasp.org/www-project-secure-headers/#x-permitted-cross-domain-policies",
+	"https://cheatsheetseries.owasp.org/cheatsheets/HTTP_Headers_Cheat_Sheet.html#x-permitted-cross-domain-policies",
+}
+
+// xpcdpValues lists the meta-policies defined for X-Permitted-Cross-Domain-Policies,
+// from the most to the least restrictive.
+var xpcdpValues = []string{"none", "none-this-response", "master-only", "by-content-type", "by-ftp-filename", "all"}
+
+// NewXPermittedCrossDomainPoliciesTest creates a new ResponseTest that analyzes the
+// X-Permitted-Cross-Domain-Policies header. The header is the meta-policy of legacy plugin
+// clients such as Adobe Flash Player and Adobe Acrobat: it tells them which
+// crossdomain.xml policy files on the site they may honour. Without it those clients fall
+// back to permissive behaviour, so a policy file uploaded or injected anywhere on the site
+// could grant other domains read access to its content.
+//
+// The test evaluates:
+//   - Presence of the header
+//   - The meta-policy value (none, none-this-response, master-only, by-content-type,
+//     by-ftp-filename or all)
+//
+// Threat level assessment:
+//   - None (0): "none" or "none-this-response" - policy files are not honoured
+//   - Info (1): Header missing or invalid (legacy defaults apply), or a value restricting
+//     policy files to the master policy or specific content types
+//   - Low (2): "all" - every policy file on the site is honoured
+//
+// Returns:
+//   - *ResponseTest: Configured X-Permitted-Cross-Domain-Policies test ready for execution
+//
+// Example usage:
+//
+//	xpcdpTest := NewXPermittedCrossDomainPoliciesTest()
+//	result := xpcdpTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Remediation recommends X-Permitted-Cross-Domain-Policies: none when needed
+func NewXPermittedCrossDomainPoliciesTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "xpcdp",
+		Name:          "X-Permitted-Cross-Domain-Policies Header Analysis",
+		Description:   "Checks the X-Permitted-Cross-Domain-Policies header restricting legacy Flash/PDF cross-domain policy files",
+		Category:      "Headers",
+		CWE:           "CWE-942",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"X-Permitted-Cross-Domain-Policies"},
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeXPCDP(HeaderValues(params.Response.Header, "X-Permitted-Cross-Domain-Policies"))
+			threatLevel := evaluateXPCDPThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "X-Permitted-Cross-Domain-Policies Header Analysis",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateXPCDPDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Send X-Permitted-Cross-Domain-Policies: none unless Flash or PDF clients must read " +
+					"the site through a crossdomain.xml policy file"
+				result.References = xpcdpReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeXPCDP parses the X-Permitted-Cross-Domain-Policies header into structured metadata.
+//
+// Parameters:
+//   - values: X-Permitted-Cross-Domain-Policies header lines
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "present" (bool): Header was sent
+//   - "value" (string): Raw header value (first line)
+//   - "policy" (string): Recognized meta-policy in lowercase, empty if invalid or absent
+//   - "valid" (bool): Value is one of the defined meta-policies
+//   - "multiple" (bool): Header was sent more than once
+//
+// Example:
+//
+//	metadata := analyzeXPCDP([]string{"master-only"})
+//	// metadata["policy"] == "master-only", metadata["valid"] == true
+func analyzeXPCDP(values []string) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"present":  len(values) > 0,
+		"value":    "",
+		"policy":   "",
+		"valid":    false,
+		"multiple": len(values) > 1,
+	}
+	if len(values) == 0 {
+		return metadata
+	}
+
+	value := strings.TrimSpace(values[0])
+	metadata["value"] = value
+	policy := strings.ToLower(value)
+	for _, known := range xpcdpValues {
+		if policy == known {
+			metadata["policy"] = policy
+			metadata["valid"] = true
+			break
+		}
+	}
+	return metadata
+}
+
+// evaluateXPCDPThreatLevel maps the X-Permitted-Cross-Domain-Policies analysis to a threat level.
+func evaluateXPCDPThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch metadata["policy"].(string) {
+	case "none", "none-this-response":
+		return None
+	case "all":
+		return Low
+	default:
+		return Info
+	}
+}
+
+// generateXPCDPDescription builds a human-readable summary of the X-Permitted-Cross-Domain-Policies analysis.
+func generateXPCDPDescription(metadata map[string]interface{}) string {
+	if !metadata["present"].(bool) {
+		return "No X-Permitted-Cross-Domain-Policies header, legacy Flash and PDF clients fall back to permissive " +
+			"cross-domain policy file handling"
+	}
+	value := metadata["value"].(string)
+	if !metadata["valid"].(bool) {
+		return "X-Permitted-Cross-Domain-Policies: " + value + " is not a valid meta-policy, legacy clients " +
+			"fall back to permissive cross-domain policy file handling"
+	}
+	switch metadata["policy"].(string) {
+	case "none", "none-this-response":
+		return "X-Permitted-Cross-Domain-Policies: " + value + " prevents legacy clients from honouring cross-domain policy files"
+	case "all":
+		return "X-Permitted-Cross-Domain-Policies: all lets legacy clients honour every cross-domain policy file on the site, " +
+			"so a policy file uploaded anywhere could grant other domains read access"
+	default:
+		return "X-Permitted-Cross-Domain-Policies: " + value + " restricts but still allows cross-domain policy files"
+	}
+}
@@ -0,0 +1,51 @@
+package Tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestXPermittedCrossDomainPoliciesTest(t *testing.T) {
+	tests := []struct {
+		name            string
+		header          []string
+		wantThreat      ThreatLevel
+		wantPolicy      string
+		wantRemediation bool
+	}{
+		{name: "Missing", header: nil, wantThreat: Info, wantRemediation: true},
+		{name: "None", header: []string{"none"}, wantThreat: None, wantPolicy: "none"},
+		{name: "None this response", header: []string{"none-this-response"}, wantThreat: None, wantPolicy: "none-this-response"},
+		{name: "Master only", header: []string{"master-only"}, wantThreat: Info, wantPolicy: "master-only", wantRemediation: true},
+		{name: "By content type", header: []string{"by-content-type"}, wantThreat: Info, wantPolicy: "by-content-type", wantRemediation: true},
+		{name: "By FTP filename", header: []string{"by-ftp-filename"}, wantThreat: Info, wantPolicy: "by-ftp-filename", wantRemediation: true},
+		{name: "All", header: []string{"all"}, wantThreat: Low, wantPolicy: "all", wantRemediation: true},
+		{name: "Mixed case", header: []string{" None "}, wantThreat: None, wantPolicy: "none"},
+		{name: "Invalid value", header: []string{"deny"}, wantThreat: Info, wantRemediation: true},
+		{name: "First of multiple values", header: []string{"all", "none"}, wantThreat: Low, wantPolicy: "all", wantRemediation: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			for _, value := range tt.header {
+				response.Header.Add("X-Permitted-Cross-Domain-Policies", value)
+			}
+
+			result := NewXPermittedCrossDomainPoliciesTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if metadata["policy"] != tt.wantPolicy {
+				t.Errorf("Expected policy %q, got %q", tt.wantPolicy, metadata["policy"])
+			}
+			if (result.Remediation != "") != tt.wantRemediation {
+				t.Errorf("Expected remediation %v, got %q", tt.wantRemediation, result.Remediation)
+			}
+			if result.TestId != "xpcdp" || result.CWE != "CWE-942" {
+				t.Errorf("Expected the xpcdp test ID and CWE-942, got %q and %q", result.TestId, result.CWE)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the cross-site tracing (XST) test that sends an HTTP TRACE request
+// and detects servers echoing the request back.
+package Tests
+
+import (
+	HttpClient "Engine-AntiGinx/App/HTTP"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// xstReferences documents cross-site tracing and the TRACE method.
+var xstReferences = []string{
+	"https://owasp.org/www-community/attacks/Cross_Site_Tracing",
+	"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/02-Configuration_and_Deployment_Management_Testing/06-Test_HTTP_Methods",
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Methods/TRACE",
+}
+
+// xstMarkerHeader is the request header carrying the random marker looked for in the echo.
+const xstMarkerHeader = "X-AntiGinx-XST"
+
+// xstMaxBody caps how much of the TRACE response body is searched for the marker.
+const xstMaxBody = 64 << 10
+
+// NewXSTTest creates a new ResponseTest that checks whether the target answers HTTP TRACE
+// requests by echoing them back. A reflected TRACE request contains every request header,
+// including cookies and Authorization headers marked HttpOnly or otherwise hidden from
+// scripts; combined with an XSS or a proxy flaw, cross-site tracing (XST) reads them.
+//
+// The test sends a TRACE request (HttpClient.Trace) with the scan client to the scanned URL
+// with a random marker header and checks whether the marker comes back in the response body.
+//
+// Threat level assessment:
+//   - None (0): TRACE is rejected (e.g., 405), or answered without echoing the request
+//   - High (4): The TRACE request, including its headers, is reflected in the response
+//
+// Returns:
+//   - *ResponseTest: Configured XST test ready for execution
+//
+// Example usage:
+//
+//	xstTest := NewXSTTest()
+//	result := xstTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Metadata["reflected"] is true when the server echoes TRACE requests
+func NewXSTTest() *ResponseTest {
+	return &ResponseTest{
+		Id:              "xst",
+		Name:            "Cross-Site Tracing (XST)",
+		Description:     "Sends an HTTP TRACE request and checks whether the server reflects it, exposing request headers such as cookies",
+		Category:        "Protocol",
+		CWE:             "CWE-693",
+		OWASPCategory:   "A05:2021-Security Misconfiguration",
+		RequiresNetwork: true,
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeXST(params)
+			threatLevel := evaluateXSTThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "Cross-Site Tracing (XST)",
+				Certainty:   95,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateXSTDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Disable the TRACE method on the web server and every proxy in front of it " +
+					"(e.g., \"TraceEnable off\" in Apache, rejecting TRACE in nginx or the load balancer)"
+				result.References = xstReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeXST sends a TRACE request carrying a random marker header to the URL of the
+// scanned response and reports whether the server echoed it.
+//
+// Parameters:
+//   - params: Test parameters holding the response, scan client and scan context
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "url" (string): URL the TRACE request was sent to
+//   - "sent" (bool): A response to the TRACE request was received
+//   - "status_code" (int): Status code of the TRACE response (0 if none)
+//   - "content_type" (string): Media type of the TRACE response, lower-cased
+//   - "trace_enabled" (bool): TRACE answered with a 2xx status
+//   - "reflected" (bool): The marker header was echoed in the response body
+//   - "error" (string): Why the request failed, empty on success
+//
+// Example:
+//
+//	metadata := analyzeXST(ResponseTestParams{Response: httpResponse})
+//	// metadata["reflected"] == true for a server echoing TRACE requests
+func analyzeXST(params ResponseTestParams) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"url":           "",
+		"sent":          false,
+		"status_code":   0,
+		"content_type":  "",
+		"trace_enabled": false,
+		"reflected":     false,
+		"error":         "",
+	}
+	if params.Response == nil || params.Response.Request == nil || params.Response.Request.URL == nil {
+		metadata["error"] = "request URL unknown"
+		return metadata
+	}
+	target := *params.Response.Request.URL
+	target.Fragment = ""
+	metadata["url"] = target.String()
+
+	marker := xstMarker()
+	response, err := HttpClient.Trace(params.scanContext(), params.httpClient(), target.String(),
+		http.Header{xstMarkerHeader: {marker}})
+	if err != nil {
+		metadata["error"] = err.Error()
+		return metadata
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(response.Body, xstMaxBody))
+
+	contentType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	metadata["sent"] = true
+	metadata["status_code"] = response.StatusCode
+	metadata["content_type"] = strings.ToLower(contentType)
+	metadata["trace_enabled"] = response.StatusCode >= 200 && response.StatusCode < 300
+	metadata["reflected"] = metadata["trace_enabled"].(bool) && strings.Contains(string(body), marker)
+	return metadata
+}
+
+// xstMarker returns a random value identifying the TRACE request in its echo.
+func xstMarker() string {
+	buf := make([]byte, 12)
+	_, _ = rand.Read(buf)
+	return "antiginx-" + hex.EncodeToString(buf)
+}
+
+// evaluateXSTThreatLevel maps the XST analysis to a threat level.
+func evaluateXSTThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	if metadata["reflected"].(bool) {
+		return High
+	}
+	return None
+}
+
+// generateXSTDescription builds a human-readable summary of the XST analysis.
+func generateXSTDescription(metadata map[string]interface{}) string {
+	switch {
+	case !metadata["sent"].(bool):
+		return "TRACE request could not be sent (" + metadata["error"].(string) + "), cross-site tracing was not checked"
+	case metadata["reflected"].(bool):
+		return "Server echoes TRACE requests including their headers; cross-site tracing can expose cookies and " +
+			"credentials hidden from scripts"
+	case metadata["trace_enabled"].(bool):
+		return "Server accepts TRACE requests but does not echo them back, cross-site tracing is not possible"
+	default:
+		return "Server rejects TRACE requests, cross-site tracing is not possible"
+	}
+}
@@ -0,0 +1,92 @@
+package Tests
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestXSTTest(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		wantThreat    ThreatLevel
+		wantReflected bool
+		wantEnabled   bool
+	}{
+		{
+			name: "TRACE echoed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodTrace {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				w.Header().Set("Content-Type", "message/http")
+				_ = r.Write(w)
+			},
+			wantThreat:    High,
+			wantReflected: true,
+			wantEnabled:   true,
+		},
+		{
+			name: "TRACE rejected",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodTrace {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			},
+			wantThreat: None,
+		},
+		{
+			name: "TRACE answered with the page",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, "<html><body>Hello</body></html>")
+			},
+			wantThreat:  None,
+			wantEnabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			request, _ := http.NewRequest(http.MethodGet, server.URL+"/login", nil)
+			response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: request}
+
+			result := NewXSTTest().Run(ResponseTestParams{Response: response, HTTPClient: server.Client()})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat level %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			metadata := result.Metadata.(map[string]interface{})
+			if metadata["reflected"] != tt.wantReflected {
+				t.Errorf("Expected reflected %v, got %v", tt.wantReflected, metadata["reflected"])
+			}
+			if metadata["trace_enabled"] != tt.wantEnabled {
+				t.Errorf("Expected trace_enabled %v, got %v", tt.wantEnabled, metadata["trace_enabled"])
+			}
+			if (result.Remediation != "") != (tt.wantThreat > None) {
+				t.Errorf("Unexpected remediation %q for threat level %v", result.Remediation, result.ThreatLevel)
+			}
+		})
+	}
+}
+
+func TestXSTTest_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	server.Close()
+
+	result := NewXSTTest().Run(ResponseTestParams{Response: &http.Response{Header: http.Header{}, Request: request}})
+
+	if result.ThreatLevel != None {
+		t.Errorf("Expected None for an unreachable target, got %v", result.ThreatLevel)
+	}
+	if result.Metadata.(map[string]interface{})["sent"] != false {
+		t.Error("Expected sent to be false for an unreachable target")
+	}
+}
@@ -0,0 +1,188 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the X-XSS-Protection test that checks the legacy browser XSS filter
+// header and recommends a Content-Security-Policy in its place.
+package Tests
+
+import (
+	"strings"
+)
+
+// xxssReferences documents why X-XSS-Protection is deprecated and CSP replaces it.
+var xxssReferences = []string{
+	"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-XSS-Protection",
+	"https://cheatsheetseries.owasp.org/cheatsheets/HTTP_Headers_Cheat_Sheet.html#x-xss-protection",
+}
+
+// NewXXSSProtectionTest creates a new ResponseTest that analyzes the legacy X-XSS-Protection
+// header. Modern browsers removed their XSS auditors and ignore the header; where the filter
+// still exists, its default sanitizing mode could be abused to disable legitimate scripts or
+// leak information across origins. Protection against XSS should come from a
+// Content-Security-Policy instead.
+//
+// The test evaluates:
+//   - Filter state: "0" (disabled) or "1" (enabled)
+//   - mode=block: Blocks the page instead of sanitizing the reflected script
+//   - report=<uri>: Chromium-only violation reporting of the reflected-XSS filter
+//   - Content-Security-Policy presence (the modern replacement)
+//
+// Threat level assessment:
+//   - None (0): Header absent, or "0" with a Content-Security-Policy
+//   - Info (1): "1; mode=block" - acceptable but legacy
+//   - Low (2): "0" without a Content-Security-Policy, "1" in sanitizing mode or an invalid value
+//
+// Returns:
+//   - *ResponseTest: Configured X-XSS-Protection test ready for execution
+//
+// Example usage:
+//
+//	xxssTest := NewXXSSProtectionTest()
+//	result := xxssTest.Run(ResponseTestParams{Response: httpResponse})
+//	// Remediation recommends a Content-Security-Policy when one is needed
+func NewXXSSProtectionTest() *ResponseTest {
+	return &ResponseTest{
+		Id:            "x-xss",
+		Name:          "X-XSS-Protection Header Analysis",
+		Description:   "Checks the legacy X-XSS-Protection header and recommends Content-Security-Policy in its place",
+		Category:      "Headers",
+		CWE:           "CWE-693",
+		OWASPCategory: "A05:2021-Security Misconfiguration",
+		CacheHeaders:  []string{"X-XSS-Protection", "Content-Security-Policy"},
+		RunTest: func(params ResponseTestParams) TestResult {
+			metadata := analyzeXXSSProtection(HeaderValues(params.Response.Header, "X-XSS-Protection"),
+				HeaderValue(params.Response.Header, "Content-Security-Policy") != "")
+			threatLevel := evaluateXXSSThreatLevel(metadata)
+
+			result := TestResult{
+				Name:        "X-XSS-Protection Header Analysis",
+				Certainty:   100,
+				ThreatLevel: threatLevel,
+				Metadata:    metadata,
+				Description: generateXXSSDescription(metadata),
+			}
+			if threatLevel > None {
+				result.Remediation = "Send X-XSS-Protection: 0 (or drop the header) and protect against XSS " +
+					"with a Content-Security-Policy, e.g. " + baselineCSP
+				result.References = xxssReferences
+			}
+			return result
+		},
+	}
+}
+
+// analyzeXXSSProtection parses the X-XSS-Protection header into structured metadata.
+//
+// Parameters:
+//   - values: X-XSS-Protection header lines (only the first one is used by browsers)
+//   - hasCSP: Whether the response carries a Content-Security-Policy header
+//
+// Returns:
+//   - map[string]interface{}: Structured metadata containing:
+//   - "present" (bool): Header was sent
+//   - "value" (string): Raw header value
+//   - "valid" (bool): Value starts with "0" or "1"
+//   - "enabled" (bool): The filter is enabled ("1")
+//   - "mode_block" (bool): mode=block directive present
+//   - "report_uri" (string): Value of the report directive, empty if absent
+//   - "has_csp" (bool): Content-Security-Policy is present
+//   - "issues" ([]string): Detected problems
+//
+// Example:
+//
+//	metadata := analyzeXXSSProtection([]string{"1; mode=block"}, false)
+//	// metadata["enabled"] == true, metadata["mode_block"] == true
+func analyzeXXSSProtection(values []string, hasCSP bool) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"present":    len(values) > 0,
+		"value":      "",
+		"valid":      false,
+		"enabled":    false,
+		"mode_block": false,
+		"report_uri": "",
+		"has_csp":    hasCSP,
+		"issues":     []string{},
+	}
+	if len(values) == 0 {
+		return metadata
+	}
+
+	value := strings.TrimSpace(values[0])
+	metadata["value"] = value
+	parts := strings.Split(value, ";")
+	state := strings.TrimSpace(parts[0])
+	issues := []string{}
+
+	switch state {
+	case "0":
+		metadata["valid"] = true
+		if !hasCSP {
+			issues = append(issues, "the XSS filter is disabled and no Content-Security-Policy protects against XSS")
+		}
+	case "1":
+		metadata["valid"] = true
+		metadata["enabled"] = true
+	default:
+		issues = append(issues, "invalid value \""+value+"\", browsers ignore the header")
+	}
+
+	for _, part := range parts[1:] {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "mode":
+			if strings.EqualFold(strings.TrimSpace(arg), "block") {
+				metadata["mode_block"] = true
+			}
+		case "report":
+			metadata["report_uri"] = strings.TrimSpace(arg)
+		}
+	}
+
+	if metadata["enabled"].(bool) {
+		if !metadata["mode_block"].(bool) {
+			issues = append(issues, "the filter runs in sanitizing mode, which can be abused to disable legitimate scripts or leak cross-origin information")
+		}
+		if metadata["report_uri"].(string) != "" {
+			issues = append(issues, "report= relies on the removed Chromium reflected-XSS filter, no reports are sent by current browsers")
+		}
+	}
+	metadata["issues"] = issues
+	return metadata
+}
+
+// evaluateXXSSThreatLevel maps the X-XSS-Protection analysis to a threat level.
+func evaluateXXSSThreatLevel(metadata map[string]interface{}) ThreatLevel {
+	switch {
+	case !metadata["present"].(bool):
+		return None
+	case !metadata["valid"].(bool):
+		return Low
+	case !metadata["enabled"].(bool):
+		if metadata["has_csp"].(bool) {
+			return None
+		}
+		return Low
+	case !metadata["mode_block"].(bool):
+		return Low
+	default:
+		return Info
+	}
+}
+
+// generateXXSSDescription builds a human-readable summary of the X-XSS-Protection analysis.
+func generateXXSSDescription(metadata map[string]interface{}) string {
+	cspNote := ""
+	if !metadata["has_csp"].(bool) {
+		cspNote = "; add a Content-Security-Policy to protect against XSS"
+	}
+	if !metadata["present"].(bool) {
+		return "No X-XSS-Protection header, modern browsers ignore it" + cspNote
+	}
+	issues := metadata["issues"].([]string)
+	if len(issues) > 0 {
+		return "X-XSS-Protection: " + metadata["value"].(string) + " - " + strings.Join(issues, "; ") +
+			". Modern browsers ignore this header, rely on Content-Security-Policy instead"
+	}
+	if metadata["enabled"].(bool) {
+		return "X-XSS-Protection: " + metadata["value"].(string) + " is acceptable but legacy, modern browsers ignore it" + cspNote
+	}
+	return "X-XSS-Protection disables the legacy XSS filter and a Content-Security-Policy is present"
+}
@@ -0,0 +1,52 @@
+package Tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestXXSSProtectionTest(t *testing.T) {
+	tests := []struct {
+		name            string
+		header          string
+		csp             string
+		wantThreat      ThreatLevel
+		wantRemediation bool
+	}{
+		{name: "Absent", header: "", wantThreat: None},
+		{name: "Disabled without CSP", header: "0", wantThreat: Low, wantRemediation: true},
+		{name: "Disabled with CSP", header: "0", csp: "default-src 'self'", wantThreat: None},
+		{name: "Mode block", header: "1; mode=block", wantThreat: Info, wantRemediation: true},
+		{name: "Sanitizing mode", header: "1", wantThreat: Low, wantRemediation: true},
+		{name: "Report without mode block", header: "1; report=https://example.com/xss", wantThreat: Low, wantRemediation: true},
+		{name: "Invalid value", header: "yes", wantThreat: Low, wantRemediation: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				response.Header.Set("X-XSS-Protection", tt.header)
+			}
+			if tt.csp != "" {
+				response.Header.Set("Content-Security-Policy", tt.csp)
+			}
+
+			result := NewXXSSProtectionTest().Run(ResponseTestParams{Response: response})
+
+			if result.ThreatLevel != tt.wantThreat {
+				t.Errorf("Expected threat %v, got %v (%s)", tt.wantThreat, result.ThreatLevel, result.Description)
+			}
+			if (result.Remediation != "") != tt.wantRemediation {
+				t.Errorf("Expected remediation %v, got %q", tt.wantRemediation, result.Remediation)
+			}
+		})
+	}
+
+	t.Run("Report directive is noted", func(t *testing.T) {
+		metadata := analyzeXXSSProtection([]string{"1; mode=block; report=/xss"}, true)
+
+		if metadata["report_uri"] != "/xss" || len(metadata["issues"].([]string)) != 1 {
+			t.Errorf("Expected report URI and a single issue, got %v", metadata)
+		}
+	})
+}
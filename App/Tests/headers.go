@@ -0,0 +1,87 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the header normalization helpers tests use to read response headers.
+package Tests
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// HeaderValues returns every value of the named header regardless of how its name is cased
+// in the header map. http.Header.Get and Values only look up the canonical key, so headers
+// stored under a non-canonical name (e.g. lowercase HTTP/2 names in a hand-built or
+// replayed response) are otherwise missed.
+//
+// Values stored under the canonical key come first, followed by the values of the other
+// spellings in sorted order. Obsolete line folding (a line break followed by spaces or
+// tabs) is collapsed into a single space and surrounding whitespace is trimmed.
+//
+// Parameters:
+//   - header: Response headers to read
+//   - name: Header name in any case (e.g., "Strict-Transport-Security")
+//
+// Returns:
+//   - []string: Normalized values, nil when the header is absent
+//
+// Example:
+//
+//	header := http.Header{"strict-transport-security": {"max-age=31536000"}}
+//	values := HeaderValues(header, "Strict-Transport-Security")
+//	// values == []string{"max-age=31536000"}
+func HeaderValues(header http.Header, name string) []string {
+	canonical := http.CanonicalHeaderKey(name)
+	keys := make([]string, 0, 1)
+	for key := range header {
+		if key != canonical && strings.EqualFold(key, canonical) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if _, ok := header[canonical]; ok {
+		keys = append([]string{canonical}, keys...)
+	}
+
+	var values []string
+	for _, key := range keys {
+		for _, value := range header[key] {
+			values = append(values, unfoldHeaderValue(value))
+		}
+	}
+	return values
+}
+
+// HeaderValue returns the first value of the named header regardless of its case, the
+// normalizing counterpart of http.Header.Get.
+//
+// Parameters:
+//   - header: Response headers to read
+//   - name: Header name in any case
+//
+// Returns:
+//   - string: First normalized value, or "" when the header is absent
+func HeaderValue(header http.Header, name string) string {
+	if values := HeaderValues(header, name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// responseCookies parses the cookies of a response like http.Response.Cookies, but also
+// reads Set-Cookie headers stored under a non-canonical name.
+func responseCookies(response *http.Response) []*http.Cookie {
+	normalized := &http.Response{Header: http.Header{"Set-Cookie": HeaderValues(response.Header, "Set-Cookie")}}
+	return normalized.Cookies()
+}
+
+// unfoldHeaderValue replaces obsolete line folding with a single space and trims the value.
+func unfoldHeaderValue(value string) string {
+	if strings.ContainsAny(value, "\r\n") {
+		fields := strings.FieldsFunc(value, func(r rune) bool { return r == '\r' || r == '\n' })
+		for i, field := range fields {
+			fields[i] = strings.TrimSpace(field)
+		}
+		value = strings.Join(fields, " ")
+	}
+	return strings.TrimSpace(value)
+}
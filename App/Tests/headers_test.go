@@ -0,0 +1,69 @@
+package Tests
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHeaderValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   http.Header
+		expected []string
+	}{
+		{name: "Canonical", header: http.Header{"Vary": {"Accept-Encoding"}}, expected: []string{"Accept-Encoding"}},
+		{name: "Lowercase", header: http.Header{"vary": {"Accept-Encoding"}}, expected: []string{"Accept-Encoding"}},
+		{
+			name:     "Canonical first, then other spellings",
+			header:   http.Header{"vary": {"Origin"}, "VARY": {"Cookie"}, "Vary": {"Accept-Encoding"}},
+			expected: []string{"Accept-Encoding", "Cookie", "Origin"},
+		},
+		{name: "Obsolete line folding", header: http.Header{"Vary": {" Accept-Encoding,\r\n\tCookie "}}, expected: []string{"Accept-Encoding, Cookie"}},
+		{name: "Absent", header: http.Header{"Server": {"nginx"}}, expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HeaderValues(tt.header, "vary"); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("HeaderValues() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestHeaderNormalization_HTTP2Lowercase runs header tests against an HTTP/2 response whose
+// header names are lowercase, as written on the wire, and asserts the headers are found.
+func TestHeaderNormalization_HTTP2Lowercase(t *testing.T) {
+	response := &http.Response{
+		Proto:      "HTTP/2.0",
+		ProtoMajor: 2,
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"strict-transport-security": {"max-age=31536000; includeSubDomains; preload"},
+			"x-frame-options":           {"DENY"},
+			"x-content-type-options":    {"nosniff"},
+			"referrer-policy":           {"no-referrer"},
+			"set-cookie":                {"session=3f9a7c1e5b2d4086a1c9e7f3b5d20c48; Secure; HttpOnly; SameSite=Strict; Path=/"},
+		},
+		Request: &http.Request{Header: http.Header{}},
+	}
+
+	tests := []struct {
+		test     *ResponseTest
+		expected ThreatLevel
+	}{
+		{test: NewHSTSTest(), expected: None},
+		{test: NewXFrameTest(), expected: None},
+		{test: NewXContentTypeOptionsTest(), expected: None},
+		{test: NewReferrerPolicyTest(), expected: None},
+		{test: NewCookieSecurityTest(), expected: None},
+	}
+	for _, tt := range tests {
+		t.Run(tt.test.Id, func(t *testing.T) {
+			result := tt.test.Run(ResponseTestParams{Response: response, DisableCVE: true})
+			if result.ThreatLevel != tt.expected {
+				t.Errorf("Expected threat level %s, got %s: %s", tt.expected, result.ThreatLevel, result.Description)
+			}
+		})
+	}
+}
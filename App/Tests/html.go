@@ -0,0 +1,81 @@
+// Package Tests provides security test implementations for HTTP response analysis.
+// This file contains the HTML helpers body based tests use to read the tags of a page.
+package Tests
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// HTMLTag is a start tag found in an HTML document.
+type HTMLTag struct {
+	Name  string            // Lowercase tag name (e.g., "a", "script")
+	Attrs map[string]string // Attributes keyed by lowercase name, values unescaped
+}
+
+// Attr returns the value of the named attribute and whether the tag has it.
+func (t HTMLTag) Attr(name string) (string, bool) {
+	value, ok := t.Attrs[strings.ToLower(name)]
+	return value, ok
+}
+
+var (
+	// htmlCommentPattern matches HTML comments, whose content is not markup.
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// htmlRawTextPattern matches script and style elements, whose content is not markup.
+	htmlRawTextPattern = regexp.MustCompile(`(?is)<(script|style)\b([^>]*)>.*?</(script|style)\s*>`)
+	// htmlStartTagPattern matches a start tag with its attribute section.
+	htmlStartTagPattern = regexp.MustCompile(`(?is)<([a-z][a-z0-9-]*)((?:\s+[^\s"'>/=]+(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'>]+))?)*)\s*/?>`)
+	// htmlAttrPattern matches one attribute: a name and an optional double-quoted,
+	// single-quoted or unquoted value.
+	htmlAttrPattern = regexp.MustCompile(`(?s)([^\s"'>/=]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+)
+
+// FindHTMLTags returns the start tags with the given names in document order. Comments
+// are ignored, and so is the content of script and style elements (their own start tags
+// are still returned). For duplicated attributes the first value wins, as in browsers.
+//
+// The parser is a tolerant scanner rather than a full HTML5 parser: it is meant for
+// finding links, scripts and other resources referenced by a page.
+//
+// Parameters:
+//   - body: HTML document
+//   - names: Tag names to return (case-insensitive); none returns every tag
+//
+// Returns:
+//   - []HTMLTag: Matching start tags
+//
+// Example:
+//
+//	links := FindHTMLTags(body, "a")
+//	for _, link := range links {
+//	    href, _ := link.Attr("href")
+//	}
+func FindHTMLTags(body []byte, names ...string) []HTMLTag {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[strings.ToLower(name)] = true
+	}
+
+	document := htmlCommentPattern.ReplaceAllString(string(body), "")
+	document = htmlRawTextPattern.ReplaceAllString(document, "<$1$2></$1>")
+
+	var tags []HTMLTag
+	for _, match := range htmlStartTagPattern.FindAllStringSubmatch(document, -1) {
+		name := strings.ToLower(match[1])
+		if len(wanted) > 0 && !wanted[name] {
+			continue
+		}
+		tag := HTMLTag{Name: name, Attrs: map[string]string{}}
+		for _, attr := range htmlAttrPattern.FindAllStringSubmatch(match[2], -1) {
+			attrName := strings.ToLower(attr[1])
+			if _, seen := tag.Attrs[attrName]; seen {
+				continue
+			}
+			tag.Attrs[attrName] = html.UnescapeString(attr[2] + attr[3] + attr[4])
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
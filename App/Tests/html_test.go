@@ -0,0 +1,36 @@
+package Tests
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindHTMLTags(t *testing.T) {
+	body := []byte(`<html><head>
+<!-- <script src="/commented.js"></script> -->
+<script src="/app.js" async></script>
+<script>var tag = '<img src="/in-script.png">';</script>
+</head><body>
+<IMG SRC='/logo.png' alt=Logo src="/ignored.png">
+<a href="/search?q=1&amp;page=2" title="a > b">Search</a>
+<br/>
+</body></html>`)
+
+	tags := FindHTMLTags(body, "script", "IMG", "a")
+
+	want := []HTMLTag{
+		{Name: "script", Attrs: map[string]string{"src": "/app.js", "async": ""}},
+		{Name: "script", Attrs: map[string]string{}},
+		{Name: "img", Attrs: map[string]string{"src": "/logo.png", "alt": "Logo"}},
+		{Name: "a", Attrs: map[string]string{"href": "/search?q=1&page=2", "title": "a > b"}},
+	}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("Expected tags %+v, got %+v", want, tags)
+	}
+	if src, ok := tags[2].Attr("SRC"); !ok || src != "/logo.png" {
+		t.Errorf("Expected Attr to be case-insensitive, got %q, %v", src, ok)
+	}
+	if all := FindHTMLTags(body); len(all) != 8 {
+		t.Errorf("Expected every start tag without names, got %d", len(all))
+	}
+}
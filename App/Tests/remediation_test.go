@@ -0,0 +1,52 @@
+package Tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRemediation(t *testing.T) {
+	tests := []struct {
+		name            string
+		test            *ResponseTest
+		headers         http.Header
+		wantRemediation string
+	}{
+		{name: "Missing HSTS", test: NewHSTSTest(), headers: http.Header{},
+			wantRemediation: "Strict-Transport-Security: " + recommendedHSTSHeader},
+		{name: "Short HSTS max-age", test: NewHSTSTest(), headers: http.Header{"Strict-Transport-Security": {"max-age=300"}},
+			wantRemediation: recommendedHSTSHeader},
+		{name: "Missing CSP", test: NewCSPTest(), headers: http.Header{},
+			wantRemediation: baselineCSP},
+		{name: "Unsafe CSP", test: NewCSPTest(), headers: http.Header{"Content-Security-Policy": {"script-src 'self' 'unsafe-inline'"}},
+			wantRemediation: "script-src: 'unsafe-inline'"},
+		{name: "Insecure cookie", test: NewCookieSecurityTest(), headers: http.Header{"Set-Cookie": {"sessionid=abc123"}},
+			wantRemediation: "Add HttpOnly"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+			response := &http.Response{Header: tt.headers, Request: request}
+
+			result := tt.test.Run(ResponseTestParams{Response: response})
+
+			if !strings.Contains(result.Remediation, tt.wantRemediation) {
+				t.Errorf("Expected remediation containing %q, got %q", tt.wantRemediation, result.Remediation)
+			}
+			if len(result.References) == 0 {
+				t.Error("Expected references backing the remediation")
+			}
+		})
+	}
+
+	t.Run("Preload-ready HSTS", func(t *testing.T) {
+		response := &http.Response{Header: http.Header{"Strict-Transport-Security": {recommendedHSTSHeader}}}
+
+		result := NewHSTSTest().Run(ResponseTestParams{Response: response})
+
+		if result.Remediation != "" || result.References != nil {
+			t.Errorf("Expected no remediation for an excellent configuration, got %q %v", result.Remediation, result.References)
+		}
+	})
+}
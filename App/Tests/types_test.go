@@ -0,0 +1,165 @@
+package Tests
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestThreatLevel_MarshalUnmarshalRoundTrip(t *testing.T) {
+	for level := None; level <= Critical; level++ {
+		t.Run(level.String(), func(t *testing.T) {
+			data, err := json.Marshal(level)
+			if err != nil {
+				t.Fatalf("Unexpected marshal error: %v", err)
+			}
+
+			var parsed ThreatLevel
+			if err := json.Unmarshal(data, &parsed); err != nil {
+				t.Fatalf("Unexpected unmarshal error for %s: %v", data, err)
+			}
+			if parsed != level {
+				t.Errorf("Expected %v after round trip, got %v", level, parsed)
+			}
+		})
+	}
+}
+
+func TestThreatLevel_UnmarshalJSON_Invalid(t *testing.T) {
+	var parsed ThreatLevel
+	if err := json.Unmarshal([]byte(`"Severe"`), &parsed); err == nil {
+		t.Error("Expected error for unknown threat level name")
+	}
+}
+
+func TestParseThreatLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ThreatLevel
+		wantErr bool
+	}{
+		{input: "none", want: None},
+		{input: "Info", want: Info},
+		{input: " LOW ", want: Low},
+		{input: "medium", want: Medium},
+		{input: "4", want: High},
+		{input: "CRITICAL", want: Critical},
+		{input: "urgent", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseThreatLevel(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v for %q, got %v", tt.want, tt.input, got)
+			}
+		})
+	}
+}
+
+func TestThreatLevel_AtLeast(t *testing.T) {
+	threshold := Medium
+	for level := None; level <= Critical; level++ {
+		want := level >= Medium
+		if got := level.AtLeast(threshold); got != want {
+			t.Errorf("%v.AtLeast(%v) = %t, want %t", level, threshold, got, want)
+		}
+	}
+}
+
+func TestThreatLevel_String_UnknownValue(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Unexpected panic for out-of-range threat level: %v", r)
+		}
+	}()
+
+	data, err := json.Marshal(TestResult{Name: "Corrupted", ThreatLevel: ThreatLevel(42)})
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if decoded["ThreatLevel"] != "Unknown(42)" {
+		t.Errorf("Expected ThreatLevel to be marshaled as \"Unknown(42)\", got %v", decoded["ThreatLevel"])
+	}
+}
+
+func TestResponseTestParams_SharedBody(t *testing.T) {
+	body := "<html><script>eval(atob('YWxlcnQoMSk='))</script>" +
+		"at com.example.Handler.run(Handler.java:42)</html>"
+	rules, err := ParseCustomRules([]byte(customRulesJSON))
+	if err != nil {
+		t.Fatalf("ParseCustomRules failed: %v", err)
+	}
+	response := &http.Response{
+		Header: http.Header{"Content-Type": {"text/html"}},
+		Body:   io.NopCloser(strings.NewReader(body)),
+	}
+	params := ResponseTestParams{Response: response, CustomRules: rules, Body: []byte(body)}
+
+	// Both tests read the body of the same response; the first must not starve the second.
+	jsResult := NewJSObfuscationTest().Run(params)
+	if strings.Contains(jsResult.Description, "No JavaScript") {
+		t.Errorf("Expected js-obf to see the script, got %q", jsResult.Description)
+	}
+	customResult := NewCustomCheckTest().Run(params)
+	if customResult.ThreatLevel != Low {
+		t.Errorf("Expected the body rule of custom to match, got %v (%s)", customResult.ThreatLevel, customResult.Description)
+	}
+}
+
+func TestResponseTest_Applicable(t *testing.T) {
+	responseFor := func(scheme string, tlsState *tls.ConnectionState) *http.Response {
+		return &http.Response{Request: &http.Request{URL: &url.URL{Scheme: scheme, Host: "example.com"}}, TLS: tlsState}
+	}
+	httpsOnly := &ResponseTest{Id: "tls-only", RequiresHTTPS: true}
+	network := &ResponseTest{Id: "xst", RequiresNetwork: true}
+	tests := []struct {
+		name     string
+		test     *ResponseTest
+		response *http.Response
+		offline  bool
+		want     bool
+	}{
+		{name: "HTTPS-only test on HTTP", test: httpsOnly, response: responseFor("http", nil), want: false},
+		{name: "HTTPS-only test on HTTPS", test: httpsOnly, response: responseFor("https", nil), want: true},
+		{name: "HTTPS-only test with TLS state", test: httpsOnly, response: responseFor("http", &tls.ConnectionState{}), want: true},
+		{name: "HTTPS-only test without request", test: httpsOnly, response: &http.Response{}, want: true},
+		{name: "Regular test on HTTP", test: &ResponseTest{Id: "csp"}, response: responseFor("http", nil), want: true},
+		{name: "Network test online", test: network, response: responseFor("https", nil), want: true},
+		{name: "Network test offline", test: network, response: responseFor("https", nil), offline: true, want: false},
+		{name: "Regular test offline", test: &ResponseTest{Id: "csp"}, response: responseFor("https", nil), offline: true, want: true},
+		{name: "Sitemap offline", test: NewSitemapSecurityTest(), response: responseFor("https", nil), offline: true, want: false},
+		{name: "SSL certificate offline", test: NewSSLCertificateSecurityTest(), response: responseFor("https", nil), offline: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applicable, reason := tt.test.Applicable(ResponseTestParams{Response: tt.response, Offline: tt.offline})
+			if applicable != tt.want || (reason == "") != tt.want {
+				t.Errorf("Expected applicable %v, got %v (%q)", tt.want, applicable, reason)
+			}
+		})
+	}
+
+	skipped := httpsOnly.SkippedResult("not applicable")
+	if !skipped.Skipped || skipped.TestId != "tls-only" || skipped.ThreatLevel != Info {
+		t.Errorf("Expected an informational skipped result of the test, got %+v", skipped)
+	}
+}
@@ -1,6 +1,13 @@
 package execution
 
-import "Engine-AntiGinx/App/execution/strategy"
+import (
+	"Engine-AntiGinx/App/Locale"
+	"Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Suppression"
+	"Engine-AntiGinx/App/Tests"
+	"Engine-AntiGinx/App/execution/strategy"
+	"time"
+)
 
 // Plan represents a complete blueprint for a security scanning task.
 // It encapsulates all necessary configurations, the sequence of tests to be
@@ -19,6 +26,43 @@ import "Engine-AntiGinx/App/execution/strategy"
 //     values are the specific arguments and targets for that strategy.
 //   - TaskId: A unique identifier for the execution, required when reporting
 //     to a backend service (BACK_URL).
+//   - ScanId: Correlation ID attached to logs and reported results. Taken from
+//     --taskId when given; the Runner generates one when empty.
+//   - SeverityThreshold: Optional minimum threat level of an unsuppressed finding that makes
+//     the engine exit with a non-zero code (nil disables the check).
+//   - Suppressions: Optional baseline of accepted findings, excluded from the threshold.
+//   - CustomRules: Optional user-defined rules applied by the custom test (--custom-rules).
+//   - IgnoredHeaders: Disclosure headers the organisation exposes on purpose, not counted by
+//     the server disclosure test (--ignore-headers).
+//   - Targets: All targets of a batch scan (--targets-file). When set, the strategies are
+//     executed once per target and Target holds the first of them.
+//   - InvalidTargets: Descriptions of targets file lines that were rejected; they are
+//     reported without aborting the batch.
+//   - MetadataLevel: How much per-finding metadata reporters emit (--metadata, empty means full).
+//   - NoCVE: Disables external CVE lookups (--no-cve); tests rely on local heuristics only.
+//   - CVEYears: Publication window of CVE lookups in years (--cve-years, defaults to
+//     CVE.DefaultRecentYears, 0 = every CVE).
+//   - CVEMinSeverity: Lowest CVSS v3 severity counted by CVE lookups (--cve-min-severity,
+//     empty = every severity).
+//   - Deadline: Overall scan deadline (--deadline, zero = none). Tests still running when it
+//     passes are cancelled and the results gathered so far are reported as partial.
+//   - FailFast: Stop the scan on the first unsuppressed Critical finding (--fail-fast); tests
+//     still running are cancelled and the results gathered so far are reported as partial.
+//   - DropOnOverflow: Discard test results the reporter cannot keep up with instead of
+//     slowing the tests down (--drop-on-overflow, see Runner.OverflowDrop); CLI mode only.
+//   - Lang: Language of finding descriptions and remediation (--lang, empty means English);
+//     only hsts findings are translated so far.
+//   - DescriptionTemplate: Optional template rewriting every finding description
+//     (--description-template, nil keeps descriptions unchanged).
+//   - Quiet: Report only the one-line summary instead of every finding (--quiet).
+//   - Format: How the local reporter renders the results (--format, empty means text).
+//   - Output: File the JUnit or HTML report is written to (--output, empty means stdout).
+//   - Report: User-supplied report header fields (--report-title, --report-logo, --operator);
+//     the Runner adds the scan time, target and scanner version.
+//   - Artifacts: Writer of the raw response of every target, with the operator's
+//     credentials redacted (--save-artifacts, nil when artifacts are not saved).
+//   - IncludeWWW: Targets were extended with their apex/www variants (--include-www); the
+//     Runner scans only the destination of a pair whose members redirect to each other.
 //
 // Usage:
 //
@@ -35,5 +79,32 @@ type Plan struct {
 	Strategies  []strategy.TestStrategy
 	Contexts    map[string]strategy.TestContext
 	TaskId      string
+	ScanId      string
 	IsHelp      bool
+
+	SeverityThreshold *Tests.ThreatLevel
+	Suppressions      *Suppression.List
+	CustomRules       *Tests.CustomRules
+	IgnoredHeaders    []string
+
+	Targets        []string
+	InvalidTargets []string
+
+	MetadataLevel  types.MetadataLevel
+	NoCVE          bool
+	CVEYears       int
+	CVEMinSeverity string
+	Deadline       time.Duration
+	FailFast       bool
+	DropOnOverflow bool
+
+	Lang                Locale.Lang
+	DescriptionTemplate *types.DescriptionTemplate
+
+	Quiet      bool
+	Format     types.ReportFormat
+	Output     string
+	Report     types.ReportMetadata
+	Artifacts  *strategy.Artifacts
+	IncludeWWW bool
 }
@@ -1,13 +1,32 @@
 package formatterImpl
 
 import (
+	"Engine-AntiGinx/App/CVE"
 	error "Engine-AntiGinx/App/Errors"
+	HttpClient "Engine-AntiGinx/App/HTTP"
+	helpers "Engine-AntiGinx/App/Helpers"
+	"Engine-AntiGinx/App/Locale"
+	reporterTypes "Engine-AntiGinx/App/Reporter/types"
+	"Engine-AntiGinx/App/Suppression"
+	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution"
 	"Engine-AntiGinx/App/execution/strategy"
 	"Engine-AntiGinx/App/parser/config/types"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// quietSeverityThreshold is the severity threshold of a --quiet scan without an explicit
+// --severity-threshold, so the summary-only mode still fails CI on serious findings.
+const quietSeverityThreshold = Tests.High
+
 type ScanFormatter struct {
 	getStrategy func(name string) (strategy.TestStrategy, bool)
 }
@@ -33,20 +52,39 @@ func InitializeFormatter(getStrategy func(name string) (strategy.TestStrategy, b
 //
 //	If the environment variable "BACK_URL" is set, the function requires a "--taskId"
 //	parameter to be present. If missing, it panics with an error.Error (code 101).
+//	If only one of "--client-cert" and "--client-key" is given, it panics with an
+//	error.Error (code 102). Invalid authentication parameters panic with an
+//	error.Error (code 103), malformed cookies with code 104. An unknown
+//	"--severity-threshold" level panics with code 105 and an unreadable or invalid
+//	"--suppress" file with the Suppression package error, an unreadable or invalid
+//	"--custom-rules" file with the Tests package error (codes 401-403). An unreadable "--targets-file"
+//	panics with code 106 and one without any valid target with code 107. An invalid
+//	"--deadline" panics with code 108, an invalid "--body-timeout" with code 109, an
+//	invalid "--description-template" with code 110, an invalid "--connect-timeout" with
+//	code 111, an invalid "--response-timeout" with code 112, an invalid "--cve-years"
+//	with code 113 and "--drop-on-overflow" while "BACK_URL" is set with code 114.
 //
 // Returns:
 //
 //	A pointer to a Plan ready to be executed by the JobRunner.
 func (f *ScanFormatter) FormatParameters(params []*types.CommandParameter) *execution.Plan {
-	target := params[0].Arguments[0]
+	target, targets, invalidTargets := resolveTargets(params)
+	includeWWW := findParam(params, "--include-www") != -1
+	if includeWWW {
+		targets = withWWWVariants(target, targets)
+	}
 
 	// Check for global flags
 	antiBotParam := findParam(params, "--antiBotDetection")
 	useAntiBotDetection := antiBotParam != -1
+	quiet := findParam(params, "--quiet") != -1
 
 	// Map parameters to executable strategies and their specific contexts
-	mappedStrategies, mappedContexts := f.mapStrategies(params, target)
-	var taskId string
+	mappedStrategies, mappedContexts := f.mapStrategies(params, target, buildClientOptions(params), parseResponseFile(params))
+	var taskId, scanId string
+	if taskIdParam := findParam(params, "--taskId"); taskIdParam != -1 && len(params[taskIdParam].Arguments) > 0 {
+		scanId = params[taskIdParam].Arguments[0]
+	}
 	if _, exists := os.LookupEnv("BACK_URL"); exists {
 		taskIdParam := findParam(params, "--taskId")
 		if taskIdParam == -1 {
@@ -62,12 +100,36 @@ func (f *ScanFormatter) FormatParameters(params []*types.CommandParameter) *exec
 	}
 
 	return &execution.Plan{
-		Target:      target,
-		AntiBotFlag: useAntiBotDetection,
-		Strategies:  mappedStrategies,
-		Contexts:    mappedContexts,
-		TaskId:      taskId,
-		IsHelp:      false,
+		Target:            target,
+		AntiBotFlag:       useAntiBotDetection,
+		Strategies:        mappedStrategies,
+		Contexts:          mappedContexts,
+		TaskId:            taskId,
+		ScanId:            scanId,
+		IsHelp:            false,
+		SeverityThreshold: parseSeverityThreshold(params, quiet),
+		Suppressions:      loadSuppressions(params),
+		CustomRules:       loadCustomRules(params),
+		IgnoredHeaders:    parseIgnoredHeaders(params),
+		Targets:           targets,
+		InvalidTargets:    invalidTargets,
+		MetadataLevel:     parseMetadataLevel(params),
+		NoCVE:             findParam(params, "--no-cve") != -1,
+		CVEYears:          parseCVEYears(params),
+		CVEMinSeverity:    parseCVEMinSeverity(params),
+		Deadline:          parseDeadline(params),
+		FailFast:          findParam(params, "--fail-fast") != -1,
+		DropOnOverflow:    parseDropOnOverflow(params),
+
+		Lang:                parseLang(params),
+		DescriptionTemplate: parseDescriptionTemplate(params),
+
+		Quiet:      quiet,
+		Format:     parseFormat(params),
+		Output:     parseOutput(params),
+		Report:     parseReportMetadata(params),
+		Artifacts:  parseArtifacts(params),
+		IncludeWWW: includeWWW,
 	}
 }
 
@@ -78,7 +140,7 @@ func (f *ScanFormatter) FormatParameters(params []*types.CommandParameter) *exec
 // Returns:
 //   - A slice of TestStrategy: The sequence of tests to be performed.
 //   - A map of TestContext: Data specific to each strategy, keyed by strategy name.
-func (f *ScanFormatter) mapStrategies(params []*types.CommandParameter, target string) ([]strategy.TestStrategy, map[string]strategy.TestContext) {
+func (f *ScanFormatter) mapStrategies(params []*types.CommandParameter, target string, clientOpts []HttpClient.WrapperOption, responseFile string) ([]strategy.TestStrategy, map[string]strategy.TestContext) {
 	maxCapacity := len(params) - 1
 	if maxCapacity <= 0 {
 		return nil, nil
@@ -95,21 +157,625 @@ func (f *ScanFormatter) mapStrategies(params []*types.CommandParameter, target s
 				allStrategy := append(make([]strategy.TestStrategy, 0, 1), s)
 				allStrategyContext := make(map[string]strategy.TestContext)
 				allStrategyContext[s.GetName()] = strategy.TestContext{
-					Target: target,
-					Args:   params[i].Arguments,
+					Target:        target,
+					Args:          params[i].Arguments,
+					ClientOptions: clientOpts,
+					ResponseFile:  responseFile,
 				}
 				return allStrategy, allStrategyContext
 			}
 			mappedStrategies = append(mappedStrategies, s)
 			mappedContexts[s.GetName()] = strategy.TestContext{
-				Target: target,
-				Args:   params[i].Arguments,
+				Target:        target,
+				Args:          params[i].Arguments,
+				ClientOptions: clientOpts,
+				ResponseFile:  responseFile,
 			}
 		}
 	}
 	return mappedStrategies, mappedContexts
 }
 
+// buildClientOptions collects HTTP client options from connection related parameters
+// such as the client certificate used for mutual TLS and authentication credentials.
+//
+// Panic Behavior:
+//
+//	Panics with an error.Error (code 102) if only one of "--client-cert" and
+//	"--client-key" is provided, and with code 103 if "--auth-basic" is not in
+//	user:password form or is combined with "--auth-bearer", and with code 104 if a
+//	"--cookie" argument is not in name=value form. "--cookie" may be repeated. An invalid
+//	"--body-timeout" panics with code 109, "--connect-timeout" with code 111 and
+//	"--response-timeout" with code 112.
+//
+// Returns:
+//
+//	A slice of WrapperOption applied when strategies fetch the target, or nil if none are configured.
+func buildClientOptions(params []*types.CommandParameter) []HttpClient.WrapperOption {
+	var opts []HttpClient.WrapperOption
+
+	certParam := findParam(params, "--client-cert")
+	keyParam := findParam(params, "--client-key")
+	if (certParam == -1) != (keyParam == -1) {
+		panic(error.Error{
+			Code: 102,
+			Message: `Runner error occurred. This could be due to:
+					- --client-cert and --client-key must be provided together`,
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	if certParam != -1 {
+		opts = append(opts, HttpClient.WithClientCertificate(params[certParam].Arguments[0], params[keyParam].Arguments[0]))
+	}
+
+	basicParam := findParam(params, "--auth-basic")
+	bearerParam := findParam(params, "--auth-bearer")
+	if basicParam != -1 && bearerParam != -1 {
+		panic(error.Error{
+			Code: 103,
+			Message: `Runner error occurred. This could be due to:
+					- --auth-basic and --auth-bearer cannot be used together`,
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	if basicParam != -1 {
+		username, password, ok := strings.Cut(params[basicParam].Arguments[0], ":")
+		if !ok {
+			panic(error.Error{
+				Code: 103,
+				Message: `Runner error occurred. This could be due to:
+					- --auth-basic credentials must be in user:password form`,
+				Source:      "Runner",
+				IsRetryable: false,
+			})
+		}
+		opts = append(opts, HttpClient.WithBasicAuth(username, password))
+	}
+	if bearerParam != -1 {
+		opts = append(opts, HttpClient.WithBearerToken(params[bearerParam].Arguments[0]))
+	}
+
+	var cookies []*http.Cookie
+	for i := 1; i < len(params); i++ {
+		if params[i].Name != "--cookie" {
+			continue
+		}
+		for _, arg := range params[i].Arguments {
+			name, value, ok := strings.Cut(arg, "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				panic(error.Error{
+					Code: 104,
+					Message: `Runner error occurred. This could be due to:
+					- --cookie arguments must be in name=value form`,
+					Source:      "Runner",
+					IsRetryable: false,
+				})
+			}
+			cookies = append(cookies, &http.Cookie{Name: strings.TrimSpace(name), Value: value})
+		}
+	}
+	if len(cookies) > 0 {
+		opts = append(opts, HttpClient.WithSessionCookies(cookies))
+	}
+	if timeout := parseTimeout(params, "--body-timeout", 109); timeout > 0 {
+		opts = append(opts, HttpClient.WithBodyReadTimeout(timeout))
+	}
+	if timeout := parseTimeout(params, "--connect-timeout", 111); timeout > 0 {
+		opts = append(opts, HttpClient.WithDialTimeout(timeout), HttpClient.WithTLSHandshakeTimeout(timeout))
+	}
+	if timeout := parseTimeout(params, "--response-timeout", 112); timeout > 0 {
+		opts = append(opts, HttpClient.WithResponseTimeout(timeout))
+	}
+	return opts
+}
+
+// parseMetadataLevel reads the optional "--metadata" parameter. Its value is validated by
+// the parameter registry, so no further checks are needed.
+//
+// Returns:
+//
+//	The requested metadata level, or an empty level (full metadata) if the parameter is absent.
+func parseMetadataLevel(params []*types.CommandParameter) reporterTypes.MetadataLevel {
+	idx := findParam(params, "--metadata")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return ""
+	}
+	return reporterTypes.MetadataLevel(params[idx].Arguments[0])
+}
+
+// parseFormat reads the optional "--format" parameter (values validated by the parser).
+//
+// Returns:
+//
+//	The report format, or an empty string (text) if the parameter is absent.
+func parseFormat(params []*types.CommandParameter) reporterTypes.ReportFormat {
+	idx := findParam(params, "--format")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return ""
+	}
+	return reporterTypes.ReportFormat(params[idx].Arguments[0])
+}
+
+// parseOutput reads the optional "--output" parameter, the path of the report file.
+//
+// Returns:
+//
+//	The report file path, or an empty string (stdout) if the parameter is absent.
+func parseOutput(params []*types.CommandParameter) string {
+	idx := findParam(params, "--output")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return ""
+	}
+	return params[idx].Arguments[0]
+}
+
+// parseReportMetadata reads the optional report header parameters "--report-title",
+// "--report-logo" and "--operator".
+//
+// Returns:
+//
+//	The user-supplied report header fields, empty when the parameters are absent.
+func parseReportMetadata(params []*types.CommandParameter) reporterTypes.ReportMetadata {
+	value := func(name string) string {
+		idx := findParam(params, name)
+		if idx == -1 || len(params[idx].Arguments) == 0 {
+			return ""
+		}
+		return params[idx].Arguments[0]
+	}
+	return reporterTypes.ReportMetadata{
+		Title:    value("--report-title"),
+		LogoURL:  value("--report-logo"),
+		Operator: value("--operator"),
+	}
+}
+
+// parseArtifacts reads the optional "--save-artifacts" parameter, the directory receiving
+// the raw response of every target. The credentials configured with "--auth-basic",
+// "--auth-bearer" and "--cookie" (validated by buildClientOptions) are redacted from it.
+//
+// Returns:
+//
+//	The artifact writer, or nil if the parameter is absent.
+func parseArtifacts(params []*types.CommandParameter) *strategy.Artifacts {
+	idx := findParam(params, "--save-artifacts")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return nil
+	}
+	var secrets []string
+	if basicParam := findParam(params, "--auth-basic"); basicParam != -1 {
+		credentials := params[basicParam].Arguments[0]
+		_, password, _ := strings.Cut(credentials, ":")
+		secrets = append(secrets, credentials, password, base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+	if bearerParam := findParam(params, "--auth-bearer"); bearerParam != -1 {
+		secrets = append(secrets, params[bearerParam].Arguments[0])
+	}
+	for i := 1; i < len(params); i++ {
+		if params[i].Name != "--cookie" {
+			continue
+		}
+		for _, arg := range params[i].Arguments {
+			if _, value, ok := strings.Cut(arg, "="); ok {
+				secrets = append(secrets, value)
+			}
+		}
+	}
+	return strategy.NewArtifacts(params[idx].Arguments[0], secrets...)
+}
+
+// parseDropOnOverflow reads the optional "--drop-on-overflow" flag. The backend must
+// receive every result, so the flag is rejected when results are sent to BACK_URL.
+//
+// Panic Behavior:
+//
+//	Panics with an error.Error (code 114) if the flag is given while BACK_URL is set.
+//
+// Returns:
+//
+//	True if test results may be dropped when the reporter falls behind.
+func parseDropOnOverflow(params []*types.CommandParameter) bool {
+	if findParam(params, "--drop-on-overflow") == -1 {
+		return false
+	}
+	if _, exists := os.LookupEnv("BACK_URL"); exists {
+		panic(error.Error{
+			Code: 114,
+			Message: `Runner error occurred. This could be due to:
+					- --drop-on-overflow cannot be used while BACK_URL is set, the backend must receive every result`,
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	return true
+}
+
+// parseDeadline reads the optional "--deadline" parameter, given either as a Go duration
+// (e.g., "90s", "5m") or as a number of seconds.
+//
+// Panic Behavior:
+//
+//	Panics with an error.Error (code 108) if the value is not a positive duration.
+//
+// Returns:
+//
+//	The overall scan deadline, or zero (no deadline) if the parameter is absent.
+func parseDeadline(params []*types.CommandParameter) time.Duration {
+	idx := findParam(params, "--deadline")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return 0
+	}
+	deadline, ok := parsePositiveDuration(params[idx].Arguments[0])
+	if !ok {
+		panic(error.Error{
+			Code: 108,
+			Message: `Runner error occurred. This could be due to:
+					- --deadline must be a positive duration (e.g., 90s, 5m) or number of seconds`,
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	return deadline
+}
+
+// parseResponseFile reads the optional "--from-file" parameter naming a saved response
+// (raw HTTP response or single-entry HAR) to analyse instead of requesting the target.
+// The file is loaded by the strategies, which report an unreadable file like a failed request.
+//
+// Returns:
+//
+//	The response file path, or an empty string (live scan) if the parameter is absent.
+func parseResponseFile(params []*types.CommandParameter) string {
+	idx := findParam(params, "--from-file")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return ""
+	}
+	return params[idx].Arguments[0]
+}
+
+// parseTimeout reads an optional HTTP client timeout parameter ("--body-timeout",
+// "--connect-timeout" or "--response-timeout"), given like "--deadline" as a Go duration or
+// number of seconds.
+//
+// Panic Behavior:
+//
+//	Panics with an error.Error with the given code if the value is not a positive duration.
+//
+// Returns:
+//
+//	The timeout, or zero (HttpClient default) if the parameter is absent.
+func parseTimeout(params []*types.CommandParameter, name string, code int) time.Duration {
+	idx := findParam(params, name)
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return 0
+	}
+	timeout, ok := parsePositiveDuration(params[idx].Arguments[0])
+	if !ok {
+		panic(error.Error{
+			Code: code,
+			Message: `Runner error occurred. This could be due to:
+					- ` + name + ` must be a positive duration (e.g., 5s, 500ms) or number of seconds`,
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	return timeout
+}
+
+// parseCVEYears reads the optional "--cve-years" parameter, the publication window of CVE
+// lookups in years.
+//
+// Panic Behavior:
+//
+//	Panics with an error.Error (code 113) if the value is not a non-negative number of years.
+//
+// Returns:
+//
+//	The window in years, CVE.DefaultRecentYears if the parameter is absent, or 0 (every CVE).
+func parseCVEYears(params []*types.CommandParameter) int {
+	idx := findParam(params, "--cve-years")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return CVE.DefaultRecentYears
+	}
+	years, err := strconv.Atoi(params[idx].Arguments[0])
+	if err != nil || years < 0 {
+		panic(error.Error{
+			Code: 113,
+			Message: `Runner error occurred. This could be due to:
+					- --cve-years must be a non-negative number of years (0 looks up every CVE)`,
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	return years
+}
+
+// parseCVEMinSeverity reads the optional "--cve-min-severity" parameter (values validated by
+// the parser), the lowest CVSS v3 severity counted by CVE lookups.
+//
+// Returns:
+//
+//	The minimum severity, or an empty string (every severity) if the parameter is absent.
+func parseCVEMinSeverity(params []*types.CommandParameter) string {
+	idx := findParam(params, "--cve-min-severity")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return ""
+	}
+	return params[idx].Arguments[0]
+}
+
+// parsePositiveDuration parses a Go duration (e.g., "90s") or a number of seconds and
+// reports whether the result is a positive duration.
+func parsePositiveDuration(value string) (time.Duration, bool) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(value)
+		duration, err = time.Duration(seconds)*time.Second, convErr
+	}
+	return duration, err == nil && duration > 0
+}
+
+// parseLang reads the optional "--lang" parameter. Its value is validated by the parameter
+// registry against the languages with a message catalog.
+//
+// Returns:
+//
+//	The requested language, or an empty language (English) if the parameter is absent.
+func parseLang(params []*types.CommandParameter) Locale.Lang {
+	idx := findParam(params, "--lang")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return ""
+	}
+	return Locale.Lang(params[idx].Arguments[0])
+}
+
+// parseDescriptionTemplate reads the optional "--description-template" parameter holding a
+// Go text/template that rewrites every finding description (see types.DescriptionTemplate).
+//
+// Panic Behavior:
+//
+//	Panics with an error.Error (code 110) if the template does not parse.
+//
+// Returns:
+//
+//	The compiled template, or nil (descriptions unchanged) if the parameter is absent.
+func parseDescriptionTemplate(params []*types.CommandParameter) *reporterTypes.DescriptionTemplate {
+	idx := findParam(params, "--description-template")
+	if idx == -1 || len(params[idx].Arguments) == 0 {
+		return nil
+	}
+	tmpl, err := reporterTypes.ParseDescriptionTemplate(params[idx].Arguments[0])
+	if err != nil {
+		panic(error.Error{
+			Code: 110,
+			Message: fmt.Sprintf(`Runner error occurred. This could be due to:
+					- --description-template is not a valid Go text/template: %v`, err),
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	return tmpl
+}
+
+// parseSeverityThreshold reads the optional "--severity-threshold" parameter. In quiet mode
+// the scan is meant as a CI gate, so a missing threshold defaults to quietSeverityThreshold.
+//
+// Panic Behavior:
+//
+//	Panics with an error.Error (code 105) if the level is not a valid threat level.
+//
+// Returns:
+//
+//	A pointer to the parsed threat level, or nil if the parameter is absent outside quiet mode.
+func parseSeverityThreshold(params []*types.CommandParameter, quiet bool) *Tests.ThreatLevel {
+	idx := findParam(params, "--severity-threshold")
+	if idx == -1 {
+		if quiet {
+			level := quietSeverityThreshold
+			return &level
+		}
+		return nil
+	}
+	level, err := Tests.ParseThreatLevel(params[idx].Arguments[0])
+	if err != nil {
+		panic(error.Error{
+			Code: 105,
+			Message: `Runner error occurred. This could be due to:
+					- --severity-threshold must be one of none, info, low, medium, high, critical`,
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	return &level
+}
+
+// loadSuppressions reads the optional "--suppress" baseline file.
+//
+// Panic Behavior:
+//
+//	Panics with the Suppression package error if the file cannot be loaded.
+//
+// Returns:
+//
+//	The loaded suppressions, or nil if the parameter is absent.
+func loadSuppressions(params []*types.CommandParameter) *Suppression.List {
+	idx := findParam(params, "--suppress")
+	if idx == -1 {
+		return nil
+	}
+	list, err := Suppression.Load(params[idx].Arguments[0])
+	if err != nil {
+		panic(*err)
+	}
+	return list
+}
+
+// loadCustomRules reads the optional "--custom-rules" file of the custom test.
+//
+// Panic Behavior:
+//
+//	Panics with the Tests package error if the file cannot be loaded or holds an invalid rule.
+//
+// Returns:
+//
+//	The validated rules, or nil if the parameter is absent.
+func loadCustomRules(params []*types.CommandParameter) *Tests.CustomRules {
+	idx := findParam(params, "--custom-rules")
+	if idx == -1 {
+		return nil
+	}
+	rules, err := Tests.LoadCustomRules(params[idx].Arguments[0])
+	if err != nil {
+		panic(*err)
+	}
+	return rules
+}
+
+// parseIgnoredHeaders reads the optional "--ignore-headers" parameter, the disclosure headers
+// the server disclosure test does not count. Names are canonicalized (e.g., "x-served-by"
+// becomes "X-Served-By") and duplicates dropped.
+//
+// Returns:
+//
+//	The header names, or nil if the parameter is absent.
+func parseIgnoredHeaders(params []*types.CommandParameter) []string {
+	idx := findParam(params, "--ignore-headers")
+	if idx == -1 {
+		return nil
+	}
+	var headers []string
+	for _, name := range params[idx].Arguments {
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if name != "" && !slices.Contains(headers, name) {
+			headers = append(headers, name)
+		}
+	}
+	return headers
+}
+
+// resolveTargets determines the scan targets. Without "--targets-file" the first parameter
+// holds the single target. With it, every line of the file is a target; blank lines and
+// lines starting with '#' are skipped, and an explicit "--target" is scanned as well.
+//
+// Panic Behavior:
+//
+//	Panics with an error.Error (code 106) if the targets file cannot be read and with
+//	code 107 if it contains no valid target.
+//
+// Returns:
+//
+//	The primary target, all batch targets (nil for a single target scan) and descriptions
+//	of rejected targets file lines.
+func resolveTargets(params []*types.CommandParameter) (string, []string, []string) {
+	fileIdx := -1
+	for i, p := range params {
+		if p.Name == "--targets-file" {
+			fileIdx = i
+			break
+		}
+	}
+	if fileIdx == -1 {
+		return params[0].Arguments[0], nil, nil
+	}
+
+	path := params[fileIdx].Arguments[0]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(error.Error{
+			Code: 106,
+			Message: fmt.Sprintf(`Runner error occurred. This could be due to:
+					- targets file %s cannot be read: %v`, path, err),
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+
+	var targets []string
+	if params[0].Name == "--target" {
+		targets = append(targets, params[0].Arguments[0])
+	}
+	valid, invalid := parseTargetsFile(data)
+	targets = append(targets, valid...)
+	if len(targets) == 0 {
+		panic(error.Error{
+			Code: 107,
+			Message: fmt.Sprintf(`Runner error occurred. This could be due to:
+					- targets file %s does not contain any valid target`, path),
+			Source:      "Runner",
+			IsRetryable: false,
+		})
+	}
+	return targets[0], targets, invalid
+}
+
+// withWWWVariants adds the apex/www variant (see helpers.WWWVariant) of every target right
+// after it, skipping targets already listed. A single target scan becomes a batch scan of
+// the target and its variant, so both are reported as distinct targets; a target without
+// a variant stays a single target scan.
+//
+// Returns:
+//
+//	The extended batch targets, or targets unchanged when nothing was added to a single target.
+func withWWWVariants(target string, targets []string) []string {
+	if targets == nil {
+		if _, ok := helpers.WWWVariant(target); !ok {
+			return nil
+		}
+		targets = []string{target}
+	}
+	expanded := make([]string, 0, 2*len(targets))
+	seen := make(map[string]bool, 2*len(targets))
+	add := func(target string) {
+		if key := strings.ToLower(target); !seen[key] {
+			seen[key] = true
+			expanded = append(expanded, target)
+		}
+	}
+	for _, target := range targets {
+		add(target)
+		if variant, ok := helpers.WWWVariant(target); ok {
+			add(variant)
+		}
+	}
+	return expanded
+}
+
+// parseTargetsFile splits targets file content into valid targets and descriptions of
+// invalid lines. Blank lines and '#' comments are ignored.
+func parseTargetsFile(data []byte) ([]string, []string) {
+	var valid, invalid []string
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !isValidTarget(line) {
+			invalid = append(invalid, fmt.Sprintf("Invalid target on line %d of targets file: %q", i+1, line))
+			continue
+		}
+		valid = append(valid, line)
+	}
+	return valid, invalid
+}
+
+// isValidTarget reports whether a targets file entry is a host or an http(s) URL.
+func isValidTarget(target string) bool {
+	if strings.ContainsAny(target, " \t") {
+		return false
+	}
+	candidate := target
+	if !strings.Contains(candidate, "://") {
+		candidate = "https://" + candidate
+	}
+	parsed, err := url.Parse(candidate)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Hostname() != ""
+}
+
 // findParam is a helper function that performs a linear search through parameters
 // to find a match by name. Returns the index of the parameter or -1 if not found.
 func findParam(params []*types.CommandParameter, paramToFind string) int {
@@ -1,11 +1,16 @@
 package formatterImpl
 
 import (
+	"Engine-AntiGinx/App/CVE"
+	"Engine-AntiGinx/App/Locale"
+	reporterTypes "Engine-AntiGinx/App/Reporter/types"
 	"Engine-AntiGinx/App/Runner"
+	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution"
 	"Engine-AntiGinx/App/execution/strategy"
 	"Engine-AntiGinx/App/parser/config/types"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -43,8 +48,10 @@ func TestScanFormatter_FormatParameters(t *testing.T) {
 					Args:   []string{"test"},
 				},
 			},
-			TaskId: taskId,
-			IsHelp: false,
+			TaskId:   taskId,
+			ScanId:   taskId,
+			IsHelp:   false,
+			CVEYears: CVE.DefaultRecentYears,
 		}
 	}
 
@@ -98,7 +105,8 @@ func TestScanFormatter_FormatParameters(t *testing.T) {
 						Args:   []string{},
 					},
 				},
-				IsHelp: false,
+				IsHelp:   false,
+				CVEYears: CVE.DefaultRecentYears,
 			},
 			getStrategies: func(name string) (strategy.TestStrategy, bool) {
 				if name == "--all" {
@@ -133,3 +141,460 @@ func TestScanFormatter_FormatParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestScanFormatter_FormatParameters_ClientCertificate(t *testing.T) {
+	mockStrategy := &Runner.MockStrategy{Name: "--tests"}
+	getStrategy := func(name string) (strategy.TestStrategy, bool) {
+		if name == "--tests" {
+			return mockStrategy, true
+		}
+		return nil, false
+	}
+	targetParam := &types.CommandParameter{Name: "--target", Arguments: []string{"testTarget"}}
+	testsParam := &types.CommandParameter{Name: "--tests", Arguments: []string{"test"}}
+	certParam := &types.CommandParameter{Name: "--client-cert", Arguments: []string{"client.crt"}}
+	keyParam := &types.CommandParameter{Name: "--client-key", Arguments: []string{"client.key"}}
+
+	t.Run("Certificate and key produce a client option", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam, certParam, keyParam})
+		assert.Len(t, plan.Contexts["--tests"].ClientOptions, 1, "Context should carry the client certificate option")
+	})
+
+	t.Run("No certificate leaves client options empty", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Empty(t, plan.Contexts["--tests"].ClientOptions, "Context should not carry client options")
+	})
+
+	t.Run("Certificate without key", func(t *testing.T) {
+		assert.Panics(t, func() {
+			formatter := InitializeFormatter(getStrategy)
+			formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam, certParam})
+		}, "Should panic when --client-key is missing")
+	})
+}
+
+func TestScanFormatter_FormatParameters_Authentication(t *testing.T) {
+	getStrategy := func(name string) (strategy.TestStrategy, bool) {
+		if name == "--tests" {
+			return &Runner.MockStrategy{Name: "--tests"}, true
+		}
+		return nil, false
+	}
+	targetParam := &types.CommandParameter{Name: "--target", Arguments: []string{"testTarget"}}
+	testsParam := &types.CommandParameter{Name: "--tests", Arguments: []string{"test"}}
+	basicParam := &types.CommandParameter{Name: "--auth-basic", Arguments: []string{"admin:secret"}}
+	bearerParam := &types.CommandParameter{Name: "--auth-bearer", Arguments: []string{"token123"}}
+	malformedBasicParam := &types.CommandParameter{Name: "--auth-basic", Arguments: []string{"admin"}}
+
+	tests := []struct {
+		name            string
+		input           []*types.CommandParameter
+		wantErr         bool
+		expectedOptions int
+	}{
+		{name: "Basic auth", input: []*types.CommandParameter{targetParam, testsParam, basicParam}, expectedOptions: 1},
+		{name: "Bearer token", input: []*types.CommandParameter{targetParam, testsParam, bearerParam}, expectedOptions: 1},
+		{name: "Basic auth without password separator", input: []*types.CommandParameter{targetParam, testsParam, malformedBasicParam}, wantErr: true},
+		{name: "Basic auth combined with bearer token", input: []*types.CommandParameter{targetParam, testsParam, basicParam, bearerParam}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := InitializeFormatter(getStrategy)
+			if tt.wantErr {
+				assert.Panics(t, func() { formatter.FormatParameters(tt.input) }, "Should panic on invalid credentials")
+				return
+			}
+			plan := formatter.FormatParameters(tt.input)
+			assert.Len(t, plan.Contexts["--tests"].ClientOptions, tt.expectedOptions)
+		})
+	}
+}
+
+func TestScanFormatter_FormatParameters_SessionCookies(t *testing.T) {
+	getStrategy := func(name string) (strategy.TestStrategy, bool) {
+		if name == "--tests" {
+			return &Runner.MockStrategy{Name: "--tests"}, true
+		}
+		return nil, false
+	}
+	targetParam := &types.CommandParameter{Name: "--target", Arguments: []string{"testTarget"}}
+	testsParam := &types.CommandParameter{Name: "--tests", Arguments: []string{"test"}}
+
+	t.Run("Repeated cookie params are combined", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--cookie", Arguments: []string{"session=abc"}},
+			{Name: "--cookie", Arguments: []string{"csrf=xyz"}},
+		})
+		assert.Len(t, plan.Contexts["--tests"].ClientOptions, 1, "Cookies should be combined into a single option")
+	})
+
+	t.Run("Cookie without value separator", func(t *testing.T) {
+		assert.Panics(t, func() {
+			formatter := InitializeFormatter(getStrategy)
+			formatter.FormatParameters([]*types.CommandParameter{
+				targetParam, testsParam,
+				{Name: "--cookie", Arguments: []string{"session"}},
+			})
+		}, "Should panic on malformed cookie")
+	})
+}
+
+func TestScanFormatter_FormatParameters_ThresholdAndSuppressions(t *testing.T) {
+	getStrategy := func(name string) (strategy.TestStrategy, bool) {
+		if name == "--tests" {
+			return &Runner.MockStrategy{Name: "--tests"}, true
+		}
+		return nil, false
+	}
+	targetParam := &types.CommandParameter{Name: "--target", Arguments: []string{"testTarget"}}
+	testsParam := &types.CommandParameter{Name: "--tests", Arguments: []string{"test"}}
+
+	t.Run("Threshold is parsed", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--severity-threshold", Arguments: []string{"high"}},
+		})
+		if assert.NotNil(t, plan.SeverityThreshold) {
+			assert.Equal(t, Tests.High, *plan.SeverityThreshold)
+		}
+		assert.Nil(t, plan.Suppressions)
+	})
+
+	t.Run("Invalid threshold", func(t *testing.T) {
+		assert.Panics(t, func() {
+			formatter := InitializeFormatter(getStrategy)
+			formatter.FormatParameters([]*types.CommandParameter{
+				targetParam, testsParam,
+				{Name: "--severity-threshold", Arguments: []string{"severe"}},
+			})
+		}, "Should panic on unknown threat level")
+	})
+
+	t.Run("Missing suppressions file", func(t *testing.T) {
+		assert.Panics(t, func() {
+			formatter := InitializeFormatter(getStrategy)
+			formatter.FormatParameters([]*types.CommandParameter{
+				targetParam, testsParam,
+				{Name: "--suppress", Arguments: []string{"does-not-exist.json"}},
+			})
+		}, "Should panic when suppressions file cannot be loaded")
+	})
+
+	t.Run("No CVE flag", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.False(t, plan.NoCVE)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--no-cve", Arguments: []string{}},
+		})
+		assert.True(t, plan.NoCVE)
+	})
+
+	t.Run("CVE years", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Equal(t, CVE.DefaultRecentYears, plan.CVEYears, "Should default to the recent CVE window")
+
+		for value, expected := range map[string]int{"2": 2, "0": 0} {
+			plan = formatter.FormatParameters([]*types.CommandParameter{
+				targetParam, testsParam,
+				{Name: "--cve-years", Arguments: []string{value}},
+			})
+			assert.Equal(t, expected, plan.CVEYears)
+		}
+		for _, value := range []string{"-1", "five"} {
+			assert.Panics(t, func() {
+				formatter.FormatParameters([]*types.CommandParameter{
+					targetParam, testsParam,
+					{Name: "--cve-years", Arguments: []string{value}},
+				})
+			}, "Should panic on invalid CVE window %q", value)
+		}
+	})
+
+	t.Run("CVE minimum severity", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Empty(t, plan.CVEMinSeverity, "Should count every severity by default")
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--cve-min-severity", Arguments: []string{"high"}},
+		})
+		assert.Equal(t, "high", plan.CVEMinSeverity)
+	})
+
+	t.Run("Ignored headers", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Nil(t, plan.IgnoredHeaders)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--ignore-headers", Arguments: []string{"x-served-by", "X-Cache", "X-Served-By"}},
+		})
+		assert.Equal(t, []string{"X-Served-By", "X-Cache"}, plan.IgnoredHeaders)
+	})
+
+	t.Run("Quiet", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--quiet", Arguments: []string{}},
+		})
+		assert.True(t, plan.Quiet)
+		if assert.NotNil(t, plan.SeverityThreshold) {
+			assert.Equal(t, Tests.High, *plan.SeverityThreshold)
+		}
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--quiet", Arguments: []string{}},
+			{Name: "--severity-threshold", Arguments: []string{"low"}},
+		})
+		if assert.NotNil(t, plan.SeverityThreshold) {
+			assert.Equal(t, Tests.Low, *plan.SeverityThreshold)
+		}
+	})
+
+	t.Run("Format and output", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Empty(t, plan.Format)
+		assert.Empty(t, plan.Output)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--format", Arguments: []string{"junit"}},
+			{Name: "--output", Arguments: []string{"report.xml"}},
+		})
+		assert.Equal(t, reporterTypes.FormatJUnit, plan.Format)
+		assert.Equal(t, "report.xml", plan.Output)
+	})
+
+	t.Run("Report header", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Empty(t, plan.Report)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--report-title", Arguments: []string{"Q3 audit"}},
+			{Name: "--report-logo", Arguments: []string{"https://cdn.example.com/logo.png"}},
+			{Name: "--operator", Arguments: []string{"Blue team"}},
+		})
+		assert.Equal(t, reporterTypes.ReportMetadata{
+			Title:    "Q3 audit",
+			LogoURL:  "https://cdn.example.com/logo.png",
+			Operator: "Blue team",
+		}, plan.Report)
+	})
+
+	t.Run("Metadata level", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Empty(t, plan.MetadataLevel)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--metadata", Arguments: []string{"summary"}},
+		})
+		assert.Equal(t, reporterTypes.MetadataSummary, plan.MetadataLevel)
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Zero(t, plan.Deadline)
+
+		for value, want := range map[string]time.Duration{"90s": 90 * time.Second, "2m": 2 * time.Minute, "45": 45 * time.Second} {
+			plan = formatter.FormatParameters([]*types.CommandParameter{
+				targetParam, testsParam,
+				{Name: "--deadline", Arguments: []string{value}},
+			})
+			assert.Equal(t, want, plan.Deadline, value)
+		}
+
+		for _, value := range []string{"soon", "0", "-5s"} {
+			assert.Panics(t, func() {
+				formatter.FormatParameters([]*types.CommandParameter{
+					targetParam, testsParam,
+					{Name: "--deadline", Arguments: []string{value}},
+				})
+			}, "Should panic on invalid deadline %q", value)
+		}
+	})
+
+	t.Run("DropOnOverflow", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.False(t, plan.DropOnOverflow)
+
+		dropParam := &types.CommandParameter{Name: "--drop-on-overflow", Arguments: []string{}}
+		plan = formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam, dropParam})
+		assert.True(t, plan.DropOnOverflow)
+
+		t.Setenv("BACK_URL", "test")
+		assert.Panics(t, func() {
+			formatter.FormatParameters([]*types.CommandParameter{
+				targetParam, testsParam, dropParam,
+				{Name: "--taskId", Arguments: []string{"task-1"}},
+			})
+		}, "Should panic when results may be dropped in backend mode")
+	})
+
+	t.Run("BodyTimeout", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--body-timeout", Arguments: []string{"500ms"}},
+		})
+		assert.Len(t, plan.Contexts["--tests"].ClientOptions, 1)
+
+		for _, value := range []string{"never", "0"} {
+			assert.Panics(t, func() {
+				formatter.FormatParameters([]*types.CommandParameter{
+					targetParam, testsParam,
+					{Name: "--body-timeout", Arguments: []string{value}},
+				})
+			}, "Should panic on invalid body timeout %q", value)
+		}
+	})
+
+	t.Run("ConnectionTimeouts", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--connect-timeout", Arguments: []string{"3s"}},
+			{Name: "--response-timeout", Arguments: []string{"120"}},
+		})
+		// --connect-timeout bounds both the dial and the TLS handshake
+		assert.Len(t, plan.Contexts["--tests"].ClientOptions, 3)
+
+		for _, name := range []string{"--connect-timeout", "--response-timeout"} {
+			assert.Panics(t, func() {
+				formatter.FormatParameters([]*types.CommandParameter{
+					targetParam, testsParam,
+					{Name: name, Arguments: []string{"-1s"}},
+				})
+			}, "Should panic on invalid %s", name)
+		}
+	})
+
+	t.Run("Lang", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Empty(t, plan.Lang)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--lang", Arguments: []string{"pl"}},
+		})
+		assert.Equal(t, Locale.Polish, plan.Lang)
+	})
+
+	t.Run("DescriptionTemplate", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Nil(t, plan.DescriptionTemplate)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--description-template", Arguments: []string{"[{{.TestId}}] {{.Description}}"}},
+		})
+		assert.NotNil(t, plan.DescriptionTemplate)
+
+		assert.Panics(t, func() {
+			formatter.FormatParameters([]*types.CommandParameter{
+				targetParam, testsParam,
+				{Name: "--description-template", Arguments: []string{"{{.TestId"}},
+			})
+		}, "Should panic on an invalid template")
+	})
+
+	t.Run("ResponseFile", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{targetParam, testsParam})
+		assert.Empty(t, plan.Contexts["--tests"].ResponseFile)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			targetParam, testsParam,
+			{Name: "--from-file", Arguments: []string{"response.har"}},
+		})
+		assert.Equal(t, "response.har", plan.Contexts["--tests"].ResponseFile)
+	})
+}
+
+func TestScanFormatter_FormatParameters_TargetsFile(t *testing.T) {
+	getStrategy := func(name string) (strategy.TestStrategy, bool) {
+		if name == "--tests" {
+			return &Runner.MockStrategy{Name: "--tests"}, true
+		}
+		return nil, false
+	}
+	testsParam := &types.CommandParameter{Name: "--tests", Arguments: []string{"test"}}
+
+	t.Run("Targets are read from file", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{
+			{Name: "--targets-file", Arguments: []string{"testdata/targets.txt"}},
+			testsParam,
+		})
+		assert.Equal(t, []string{"example.com", "https://example.org/login"}, plan.Targets)
+		assert.Equal(t, "example.com", plan.Target)
+		assert.Len(t, plan.InvalidTargets, 1, "Invalid line should be reported")
+	})
+
+	t.Run("Explicit target is scanned with the file targets", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{
+			{Name: "--target", Arguments: []string{"explicit.com"}},
+			testsParam,
+			{Name: "--targets-file", Arguments: []string{"testdata/targets.txt"}},
+		})
+		assert.Equal(t, []string{"explicit.com", "example.com", "https://example.org/login"}, plan.Targets)
+	})
+
+	t.Run("Include www variants", func(t *testing.T) {
+		formatter := InitializeFormatter(getStrategy)
+		plan := formatter.FormatParameters([]*types.CommandParameter{
+			{Name: "--target", Arguments: []string{"example.com"}},
+			testsParam,
+			{Name: "--include-www"},
+		})
+		assert.True(t, plan.IncludeWWW)
+		assert.Equal(t, []string{"example.com", "www.example.com"}, plan.Targets)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			{Name: "--target", Arguments: []string{"www.example.com"}},
+			testsParam,
+			{Name: "--targets-file", Arguments: []string{"testdata/targets.txt"}},
+			{Name: "--include-www"},
+		})
+		assert.Equal(t, []string{"www.example.com", "example.com", "https://example.org/login", "https://www.example.org/login"}, plan.Targets)
+
+		plan = formatter.FormatParameters([]*types.CommandParameter{
+			{Name: "--target", Arguments: []string{"api.example.com"}},
+			testsParam,
+			{Name: "--include-www"},
+		})
+		assert.Nil(t, plan.Targets, "A subdomain has no www variant and stays a single target scan")
+	})
+
+	t.Run("Missing targets file", func(t *testing.T) {
+		assert.Panics(t, func() {
+			formatter := InitializeFormatter(getStrategy)
+			formatter.FormatParameters([]*types.CommandParameter{
+				{Name: "--targets-file", Arguments: []string{"testdata/missing.txt"}},
+				testsParam,
+			})
+		}, "Should panic when targets file cannot be read")
+	})
+}
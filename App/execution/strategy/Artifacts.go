@@ -0,0 +1,226 @@
+package strategy
+
+import (
+	"Engine-AntiGinx/App/Tests"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ArtifactsWarningId identifies the warning result emitted when the response artifacts of a
+// target could not be written (--save-artifacts).
+const ArtifactsWarningId = "save-artifacts"
+
+// artifactRedacted replaces the operator's credentials in the artifacts.
+const artifactRedacted = "[REDACTED]"
+
+// artifactMinSecretLength is the length below which a credential is not redacted from the
+// body, where replacing a short value would mangle unrelated content.
+const artifactMinSecretLength = 4
+
+// artifactCredentialHeaders are request headers carrying the operator's credentials; their
+// values are always redacted.
+var artifactCredentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// artifactNameUnsafe matches the characters replaced in artifact file names.
+var artifactNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// Artifacts writes the raw response of every scanned target to a directory
+// (--save-artifacts), as evidence that findings can be reproduced from and attached to
+// reports. Credentials configured by the operator (--auth-basic, --auth-bearer, --cookie)
+// are redacted from the written files.
+//
+// For every target two files are written, named after its host and path:
+//   - <name>.http: The response as received (status line, headers, blank line, body), which
+//     --from-file can analyse again
+//   - <name>.request.txt: The request line and headers sent for it
+type Artifacts struct {
+	dir     string
+	secrets []string
+}
+
+// NewArtifacts creates an artifact writer for dir, redacting the given credentials.
+//
+// Parameters:
+//   - dir: Directory receiving the artifacts, created when missing
+//   - secrets: Credential values of the operator (passwords, tokens, cookie values)
+//
+// Returns:
+//   - *Artifacts: Writer ready to Save responses
+//
+// Example:
+//
+//	artifacts := strategy.NewArtifacts("./evidence", "s3cr3t-token")
+//	files, err := artifacts.Save("https://example.com", response, body)
+func NewArtifacts(dir string, secrets ...string) *Artifacts {
+	kept := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		if len(secret) >= artifactMinSecretLength {
+			kept = append(kept, secret)
+		}
+	}
+	// Longest first, so a secret containing another one is redacted whole.
+	sort.SliceStable(kept, func(i, j int) bool { return len(kept[i]) > len(kept[j]) })
+	return &Artifacts{dir: dir, secrets: kept}
+}
+
+// Dir returns the directory the artifacts are written to.
+func (a *Artifacts) Dir() string { return a.dir }
+
+// Save writes the artifacts of the response loaded for target.
+//
+// Parameters:
+//   - target: Formatted target URL, naming the files
+//   - response: Response loaded for the target
+//   - body: Body of the response, already read
+//
+// Returns:
+//   - []string: Paths of the written files
+//   - error: Why the directory or a file could not be written
+func (a *Artifacts) Save(target string, response *http.Response, body []byte) ([]string, error) {
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return nil, err
+	}
+	base := filepath.Join(a.dir, artifactName(target))
+
+	var raw bytes.Buffer
+	redactedBody := a.redact(body)
+	saved := *response
+	saved.Header = a.redactHeader(response.Header, false)
+	saved.Body = io.NopCloser(bytes.NewReader(redactedBody))
+	saved.ContentLength = int64(len(redactedBody))
+	saved.TransferEncoding = nil
+	saved.Request = nil
+	if saved.ProtoMajor == 0 {
+		saved.ProtoMajor, saved.ProtoMinor = 1, 1
+	}
+	if saved.StatusCode == 0 {
+		saved.StatusCode = http.StatusOK
+	}
+	if err := saved.Write(&raw); err != nil {
+		return nil, err
+	}
+
+	var request bytes.Buffer
+	if response.Request != nil && response.Request.URL != nil {
+		method := response.Request.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+		fmt.Fprintf(&request, "%s %s HTTP/1.1\r\n", method, a.redactString(response.Request.URL.String()))
+		_ = a.redactHeader(response.Request.Header, true).Write(&request)
+	} else {
+		fmt.Fprintf(&request, "GET %s HTTP/1.1\r\n", a.redactString(target))
+	}
+	request.WriteString("\r\n")
+
+	files := []string{base + ".http", base + ".request.txt"}
+	for i, content := range [][]byte{raw.Bytes(), request.Bytes()} {
+		if err := os.WriteFile(files[i], content, 0o600); err != nil {
+			return files[:i], err
+		}
+	}
+	return files, nil
+}
+
+// redactHeader returns a copy of header with the operator's credentials redacted; with
+// request set, the credential headers are redacted whole.
+func (a *Artifacts) redactHeader(header http.Header, request bool) http.Header {
+	redacted := make(http.Header, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			if request && isCredentialHeader(name) {
+				value = artifactRedacted
+			}
+			redacted[name] = append(redacted[name], a.redactString(value))
+		}
+	}
+	return redacted
+}
+
+// isCredentialHeader reports whether name is one of artifactCredentialHeaders.
+func isCredentialHeader(name string) bool {
+	for _, credential := range artifactCredentialHeaders {
+		if strings.EqualFold(name, credential) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact replaces every credential of the operator in data.
+func (a *Artifacts) redact(data []byte) []byte {
+	for _, secret := range a.secrets {
+		data = bytes.ReplaceAll(data, []byte(secret), []byte(artifactRedacted))
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			data = bytes.ReplaceAll(data, []byte(escaped), []byte(artifactRedacted))
+		}
+	}
+	return data
+}
+
+// redactString replaces every credential of the operator in value.
+func (a *Artifacts) redactString(value string) string {
+	return string(a.redact([]byte(value)))
+}
+
+// artifactName derives the file name of a target from its host and path, e.g.
+// "example.com_8443_login" for https://example.com:8443/login.
+func artifactName(target string) string {
+	name := target
+	if parsed, err := url.Parse(target); err == nil && parsed.Host != "" {
+		name = parsed.Host + parsed.Path
+	}
+	name = strings.Trim(artifactNameUnsafe.ReplaceAllString(name, "_"), "_.")
+	if name == "" {
+		return "target"
+	}
+	return name
+}
+
+// SaveArtifacts writes the artifacts of the response loaded for target when the scan saves
+// them (--save-artifacts), and publishes a warning result when they cannot be written. The
+// body of the response is restored after reading, like CheckChallengePage does.
+//
+// Parameters:
+//   - artifacts: Artifact writer of the scan (nil disables saving)
+//   - target: Formatted target URL
+//   - response: Shared HTTP response loaded for the scan
+//   - results: Channel receiving the warning result
+//
+// Returns:
+//   - bool: true if the artifacts were written
+func SaveArtifacts(artifacts *Artifacts, target string, response *http.Response, results chan<- ResultWrapper) bool {
+	if artifacts == nil || response == nil {
+		return false
+	}
+	var body []byte
+	var err error
+	if response.Body != nil {
+		body, err = io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if err == nil {
+		_, err = artifacts.Save(target, response, body)
+	}
+	if err == nil {
+		return true
+	}
+	results <- WrapStrategyResult(&Tests.TestResult{
+		TestId:      ArtifactsWarningId,
+		Name:        "Response Artifacts",
+		Certainty:   100,
+		ThreatLevel: Tests.Info,
+		Metadata:    map[string]any{"dir": artifacts.Dir(), "error": err.Error()},
+		Description: "The response artifacts could not be saved to " + artifacts.Dir() + ": " + err.Error(),
+	}, nil, nil)
+	return false
+}
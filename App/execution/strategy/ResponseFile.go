@@ -0,0 +1,175 @@
+package strategy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ResponseFileErrorCode is the RequestInfo code reported when a saved response
+// (--from-file) cannot be read or parsed.
+const ResponseFileErrorCode = 500
+
+// harFile mirrors the subset of the HTTP Archive (HAR 1.2) format needed to rebuild a response.
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method string `json:"method"`
+				URL    string `json:"url"`
+			} `json:"request"`
+			Response struct {
+				Status      int    `json:"status"`
+				StatusText  string `json:"statusText"`
+				HTTPVersion string `json:"httpVersion"`
+				Headers     []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"headers"`
+				Content struct {
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// LoadResponseFile loads a saved response for offline analysis (--from-file) instead of
+// requesting the target. It is the offline counterpart of LoadWebsiteContent and returns
+// the response in the same form, so header and body tests run unchanged.
+//
+// Supported formats:
+//   - HAR: A JSON HTTP Archive with exactly one entry; the request URL of the entry
+//     becomes the request of the response
+//   - Raw: An HTTP/1.x response as sent on the wire (status line, headers, blank line, body);
+//     the formatted target becomes the request of the response
+//
+// Saved responses carry no TLS connection state, so tests relying on it (e.g., the TLS
+// version) report it as unavailable. The target is not contacted: tests sending requests to
+// it are skipped and the others do not fetch subresources (see Tests.ResponseTestParams.Offline).
+//
+// Parameters:
+//   - path: Path of the saved response file
+//   - target: Formatted target URL, used as request URL for raw responses
+//
+// Returns:
+//   - *http.Response: Response rebuilt from the file, with its body held in memory
+//   - *RequestInfo: Code 0 on success, ResponseFileErrorCode if the file cannot be read or parsed
+//
+// Example:
+//
+//	response, info := LoadResponseFile("response.har", "https://example.com")
+//	if info.Code != 0 {
+//		// report info.Message
+//	}
+func LoadResponseFile(path string, target string) (*http.Response, *RequestInfo) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, responseFileError(path, err)
+	}
+	var response *http.Response
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		response, err = parseHARResponse(trimmed)
+	} else {
+		response, err = parseRawResponse(data, target)
+	}
+	if err != nil {
+		return nil, responseFileError(path, err)
+	}
+	return response, &RequestInfo{
+		Message: "Content loaded successfully",
+		Code:    0,
+	}
+}
+
+// parseHARResponse rebuilds the response of a single-entry HAR file.
+func parseHARResponse(data []byte) (*http.Response, error) {
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("invalid HAR file: %w", err)
+	}
+	if len(har.Log.Entries) != 1 {
+		return nil, fmt.Errorf("HAR file must contain exactly one entry, found %d", len(har.Log.Entries))
+	}
+	entry := har.Log.Entries[0]
+
+	method := entry.Request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	request, err := http.NewRequest(method, entry.Request.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HAR request URL: %w", err)
+	}
+
+	body := []byte(entry.Response.Content.Text)
+	if entry.Response.Content.Encoding == "base64" {
+		if body, err = base64.StdEncoding.DecodeString(entry.Response.Content.Text); err != nil {
+			return nil, fmt.Errorf("invalid base64 HAR content: %w", err)
+		}
+	}
+
+	header := http.Header{}
+	for _, h := range entry.Response.Headers {
+		// HTTP/2 pseudo headers (e.g. ":status") are not response headers
+		if strings.HasPrefix(h.Name, ":") {
+			continue
+		}
+		header.Add(h.Name, h.Value)
+	}
+
+	proto := entry.Response.HTTPVersion
+	major, minor, ok := http.ParseHTTPVersion(strings.ToUpper(proto))
+	if !ok {
+		proto, major, minor = "HTTP/1.1", 1, 1
+	}
+	return &http.Response{
+		Status:        strings.TrimSpace(fmt.Sprintf("%d %s", entry.Response.Status, entry.Response.StatusText)),
+		StatusCode:    entry.Response.Status,
+		Proto:         proto,
+		ProtoMajor:    major,
+		ProtoMinor:    minor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
+
+// parseRawResponse parses an HTTP/1.x response as sent on the wire. Bare LF line endings,
+// common in hand-written fixtures, are accepted, as is a body shorter than its Content-Length.
+func parseRawResponse(data []byte, target string) (*http.Response, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target: %w", err)
+	}
+	request := &http.Request{Method: http.MethodGet, URL: targetURL, Header: http.Header{}}
+
+	response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw HTTP response: %w", err)
+	}
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("invalid raw HTTP response body: %w", err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	return response, nil
+}
+
+// responseFileError converts a load failure into the RequestInfo reported to the user.
+func responseFileError(path string, err error) *RequestInfo {
+	return &RequestInfo{
+		Message: fmt.Sprintf("Failed to load response file %s: %v", path, err),
+		Code:    ResponseFileErrorCode,
+	}
+}
@@ -3,11 +3,49 @@ package strategy
 import (
 	HttpClient "Engine-AntiGinx/App/HTTP"
 	"Engine-AntiGinx/App/Tests"
+	"bytes"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
 
+// challengeCertaintyPercent is the share of the original certainty kept by test results
+// computed from a suspected bot protection challenge page.
+const challengeCertaintyPercent = 50
+
+// ChallengeWarningId identifies the scan-wide warning result emitted when the loaded
+// content looks like a bot protection challenge page.
+const ChallengeWarningId = "anti-bot-challenge"
+
+// TruncatedBodyWarningId identifies the scan-wide warning result emitted when the body of
+// the loaded content was cut off at the body read timeout.
+const TruncatedBodyWarningId = "truncated-body"
+
+// DeadlineWarningId identifies the scan-wide warning result emitted by the Runner when
+// the scan deadline (--deadline) passed before every test finished. Reporters use it to
+// mark the scan as timed out, as the results are then partial.
+const DeadlineWarningId = "scan-deadline"
+
+// FailFastWarningId identifies the scan-wide warning result emitted by the Runner when an
+// unsuppressed Critical finding stopped the scan early (--fail-fast); the results are then
+// partial.
+const FailFastWarningId = "fail-fast"
+
+// TechnologyStackId identifies the summary result emitted by the Runner for every target,
+// listing the technologies detected by all of its tests merged into one inventory.
+const TechnologyStackId = "technology-stack"
+
+// AuthenticationRequiredId identifies the informational result emitted instead of the test
+// results when the target requires HTTP authentication and no credentials were configured.
+const AuthenticationRequiredId = "authentication-required"
+
+// CookieCSPConsistencyId identifies the note emitted by the Runner for a target whose session
+// cookies lack HttpOnly while its Content-Security-Policy blocks inline scripts.
+const CookieCSPConsistencyId = "cookie-csp-consistency"
+
 // LoadWebsiteContent fetches the target website content via HTTP GET request and returns
 // the response for sharing across all test executions. This function performs a single
 // HTTP request to avoid redundant network calls for each test.
@@ -25,11 +63,15 @@ import (
 //   - Request creation fails (code 100)
 //   - Network error occurs (code 101)
 //   - Non-200 status code returned (code 102)
+//   - 401 returned without configured credentials (code 103); the 401 response is returned
+//     with the request info, see CheckAuthenticationRequired
 //   - Response body reading fails (code 200)
 //   - Bot protection detected (code 300)
 //
 // Parameters:
 //   - target: The fully qualified URL to request (e.g., "https://example.com")
+//   - useAntiBotDetection: Enables anti-bot detection bypass features
+//   - clientOpts: Additional HTTP client options (e.g. client certificates)
 //
 // Returns:
 //   - *http.Response: Raw HTTP response object to be shared across all tests
@@ -39,7 +81,7 @@ import (
 //	response := loadWebsiteContent("https://example.com", true)
 //	// Response contains headers, body, status code, etc.
 //	// This single response is analyzed by all tests
-func LoadWebsiteContent(target string, useAntiBotDetection bool) (*http.Response, *RequestInfo) {
+func LoadWebsiteContent(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *RequestInfo) {
 	opts := []HttpClient.WrapperOption{
 		HttpClient.WithHeaders(map[string]string{
 			"User-Agent": "AntiGinx-TestClient/1.0",
@@ -48,6 +90,7 @@ func LoadWebsiteContent(target string, useAntiBotDetection bool) (*http.Response
 	if useAntiBotDetection {
 		opts = append(opts, HttpClient.WithAntiBotDetection())
 	}
+	opts = append(opts, clientOpts...)
 	httpClient := HttpClient.CreateHttpWrapper(opts...)
 	var content *http.Response
 	var reqInfo *RequestInfo
@@ -64,6 +107,9 @@ func LoadWebsiteContent(target string, useAntiBotDetection bool) (*http.Response
 							Message: val.Message,
 							Code:    val.Code,
 						}
+						if resp, ok := val.Error.(*http.Response); ok && val.Code == HttpClient.AuthenticationRequiredCode {
+							content = resp
+						}
 						if !val.IsRetryable {
 							return
 						}
@@ -83,6 +129,9 @@ func LoadWebsiteContent(target string, useAntiBotDetection bool) (*http.Response
 				Code:    0,
 			}
 		}
+		if reqInfo.Code == HttpClient.AuthenticationRequiredCode {
+			return content, reqInfo
+		}
 		if i < 1 {
 			time.Sleep(time.Second * 2)
 		}
@@ -100,6 +149,12 @@ func LoadWebsiteContent(target string, useAntiBotDetection bool) (*http.Response
 //  3. Send the TestResult to the results channel
 //  4. Signal completion via WaitGroup (deferred)
 //
+// The time spent in Run is recorded in the Duration of the result.
+//
+// Tests that do not apply to the target (see Tests.ResponseTest.Applicable, e.g. an
+// HTTPS-only test on a plain HTTP target or a network test on a saved response) are not
+// run; an informational result marked Skipped explains why instead.
+//
 // The function uses defer wg.Done() to ensure the WaitGroup is always decremented,
 // even if the test panics or encounters an error. This guarantees proper synchronization
 // and prevents deadlocks in the orchestration logic.
@@ -114,17 +169,200 @@ func LoadWebsiteContent(target string, useAntiBotDetection bool) (*http.Response
 //   - test: Pointer to the ResponseTest to execute
 //   - wg: WaitGroup for synchronizing test completion
 //   - results: Send-only channel for publishing test results
-//   - response: Shared HTTP response object to analyze
+//   - params: Test input holding the shared HTTP response and the scan's CVE client
+//   - challengeDetected: Whether the response looks like a bot protection challenge page;
+//     if so, the certainty of the result is reduced
 //
 // Example usage (called by Orchestrate):
 //
 //	wg.Add(1)
-//	go performTest(httpsTest, &wg, resultChannel, httpResponse)
+//	go PerformTest(httpsTest, &wg, resultChannel, Tests.ResponseTestParams{Response: httpResponse}, false)
 //	// Test runs concurrently, result sent to channel, WaitGroup decremented
-func PerformTest(test *Tests.ResponseTest, wg *sync.WaitGroup, results chan<- ResultWrapper, response *http.Response) {
+func PerformTest(test *Tests.ResponseTest, wg *sync.WaitGroup, results chan<- ResultWrapper, params Tests.ResponseTestParams, challengeDetected bool) {
 	defer wg.Done()
-	testParams := Tests.ResponseTestParams{Response: response}
-	testResult := test.Run(testParams)
+	if applicable, reason := test.Applicable(params); !applicable {
+		skipped := test.SkippedResult(reason)
+		results <- WrapStrategyResult(&skipped, nil, nil)
+		return
+	}
+	started := time.Now()
+	testResult := test.Run(params)
+	testResult.Duration = time.Since(started)
+	if challengeDetected {
+		testResult.Certainty = testResult.Certainty * challengeCertaintyPercent / 100
+	}
 	wrapped := WrapStrategyResult(&testResult, nil, nil)
 	results <- wrapped
 }
+
+// CheckTruncatedBody publishes a scan-wide warning result when the body of the loaded
+// content was cut off at the HttpClient body read timeout, e.g. because the server kept a
+// chunked response open. Body based tests then only analyse the part received.
+//
+// Parameters:
+//   - response: Shared HTTP response loaded for the scan
+//   - results: Channel receiving the warning result
+//
+// Returns:
+//   - bool: true if the body was truncated
+func CheckTruncatedBody(response *http.Response, results chan<- ResultWrapper) bool {
+	if !HttpClient.BodyTruncated(response) {
+		return false
+	}
+	results <- WrapStrategyResult(&Tests.TestResult{
+		TestId:      TruncatedBodyWarningId,
+		Name:        "Truncated Response Body",
+		Certainty:   100,
+		ThreatLevel: Tests.Info,
+		Metadata:    map[string]any{"received_bytes": response.ContentLength},
+		Description: "The server kept sending the response body past the body read timeout, so the read was aborted. " +
+			"Tests analysing the body only saw the part received before the timeout.",
+	}, nil, nil)
+	return true
+}
+
+// CheckAuthenticationRequired publishes an informational result when the target answered
+// 401 (Unauthorized) and no credentials were configured (HttpClient code 103), naming the
+// authentication schemes advertised in WWW-Authenticate. The caller should then skip the
+// tests, as they would analyse the challenge response instead of the protected site.
+//
+// Parameters:
+//   - response: The 401 response returned by LoadWebsiteContent
+//   - reqInfo: Request info returned by LoadWebsiteContent
+//   - results: Channel receiving the informational result
+//
+// Returns:
+//   - bool: true if authentication is required and the result was published
+func CheckAuthenticationRequired(response *http.Response, reqInfo *RequestInfo, results chan<- ResultWrapper) bool {
+	if reqInfo == nil || reqInfo.Code != HttpClient.AuthenticationRequiredCode || response == nil {
+		return false
+	}
+	challenges := HttpClient.ParseWWWAuthenticate(response.Header.Values("WWW-Authenticate"))
+	schemes := make([]string, 0, len(challenges))
+	advertised := make([]string, 0, len(challenges))
+	for _, challenge := range challenges {
+		schemes = append(schemes, challenge.Scheme)
+		if realm := challenge.Realm(); realm != "" {
+			advertised = append(advertised, fmt.Sprintf("%s (realm %q)", challenge.Scheme, realm))
+		} else {
+			advertised = append(advertised, challenge.Scheme)
+		}
+	}
+
+	description := "The target requires authentication (401 Unauthorized) but advertises no WWW-Authenticate challenge."
+	if len(advertised) > 0 {
+		description = fmt.Sprintf("The target requires HTTP authentication, advertising %s.", strings.Join(advertised, ", "))
+	}
+	results <- WrapStrategyResult(&Tests.TestResult{
+		TestId:      AuthenticationRequiredId,
+		Name:        "Authentication Required",
+		Certainty:   100,
+		ThreatLevel: Tests.Info,
+		Metadata: map[string]any{
+			"status_code": response.StatusCode,
+			"schemes":     schemes,
+			"challenges":  challenges,
+		},
+		Description: description + " No credentials were configured, so the security tests were not run; " +
+			"pass --auth-basic or --auth-bearer to scan the protected site.",
+	}, nil, nil)
+	return true
+}
+
+// CheckChallengePage detects whether content loaded in anti-bot mode is a bot protection
+// challenge or interstitial page rather than the real site. In that case tests would analyze
+// the challenge instead of the target, so a scan-wide warning result is published and the
+// caller should reduce the certainty of every test (see PerformTest).
+//
+// Detection reuses the HttpClient challenge indicators. Without anti-bot detection the
+// HttpClient already rejects protected responses, so no check is performed.
+//
+// Parameters:
+//   - response: Shared HTTP response loaded for the scan (its body is restored after reading)
+//   - antiBotFlag: Whether anti-bot detection is enabled for the scan
+//   - results: Channel receiving the warning result
+//
+// Returns:
+//   - bool: true if a challenge page was detected
+func CheckChallengePage(response *http.Response, antiBotFlag bool, results chan<- ResultWrapper) bool {
+	if !antiBotFlag || response == nil || response.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	indicators := HttpClient.DetectChallengePage(response.Header, body)
+	if len(indicators) == 0 {
+		return false
+	}
+	results <- WrapStrategyResult(&Tests.TestResult{
+		TestId:      ChallengeWarningId,
+		Name:        "Anti-Bot Challenge Page Detection",
+		Certainty:   100,
+		ThreatLevel: Tests.Info,
+		Metadata:    map[string]any{"indicators": indicators},
+		Description: fmt.Sprintf("The loaded content looks like a bot protection challenge page (%s). "+
+			"Results may describe the challenge instead of the real site; their certainty was reduced to %d%%.",
+			strings.Join(indicators, "; "), challengeCertaintyPercent),
+	}, nil, nil)
+	return true
+}
+
+// NewTestParams prepares the parameters of the tests of a scan. The body of the shared
+// response is read once; every call of the returned function yields parameters holding
+// the scan settings of ctx, that body (ResponseTestParams.Body) and a shallow copy of the
+// response with its own reader over it, so tests running concurrently can each see the
+// whole body. Secondary requests of the tests share one client built from the client
+// options of ctx (ResponseTestParams.HTTPClient).
+//
+// Parameters:
+//   - ctx: Context of the scan (CVE client, scan context, result cache, custom rules)
+//   - response: Shared HTTP response loaded for the scan
+//
+// Returns:
+//   - func() Tests.ResponseTestParams: Builds the parameters of one test
+//
+// Example:
+//
+//	params := strategy.NewTestParams(ctx, result)
+//	for _, test := range tests {
+//	    wg.Add(1)
+//	    go strategy.PerformTest(test, wg, channel, params(), challengeDetected)
+//	}
+func NewTestParams(ctx TestContext, response *http.Response) func() Tests.ResponseTestParams {
+	var body []byte
+	if response != nil && response.Body != nil {
+		body, _ = io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	client := HttpClient.CreateHttpWrapper(ctx.ClientOptions...).Client()
+
+	return func() Tests.ResponseTestParams {
+		testResponse := response
+		if response != nil && response.Body != nil {
+			copied := *response
+			copied.Body = io.NopCloser(bytes.NewReader(body))
+			testResponse = &copied
+		}
+		return Tests.ResponseTestParams{
+			Response:       testResponse,
+			CVEClient:      ctx.CVEClient,
+			DisableCVE:     ctx.DisableCVE,
+			CVEYears:       ctx.CVEYears,
+			CVEMinSeverity: ctx.CVEMinSeverity,
+			Context:        ctx.Context,
+			ResultCache:    ctx.ResultCache,
+			CustomRules:    ctx.CustomRules,
+			IgnoredHeaders: ctx.IgnoredHeaders,
+			Body:           body,
+			HTTPClient:     client,
+			Offline:        ctx.ResponseFile != "",
+		}
+	}
+}
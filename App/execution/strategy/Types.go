@@ -18,6 +18,8 @@ type ResultWrapper struct {
 	testResult  *Tests.TestResult
 	reqInfo     *RequestInfo
 	helpMessage *HelpStrategyResult
+	target      string
+	scanId      string
 }
 
 // HelpStrategyResult represents the structured content of a help command output.
@@ -69,6 +71,48 @@ func (w ResultWrapper) GetReqInfo() (bool, *RequestInfo) {
 	return w.reqInfo != nil, w.reqInfo
 }
 
+// WithTarget returns a copy of the wrapper tagged with the target that produced it.
+// The Runner tags results when several targets are scanned in one run so that
+// reporters can attribute each result to its target.
+//
+// Parameters:
+//   - target: The scanned target the result belongs to
+//
+// Returns:
+//   - ResultWrapper: The tagged copy of the wrapper
+func (w ResultWrapper) WithTarget(target string) ResultWrapper {
+	w.target = target
+	return w
+}
+
+// GetTarget retrieves the target the result was tagged with.
+//
+// Returns:
+//   - string: The tagged target, or an empty string for untagged (single target) results
+func (w ResultWrapper) GetTarget() string {
+	return w.target
+}
+
+// WithScanId returns a copy of the wrapper tagged with the correlation ID of the scan
+// that produced it. The Runner tags every result so that reporters and logs of
+// concurrent scans can be correlated.
+//
+// Parameters:
+//   - scanId: Correlation ID of the scan
+//
+// Returns:
+//   - ResultWrapper: Copy of the wrapper carrying the scan ID
+func (w ResultWrapper) WithScanId(scanId string) ResultWrapper {
+	w.scanId = scanId
+	return w
+}
+
+// GetScanId returns the correlation ID of the scan, or an empty string if the result
+// was not tagged.
+func (w ResultWrapper) GetScanId() string {
+	return w.scanId
+}
+
 // GetHelpMessage retrieves the underlying help strategy result from the wrapper.
 //
 // It provides a safe way to check if the result contains help documentation.
@@ -1,6 +1,7 @@
 package strategyImpl
 
 import (
+	HttpClient "Engine-AntiGinx/App/HTTP"
 	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution/strategy"
 	"net/http"
@@ -8,12 +9,12 @@ import (
 )
 
 type allTestsStrategy struct {
-	loadWebsiteContent func(target string, useAntiBotDetection bool) (*http.Response, *strategy.RequestInfo)
+	loadWebsiteContent func(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *strategy.RequestInfo)
 	getAllTests        func() []*Tests.ResponseTest
 	format             func(target string, params []string) *string
 }
 
-func InitializeAllTestsStrategy(loadWebsiteContent func(target string, useAntiBotDetection bool) (*http.Response, *strategy.RequestInfo),
+func InitializeAllTestsStrategy(loadWebsiteContent func(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *strategy.RequestInfo),
 	getAllTests func() []*Tests.ResponseTest,
 	format func(target string, params []string) *string) *allTestsStrategy {
 	return &allTestsStrategy{
@@ -25,16 +26,30 @@ func InitializeAllTestsStrategy(loadWebsiteContent func(target string, useAntiBo
 
 func (a *allTestsStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
 	target := a.format(ctx.Target, ctx.Args)
-	result, reqInfo := a.loadWebsiteContent(*target, antiBotFlag)
+	var result *http.Response
+	var reqInfo *strategy.RequestInfo
+	if ctx.ResponseFile != "" {
+		result, reqInfo = strategy.LoadResponseFile(ctx.ResponseFile, *target)
+	} else {
+		result, reqInfo = a.loadWebsiteContent(*target, antiBotFlag, ctx.ClientOptions...)
+	}
 
+	if strategy.CheckAuthenticationRequired(result, reqInfo, channel) {
+		return
+	}
 	if reqInfo.Code != 0 {
 		channel <- strategy.WrapStrategyResult(nil, nil, reqInfo)
 		return
 	}
+	strategy.CheckTruncatedBody(result, channel)
+	challengeDetected := strategy.CheckChallengePage(result, antiBotFlag, channel)
+	strategy.SaveArtifacts(ctx.Artifacts, *target, result, channel)
+
+	params := strategy.NewTestParams(ctx, result)
 
 	for _, val := range a.getAllTests() {
 		wg.Add(1)
-		go strategy.PerformTest(val, wg, channel, result)
+		go strategy.PerformTest(val, wg, channel, params(), challengeDetected)
 	}
 }
 
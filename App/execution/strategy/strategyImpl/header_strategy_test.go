@@ -1,12 +1,20 @@
 package strategyImpl
 
 import (
+	error "Engine-AntiGinx/App/Errors"
+	HttpClient "Engine-AntiGinx/App/HTTP"
+	"Engine-AntiGinx/App/Registry"
 	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution/strategy"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 type HeaderStrategyTest struct {
@@ -16,6 +24,8 @@ type HeaderStrategyTest struct {
 	expectedResults int
 	antiBotFlag     bool
 	getTest         func(testId string) (*Tests.ResponseTest, bool)
+	suggestTests    func(testId string) []string
+	expectedMessage string
 }
 
 func setUp(t *testing.T) *httptest.Server {
@@ -64,6 +74,23 @@ func TestHeaderTestHelp_Execute(t *testing.T) {
 				return nil, false
 			},
 		},
+		{
+			Name:    "Test does not exists, close match suggested",
+			wantErr: true,
+			Ctx: strategy.TestContext{
+				Target: server.URL,
+				Args:   []string{"hstss"},
+			},
+			expectedResults: 0,
+			antiBotFlag:     false,
+			getTest: func(testId string) (*Tests.ResponseTest, bool) {
+				return nil, false
+			},
+			suggestTests: func(testId string) []string {
+				return []string{"hsts"}
+			},
+			expectedMessage: "did you mean 'hsts'?",
+		},
 	}
 	for _, val := range tests {
 		t.Run(val.Name, func(t *testing.T) {
@@ -82,12 +109,19 @@ func TestHeaderTestHelp_Execute(t *testing.T) {
 
 				if r == nil {
 					t.Errorf("Expected panic but got none in test %s, \n %v", val.Name, r)
+					return
+				}
+				if val.expectedMessage != "" {
+					err, ok := r.(error.Error)
+					if !ok || !strings.Contains(err.Message, val.expectedMessage) {
+						t.Errorf("Expected panic message to contain %q, got %v", val.expectedMessage, r)
+					}
 				}
 			}()
 			headerStrategy := InitializeHeaderStrategy(
-				func(target string, useAntiBotDetection bool) (*http.Response, *strategy.RequestInfo) {
+				func(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *strategy.RequestInfo) {
 					return &http.Response{}, &strategy.RequestInfo{}
-				}, val.getTest,
+				}, val.getTest, val.suggestTests,
 				func(target string, params []string) *string {
 					return &target
 				},
@@ -106,3 +140,334 @@ func TestHeaderTestHelp_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestHeaderTestStrategy_Execute_ChallengePage(t *testing.T) {
+	challengeBody := `<html><head><title>Attention Required! | Cloudflare</title></head>
+		<body><div class="cf-browser-verification">Checking your browser before accessing the site.</div></body></html>`
+
+	tests := []struct {
+		name              string
+		antiBotFlag       bool
+		body              string
+		expectWarning     bool
+		expectedCertainty int
+	}{
+		{name: "Challenge page in anti-bot mode", antiBotFlag: true, body: challengeBody, expectWarning: true, expectedCertainty: 45},
+		{name: "Regular page in anti-bot mode", antiBotFlag: true, body: "<html><body>Welcome</body></html>", expectedCertainty: 90},
+		{name: "Anti-bot mode disabled", antiBotFlag: false, body: challengeBody, expectedCertainty: 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := make(chan strategy.ResultWrapper, 10)
+			wg := &sync.WaitGroup{}
+			headerStrategy := InitializeHeaderStrategy(
+				func(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *strategy.RequestInfo) {
+					return &http.Response{
+						Header: http.Header{},
+						Body:   io.NopCloser(strings.NewReader(tt.body)),
+					}, &strategy.RequestInfo{}
+				},
+				func(testId string) (*Tests.ResponseTest, bool) {
+					return &Tests.ResponseTest{
+						Id: testId,
+						RunTest: func(params Tests.ResponseTestParams) Tests.TestResult {
+							return Tests.TestResult{Certainty: 90}
+						},
+					}, true
+				}, nil,
+				func(target string, params []string) *string {
+					return &target
+				},
+			)
+			headerStrategy.Execute(strategy.TestContext{Target: "example.com", Args: []string{"hsts", "csp"}}, channel, wg, tt.antiBotFlag)
+			wg.Wait()
+			close(channel)
+
+			warnings := 0
+			for res := range channel {
+				_, val := res.GetTestResult()
+				if val.TestId == strategy.ChallengeWarningId {
+					warnings++
+					continue
+				}
+				if val.Certainty != tt.expectedCertainty {
+					t.Errorf("Expected certainty %d for %s, got %d", tt.expectedCertainty, val.TestId, val.Certainty)
+				}
+			}
+			if tt.expectWarning != (warnings == 1) {
+				t.Errorf("Expected warning emitted: %t, got %d warnings", tt.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestHeaderTestStrategy_Execute_ResponseFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseFile string
+		wantThreats  map[string]Tests.ThreatLevel
+		wantReqCode  int
+	}{
+		{
+			name:         "HAR fixture",
+			responseFile: "testdata/response.har",
+			wantThreats: map[string]Tests.ThreatLevel{
+				"https":  Tests.None,
+				"hsts":   Tests.None,
+				"xframe": Tests.None,
+				"x-xss":  Tests.Info,
+			},
+		},
+		{
+			name:         "Raw response fixture",
+			responseFile: "testdata/response.txt",
+			wantThreats: map[string]Tests.ThreatLevel{
+				"https":  Tests.None,
+				"xframe": Tests.None,
+				"x-xss":  Tests.None,
+			},
+		},
+		{name: "Missing file", responseFile: "testdata/missing.har", wantReqCode: strategy.ResponseFileErrorCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := make(chan strategy.ResultWrapper, 10)
+			wg := &sync.WaitGroup{}
+			args := make([]string, 0, len(tt.wantThreats))
+			for id := range tt.wantThreats {
+				args = append(args, id)
+			}
+			if len(args) == 0 {
+				args = append(args, "hsts")
+			}
+			headerStrategy := InitializeHeaderStrategy(
+				func(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *strategy.RequestInfo) {
+					t.Error("Expected the saved response to be analysed without a request")
+					return nil, &strategy.RequestInfo{Code: 101}
+				}, Registry.GetTest, nil,
+				func(target string, params []string) *string {
+					formatted := "https://" + target
+					return &formatted
+				},
+			)
+			headerStrategy.Execute(strategy.TestContext{Target: "example.com", Args: args, ResponseFile: tt.responseFile}, channel, wg, false)
+			wg.Wait()
+			close(channel)
+
+			results := 0
+			for res := range channel {
+				if ok, info := res.GetReqInfo(); ok {
+					if info.Code != tt.wantReqCode {
+						t.Errorf("Expected request code %d, got %d (%s)", tt.wantReqCode, info.Code, info.Message)
+					}
+					continue
+				}
+				_, val := res.GetTestResult()
+				results++
+				if want, ok := tt.wantThreats[val.TestId]; !ok || val.ThreatLevel != want {
+					t.Errorf("Expected %s threat %v, got %v (%s)", val.TestId, want, val.ThreatLevel, val.Description)
+				}
+			}
+			if results != len(tt.wantThreats) {
+				t.Errorf("Expected %d results, got %d", len(tt.wantThreats), results)
+			}
+		})
+	}
+}
+
+func TestHeaderTestStrategy_Execute_AuthenticationRequired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+		w.Header().Add("WWW-Authenticate", "NTLM")
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	channel := make(chan strategy.ResultWrapper, 10)
+	wg := &sync.WaitGroup{}
+	testRan := false
+	headerStrategy := InitializeHeaderStrategy(strategy.LoadWebsiteContent,
+		func(testId string) (*Tests.ResponseTest, bool) {
+			return &Tests.ResponseTest{
+				Id: testId,
+				RunTest: func(params Tests.ResponseTestParams) Tests.TestResult {
+					testRan = true
+					return Tests.TestResult{}
+				},
+			}, true
+		}, nil,
+		func(target string, params []string) *string {
+			return &target
+		},
+	)
+	headerStrategy.Execute(strategy.TestContext{Target: server.URL, Args: []string{"hsts"}}, channel, wg, false)
+	wg.Wait()
+	close(channel)
+
+	var results []strategy.ResultWrapper
+	for res := range channel {
+		results = append(results, res)
+	}
+	if len(results) != 1 || testRan {
+		t.Fatalf("Expected only the authentication result, got %d results (tests ran: %t)", len(results), testRan)
+	}
+	ok, val := results[0].GetTestResult()
+	if !ok || val.TestId != strategy.AuthenticationRequiredId || val.ThreatLevel != Tests.Info {
+		t.Fatalf("Expected an informational authentication result, got %+v", val)
+	}
+	schemes := val.Metadata.(map[string]any)["schemes"].([]string)
+	if len(schemes) != 2 || schemes[0] != "Bearer" || schemes[1] != "NTLM" {
+		t.Errorf("Expected the Bearer and NTLM schemes, got %v", schemes)
+	}
+	if !strings.Contains(val.Description, `Bearer (realm "api")`) {
+		t.Errorf("Expected the description to name the scheme and realm, got %q", val.Description)
+	}
+}
+
+func TestHeaderTestStrategy_Execute_HTTPOnlyTargetSkipsTLSTests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	channel := make(chan strategy.ResultWrapper, 10)
+	wg := &sync.WaitGroup{}
+	headerStrategy := InitializeHeaderStrategy(strategy.LoadWebsiteContent, Registry.GetTest, nil,
+		func(target string, params []string) *string {
+			return &target
+		},
+	)
+	headerStrategy.Execute(strategy.TestContext{Target: server.URL, Args: []string{"ssl-cert", "hsts", "https"}}, channel, wg, false)
+	wg.Wait()
+	close(channel)
+
+	byId := map[string]*Tests.TestResult{}
+	for res := range channel {
+		if ok, val := res.GetTestResult(); ok {
+			byId[val.TestId] = val
+		}
+	}
+	for _, id := range []string{"ssl-cert", "hsts"} {
+		val, ok := byId[id]
+		if !ok {
+			t.Fatalf("Expected a note for the skipped %s test, got %v", id, byId)
+		}
+		if !val.Skipped || val.ThreatLevel != Tests.Info || !strings.Contains(val.Description, "plain HTTP") {
+			t.Errorf("Expected %s to be skipped with an informational note, got %+v", id, val)
+		}
+	}
+	if https, ok := byId["https"]; !ok || https.Skipped || https.ThreatLevel == Tests.Info {
+		t.Errorf("Expected the https test to run and report plain HTTP, got %+v", https)
+	}
+}
+
+func TestHeaderTestStrategy_Execute_RecordsDurations(t *testing.T) {
+	channel := make(chan strategy.ResultWrapper, 10)
+	wg := &sync.WaitGroup{}
+	headerStrategy := InitializeHeaderStrategy(
+		func(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *strategy.RequestInfo) {
+			return &http.Response{Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, &strategy.RequestInfo{}
+		},
+		func(testId string) (*Tests.ResponseTest, bool) {
+			return &Tests.ResponseTest{
+				Id: testId,
+				RunTest: func(params Tests.ResponseTestParams) Tests.TestResult {
+					if testId == "slow" {
+						time.Sleep(10 * time.Millisecond)
+					}
+					return Tests.TestResult{}
+				},
+			}, true
+		}, nil,
+		func(target string, params []string) *string {
+			return &target
+		},
+	)
+	headerStrategy.Execute(strategy.TestContext{Target: "example.com", Args: []string{"slow", "fast"}}, channel, wg, false)
+	wg.Wait()
+	close(channel)
+
+	durations := map[string]time.Duration{}
+	for res := range channel {
+		_, val := res.GetTestResult()
+		durations[val.TestId] = val.Duration
+	}
+	if len(durations) != 2 || durations["fast"] < 0 {
+		t.Fatalf("Expected a non-negative duration for every test, got %v", durations)
+	}
+	if durations["slow"] < 10*time.Millisecond {
+		t.Errorf("Expected the slow test to take at least 10ms, got %s", durations["slow"])
+	}
+}
+
+func TestHeaderTestStrategy_Execute_SavesArtifacts(t *testing.T) {
+	const token = "s3cr3t-bearer-token"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Frame-Options", "DENY")
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = io.WriteString(w, "<html><body>Welcome, your token is "+token+"</body></html>")
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	channel := make(chan strategy.ResultWrapper, 10)
+	wg := &sync.WaitGroup{}
+	headerStrategy := InitializeHeaderStrategy(strategy.LoadWebsiteContent,
+		func(testId string) (*Tests.ResponseTest, bool) {
+			return &Tests.ResponseTest{
+				Id: testId,
+				RunTest: func(params Tests.ResponseTestParams) Tests.TestResult {
+					if !strings.Contains(string(params.Body), token) {
+						t.Errorf("Expected the tests to see the unredacted body, got %q", params.Body)
+					}
+					return Tests.TestResult{}
+				},
+			}, true
+		}, nil,
+		func(target string, params []string) *string {
+			return &target
+		},
+	)
+	ctx := strategy.TestContext{
+		Target:        server.URL + "/login",
+		Args:          []string{"xframe"},
+		ClientOptions: []HttpClient.WrapperOption{HttpClient.WithBearerToken(token)},
+		Artifacts:     strategy.NewArtifacts(dir, token),
+	}
+	headerStrategy.Execute(ctx, channel, wg, false)
+	wg.Wait()
+	close(channel)
+	for res := range channel {
+		if _, val := res.GetTestResult(); val != nil && val.TestId == strategy.ArtifactsWarningId {
+			t.Fatalf("Expected the artifacts to be saved, got %s", val.Description)
+		}
+	}
+
+	name := strings.NewReplacer(":", "_").Replace(strings.TrimPrefix(server.URL, "http://")) + "_login"
+	raw, err := os.ReadFile(filepath.Join(dir, name+".http"))
+	if err != nil {
+		t.Fatalf("Expected the response artifact to be written: %v", err)
+	}
+	response := string(raw)
+	for _, want := range []string{"HTTP/1.1 200 OK\r\n", "X-Frame-Options: DENY\r\n", "\r\n\r\n<html><body>Welcome, your token is [REDACTED]</body></html>"} {
+		if !strings.Contains(response, want) {
+			t.Errorf("Expected %q in the response artifact, got:\n%s", want, response)
+		}
+	}
+	request, err := os.ReadFile(filepath.Join(dir, name+".request.txt"))
+	if err != nil {
+		t.Fatalf("Expected the request artifact to be written: %v", err)
+	}
+	if !strings.HasPrefix(string(request), "GET "+server.URL+"/login HTTP/1.1\r\n") ||
+		!strings.Contains(string(request), "Authorization: [REDACTED]\r\n") {
+		t.Errorf("Expected the request line and a redacted Authorization header, got:\n%s", request)
+	}
+	if strings.Contains(response+string(request), token) {
+		t.Errorf("Expected the operator's token to be redacted from the artifacts")
+	}
+
+	replayed, info := strategy.LoadResponseFile(filepath.Join(dir, name+".http"), server.URL+"/login")
+	if info.Code != 0 || replayed.Header.Get("X-Frame-Options") != "DENY" {
+		t.Errorf("Expected the response artifact to load with --from-file, got %+v", info)
+	}
+}
@@ -2,10 +2,12 @@ package strategyImpl
 
 import (
 	error "Engine-AntiGinx/App/Errors"
+	HttpClient "Engine-AntiGinx/App/HTTP"
 	"Engine-AntiGinx/App/Tests"
 	"Engine-AntiGinx/App/execution/strategy"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 )
 
@@ -13,19 +15,22 @@ import (
 // It is responsible for orchestrating header-based security assessments
 // by fetching target content and executing a suite of sub-tests concurrently.
 type headerTestStrategy struct {
-	loadWebsiteContent func(target string, useAntiBotDetection bool) (*http.Response, *strategy.RequestInfo)
+	loadWebsiteContent func(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *strategy.RequestInfo)
 	getTest            func(testId string) (*Tests.ResponseTest, bool)
+	suggestTests       func(testId string) []string
 	format             func(target string, params []string) *string
 }
 
 // InitializeHeaderStrategy returns a pointer to a new headerTestStrategy.
 // It acts as the constructor for the header-based testing logic.
-func InitializeHeaderStrategy(loadWebsiteContent func(target string, useAntiBotDetection bool) (*http.Response, *strategy.RequestInfo),
+func InitializeHeaderStrategy(loadWebsiteContent func(target string, useAntiBotDetection bool, clientOpts ...HttpClient.WrapperOption) (*http.Response, *strategy.RequestInfo),
 	getTest func(testId string) (*Tests.ResponseTest, bool),
+	suggestTests func(testId string) []string,
 	format func(target string, params []string) *string) *headerTestStrategy {
 	return &headerTestStrategy{
 		loadWebsiteContent: loadWebsiteContent,
 		getTest:            getTest,
+		suggestTests:       suggestTests,
 		format:             format,
 	}
 }
@@ -39,7 +44,9 @@ func InitializeHeaderStrategy(loadWebsiteContent func(target string, useAntiBotD
 //
 // Logic Flow:
 //  1. Formats the target URL using the format helper.
-//  2. Fetches the raw website content (respecting the antiBotFlag).
+//  2. Fetches the raw website content (respecting the antiBotFlag), or loads the
+//     saved response when ctx.ResponseFile is set (--from-file). A target requiring
+//     authentication without configured credentials only yields an informational result.
 //  3. Iterates through ctx.Args to identify specific sub-tests in the Registry.
 //  4. Launches each valid sub-test in its own goroutine.
 //
@@ -47,23 +54,38 @@ func InitializeHeaderStrategy(loadWebsiteContent func(target string, useAntiBotD
 //
 //	If an argument corresponds to a test ID that does not exist in the Registry,
 //	the function panics with an error.Error (code 100), which is caught by the
-//	global ErrorHandler.
+//	global ErrorHandler. When a registered ID is within a small edit distance of
+//	the unknown one, the message includes a "did you mean" hint.
 func (h *headerTestStrategy) Execute(ctx strategy.TestContext, channel chan strategy.ResultWrapper, wg *sync.WaitGroup, antiBotFlag bool) {
 	// Using target formatter to properly build target URL
 	target := h.format(ctx.Target, ctx.Args)
-	result, reqInfo := h.loadWebsiteContent(*target, antiBotFlag)
+	var result *http.Response
+	var reqInfo *strategy.RequestInfo
+	if ctx.ResponseFile != "" {
+		result, reqInfo = strategy.LoadResponseFile(ctx.ResponseFile, *target)
+	} else {
+		result, reqInfo = h.loadWebsiteContent(*target, antiBotFlag, ctx.ClientOptions...)
+	}
 
+	if strategy.CheckAuthenticationRequired(result, reqInfo, channel) {
+		return
+	}
 	if reqInfo.Code != 0 {
 		channel <- strategy.WrapStrategyResult(nil, nil, reqInfo)
 		return
 	}
+	strategy.CheckTruncatedBody(result, channel)
+	challengeDetected := strategy.CheckChallengePage(result, antiBotFlag, channel)
+	strategy.SaveArtifacts(ctx.Artifacts, *target, result, channel)
+
+	params := strategy.NewTestParams(ctx, result)
 
 	for _, val := range ctx.Args {
 		t, ok := h.getTest(val)
 		if !ok {
 			panic(error.Error{
 				Code:        100,
-				Message:     fmt.Sprintf("Parsing error occurred. This could be due to:\n- test with Id %s does not exists", val),
+				Message:     fmt.Sprintf("Parsing error occurred. This could be due to:\n- test with Id %s does not exists%s", val, h.suggestionHint(val)),
 				Source:      "Header Test Strategy",
 				IsRetryable: false,
 			})
@@ -74,7 +96,7 @@ func (h *headerTestStrategy) Execute(ctx strategy.TestContext, channel chan stra
 		wg.Add(1)
 
 		// Launch the test asynchronously.
-		go strategy.PerformTest(t, wg, channel, result)
+		go strategy.PerformTest(t, wg, channel, params(), challengeDetected)
 
 	}
 }
@@ -95,3 +117,16 @@ func (h *headerTestStrategy) GetName() string {
 func (h *headerTestStrategy) GetPreferredReporterType() strategy.ReporterType {
 	return strategy.CLIReporter
 }
+
+// suggestionHint builds the "did you mean" suffix for an unknown test ID using the
+// configured suggestion lookup. It returns an empty string when no close match exists.
+func (h *headerTestStrategy) suggestionHint(testId string) string {
+	if h.suggestTests == nil {
+		return ""
+	}
+	suggestions := h.suggestTests(testId)
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean '%s'?)", strings.Join(suggestions, "', '"))
+}
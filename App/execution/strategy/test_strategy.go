@@ -1,6 +1,10 @@
 package strategy
 
 import (
+	"Engine-AntiGinx/App/CVE"
+	HttpClient "Engine-AntiGinx/App/HTTP"
+	"Engine-AntiGinx/App/Tests"
+	"context"
 	"sync"
 )
 
@@ -44,4 +48,47 @@ type TestContext struct {
 	// Args holds a slice of sub-test identifiers or specific parameters
 	// passed by the user for this particular strategy.
 	Args []string
+
+	// ClientOptions holds additional HTTP client configuration (e.g. client
+	// certificates) applied when the strategy fetches the target.
+	ClientOptions []HttpClient.WrapperOption
+
+	// CVEClient is the CVE client shared by every test of the scan, injected by the
+	// Runner so that vulnerability lookups are cached and rate limited in one place.
+	CVEClient *CVE.CVEClient
+
+	// DisableCVE turns off external CVE lookups for the scan (--no-cve).
+	DisableCVE bool
+
+	// CVEYears is the publication window of CVE lookups in years (--cve-years, 0 = every CVE).
+	CVEYears int
+
+	// CVEMinSeverity is the lowest CVSS v3 severity counted by CVE lookups
+	// (--cve-min-severity, empty = every severity).
+	CVEMinSeverity string
+
+	// Context is the scan context injected by the Runner. It is cancelled when the
+	// scan deadline (--deadline) passes, abandoning pending secondary requests.
+	// A nil Context never cancels.
+	Context context.Context
+
+	// ResponseFile is the path of a saved response (raw HTTP response or single-entry
+	// HAR) analysed instead of fetching Target (--from-file). Empty for live scans.
+	ResponseFile string
+
+	// ResultCache is the cache of pure header test results injected by the Runner,
+	// shared by every scan of the runner. A nil ResultCache disables caching.
+	ResultCache *Tests.ResultCache
+
+	// CustomRules are the user-defined rules applied by the custom test, loaded from
+	// --custom-rules. Nil when no rules file was given.
+	CustomRules *Tests.CustomRules
+
+	// IgnoredHeaders are the disclosure headers the server disclosure test does not count
+	// (--ignore-headers). Nil counts every disclosure header.
+	IgnoredHeaders []string
+
+	// Artifacts writes the raw response of the target as evidence (--save-artifacts),
+	// injected by the Runner. Nil when artifacts are not saved.
+	Artifacts *Artifacts
 }
@@ -8,6 +8,8 @@
 // Usage:
 //
 //	engine-antiginx test --target <url> --tests <test_ids...>
+//	engine-antiginx version
+//	engine-antiginx list [--format json]
 //
 // Example:
 //
@@ -32,6 +34,7 @@ package main
 
 import (
 	"Engine-AntiGinx/App/GlobalHandler"
+	_ "Engine-AntiGinx/App/Plugins"
 	"os"
 
 	"github.com/joho/godotenv"
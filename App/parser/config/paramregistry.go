@@ -10,6 +10,9 @@ import (
 //   - DefaultVal: Default value when parameter is provided without arguments
 //   - ArgRequired: Whether arguments are mandatory
 //   - ArgCount: Number of arguments (1 for single, -1 for multiple)
+//
+// The --tests whitelist lists the built-in tests; tests registered at runtime through
+// Registry.Register are appended to it.
 var Params = map[string]types.Parameter{
 	"--target": {
 		Arguments:   []string{},
@@ -36,7 +39,7 @@ var Params = map[string]types.Parameter{
 			ArgCount:    1,
 		},*/
 	"--tests": {
-		Arguments: []string{"https", "hsts", "serv-h-a", "csp", "cookie-sec", "js-obf", "xframe", "permissions-policy", "x-content-type-options", "referrer-policy", "ssl-cert", "cross-origin-x", "sitemap", "phishing-url"},
+		Arguments: []string{"https", "hsts", "serv-h-a", "csp", "cookie-sec", "js-obf", "xframe", "permissions-policy", "x-content-type-options", "referrer-policy", "ssl-cert", "cross-origin-x", "sitemap", "phishing-url", "caa", "email-dns", "alt-svc", "cors-allow", "api-cache", "x-xss", "transport", "vary", "server-timing", "hpkp", "content-disposition", "xst", "http-methods", "insecure-deser", "cookie-scheme-reuse", "custom", "transfer-integrity", "xpcdp", "well-known", "rate-limit", "tabnabbing", "cors-max-age", "url-secrets", "js-libs"},
 		/*"refererPol", "xxss", "featurePol", "listing", "openRedirect", "fCookies", "fHttpOnly"*/
 		DefaultVal:  "",
 		ArgRequired: true,
@@ -61,6 +64,192 @@ var Params = map[string]types.Parameter{
 		ArgRequired: false,
 		ArgCount:    0,
 	},
+	"--client-cert": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--client-key": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--auth-basic": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--auth-bearer": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--cookie": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    -1,
+	},
+	"--severity-threshold": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--suppress": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--custom-rules": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--targets-file": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--metadata": {
+		Arguments:   []string{"none", "summary", "full"},
+		DefaultVal:  "full",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--deadline": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--body-timeout": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--connect-timeout": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--response-timeout": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--lang": {
+		Arguments:   []string{"en", "pl"},
+		DefaultVal:  "en",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--description-template": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--from-file": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--no-cve": {
+		Arguments:   []string{},
+		DefaultVal:  "", // DefaultVal is not used for flag parameters (ArgCount: 0)
+		ArgRequired: false,
+		ArgCount:    0,
+	},
+	"--cve-years": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--cve-min-severity": {
+		Arguments:   []string{"low", "medium", "high", "critical"},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--fail-fast": {
+		Arguments:   []string{},
+		DefaultVal:  "", // DefaultVal is not used for flag parameters (ArgCount: 0)
+		ArgRequired: false,
+		ArgCount:    0,
+	},
+	"--drop-on-overflow": {
+		Arguments:   []string{},
+		DefaultVal:  "", // DefaultVal is not used for flag parameters (ArgCount: 0)
+		ArgRequired: false,
+		ArgCount:    0,
+	},
+	"--quiet": {
+		Arguments:   []string{},
+		DefaultVal:  "", // DefaultVal is not used for flag parameters (ArgCount: 0)
+		ArgRequired: false,
+		ArgCount:    0,
+	},
+	"--include-www": {
+		Arguments:   []string{},
+		DefaultVal:  "", // DefaultVal is not used for flag parameters (ArgCount: 0)
+		ArgRequired: false,
+		ArgCount:    0,
+	},
+	"--format": {
+		Arguments:   []string{"text", "junit", "html"},
+		DefaultVal:  "text",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--output": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--report-title": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--report-logo": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--operator": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
+	"--ignore-headers": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    -1,
+	},
+	"--save-artifacts": {
+		Arguments:   []string{},
+		DefaultVal:  "",
+		ArgRequired: true,
+		ArgCount:    1,
+	},
 	"--all": {
 		Arguments:   []string{},
 		DefaultVal:  "", // DefaultVal is not used for flag parameters (ArgCount: 0)
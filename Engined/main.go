@@ -36,6 +36,12 @@
 //   - Scanner execution errors are NACK'd without requeue
 //   - Successful scans are ACK'd to remove from queue
 //
+// Connection Reuse:
+//
+// Every task runs in its own engine process, so HTTP connections are not reused between
+// tasks. Scans run in one process (the engine's --stdin task stream or the Harness
+// package) share a single HTTP transport and reuse its keep-alive connections.
+//
 // Graceful Shutdown:
 //
 // The daemon handles SIGINT (Ctrl+C) gracefully by:
@@ -52,9 +58,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"os/exec"
 	"os/signal"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"github.com/streadway/amqp"
@@ -65,7 +73,8 @@ import (
 // against a target URL.
 //
 // Fields:
-//   - Id: Unique identifier for the task, used for tracking and reporting
+//   - Id: Unique identifier for the task, used for tracking and reporting and as the
+//     scan correlation ID ("scan_id") of the daemon and engine logs and results
 //   - Target: The URL to scan (e.g., "https://example.com")
 //
 // JSON Example:
@@ -200,33 +209,35 @@ OUTER:
 				continue
 			}
 			idParam := task.Parameters[taskId]
+			// The task id is the scan's correlation ID; the engine receives it through
+			// --taskId and attaches it to its own logs and reported results.
+			logger := slog.With("scan_id", strings.Join(idParam.Arguments, ""))
 
 			ackCounter := getRetryCount(msg)
-			fmt.Printf("Ack counter %d \n", ackCounter)
+			logger.Info("task delivery", "retries", ackCounter)
 			if ackCounter > int64(3) {
-				fmt.Printf("Too many requeing for task with id: %s\n", idParam)
+				logger.Warn("too many requeues, discarding task")
 				nackErr := msg.Ack(false)
 				if nackErr != nil {
-					fmt.Printf("Warning: Failed to nack task %s\n", nackErr.Error())
+					logger.Warn("failed to ack task", "error", nackErr.Error())
 				}
 				continue
 			}
 
-			fmt.Printf("Consumer received a task with id: %s\n", idParam)
-			fmt.Printf("Target url %s\n", task.Target)
+			logger.Info("consumer received a task", "target", task.Target)
 
 			var stderrBuff bytes.Buffer
 			cmdErr := runScan(msg.Body, &stderrBuff, engineCall)
 
 			if cmdErr != nil {
-				handleScanError(&stderrBuff, msg, *idParam)
+				handleScanError(&stderrBuff, msg, logger)
 				continue
 			} else {
-				fmt.Printf("Scan performed successfully: %s\n", idParam)
+				logger.Info("scan performed successfully")
 
 				err := msg.Ack(false)
 				if err != nil {
-					fmt.Printf("Warning: Failed to ack task %s\n", err.Error())
+					logger.Warn("failed to ack task", "error", err.Error())
 				}
 			}
 		}
@@ -265,30 +276,30 @@ func runScan(messageBody []byte, stderrBuff *bytes.Buffer, engineCall string) er
 	cmd.Stderr = io.MultiWriter(os.Stderr, stderrBuff)
 	return cmd.Run()
 }
-func handleScanError(stderrBuff *bytes.Buffer, msg amqp.Delivery, idParam types.CommandParameter) {
+func handleScanError(stderrBuff *bytes.Buffer, msg amqp.Delivery, logger *slog.Logger) {
 	var errJSON Errors.Error
 	errBytes := stderrBuff.Bytes()
 	if jsonErr := json.Unmarshal(errBytes, &errJSON); jsonErr == nil {
-		fmt.Printf("General error from Engine: %v\n", errJSON)
+		logger.Error("general error from engine", "code", errJSON.Code, "source", errJSON.Source, "message", errJSON.Message)
 		currRetries := getRetryCount(msg)
 		if errJSON.IsRetryable {
-			fmt.Printf("Error is retryable. Requeuing task with id: %s | Current retries: %d\n", idParam, currRetries)
+			logger.Warn("error is retryable, requeuing task", "retries", currRetries)
 			nackErr := msg.Nack(false, false)
 			if nackErr != nil {
-				fmt.Printf("Warning: Failed to nack task %s\n", nackErr.Error())
+				logger.Warn("failed to nack task", "error", nackErr.Error())
 			}
 		} else {
-			fmt.Printf("Error is fatal. Discarding task with id %s", idParam)
+			logger.Error("error is fatal, discarding task")
 			nackErr := msg.Ack(false)
 			if nackErr != nil {
-				fmt.Printf("Warning: Failed to nack task %s\n", nackErr.Error())
+				logger.Warn("failed to ack task", "error", nackErr.Error())
 			}
 		}
 	} else {
-		fmt.Printf("Fatal error: %s\n", stderrBuff)
+		logger.Error("fatal error", "stderr", stderrBuff.String())
 		nackErr := msg.Ack(false)
 		if nackErr != nil {
-			fmt.Printf("Warning: Failed to nack task %s\n", nackErr.Error())
+			logger.Warn("failed to ack task", "error", nackErr.Error())
 		}
 	}
 }